@@ -0,0 +1,355 @@
+package mpd
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// templateIdentifierRE matches SegmentTemplate identifiers like $Number%05d$ which are not valid URL parts.
+var templateIdentifierRE = regexp.MustCompile(`\$[A-Za-z]*(%0?[0-9]*[a-zA-Z])?\$`)
+
+// MakeAbsolute folds BaseURL hierarchy of m and manifestURL into URLs of segment addressing elements
+// (SegmentTemplate's media and initialization, SegmentURL's media and index and sourceURL of
+// Initialization, RepresentationIndex and BitstreamSwitching), so every segment URL becomes absolute.
+// Addressing URLs inherited by elements with their own BaseURL are copied into them first.
+// BaseURL elements are cleared, except for Representations without any SegmentTemplate,
+// which keep absolute BaseURL as their media URL.
+func MakeAbsolute(m *MPD, manifestURL string) error {
+	base, err := url.Parse(manifestURL)
+	if err != nil {
+		return fmt.Errorf("MakeAbsolute: can't parse manifest URL %q: %w", manifestURL, err)
+	}
+	if !base.IsAbs() {
+		return fmt.Errorf("MakeAbsolute: manifest URL %q is not absolute", manifestURL)
+	}
+
+	mpdBase, err := resolveBaseURL(base, m.BaseURL)
+	if err != nil {
+		return err
+	}
+	m.BaseURL = ""
+
+	for _, p := range m.Periods {
+		periodBase, err := resolveBaseURL(mpdBase, p.BaseURL)
+		if err != nil {
+			return err
+		}
+		p.BaseURL = ""
+
+		for _, as := range p.AdaptationSets {
+			asBase, err := resolveBaseURL(periodBase, as.BaseURL)
+			if err != nil {
+				return err
+			}
+			if as.BaseURL != "" {
+				// Period's addressing should be resolved against AdaptationSet's own BaseURL
+				inheritURLs(&as.SegmentTemplate, &as.SegmentList, &as.SegmentBase, p.SegmentTemplate, p.SegmentList, p.SegmentBase)
+			}
+			as.BaseURL = ""
+
+			for i := range as.Representations {
+				r := &as.Representations[i]
+				repBase, err := resolveBaseURL(asBase, r.BaseURL)
+				if err != nil {
+					return err
+				}
+				if r.BaseURL != "" {
+					var inherited Representation
+					inheritURLs(&r.SegmentTemplate, &r.SegmentList, &r.SegmentBase,
+						EffectiveSegmentTemplate(p, as, &inherited), EffectiveSegmentList(p, as, &inherited), EffectiveSegmentBase(p, as, &inherited))
+				}
+				if err = resolveAddressing(repBase, r.SegmentTemplate, r.SegmentList, r.SegmentBase); err != nil {
+					return err
+				}
+				if EffectiveSegmentTemplate(p, as, r) != nil {
+					r.BaseURL = ""
+				} else {
+					r.BaseURL = repBase.String()
+				}
+			}
+
+			if err = resolveAddressing(asBase, as.SegmentTemplate, as.SegmentList, as.SegmentBase); err != nil {
+				return err
+			}
+		}
+
+		if err = resolveAddressing(periodBase, p.SegmentTemplate, p.SegmentList, p.SegmentBase); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MakeRelative is the inverse of MakeAbsolute: it rewrites absolute BaseURLs of m relative to their parent
+// BaseURLs (or manifestURL) and absolute URLs of segment addressing elements relative to BaseURL they are
+// resolved against. Addressing URLs inherited by elements with their own BaseURL are copied into them first.
+// URLs on other hosts are left intact.
+func MakeRelative(m *MPD, manifestURL string) error {
+	base, err := url.Parse(manifestURL)
+	if err != nil {
+		return fmt.Errorf("MakeRelative: can't parse manifest URL %q: %w", manifestURL, err)
+	}
+	if !base.IsAbs() {
+		return fmt.Errorf("MakeRelative: manifest URL %q is not absolute", manifestURL)
+	}
+
+	rel := func(base *url.URL, urls ...*string) error {
+		for _, s := range urls {
+			if s == nil || *s == "" {
+				continue
+			}
+			if err := rewriteTemplate(s, func(u *url.URL) *url.URL {
+				return relativeURL(base, u)
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	mpdBase, err := resolveBaseURL(base, m.BaseURL)
+	if err != nil {
+		return err
+	}
+	if err = rel(base, &m.BaseURL); err != nil {
+		return err
+	}
+	for _, p := range m.Periods {
+		periodBase, err := resolveBaseURL(mpdBase, p.BaseURL)
+		if err != nil {
+			return err
+		}
+		for _, as := range p.AdaptationSets {
+			asBase, err := resolveBaseURL(periodBase, as.BaseURL)
+			if err != nil {
+				return err
+			}
+			if as.BaseURL != "" {
+				inheritURLs(&as.SegmentTemplate, &as.SegmentList, &as.SegmentBase, p.SegmentTemplate, p.SegmentList, p.SegmentBase)
+			}
+			for i := range as.Representations {
+				r := &as.Representations[i]
+				repBase, err := resolveBaseURL(asBase, r.BaseURL)
+				if err != nil {
+					return err
+				}
+				if r.BaseURL != "" {
+					var inherited Representation
+					inheritURLs(&r.SegmentTemplate, &r.SegmentList, &r.SegmentBase,
+						EffectiveSegmentTemplate(p, as, &inherited), EffectiveSegmentList(p, as, &inherited), EffectiveSegmentBase(p, as, &inherited))
+				}
+				if err = rel(repBase, addressingURLs(r.SegmentTemplate, r.SegmentList, r.SegmentBase)...); err != nil {
+					return err
+				}
+				if err = rel(asBase, &r.BaseURL); err != nil {
+					return err
+				}
+			}
+			if err = rel(asBase, addressingURLs(as.SegmentTemplate, as.SegmentList, as.SegmentBase)...); err != nil {
+				return err
+			}
+			if err = rel(periodBase, &as.BaseURL); err != nil {
+				return err
+			}
+		}
+		if err = rel(periodBase, addressingURLs(p.SegmentTemplate, p.SegmentList, p.SegmentBase)...); err != nil {
+			return err
+		}
+		if err = rel(mpdBase, &p.BaseURL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveBaseURL returns BaseURL ref resolved against base.
+func resolveBaseURL(base *url.URL, ref string) (*url.URL, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return base, nil
+	}
+	u, err := url.Parse(ref)
+	if err != nil {
+		return nil, fmt.Errorf("can't parse BaseURL %q: %w", ref, err)
+	}
+	return base.ResolveReference(u), nil
+}
+
+// resolveAddressing resolves URLs of segment addressing elements against base. Missing sourceURL of
+// SegmentTemplate's BitstreamSwitching refers to BaseURL, which is cleared for SegmentTemplate addressing,
+// so it is set to base.
+func resolveAddressing(base *url.URL, t *SegmentTemplate, l *SegmentList, b *SegmentBase) error {
+	if t != nil && t.BitstreamSwitching != nil && t.BitstreamSwitching.SourceURL == nil {
+		t.BitstreamSwitching.SourceURL = stringPtr("")
+	}
+	resolve := func(u *url.URL) *url.URL {
+		return base.ResolveReference(u)
+	}
+	for _, s := range addressingURLs(t, l, b) {
+		if err := rewriteTemplate(s, resolve); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addressingURLs returns pointers to present URL attributes of segment addressing elements.
+func addressingURLs(t *SegmentTemplate, l *SegmentList, b *SegmentBase) []*string {
+	var res []*string
+	add := func(urls ...*string) {
+		for _, s := range urls {
+			if s != nil {
+				res = append(res, s)
+			}
+		}
+	}
+	addURL := func(urls ...*URLType) {
+		for _, u := range urls {
+			if u != nil {
+				add(u.SourceURL)
+			}
+		}
+	}
+	if t != nil {
+		add(t.Media, t.Initialization)
+		addURL(t.BitstreamSwitching)
+	}
+	if l != nil {
+		addURL(l.Initialization, l.BitstreamSwitching)
+		for i := range l.SegmentURLs {
+			add(l.SegmentURLs[i].Media, l.SegmentURLs[i].Index)
+		}
+	}
+	if b != nil {
+		addURL(b.Initialization, b.RepresentationIndex)
+	}
+	return res
+}
+
+// inheritURLs copies URL attributes of inherited segment addressing elements pt, pl and pb, which are not
+// overridden, into elements t, l and b of a level with its own BaseURL, creating them if needed.
+func inheritURLs(t **SegmentTemplate, l **SegmentList, b **SegmentBase, pt *SegmentTemplate, pl *SegmentList, pb *SegmentBase) {
+	if pt != nil && (pt.Media != nil || pt.Initialization != nil || pt.BitstreamSwitching != nil) {
+		if *t == nil {
+			*t = new(SegmentTemplate)
+		}
+		if (*t).Media == nil {
+			(*t).Media = copyString(pt.Media)
+		}
+		if (*t).Initialization == nil {
+			(*t).Initialization = copyString(pt.Initialization)
+		}
+		if (*t).BitstreamSwitching == nil {
+			(*t).BitstreamSwitching = copyURLType(pt.BitstreamSwitching)
+		}
+	}
+	if pl != nil && (pl.Initialization != nil || pl.BitstreamSwitching != nil || len(pl.SegmentURLs) > 0) {
+		if *l == nil {
+			*l = new(SegmentList)
+		}
+		if (*l).Initialization == nil {
+			(*l).Initialization = copyURLType(pl.Initialization)
+		}
+		if (*l).BitstreamSwitching == nil {
+			(*l).BitstreamSwitching = copyURLType(pl.BitstreamSwitching)
+		}
+		if len((*l).SegmentURLs) == 0 && len(pl.SegmentURLs) > 0 {
+			(*l).SegmentURLs = make([]SegmentURL, len(pl.SegmentURLs))
+			for i, su := range pl.SegmentURLs {
+				(*l).SegmentURLs[i] = SegmentURL{
+					Media:      copyString(su.Media),
+					MediaRange: su.MediaRange,
+					Index:      copyString(su.Index),
+					IndexRange: su.IndexRange,
+				}
+			}
+		}
+	}
+	if pb != nil && (pb.Initialization != nil || pb.RepresentationIndex != nil) {
+		if *b == nil {
+			*b = new(SegmentBase)
+		}
+		if (*b).Initialization == nil {
+			(*b).Initialization = copyURLType(pb.Initialization)
+		}
+		if (*b).RepresentationIndex == nil {
+			(*b).RepresentationIndex = copyURLType(pb.RepresentationIndex)
+		}
+	}
+}
+
+// copyString returns pointer to copy of *s, or nil.
+func copyString(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	res := *s
+	return &res
+}
+
+// copyURLType returns pointer to copy of *u, or nil.
+func copyURLType(u *URLType) *URLType {
+	if u == nil {
+		return nil
+	}
+	return &URLType{SourceURL: copyString(u.SourceURL), Range: u.Range}
+}
+
+// rewriteTemplate parses template s as URL, passes it to f and stores the result back.
+// Template identifiers are protected from URL parsing and escaping.
+func rewriteTemplate(s *string, f func(*url.URL) *url.URL) error {
+	var identifiers []string
+	protected := templateIdentifierRE.ReplaceAllStringFunc(*s, func(id string) string {
+		identifiers = append(identifiers, id)
+		return "__mpd" + strconv.Itoa(len(identifiers)-1) + "__"
+	})
+
+	u, err := url.Parse(protected)
+	if err != nil {
+		return fmt.Errorf("can't parse URL template %q: %w", *s, err)
+	}
+	res := f(u).String()
+
+	for i, id := range identifiers {
+		res = strings.Replace(res, "__mpd"+strconv.Itoa(i)+"__", id, 1)
+	}
+	*s = res
+	return nil
+}
+
+// relativeURL returns target relative to base, or target itself if it can't be made relative.
+func relativeURL(base, target *url.URL) *url.URL {
+	if !target.IsAbs() || target.Scheme != base.Scheme || target.Host != base.Host || target.User.String() != base.User.String() {
+		return target
+	}
+
+	baseDir := strings.Split(base.EscapedPath(), "/")
+	baseDir = baseDir[:len(baseDir)-1]
+	targetPath := strings.Split(target.EscapedPath(), "/")
+
+	var common int
+	for common < len(baseDir) && common < len(targetPath)-1 && baseDir[common] == targetPath[common] {
+		common++
+	}
+
+	parts := make([]string, 0, len(baseDir)-common+len(targetPath)-common)
+	for i := common; i < len(baseDir); i++ {
+		parts = append(parts, "..")
+	}
+	parts = append(parts, targetPath[common:]...)
+	p := strings.Join(parts, "/")
+	if p == "" {
+		p = "./"
+	}
+
+	res, err := url.Parse(p)
+	if err != nil {
+		return target
+	}
+	res.RawQuery = target.RawQuery
+	res.Fragment = target.Fragment
+	return res
+}
@@ -0,0 +1,145 @@
+package mpd
+
+import (
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestMakeAbsolute(c *C) {
+	media := "$RepresentationID$/seg_$Number%05d$.m4s?token=1"
+	init := "$RepresentationID$/init.mp4"
+	m := &MPD{
+		BaseURL: "http://cdn.example.com/content/",
+		Periods: []*Period{{
+			BaseURL: "p1/",
+			AdaptationSets: []*AdaptationSet{{
+				SegmentTemplate: &SegmentTemplate{Media: &media, Initialization: &init},
+				Representations: []Representation{
+					{},
+					{BaseURL: "alt/"},
+				},
+			}},
+		}},
+	}
+
+	c.Assert(MakeAbsolute(m, "http://origin.example.com/live/manifest.mpd"), IsNil)
+	c.Check(m.BaseURL, Equals, "")
+	c.Check(m.Periods[0].BaseURL, Equals, "")
+
+	as := m.Periods[0].AdaptationSets[0]
+	c.Check(*as.SegmentTemplate.Media, Equals, "http://cdn.example.com/content/p1/$RepresentationID$/seg_$Number%05d$.m4s?token=1")
+	c.Check(*as.SegmentTemplate.Initialization, Equals, "http://cdn.example.com/content/p1/$RepresentationID$/init.mp4")
+	c.Check(as.Representations[0].SegmentTemplate, IsNil)
+	c.Assert(as.Representations[1].SegmentTemplate, NotNil)
+	c.Check(as.Representations[1].BaseURL, Equals, "")
+	c.Check(*as.Representations[1].SegmentTemplate.Media, Equals, "http://cdn.example.com/content/p1/alt/$RepresentationID$/seg_$Number%05d$.m4s?token=1")
+
+	c.Assert(MakeRelative(m, "http://cdn.example.com/content/manifest.mpd"), IsNil)
+	c.Check(*as.SegmentTemplate.Media, Equals, "p1/$RepresentationID$/seg_$Number%05d$.m4s?token=1")
+	c.Check(*as.Representations[1].SegmentTemplate.Initialization, Equals, "p1/alt/$RepresentationID$/init.mp4")
+
+	c.Assert(MakeRelative(m, "http://cdn.example.com/other/manifest.mpd"), IsNil)
+	c.Check(*as.SegmentTemplate.Media, Equals, "p1/$RepresentationID$/seg_$Number%05d$.m4s?token=1")
+}
+
+func (s *MPDSuite) TestMakeRelativeOtherHost(c *C) {
+	media := "http://cdn.example.com/a/$Number$.m4s"
+	m := &MPD{
+		Periods: []*Period{{
+			AdaptationSets: []*AdaptationSet{{
+				SegmentTemplate: &SegmentTemplate{Media: &media},
+			}},
+		}},
+	}
+	c.Assert(MakeRelative(m, "http://origin.example.com/b/manifest.mpd"), IsNil)
+	c.Check(media, Equals, "http://cdn.example.com/a/$Number$.m4s")
+
+	c.Assert(MakeRelative(m, "http://cdn.example.com/b/c/manifest.mpd"), IsNil)
+	c.Check(media, Equals, "../../a/$Number$.m4s")
+
+	c.Check(MakeAbsolute(m, "manifest.mpd"), ErrorMatches, `MakeAbsolute: manifest URL "manifest.mpd" is not absolute`)
+}
+
+func (s *MPDSuite) TestMakeAbsoluteInheritance(c *C) {
+	m := NewStaticMPD(ProfileFull, 8*time.Second)
+	m.BaseURL = "http://cdn.example.com/content/"
+	m.Periods = []*Period{{
+		ID:      stringPtr("0"),
+		Start:   stringPtr("PT0S"),
+		BaseURL: "p/",
+		SegmentTemplate: &SegmentTemplate{
+			Timescale:          uint64Ptr(1),
+			Duration:           uint32Ptr(2),
+			Media:              stringPtr("$RepresentationID$/$Number$.m4s"),
+			BitstreamSwitching: &URLType{},
+		},
+		AdaptationSets: []*AdaptationSet{{
+			BaseURL:         "a/",
+			SegmentTemplate: &SegmentTemplate{Initialization: stringPtr("$RepresentationID$/init.mp4")},
+			Representations: []Representation{{ID: stringPtr("v1")}, {ID: stringPtr("v2"), BaseURL: "alt/"}},
+		}, {
+			Representations: []Representation{{ID: stringPtr("v3")}},
+		}},
+	}, {
+		ID:      stringPtr("1"),
+		Start:   stringPtr("PT4S"),
+		BaseURL: "q/",
+		SegmentList: &SegmentList{
+			Timescale:          uint64Ptr(1),
+			Duration:           uint64Ptr(2),
+			Initialization:     &URLType{SourceURL: stringPtr("init.mp4")},
+			BitstreamSwitching: &URLType{Range: stringPtr("0-9")},
+			SegmentURLs:        []SegmentURL{{Media: stringPtr("1.m4s")}, {MediaRange: stringPtr("10-99")}},
+		},
+		AdaptationSets: []*AdaptationSet{{
+			BaseURL:         "b/",
+			Representations: []Representation{{ID: stringPtr("l1")}, {ID: stringPtr("l2"), BaseURL: "x/"}},
+		}, {
+			SegmentBase: &SegmentBase{Initialization: &URLType{Range: stringPtr("0-99")}, RepresentationIndex: &URLType{SourceURL: stringPtr("index.sidx")}},
+			Representations: []Representation{
+				{ID: stringPtr("b1"), BaseURL: "video.mp4", SegmentList: &SegmentList{SegmentURLs: []SegmentURL{{Media: stringPtr("s.m4s")}}}},
+			},
+		}},
+	}}
+	m.Periods[1].AdaptationSets[1].Representations[0].SegmentList = nil
+
+	segments := func(manifestURL string) []Segment {
+		var res []Segment
+		for _, p := range m.Periods {
+			for _, as := range p.AdaptationSets {
+				for i := range as.Representations {
+					list, err := Segments(manifestURL, m, p, as, &as.Representations[i])
+					c.Assert(err, IsNil)
+					res = append(res, list...)
+				}
+			}
+		}
+		return res
+	}
+	expected := segments("http://origin.example.com/manifest.mpd")
+	c.Check(expected[0], DeepEquals, Segment{Kind: InitializationSegment, URL: "http://cdn.example.com/content/p/a/v1/init.mp4"})
+
+	c.Assert(MakeAbsolute(m, "http://origin.example.com/manifest.mpd"), IsNil)
+	c.Check(segments("http://origin.example.com/manifest.mpd"), DeepEquals, expected)
+	p := m.Periods[0]
+	c.Check(*p.SegmentTemplate.Media, Equals, "http://cdn.example.com/content/p/$RepresentationID$/$Number$.m4s")
+	c.Check(*p.SegmentTemplate.BitstreamSwitching.SourceURL, Equals, "http://cdn.example.com/content/p/")
+	c.Check(*p.AdaptationSets[0].SegmentTemplate.Media, Equals, "http://cdn.example.com/content/p/a/$RepresentationID$/$Number$.m4s")
+	c.Check(*p.AdaptationSets[0].Representations[1].SegmentTemplate.Initialization, Equals, "http://cdn.example.com/content/p/a/alt/$RepresentationID$/init.mp4")
+	c.Check(p.AdaptationSets[1].SegmentTemplate, IsNil)
+	as := m.Periods[1].AdaptationSets[0]
+	c.Check(*as.SegmentList.SegmentURLs[0].Media, Equals, "http://cdn.example.com/content/q/b/1.m4s")
+	c.Check(as.SegmentList.SegmentURLs[1].Media, IsNil)
+	c.Check(*as.Representations[1].SegmentList.Initialization.SourceURL, Equals, "http://cdn.example.com/content/q/b/x/init.mp4")
+	c.Check(as.Representations[1].BaseURL, Equals, "http://cdn.example.com/content/q/b/x/")
+	c.Check(*m.Periods[1].AdaptationSets[1].SegmentBase.RepresentationIndex.SourceURL, Equals, "http://cdn.example.com/content/q/index.sidx")
+
+	c.Assert(MakeRelative(m, "http://cdn.example.com/content/manifest.mpd"), IsNil)
+	c.Check(*p.SegmentTemplate.Media, Equals, "p/$RepresentationID$/$Number$.m4s")
+	c.Check(*as.SegmentList.SegmentURLs[0].Media, Equals, "q/b/1.m4s")
+	c.Check(*m.Periods[1].AdaptationSets[1].SegmentBase.RepresentationIndex.SourceURL, Equals, "q/index.sidx")
+	c.Check(as.Representations[1].BaseURL, Equals, "q/b/x/")
+	c.Check(*as.Representations[1].SegmentList.Initialization.SourceURL, Equals, "init.mp4")
+	c.Check(segments("http://cdn.example.com/content/manifest.mpd"), DeepEquals, expected)
+}
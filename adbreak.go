@@ -0,0 +1,160 @@
+package mpd
+
+import (
+	"encoding/base64"
+	"fmt"
+	"time"
+)
+
+// SCTE35Namespace is a namespace of SCTE-35 XML elements.
+const SCTE35Namespace = "http://www.scte.org/schemas/35/2016"
+
+// SCTE35BinScheme is a schemeIdUri of SCTE 214-1 EventStreams, which Events carry SCTE-35 splice_info_section
+// in Signal element.
+const SCTE35BinScheme = "urn:scte:scte35:2014:xml+bin"
+
+// SCTE35InbandScheme is a schemeIdUri of emsg boxes with SCTE-35 splice_info_section as message data.
+const SCTE35InbandScheme = "urn:scte:scte35:2013:bin"
+
+// scte35Timescale is a timescale of SCTE-35 EventStreams created by InsertAdBreak, matching MPEG-2 TS clock.
+const scte35Timescale = 90000
+
+// InsertAdBreak adds SCTE-35 Event with spliceInfo payload for an ad break at presentation time start
+// lasting for duration to the Period containing start. EventStream is created if needed.
+func InsertAdBreak(m *MPD, start, duration time.Duration, spliceInfo []byte) (*Event, error) {
+	i, err := periodAt(m, start)
+	if err != nil {
+		return nil, fmt.Errorf("InsertAdBreak: %w", err)
+	}
+	return insertAdBreakEvent(m.Periods[i], start, duration, spliceInfo)
+}
+
+// InsertAdBreakPeriod is like InsertAdBreak, but also splits the Period containing start into content Period
+// ending at start, empty ad Period for the break with the Event at its beginning and empty content Period after it.
+// The break must start after the beginning of the Period and end before its end, if the end is known.
+// AdaptationSets of new Periods should be filled by the caller.
+func InsertAdBreakPeriod(m *MPD, start, duration time.Duration, spliceInfo []byte) (*Event, error) {
+	i, err := periodAt(m, start)
+	if err != nil {
+		return nil, fmt.Errorf("InsertAdBreakPeriod: %w", err)
+	}
+	periodStart, end, err := periodRange(m, i)
+	if err != nil {
+		return nil, fmt.Errorf("InsertAdBreakPeriod: %s: %w", periodPath(i), err)
+	}
+	if start == periodStart {
+		return nil, fmt.Errorf("InsertAdBreakPeriod: ad break starts at the beginning of %s", periodPath(i))
+	}
+	if end >= 0 && start+duration > end {
+		return nil, fmt.Errorf("InsertAdBreakPeriod: ad break ends after the end of %s at %s", periodPath(i), FormatDuration(end))
+	}
+
+	p := m.Periods[i]
+	id := "period"
+	if p.ID != nil {
+		id = *p.ID
+	}
+	n := 1
+	for m.hasPeriodID(fmt.Sprintf("%s-ad-%d", id, n)) || m.hasPeriodID(fmt.Sprintf("%s-after-ad-%d", id, n)) {
+		n++
+	}
+	ad := &Period{
+		ID:       stringPtr(fmt.Sprintf("%s-ad-%d", id, n)),
+		Start:    stringPtr(FormatDuration(start)),
+		Duration: stringPtr(FormatDuration(duration)),
+	}
+	e, err := insertAdBreakEvent(ad, start, duration, spliceInfo)
+	if err != nil {
+		return nil, err
+	}
+	periods := []*Period{ad}
+	if end < 0 || start+duration < end {
+		content := &Period{
+			ID:    stringPtr(fmt.Sprintf("%s-after-ad-%d", id, n)),
+			Start: stringPtr(FormatDuration(start + duration)),
+		}
+		if end >= 0 {
+			content.Duration = stringPtr(FormatDuration(end - start - duration))
+		}
+		periods = append(periods, content)
+	}
+	p.Duration = stringPtr(FormatDuration(start - periodStart))
+
+	res := append([]*Period{}, m.Periods[:i+1]...)
+	res = append(res, periods...)
+	m.Periods = append(res, m.Periods[i+1:]...)
+	return e, nil
+}
+
+// insertAdBreakEvent adds SCTE-35 Event to p.
+func insertAdBreakEvent(p *Period, start, duration time.Duration, spliceInfo []byte) (*Event, error) {
+	if len(spliceInfo) == 0 {
+		return nil, fmt.Errorf("InsertAdBreak: empty splice info")
+	}
+	var periodStart time.Duration
+	if p.Start != nil {
+		periodStart, _ = ParseDuration(*p.Start)
+	}
+
+	es := p.eventStream(SCTE35BinScheme, "", scte35Timescale)
+
+	ts := eventTimescale(es)
+	pt := unscaleEventTime(start-periodStart, ts)
+	if es.PresentationTimeOffset != nil {
+		pt += int64(*es.PresentationTimeOffset)
+	}
+	d := unscaleEventTime(duration, ts)
+	e := Event{ID: stringPtr(es.nextEventID()), PresentationTime: &pt, Duration: &d}
+	e.SetSpliceInfo(spliceInfo)
+	es.Events = append(es.Events, e)
+	return &es.Events[len(es.Events)-1], nil
+}
+
+// SpliceInfo returns SCTE-35 splice_info_section carried by Signal of e, or nil if e has no Signal.
+func (e *Event) SpliceInfo() ([]byte, error) {
+	if e.Signal == nil {
+		return nil, nil
+	}
+	b, err := decodeBase64Payload(e.Signal.Binary)
+	if err != nil {
+		return nil, fmt.Errorf("SpliceInfo: %w", err)
+	}
+	return b, nil
+}
+
+// SetSpliceInfo sets Signal of e to carry SCTE-35 splice_info_section b.
+func (e *Event) SetSpliceInfo(b []byte) {
+	e.Signal = &SCTE35Signal{Binary: base64.StdEncoding.EncodeToString(b)}
+}
+
+// hasPeriodID reports whether m has a Period with given id.
+func (m *MPD) hasPeriodID(id string) bool {
+	for _, p := range m.Periods {
+		if p.ID != nil && *p.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// periodAt returns index of the Period containing presentation time t.
+// Periods without @start are skipped.
+func periodAt(m *MPD, t time.Duration) (int, error) {
+	res := -1
+	for i, p := range m.Periods {
+		if p.Start == nil {
+			continue
+		}
+		start, err := ParseDuration(*p.Start)
+		if err != nil {
+			return 0, fmt.Errorf("%s@start: %w", periodPath(i), err)
+		}
+		if start <= t {
+			res = i
+		}
+	}
+	if res < 0 {
+		return 0, fmt.Errorf("no Period contains %s", FormatDuration(t))
+	}
+	return res, nil
+}
@@ -0,0 +1,92 @@
+package mpd
+
+import (
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestInsertAdBreak(c *C) {
+	splice := []byte{0xfc, 0x30, 0x11}
+
+	m := &MPD{Periods: []*Period{{ID: stringPtr("1"), Start: stringPtr("PT0S")}, {ID: stringPtr("2"), Start: stringPtr("PT1M")}}}
+	e, err := InsertAdBreak(m, 70*time.Second, 30*time.Second, splice)
+	c.Assert(err, IsNil)
+	c.Check(*e.ID, Equals, "1")
+	c.Check(*e.PresentationTime, Equals, int64(900000))
+	c.Check(*e.Duration, Equals, int64(2700000))
+
+	b, err := m.Encode()
+	c.Assert(err, IsNil)
+	c.Check(string(b), Equals, `<?xml version="1.0" encoding="utf-8"?>
+<MPD profiles="">
+  <Period start="PT0S" id="1"/>
+  <Period start="PT1M" id="2">
+    <EventStream schemeIdUri="urn:scte:scte35:2014:xml+bin" timescale="90000">
+      <Event id="1" presentationTime="900000" duration="2700000">
+        <Signal xmlns="http://www.scte.org/schemas/35/2016">
+          <Binary>/DAR</Binary>
+        </Signal>
+      </Event>
+    </EventStream>
+  </Period>
+</MPD>
+`)
+
+	decoded := new(MPD)
+	c.Assert(decoded.Decode([]byte(`<MPD xmlns="urn:mpeg:dash:schema:mpd:2011" xmlns:scte35="http://www.scte.org/schemas/35/2016"><Period>
+<EventStream schemeIdUri="urn:scte:scte35:2014:xml+bin"><Event id="5"><scte35:Signal><scte35:Binary>/DAR</scte35:Binary></scte35:Signal></Event></EventStream>
+</Period></MPD>`)), IsNil)
+	spliceInfo, err := decoded.Periods[0].EventStreams[0].Events[0].SpliceInfo()
+	c.Assert(err, IsNil)
+	c.Check(spliceInfo, DeepEquals, splice)
+
+	es := &m.Periods[1].EventStreams[0]
+	es.Events = append(es.Events, Event{ID: stringPtr("2")})
+	es.Events = es.Events[1:]
+	e, err = InsertAdBreak(m, 80*time.Second, 10*time.Second, splice)
+	c.Assert(err, IsNil)
+	c.Check(*e.ID, Equals, "3")
+
+	_, err = InsertAdBreak(m, 0, time.Second, nil)
+	c.Check(err, ErrorMatches, "InsertAdBreak: empty splice info")
+}
+
+func (s *MPDSuite) TestInsertAdBreakPeriod(c *C) {
+	m := &MPD{Periods: []*Period{{ID: stringPtr("1"), Start: stringPtr("PT0S"), Duration: stringPtr("PT2M")}, {ID: stringPtr("2"), Start: stringPtr("PT2M")}}}
+	e, err := InsertAdBreakPeriod(m, 30*time.Second, 15*time.Second, []byte{0xfc})
+	c.Assert(err, IsNil)
+	c.Assert(m.Periods, HasLen, 4)
+	c.Check(*m.Periods[0].Duration, Equals, "PT30S")
+	c.Check(m.Periods[0].EventStreams, HasLen, 0)
+	c.Check(*m.Periods[1].ID, Equals, "1-ad-1")
+	c.Check(*m.Periods[1].Start, Equals, "PT30S")
+	c.Check(*m.Periods[1].Duration, Equals, "PT15S")
+	c.Check(e, Equals, &m.Periods[1].EventStreams[0].Events[0])
+	c.Check(*e.PresentationTime, Equals, int64(0))
+	c.Check(*m.Periods[2].ID, Equals, "1-after-ad-1")
+	c.Check(*m.Periods[2].Start, Equals, "PT45S")
+	c.Check(*m.Periods[2].Duration, Equals, "PT1M15S")
+
+	gaps, err := FindPeriodGaps(&MPD{AvailabilityStartTime: stringPtr("2016-01-01T00:00:00Z"), Periods: m.Periods})
+	c.Assert(err, IsNil)
+	c.Check(gaps, HasLen, 0)
+
+	_, err = InsertAdBreakPeriod(m, 10*time.Second, 5*time.Second, []byte{0xfc})
+	c.Assert(err, IsNil)
+	c.Check(*m.Periods[1].ID, Equals, "1-ad-2")
+	c.Check(*m.Periods[2].ID, Equals, "1-after-ad-2")
+
+	_, err = InsertAdBreakPeriod(m, 110*time.Second, 15*time.Second, []byte{0xfc})
+	c.Check(err, ErrorMatches, "InsertAdBreakPeriod: ad break ends after the end of Periods\\[4\\] at PT2M")
+	_, err = InsertAdBreakPeriod(m, 2*time.Minute, 15*time.Second, []byte{0xfc})
+	c.Check(err, ErrorMatches, "InsertAdBreakPeriod: ad break starts at the beginning of Periods\\[5\\]")
+	c.Check(m.Periods, HasLen, 6)
+
+	m = &MPD{Periods: []*Period{{Start: stringPtr("PT0S")}}}
+	_, err = InsertAdBreakPeriod(m, 30*time.Second, 15*time.Second, []byte{0xfc})
+	c.Assert(err, IsNil)
+	c.Assert(m.Periods, HasLen, 3)
+	c.Check(*m.Periods[2].ID, Equals, "period-after-ad-1")
+	c.Check(m.Periods[2].Duration, IsNil)
+}
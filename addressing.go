@@ -0,0 +1,129 @@
+package mpd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AddressingAdvice describes possible conversion of SegmentTimeline addressing to fixed @duration.
+type AddressingAdvice struct {
+	// Path locates SegmentTemplate, e.g. "Periods[0].AdaptationSets[1].SegmentTemplate".
+	Path string
+	// Collapsible is true if SegmentTimeline can be replaced with @duration without changing segment times.
+	Collapsible bool
+	// Reason explains why SegmentTimeline is not collapsible.
+	Reason string
+	// RequiresNumber is true if media template uses $Time$ and should be converted to $Number$,
+	// which changes segment URLs.
+	RequiresNumber bool
+	// BytesSaved is an estimated reduction of encoded MPD size.
+	BytesSaved int
+}
+
+// AdviseAddressing returns advice for every SegmentTemplate of m with SegmentTimeline.
+func AdviseAddressing(m *MPD) ([]AddressingAdvice, error) {
+	var res []AddressingAdvice
+	add := func(path string, t *SegmentTemplate) error {
+		if t == nil || len(t.SegmentTimeline) == 0 {
+			return nil
+		}
+		a := AddressingAdvice{Path: path + ".SegmentTemplate"}
+		if ct, err := collapsedTemplate(t, true); err != nil {
+			a.Reason = err.Error()
+		} else {
+			a.Collapsible = true
+			a.RequiresNumber = t.Media != nil && strings.Contains(*t.Media, "$Time")
+
+			before, err := encodedSize(t, 0)
+			if err != nil {
+				return err
+			}
+			after, err := encodedSize(ct, 0)
+			if err != nil {
+				return err
+			}
+			a.BytesSaved = before - after
+		}
+		res = append(res, a)
+		return nil
+	}
+
+	for i, p := range m.Periods {
+		for j, as := range p.AdaptationSets {
+			if err := add(adaptationSetPath(i, j), as.SegmentTemplate); err != nil {
+				return nil, err
+			}
+			for k := range as.Representations {
+				if err := add(representationPath(i, j, k), as.Representations[k].SegmentTemplate); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return res, nil
+}
+
+// CollapseTimeline replaces SegmentTimeline of t with fixed @duration if segment times allow it.
+// If media template uses $Time$, it's replaced with $Number$ only when renameTime is true,
+// as segments should be renamed accordingly.
+func CollapseTimeline(t *SegmentTemplate, renameTime bool) error {
+	ct, err := collapsedTemplate(t, renameTime)
+	if err != nil {
+		return fmt.Errorf("CollapseTimeline: %w", err)
+	}
+	*t = *ct
+	return nil
+}
+
+// collapsedTemplate returns copy of t with SegmentTimeline replaced by @duration.
+func collapsedTemplate(t *SegmentTemplate, renameTime bool) (*SegmentTemplate, error) {
+	var entries []SegmentTimelineSegment
+	for _, tl := range t.SegmentTimeline {
+		entries = append(entries, tl.Segments...)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("no SegmentTimeline")
+	}
+
+	d := entries[0].D
+	if d == 0 || d > 1<<32-1 {
+		return nil, fmt.Errorf("unsupported segment duration %d", d)
+	}
+	var start, next uint64
+	if entries[0].T != nil {
+		start = *entries[0].T
+	}
+	next = start
+	for i, s := range entries {
+		if s.T != nil && *s.T != next {
+			return nil, fmt.Errorf("gap or overlap at %d", next)
+		}
+		last := i == len(entries)-1
+		if s.D != d && !(last && s.D < d && (s.R == nil || *s.R == 0)) {
+			return nil, fmt.Errorf("segment duration %d differs from %d", s.D, d)
+		}
+		if s.R != nil && *s.R < 0 && !last {
+			return nil, fmt.Errorf("@r=-1 is followed by other segments")
+		}
+		n := uint64(1)
+		if s.R != nil && *s.R > 0 {
+			n += uint64(*s.R)
+		}
+		next += s.D * n
+	}
+
+	if pto := t.GetPresentationTimeOffset(); start != pto {
+		return nil, fmt.Errorf("first segment at %d doesn't start at presentationTimeOffset %d", start, pto)
+	}
+
+	res := *t
+	res.SegmentTimeline = nil
+	res.Duration = uint32Ptr(uint32(d))
+	if res.Media != nil && strings.Contains(*res.Media, "$Time") {
+		if !renameTime {
+			return nil, fmt.Errorf("media template uses $Time$")
+		}
+		res.Media = stringPtr(strings.Replace(strings.Replace(*res.Media, "$Time$", "$Number$", -1), "$Time%", "$Number%", -1))
+	}
+	return &res, nil
+}
@@ -0,0 +1,56 @@
+package mpd
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestAdviseAddressing(c *C) {
+	timeline := func(segments ...SegmentTimelineSegment) []SegmentTimeline {
+		return []SegmentTimeline{{Segments: segments}}
+	}
+
+	regular := &SegmentTemplate{Timescale: uint64Ptr(1000), Media: stringPtr("$Number$.m4s"), StartNumber: uint64Ptr(1), PresentationTimeOffset: uint64Ptr(2000),
+		SegmentTimeline: timeline(SegmentTimelineSegment{T: uint64Ptr(2000), D: 4000, R: int64Ptr(9)}, SegmentTimelineSegment{D: 1000})}
+	timed := &SegmentTemplate{Timescale: uint64Ptr(1000), Media: stringPtr("$Time$.m4s"),
+		SegmentTimeline: timeline(SegmentTimelineSegment{D: 4000}, SegmentTimelineSegment{D: 4000})}
+	irregular := &SegmentTemplate{Timescale: uint64Ptr(1000), Media: stringPtr("$Number$.m4s"),
+		SegmentTimeline: timeline(SegmentTimelineSegment{D: 4000}, SegmentTimelineSegment{D: 3000}, SegmentTimelineSegment{D: 4000})}
+	shifted := &SegmentTemplate{Timescale: uint64Ptr(1000), Media: stringPtr("$Number$.m4s"),
+		SegmentTimeline: timeline(SegmentTimelineSegment{T: uint64Ptr(2000), D: 4000, R: int64Ptr(2)})}
+
+	m := &MPD{Periods: []*Period{{AdaptationSets: []*AdaptationSet{
+		{SegmentTemplate: regular},
+		{Representations: []Representation{{}, {SegmentTemplate: timed}, {SegmentTemplate: irregular}, {SegmentTemplate: shifted}}},
+	}}}}
+	advice, err := AdviseAddressing(m)
+	c.Assert(err, IsNil)
+	c.Assert(advice, HasLen, 4)
+
+	c.Check(advice[0].Path, Equals, "Periods[0].AdaptationSets[0].SegmentTemplate")
+	c.Check(advice[0].Collapsible, Equals, true)
+	c.Check(advice[0].RequiresNumber, Equals, false)
+	c.Check(advice[0].BytesSaved > 0, Equals, true)
+
+	c.Check(advice[1].Path, Equals, "Periods[0].AdaptationSets[1].Representations[1].SegmentTemplate")
+	c.Check(advice[1].Collapsible, Equals, true)
+	c.Check(advice[1].RequiresNumber, Equals, true)
+
+	c.Check(advice[2].Collapsible, Equals, false)
+	c.Check(advice[2].Reason, Equals, "segment duration 3000 differs from 4000")
+	c.Check(advice[2].BytesSaved, Equals, 0)
+
+	c.Check(advice[3].Collapsible, Equals, false)
+	c.Check(advice[3].Reason, Equals, "first segment at 2000 doesn't start at presentationTimeOffset 0")
+
+	c.Assert(CollapseTimeline(regular, false), IsNil)
+	c.Check(regular.SegmentTimeline, IsNil)
+	c.Check(*regular.Duration, Equals, uint32(4000))
+	c.Check(*regular.PresentationTimeOffset, Equals, uint64(2000))
+	c.Check(CollapseTimeline(shifted, false), ErrorMatches, "CollapseTimeline: first segment at 2000 .*")
+
+	c.Check(CollapseTimeline(timed, false), ErrorMatches, `CollapseTimeline: media template uses \$Time\$`)
+	c.Assert(CollapseTimeline(timed, true), IsNil)
+	c.Check(*timed.Media, Equals, "$Number$.m4s")
+	c.Check(CollapseTimeline(irregular, true), NotNil)
+	c.Check(irregular.SegmentTimeline, NotNil)
+}
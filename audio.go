@@ -0,0 +1,185 @@
+package mpd
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// AudioChannelConfiguration schemes.
+const (
+	// value is a channel count
+	MPEGChannelConfigurationScheme = "urn:mpeg:dash:23003:3:audio_channel_configuration:2011"
+	// value is ISO/IEC 23091-3 ChannelConfiguration, used by MPEG-H
+	CICPChannelConfigurationScheme = "urn:mpeg:mpegB:cicp:ChannelConfiguration"
+	// value is 16-bit hex channel mask, used by AC-3 and E-AC-3
+	DolbyChannelConfigurationScheme = "tag:dolby.com,2014:dash:audio_channel_configuration:2011"
+	// value is 24-bit hex channel mask, used by AC-4
+	AC4ChannelConfigurationScheme = "tag:dolby.com,2015:dash:audio_channel_configuration:2015"
+	// value is a channel count, used by DTS
+	DTSChannelConfigurationScheme = "tag:dts.com,2014:dash:audio_channel_configuration:2012"
+	// value is 32-bit hex speaker mask, used by DTS-UHD
+	DTSUHDChannelConfigurationScheme = "tag:dts.com,2018:uhd:audio_channel_configuration"
+)
+
+// E-AC-3 extension descriptor schemes.
+const (
+	EC3ExtensionTypeScheme            = "tag:dolby.com,2018:dash:EC3_ExtensionType:2018"
+	EC3ExtensionComplexityIndexScheme = "tag:dolby.com,2018:dash:EC3_ExtensionComplexityIndex:2018"
+)
+
+// Channel masks of DolbyChannelConfigurationScheme.
+const (
+	DolbyMaskStereo = 0xA000
+	DolbyMask51     = 0xF801
+	DolbyMask71     = 0xFA01
+)
+
+// NewChannelCountConfiguration returns AudioChannelConfiguration with plain channel count.
+func NewChannelCountConfiguration(channels int) AudioChannelConfiguration {
+	return newAudioChannelConfiguration(MPEGChannelConfigurationScheme, strconv.Itoa(channels))
+}
+
+// NewCICPChannelConfiguration returns AudioChannelConfiguration with ISO/IEC 23091-3 ChannelConfiguration value,
+// e.g. 6 for 5.1 or 13 for 22.2.
+func NewCICPChannelConfiguration(cicp int) AudioChannelConfiguration {
+	return newAudioChannelConfiguration(CICPChannelConfigurationScheme, strconv.Itoa(cicp))
+}
+
+// NewDolbyChannelConfiguration returns AC-3/E-AC-3 AudioChannelConfiguration with 16-bit channel mask,
+// e.g. DolbyMask51.
+func NewDolbyChannelConfiguration(mask uint16) AudioChannelConfiguration {
+	return newAudioChannelConfiguration(DolbyChannelConfigurationScheme, fmt.Sprintf("%04X", mask))
+}
+
+// NewAC4ChannelConfiguration returns AC-4 AudioChannelConfiguration with 24-bit channel mask.
+func NewAC4ChannelConfiguration(mask uint32) (AudioChannelConfiguration, error) {
+	if mask > 0xFFFFFF {
+		return AudioChannelConfiguration{}, fmt.Errorf("NewAC4ChannelConfiguration: mask %#x exceeds 24 bits", mask)
+	}
+	return newAudioChannelConfiguration(AC4ChannelConfigurationScheme, fmt.Sprintf("%06X", mask)), nil
+}
+
+// SetEC3JOC sets up r as E-AC-3 with Joint Object Coding (Dolby Atmos): codecs, channel configuration of the
+// backward compatible core and JOC SupplementalProperty descriptors with given complexity index (1-16).
+func SetEC3JOC(r *Representation, mask uint16, complexityIndex int) error {
+	if complexityIndex < 1 || complexityIndex > 16 {
+		return fmt.Errorf("SetEC3JOC: invalid complexity index %d", complexityIndex)
+	}
+	codecs := "ec-3"
+	r.Codecs = &codecs
+	acc := NewDolbyChannelConfiguration(mask)
+	r.AudioChannelConfigurations = []AudioChannelConfiguration{acc}
+
+	removeDescriptors(&r.SupplementalProperties, EC3ExtensionTypeScheme)
+	removeDescriptors(&r.SupplementalProperties, EC3ExtensionComplexityIndexScheme)
+	r.SupplementalProperties = append(r.SupplementalProperties,
+		NewDescriptor(EC3ExtensionTypeScheme, "JOC"),
+		NewDescriptor(EC3ExtensionComplexityIndexScheme, strconv.Itoa(complexityIndex)),
+	)
+	return nil
+}
+
+// SetAC4 sets up r as AC-4 with given codecs (e.g. "ac-4.02.01.00") and 24-bit channel mask.
+func SetAC4(r *Representation, codecs string, mask uint32) error {
+	acc, err := NewAC4ChannelConfiguration(mask)
+	if err != nil {
+		return err
+	}
+	r.Codecs = &codecs
+	r.AudioChannelConfigurations = []AudioChannelConfiguration{acc}
+	return nil
+}
+
+// SetMPEGH sets up r as MPEG-H 3D Audio with given mpegh3daProfileLevelIndication and CICP channel configuration.
+func SetMPEGH(r *Representation, profileLevel byte, cicp int) {
+	codecs := fmt.Sprintf("mhm1.0x%02X", profileLevel)
+	r.Codecs = &codecs
+	acc := NewCICPChannelConfiguration(cicp)
+	r.AudioChannelConfigurations = []AudioChannelConfiguration{acc}
+}
+
+// cicpChannels maps ISO/IEC 23091-3 ChannelConfiguration values to channel counts.
+var cicpChannels = map[uint64]int{
+	1: 1, 2: 2, 3: 3, 4: 4, 5: 5, 6: 6, 7: 8, 8: 2, 9: 3, 10: 4,
+	11: 7, 12: 8, 13: 24, 14: 8, 15: 12, 16: 10, 17: 12, 18: 14, 19: 12, 20: 14,
+}
+
+// Bits of Dolby and AC-4 channel masks which signal pairs of channels.
+const (
+	dolbyPairBits = 1<<10 | 1<<9 | 1<<6 | 1<<5 | 1<<4 | 1<<2
+	ac4PairBits   = 1<<0 | 1<<2 | 1<<3 | 1<<4 | 1<<5 | 1<<7 | 1<<8 | 1<<13 | 1<<16 | 1<<17 | 1<<18
+)
+
+// ChannelCount returns number of audio channels (including LFE) described by acc.
+func (acc *AudioChannelConfiguration) ChannelCount() (int, error) {
+	if acc.SchemeIDURI == nil || acc.Value == nil {
+		return 0, fmt.Errorf("ChannelCount: schemeIdUri and value are required")
+	}
+	scheme, value := *acc.SchemeIDURI, *acc.Value
+
+	parse := func(base, bits int) (uint64, error) {
+		v, err := strconv.ParseUint(value, base, bits)
+		if err != nil {
+			return 0, fmt.Errorf("ChannelCount: invalid value %q for %s", value, scheme)
+		}
+		return v, nil
+	}
+	countMask := func(mask, pairs uint64) int {
+		var n int
+		for bit := uint64(1); bit <= mask; bit <<= 1 {
+			switch {
+			case mask&bit == 0:
+			case pairs&bit != 0:
+				n += 2
+			default:
+				n++
+			}
+		}
+		return n
+	}
+
+	switch scheme {
+	case MPEGChannelConfigurationScheme, DTSChannelConfigurationScheme:
+		v, err := parse(10, 16)
+		return int(v), err
+	case CICPChannelConfigurationScheme:
+		v, err := parse(10, 8)
+		if err != nil {
+			return 0, err
+		}
+		n, ok := cicpChannels[v]
+		if !ok {
+			return 0, fmt.Errorf("ChannelCount: unknown ChannelConfiguration %d", v)
+		}
+		return n, nil
+	case DolbyChannelConfigurationScheme:
+		v, err := parse(16, 16)
+		return countMask(v, dolbyPairBits), err
+	case AC4ChannelConfigurationScheme:
+		v, err := parse(16, 24)
+		return countMask(v, ac4PairBits), err
+	case DTSUHDChannelConfigurationScheme:
+		v, err := parse(16, 32)
+		return countMask(v, 0), err
+	}
+	return 0, fmt.Errorf("ChannelCount: unknown scheme %q", scheme)
+}
+
+// CICPChannelConfiguration returns ISO/IEC 23091-3 ChannelConfiguration of acc with CICPChannelConfigurationScheme.
+func (acc *AudioChannelConfiguration) CICPChannelConfiguration() (ChannelConfiguration, error) {
+	if acc.SchemeIDURI == nil || *acc.SchemeIDURI != CICPChannelConfigurationScheme {
+		return 0, fmt.Errorf("CICPChannelConfiguration: scheme is not %s", CICPChannelConfigurationScheme)
+	}
+	if acc.Value == nil {
+		return 0, fmt.Errorf("CICPChannelConfiguration: value is required")
+	}
+	v, err := parseCICPCode(*acc.Value)
+	if err != nil {
+		return 0, fmt.Errorf("CICPChannelConfiguration: invalid value %q", *acc.Value)
+	}
+	return ChannelConfiguration(v), nil
+}
+
+func newAudioChannelConfiguration(scheme, value string) AudioChannelConfiguration {
+	return AudioChannelConfiguration{SchemeIDURI: &scheme, Value: &value}
+}
@@ -0,0 +1,64 @@
+package mpd
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestAudioChannelConfiguration(c *C) {
+	acc := NewDolbyChannelConfiguration(DolbyMask51)
+	c.Check(*acc.SchemeIDURI, Equals, DolbyChannelConfigurationScheme)
+	c.Check(*acc.Value, Equals, "F801")
+
+	acc, err := NewAC4ChannelConfiguration(0x47)
+	c.Assert(err, IsNil)
+	c.Check(*acc.Value, Equals, "000047")
+	_, err = NewAC4ChannelConfiguration(0x1000000)
+	c.Check(err, NotNil)
+
+	c.Check(*NewCICPChannelConfiguration(6).Value, Equals, "6")
+	c.Check(*NewChannelCountConfiguration(2).Value, Equals, "2")
+
+	r := new(Representation)
+	c.Assert(SetEC3JOC(r, DolbyMask51, 16), IsNil)
+	c.Check(*r.Codecs, Equals, "ec-3")
+	c.Check(*r.AudioChannelConfigurations[0].Value, Equals, "F801")
+	c.Check(r.SupplementalProperties, DeepEquals, []Descriptor{
+		NewDescriptor(EC3ExtensionTypeScheme, "JOC"),
+		NewDescriptor(EC3ExtensionComplexityIndexScheme, "16"),
+	})
+	c.Check(SetEC3JOC(r, DolbyMask51, 17), ErrorMatches, "SetEC3JOC: invalid complexity index 17")
+
+	SetMPEGH(r, 0x0D, 13)
+	c.Check(*r.Codecs, Equals, "mhm1.0x0D")
+	c.Check(*r.AudioChannelConfigurations[0].SchemeIDURI, Equals, CICPChannelConfigurationScheme)
+}
+
+func (s *MPDSuite) TestChannelCount(c *C) {
+	ac4, err := NewAC4ChannelConfiguration(0x47)
+	c.Assert(err, IsNil)
+	for acc, expected := range map[AudioChannelConfiguration]int{
+		NewChannelCountConfiguration(2):               2,
+		NewCICPChannelConfiguration(6):                6,
+		NewCICPChannelConfiguration(13):               24,
+		NewDolbyChannelConfiguration(DolbyMaskStereo): 2,
+		NewDolbyChannelConfiguration(DolbyMask51):     6,
+		NewDolbyChannelConfiguration(DolbyMask71):     8,
+		ac4: 6,
+		newAudioChannelConfiguration(DTSChannelConfigurationScheme, "6"):           6,
+		newAudioChannelConfiguration(DTSUHDChannelConfigurationScheme, "0000003F"): 6,
+	} {
+		n, err := acc.ChannelCount()
+		c.Check(err, IsNil)
+		c.Check(n, Equals, expected, Commentf("%s %s", *acc.SchemeIDURI, *acc.Value))
+	}
+
+	acc := NewCICPChannelConfiguration(99)
+	_, err = acc.ChannelCount()
+	c.Check(err, ErrorMatches, "ChannelCount: unknown ChannelConfiguration 99")
+	acc = newAudioChannelConfiguration(DolbyChannelConfigurationScheme, "xyz")
+	_, err = acc.ChannelCount()
+	c.Check(err, ErrorMatches, `ChannelCount: invalid value "xyz" for .*`)
+	acc = newAudioChannelConfiguration("urn:example", "2")
+	_, err = acc.ChannelCount()
+	c.Check(err, ErrorMatches, `ChannelCount: unknown scheme "urn:example"`)
+}
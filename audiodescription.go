@@ -0,0 +1,84 @@
+package mpd
+
+// AudioPurposeScheme is a schemeIdUri of TV-Anytime audio purpose Accessibility descriptors used by DVB-DASH.
+const AudioPurposeScheme = "urn:tva:metadata:cs:AudioPurposeCS:2007"
+
+// audioPurposeVisuallyImpaired is AudioPurposeCS value of audio description.
+const audioPurposeVisuallyImpaired = "1"
+
+// MarkAudioDescription marks audio AdaptationSet as as broadcast-mix audio description:
+// "alternate" Role, DVB-DASH audio purpose and DASH "description" Accessibility descriptors.
+// "main" Role is removed, so clients don't select the set by default.
+func MarkAudioDescription(as *AdaptationSet) {
+	removeRole(as, "main")
+	if !as.HasRole("alternate") {
+		as.Roles = append(as.Roles, NewDescriptor(RoleScheme, "alternate"))
+	}
+	removeDescriptors(&as.Accessibility, AudioPurposeScheme)
+	as.Accessibility = append(as.Accessibility, NewDescriptor(AudioPurposeScheme, audioPurposeVisuallyImpaired))
+	if !hasDescriptorValue(as.Accessibility, RoleScheme, "description") {
+		as.Accessibility = append(as.Accessibility, NewDescriptor(RoleScheme, "description"))
+	}
+}
+
+// IsAudioDescription returns true if as is marked as audio description with either DVB-DASH or DASH descriptors.
+func IsAudioDescription(as *AdaptationSet) bool {
+	return hasDescriptorValue(as.Accessibility, AudioPurposeScheme, audioPurposeVisuallyImpaired) ||
+		hasDescriptorValue(as.Accessibility, RoleScheme, "description")
+}
+
+// MarkDub marks audio AdaptationSet as as dubbed, i.e. in a language other than the original one.
+func MarkDub(as *AdaptationSet) {
+	if !as.HasRole("dub") {
+		as.Roles = append(as.Roles, NewDescriptor(RoleScheme, "dub"))
+	}
+}
+
+// IsDub returns true if as is marked as dubbed.
+func IsDub(as *AdaptationSet) bool {
+	return as.HasRole("dub")
+}
+
+// AudioDescriptions returns audio description AdaptationSets of p.
+func (p *Period) AudioDescriptions() []*AdaptationSet {
+	return p.filterAdaptationSets(IsAudioDescription)
+}
+
+// Dubs returns dubbed AdaptationSets of p.
+func (p *Period) Dubs() []*AdaptationSet {
+	return p.filterAdaptationSets(IsDub)
+}
+
+func (p *Period) filterAdaptationSets(f func(*AdaptationSet) bool) []*AdaptationSet {
+	var res []*AdaptationSet
+	for _, as := range p.AdaptationSets {
+		if f(as) {
+			res = append(res, as)
+		}
+	}
+	return res
+}
+
+// removeRole removes Role descriptors with given value from as.
+func removeRole(as *AdaptationSet, value string) {
+	res := as.Roles[:0]
+	for _, r := range as.Roles {
+		if !r.Is(RoleScheme) || r.Value == nil || *r.Value != value {
+			res = append(res, r)
+		}
+	}
+	if len(res) == 0 {
+		res = nil
+	}
+	as.Roles = res
+}
+
+// hasDescriptorValue returns true if list contains descriptor with given schemeIdUri and value.
+func hasDescriptorValue(list []Descriptor, schemeIDURI, value string) bool {
+	for _, d := range list {
+		if d.Is(schemeIDURI) && d.Value != nil && *d.Value == value {
+			return true
+		}
+	}
+	return false
+}
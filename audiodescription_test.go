@@ -0,0 +1,27 @@
+package mpd
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestAudioDescription(c *C) {
+	main := &AdaptationSet{MimeType: MimeTypeAudioMP4, Roles: []Descriptor{NewDescriptor(RoleScheme, "main")}}
+	ad := &AdaptationSet{MimeType: MimeTypeAudioMP4, Roles: []Descriptor{NewDescriptor(RoleScheme, "main")}}
+	dub := &AdaptationSet{MimeType: MimeTypeAudioMP4}
+	dvb := &AdaptationSet{MimeType: MimeTypeAudioMP4, Accessibility: []Descriptor{NewDescriptor(AudioPurposeScheme, "1")}}
+	p := &Period{AdaptationSets: []*AdaptationSet{main, ad, dub, dvb}}
+
+	MarkAudioDescription(ad)
+	MarkAudioDescription(ad)
+	c.Check(ad.Roles, DeepEquals, []Descriptor{NewDescriptor(RoleScheme, "alternate")})
+	c.Check(ad.Accessibility, DeepEquals, []Descriptor{
+		NewDescriptor(RoleScheme, "description"),
+		NewDescriptor(AudioPurposeScheme, "1"),
+	})
+	MarkDub(dub)
+	c.Check(IsDub(dub), Equals, true)
+
+	c.Check(p.AudioDescriptions(), DeepEquals, []*AdaptationSet{ad, dvb})
+	c.Check(p.Dubs(), DeepEquals, []*AdaptationSet{dub})
+	c.Check(p.Select(SelectionCriteria{}).Audio, Equals, main)
+}
@@ -0,0 +1,107 @@
+package mpd
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// EstimateBandwidth returns @bandwidth of Representation r of AdaptationSet as in Period p of m computed
+// from measured byte sizes of its first media segments, as ISO/IEC 23009-1 defines it: the lowest rate in bits
+// per second such that, delivering segments continuously from any segment on, a client which starts playout
+// after buffering for MPD@minBufferTime receives each segment before its playout starts.
+func EstimateBandwidth(m *MPD, p *Period, as *AdaptationSet, r *Representation, sizes []uint64) (uint64, error) {
+	if m.MinBufferTime == nil {
+		return 0, fmt.Errorf("EstimateBandwidth: no MPD@minBufferTime")
+	}
+	minBufferTime, err := ParseDuration(*m.MinBufferTime)
+	if err != nil {
+		return 0, fmt.Errorf("EstimateBandwidth: %w", err)
+	}
+	if minBufferTime <= 0 {
+		return 0, fmt.Errorf("EstimateBandwidth: non-positive MPD@minBufferTime")
+	}
+
+	segments, err := Segments("", m, p, as, r)
+	if err != nil {
+		return 0, fmt.Errorf("EstimateBandwidth: %w", err)
+	}
+	var starts []time.Duration
+	for _, s := range segments {
+		if s.Kind == MediaSegment && len(starts) < len(sizes) {
+			starts = append(starts, timescaled(s.Time, &s.Timescale))
+		}
+	}
+	if len(starts) < len(sizes) {
+		return 0, fmt.Errorf("EstimateBandwidth: %d sizes for %d media segments", len(sizes), len(starts))
+	}
+	return peakBandwidth(starts, sizes, minBufferTime), nil
+}
+
+// peakBandwidth returns the lowest rate delivering every run of segments with given start times and sizes
+// in time: the whole run should be received within minBufferTime plus duration of the run except its last segment.
+func peakBandwidth(starts []time.Duration, sizes []uint64, minBufferTime time.Duration) uint64 {
+	var res float64
+	for i := range sizes {
+		var bits float64
+		for j := i; j < len(sizes); j++ {
+			bits += float64(sizes[j]) * 8
+			if v := bits / (minBufferTime + starts[j] - starts[i]).Seconds(); v > res {
+				res = v
+			}
+		}
+	}
+	return uint64(math.Ceil(res))
+}
+
+// CheckBandwidth estimates bandwidth of Representations of m from measured segment sizes as EstimateBandwidth
+// does and reports Representations with @bandwidth differing from estimation by more than threshold fraction,
+// e.g. 0.1 for 10%. Representations without sizes are skipped.
+func CheckBandwidth(m *MPD, sizes map[*Representation][]uint64, threshold float64) (ValidationErrors, error) {
+	var res ValidationErrors
+	err := estimateBandwidths(m, sizes, func(path string, r *Representation, v uint64) {
+		switch {
+		case r.Bandwidth == nil:
+			res = append(res, newValidationError(path, "no @bandwidth, estimated %d", v))
+		case math.Abs(float64(*r.Bandwidth)-float64(v)) > threshold*float64(v):
+			res = append(res, newValidationError(path, "@bandwidth %d differs from estimated %d", *r.Bandwidth, v))
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("CheckBandwidth: %w", err)
+	}
+	return res, nil
+}
+
+// UpdateBandwidth sets @bandwidth of Representations of m to values estimated from measured segment sizes
+// as EstimateBandwidth does. Representations without sizes are left unchanged.
+func (m *MPD) UpdateBandwidth(sizes map[*Representation][]uint64) error {
+	err := estimateBandwidths(m, sizes, func(path string, r *Representation, v uint64) {
+		r.Bandwidth = &v
+	})
+	if err != nil {
+		return fmt.Errorf("UpdateBandwidth: %w", err)
+	}
+	return nil
+}
+
+// estimateBandwidths calls f for each Representation of m listed in sizes with its estimated bandwidth.
+func estimateBandwidths(m *MPD, sizes map[*Representation][]uint64, f func(path string, r *Representation, v uint64)) error {
+	for i, p := range m.Periods {
+		for j, as := range p.AdaptationSets {
+			for k := range as.Representations {
+				r := &as.Representations[k]
+				list, ok := sizes[r]
+				if !ok {
+					continue
+				}
+				v, err := EstimateBandwidth(m, p, as, r, list)
+				if err != nil {
+					return fmt.Errorf("%s: %w", representationPath(i, j, k), err)
+				}
+				f(representationPath(i, j, k), r, v)
+			}
+		}
+	}
+	return nil
+}
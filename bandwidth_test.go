@@ -0,0 +1,47 @@
+package mpd
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestEstimateBandwidth(c *C) {
+	m := &MPD{MinBufferTime: stringPtr("PT2S"), MediaPresentationDuration: stringPtr("PT8S"), Periods: []*Period{{AdaptationSets: []*AdaptationSet{{
+		SegmentTemplate: &SegmentTemplate{Media: stringPtr("$Number$.m4s"), Timescale: uint64Ptr(1000), Duration: uint32Ptr(2000)},
+		Representations: []Representation{{ID: stringPtr("v1")}, {ID: stringPtr("v2")}},
+	}}}}}
+	p := m.Periods[0]
+	as := p.AdaptationSets[0]
+
+	// 2 Mbit per 2 s segment
+	b, err := EstimateBandwidth(m, p, as, &as.Representations[0], []uint64{250000, 250000, 250000, 250000})
+	c.Assert(err, IsNil)
+	c.Check(b, Equals, uint64(1000000))
+
+	// 6 Mbit segment should be received within minBufferTime
+	b, err = EstimateBandwidth(m, p, as, &as.Representations[0], []uint64{250000, 750000, 250000})
+	c.Assert(err, IsNil)
+	c.Check(b, Equals, uint64(3000000))
+
+	_, err = EstimateBandwidth(m, p, as, &as.Representations[0], []uint64{1, 1, 1, 1, 1})
+	c.Check(err, ErrorMatches, "EstimateBandwidth: 5 sizes for 4 media segments")
+	m.MinBufferTime = nil
+	_, err = EstimateBandwidth(m, p, as, &as.Representations[0], []uint64{1})
+	c.Check(err, ErrorMatches, "EstimateBandwidth: no MPD@minBufferTime")
+	m.MinBufferTime = stringPtr("PT2S")
+
+	as.Representations[0].Bandwidth = uint64Ptr(1050000)
+	as.Representations[1].Bandwidth = uint64Ptr(2000000)
+	sizes := map[*Representation][]uint64{
+		&as.Representations[0]: {250000, 250000},
+		&as.Representations[1]: {250000, 250000},
+	}
+	errs, err := CheckBandwidth(m, sizes, 0.1)
+	c.Assert(err, IsNil)
+	c.Check(errs, DeepEquals, ValidationErrors{
+		newValidationError("Periods[0].AdaptationSets[0].Representations[1]", "@bandwidth 2000000 differs from estimated 1000000"),
+	})
+
+	c.Assert(m.UpdateBandwidth(sizes), IsNil)
+	c.Check(*as.Representations[0].Bandwidth, Equals, uint64(1000000))
+	c.Check(*as.Representations[1].Bandwidth, Equals, uint64(1000000))
+}
@@ -0,0 +1,50 @@
+package mpd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ByteRange is a parsed byte range like "0-499" used by @range, @mediaRange and @indexRange.
+// Both positions are inclusive.
+type ByteRange struct {
+	First uint64
+	Last  uint64
+}
+
+// ParseByteRange parses byte range in "first-last" form.
+func ParseByteRange(s string) (ByteRange, error) {
+	i := strings.IndexByte(s, '-')
+	if i < 0 {
+		return ByteRange{}, fmt.Errorf("ParseByteRange: invalid byte range %q", s)
+	}
+	first, err1 := strconv.ParseUint(s[:i], 10, 64)
+	last, err2 := strconv.ParseUint(s[i+1:], 10, 64)
+	if err1 != nil || err2 != nil || last < first {
+		return ByteRange{}, fmt.Errorf("ParseByteRange: invalid byte range %q", s)
+	}
+	return ByteRange{First: first, Last: last}, nil
+}
+
+// String returns byte range in "first-last" form.
+func (r ByteRange) String() string {
+	return formatByteRange(r.First, r.Last)
+}
+
+// Length returns number of bytes in range.
+func (r ByteRange) Length() uint64 {
+	return r.Last - r.First + 1
+}
+
+// ByteRange returns parsed @range, or nil if it is absent.
+func (u *URLType) ByteRange() (*ByteRange, error) {
+	if u.Range == nil {
+		return nil, nil
+	}
+	r, err := ParseByteRange(*u.Range)
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
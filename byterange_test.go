@@ -0,0 +1,27 @@
+package mpd
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestByteRange(c *C) {
+	r, err := ParseByteRange("100-599")
+	c.Assert(err, IsNil)
+	c.Check(r, Equals, ByteRange{First: 100, Last: 599})
+	c.Check(r.Length(), Equals, uint64(500))
+	c.Check(r.String(), Equals, "100-599")
+
+	for _, s := range []string{"", "100", "-5", "5-", "10-9", "a-b"} {
+		_, err = ParseByteRange(s)
+		c.Check(err, ErrorMatches, "ParseByteRange: invalid byte range .*", Commentf("%s", s))
+	}
+
+	u := &URLType{SourceURL: stringPtr("init.mp4")}
+	br, err := u.ByteRange()
+	c.Check(err, IsNil)
+	c.Check(br, IsNil)
+	u.Range = stringPtr("0-861")
+	br, err = u.ByteRange()
+	c.Assert(err, IsNil)
+	c.Check(*br, Equals, ByteRange{First: 0, Last: 861})
+}
@@ -0,0 +1,51 @@
+package mpd
+
+import (
+	"strings"
+)
+
+// CallbackEventScheme is a schemeIdUri of DASH callback events: a client issues HTTP GET to the URL in Event@messageData.
+const CallbackEventScheme = "urn:mpeg:dash:event:callback:2015"
+
+// CallbackEvent is a callback Event found by CallbackEvents.
+type CallbackEvent struct {
+	Event *Event
+	// Timescale of EventStream containing Event.
+	Timescale int64
+	URL       string
+}
+
+// AddCallbackEvent adds callback Event for url at presentationTime in EventStream timescale units (1 for new streams).
+func (p *Period) AddCallbackEvent(presentationTime int64, url string) *Event {
+	es := p.eventStream(CallbackEventScheme, "1", 1)
+	es.Events = append(es.Events, Event{
+		ID:               stringPtr(es.nextEventID()),
+		PresentationTime: &presentationTime,
+		MessageData:      stringPtr(url),
+	})
+	return &es.Events[len(es.Events)-1]
+}
+
+// CallbackEvents returns all callback Events of p with their URLs.
+// URL is taken from Event@messageData or, if absent, from Event content.
+func (p *Period) CallbackEvents() []CallbackEvent {
+	var res []CallbackEvent
+	for i := range p.EventStreams {
+		es := &p.EventStreams[i]
+		if es.SchemeIDURI == nil || *es.SchemeIDURI != CallbackEventScheme {
+			continue
+		}
+		for j := range es.Events {
+			e := &es.Events[j]
+			u := strings.TrimSpace(e.Content)
+			if e.MessageData != nil {
+				u = *e.MessageData
+			}
+			if u == "" {
+				continue
+			}
+			res = append(res, CallbackEvent{Event: e, Timescale: eventTimescale(es), URL: u})
+		}
+	}
+	return res
+}
@@ -0,0 +1,38 @@
+package mpd
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestCallbackEvents(c *C) {
+	p := new(Period)
+	p.AddCallbackEvent(10, "https://beacon.example.com/start")
+	p.AddCallbackEvent(20, "https://beacon.example.com/mid")
+
+	m := &MPD{Periods: []*Period{p}}
+	b, err := m.Encode()
+	c.Assert(err, IsNil)
+	c.Check(string(b), Equals, `<?xml version="1.0" encoding="utf-8"?>
+<MPD profiles="">
+  <Period>
+    <EventStream schemeIdUri="urn:mpeg:dash:event:callback:2015" value="1" timescale="1">
+      <Event id="1" presentationTime="10" messageData="https://beacon.example.com/start"/>
+      <Event id="2" presentationTime="20" messageData="https://beacon.example.com/mid"/>
+    </EventStream>
+  </Period>
+</MPD>
+`)
+
+	decoded := new(MPD)
+	c.Assert(decoded.Decode([]byte(`<MPD><Period>
+<EventStream schemeIdUri="urn:mpeg:dash:event:callback:2015" value="1" timescale="1000">
+  <Event presentationTime="1500"> https://beacon.example.com/content </Event>
+</EventStream>
+<EventStream schemeIdUri="urn:scte:scte35:2014:xml+bin"><Event><Signal xmlns="http://www.scte.org/schemas/35/2016"><Binary>/DAR</Binary></Signal></Event></EventStream>
+</Period></MPD>`)), IsNil)
+	events := decoded.Periods[0].CallbackEvents()
+	c.Assert(events, HasLen, 1)
+	c.Check(events[0].URL, Equals, "https://beacon.example.com/content")
+	c.Check(events[0].Timescale, Equals, int64(1000))
+	c.Check(*events[0].Event.PresentationTime, Equals, int64(1500))
+}
@@ -0,0 +1,73 @@
+package mpd
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Canonicalize rewrites m in canonical form, so equal presentations are encoded identically:
+// durations and dateTimes are reformatted with FormatDuration and FormatDateTime, @lang and
+// cenc:default_KID values are normalized, Representations are sorted by @bandwidth, and namespaces
+// are declared on MPD element with default prefixes.
+// It returns error for invalid values, leaving m partially canonicalized.
+func (m *MPD) Canonicalize() error {
+	durations := []*string{
+		m.MediaPresentationDuration, m.MinBufferTime, m.MinimumUpdatePeriod, m.SuggestedPresentationDelay,
+		m.TimeShiftBufferDepth, m.MaxSegmentDuration,
+	}
+	for _, p := range m.Periods {
+		durations = append(durations, p.Start, p.Duration)
+	}
+	for _, d := range durations {
+		if d == nil {
+			continue
+		}
+		v, err := ParseDuration(*d)
+		if err != nil {
+			return fmt.Errorf("Canonicalize: %w", err)
+		}
+		*d = FormatDuration(v)
+	}
+	for _, t := range []*string{m.AvailabilityStartTime, m.PublishTime} {
+		if t == nil {
+			continue
+		}
+		v, err := ParseDateTime(*t)
+		if err != nil {
+			return fmt.Errorf("Canonicalize: %w", err)
+		}
+		*t = FormatDateTime(v)
+	}
+
+	m.canonicalizeLanguages()
+	if err := m.NormalizeDefaultKIDs(); err != nil {
+		return fmt.Errorf("Canonicalize: %w", err)
+	}
+
+	var cenc, mspr bool
+	for _, p := range m.Periods {
+		for _, as := range p.AdaptationSets {
+			cps := as.ContentProtections
+			for _, r := range as.Representations {
+				cps = append(cps[:len(cps):len(cps)], r.ContentProtections...)
+			}
+			for _, cp := range cps {
+				cenc = cenc || cp.DefaultKID != nil || cp.Pssh != nil
+				mspr = mspr || cp.Pro != nil
+			}
+			sort.SliceStable(as.Representations, func(i, j int) bool {
+				return as.Representations[i].GetBandwidth() < as.Representations[j].GetBandwidth()
+			})
+		}
+	}
+
+	m.XMLNS = stringPtr(MPDNamespace)
+	m.NamespacePrefixes = nil
+	if cenc {
+		m.Cenc = stringPtr(CENCNamespace)
+	}
+	if mspr {
+		m.Mspr = stringPtr(MSPRNamespace)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package mpd
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestCanonicalize(c *C) {
+	b := []byte(`<?xml version="1.0"?>
+<dash:MPD xmlns:dash="urn:mpeg:dash:schema:mpd:2011" xmlns:c="urn:mpeg:cenc:2013" type="dynamic" profiles="urn:mpeg:dash:profile:isoff-live:2011"
+    availabilityStartTime="2016-01-01T03:00:00+03:00" minBufferTime="PT2.000S" minimumUpdatePeriod="PT0H1M0S">
+  <dash:Period start="PT0.0S">
+    <dash:AdaptationSet mimeType="video/mp4" lang="EN-us">
+      <dash:ContentProtection schemeIdUri="urn:mpeg:dash:mp4protection:2011" c:default_KID="10000000100010001000100000000000"/>
+      <dash:Representation id="high" bandwidth="3000000"/>
+      <dash:Representation id="low" bandwidth="500000"/>
+    </dash:AdaptationSet>
+  </dash:Period>
+</dash:MPD>`)
+	m := new(MPD)
+	c.Assert(m.Decode(b), IsNil)
+	c.Assert(m.Canonicalize(), IsNil)
+	enc, err := m.Encode()
+	c.Assert(err, IsNil)
+	c.Check(string(enc), Equals, `<?xml version="1.0" encoding="utf-8"?>
+<MPD xmlns="urn:mpeg:dash:schema:mpd:2011" xmlns:cenc="urn:mpeg:cenc:2013" type="dynamic" minimumUpdatePeriod="PT1M" availabilityStartTime="2016-01-01T00:00:00Z" minBufferTime="PT2S" profiles="urn:mpeg:dash:profile:isoff-live:2011">
+  <Period start="PT0S">
+    <AdaptationSet mimeType="video/mp4" lang="en-US">
+      <ContentProtection schemeIdUri="urn:mpeg:dash:mp4protection:2011" cenc:default_KID="10000000-1000-1000-1000-100000000000"/>
+      <Representation id="low" bandwidth="500000"/>
+      <Representation id="high" bandwidth="3000000"/>
+    </AdaptationSet>
+  </Period>
+</MPD>
+`)
+
+	m.MinBufferTime = stringPtr("2s")
+	c.Check(m.Canonicalize(), ErrorMatches, `Canonicalize: ParseDuration: .*`)
+}
@@ -0,0 +1,119 @@
+package mpd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Accessibility schemes of closed captions embedded in video.
+const (
+	CEA608Scheme = "urn:scte:dash:cc:cea-608:2015"
+	CEA708Scheme = "urn:scte:dash:cc:cea-708:2015"
+)
+
+// SetCEA608 replaces CEA-608 Accessibility descriptor of video AdaptationSet as
+// with a mapping of channels ("CC1" to "CC4") to languages.
+func (as *AdaptationSet) SetCEA608(channels map[string]string) error {
+	keys := make([]string, 0, len(channels))
+	for ch := range channels {
+		if ch != "CC1" && ch != "CC2" && ch != "CC3" && ch != "CC4" {
+			return fmt.Errorf("SetCEA608: invalid channel %q", ch)
+		}
+		keys = append(keys, ch)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, len(keys))
+	for i, ch := range keys {
+		parts[i] = ch + "=" + channels[ch]
+	}
+	removeDescriptors(&as.Accessibility, CEA608Scheme)
+	as.Accessibility = append(as.Accessibility, NewDescriptor(CEA608Scheme, strings.Join(parts, ";")))
+	return nil
+}
+
+// CEA608 returns a mapping of CEA-608 channels to languages from Accessibility descriptor of as, or nil.
+// Languages without channel numbers are assigned to channels CC1 to CC4 in order.
+func (as *AdaptationSet) CEA608() (map[string]string, error) {
+	d := findDescriptor(as.Accessibility, CEA608Scheme)
+	if d == nil {
+		return nil, nil
+	}
+	res := make(map[string]string)
+	if d.Value == nil {
+		return res, nil
+	}
+	for i, part := range strings.Split(*d.Value, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		ch, lang := fmt.Sprintf("CC%d", i+1), part
+		if p := strings.Index(part, "="); p >= 0 {
+			ch, lang = part[:p], part[p+1:]
+		}
+		if ch != "CC1" && ch != "CC2" && ch != "CC3" && ch != "CC4" {
+			return nil, fmt.Errorf("CEA608: invalid channel %q", ch)
+		}
+		res[ch] = lang
+	}
+	return res, nil
+}
+
+// SetCEA708 replaces CEA-708 Accessibility descriptor of video AdaptationSet as
+// with a mapping of service numbers (1 to 63) to languages.
+func (as *AdaptationSet) SetCEA708(services map[int]string) error {
+	keys := make([]int, 0, len(services))
+	for n := range services {
+		if n < 1 || n > 63 {
+			return fmt.Errorf("SetCEA708: invalid service number %d", n)
+		}
+		keys = append(keys, n)
+	}
+	sort.Ints(keys)
+
+	parts := make([]string, len(keys))
+	for i, n := range keys {
+		parts[i] = strconv.Itoa(n) + "=lang:" + services[n]
+	}
+	removeDescriptors(&as.Accessibility, CEA708Scheme)
+	as.Accessibility = append(as.Accessibility, NewDescriptor(CEA708Scheme, strings.Join(parts, ";")))
+	return nil
+}
+
+// CEA708 returns a mapping of CEA-708 service numbers to languages from Accessibility descriptor of as, or nil.
+// Other service parameters (like "war" and "er") are ignored.
+func (as *AdaptationSet) CEA708() (map[int]string, error) {
+	d := findDescriptor(as.Accessibility, CEA708Scheme)
+	if d == nil {
+		return nil, nil
+	}
+	res := make(map[int]string)
+	if d.Value == nil {
+		return res, nil
+	}
+	for _, part := range strings.Split(*d.Value, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		p := strings.Index(part, "=")
+		if p < 0 {
+			return nil, fmt.Errorf("CEA708: expected service number in %q", part)
+		}
+		n, err := strconv.Atoi(part[:p])
+		if err != nil || n < 1 || n > 63 {
+			return nil, fmt.Errorf("CEA708: invalid service number %q", part[:p])
+		}
+		var lang string
+		for _, param := range strings.Split(part[p+1:], ",") {
+			if strings.HasPrefix(param, "lang:") {
+				lang = param[5:]
+			}
+		}
+		res[n] = lang
+	}
+	return res, nil
+}
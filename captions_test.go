@@ -0,0 +1,40 @@
+package mpd
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestCEA608(c *C) {
+	as := new(AdaptationSet)
+	channels, err := as.CEA608()
+	c.Check(err, IsNil)
+	c.Check(channels, IsNil)
+
+	c.Assert(as.SetCEA608(map[string]string{"CC3": "swe", "CC1": "eng"}), IsNil)
+	c.Check(as.Accessibility, DeepEquals, []Descriptor{NewDescriptor(CEA608Scheme, "CC1=eng;CC3=swe")})
+	channels, err = as.CEA608()
+	c.Assert(err, IsNil)
+	c.Check(channels, DeepEquals, map[string]string{"CC1": "eng", "CC3": "swe"})
+
+	as.Accessibility = []Descriptor{NewDescriptor(CEA608Scheme, "eng;deu")}
+	channels, err = as.CEA608()
+	c.Assert(err, IsNil)
+	c.Check(channels, DeepEquals, map[string]string{"CC1": "eng", "CC2": "deu"})
+
+	c.Check(as.SetCEA608(map[string]string{"CC5": "eng"}), ErrorMatches, `SetCEA608: invalid channel "CC5"`)
+}
+
+func (s *MPDSuite) TestCEA708(c *C) {
+	as := new(AdaptationSet)
+	c.Assert(as.SetCEA708(map[int]string{2: "deu", 1: "eng"}), IsNil)
+	c.Check(as.Accessibility, DeepEquals, []Descriptor{NewDescriptor(CEA708Scheme, "1=lang:eng;2=lang:deu")})
+
+	as.Accessibility = []Descriptor{NewDescriptor(CEA708Scheme, "1=lang:eng,war:1,er:1;3=lang:spa")}
+	services, err := as.CEA708()
+	c.Assert(err, IsNil)
+	c.Check(services, DeepEquals, map[int]string{1: "eng", 3: "spa"})
+
+	as.Accessibility = []Descriptor{NewDescriptor(CEA708Scheme, "eng")}
+	_, err = as.CEA708()
+	c.Check(err, NotNil)
+}
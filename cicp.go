@@ -0,0 +1,206 @@
+package mpd
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// CICPValue is a typed value of urn:mpeg:mpegB:cicp descriptors: ColourPrimaries, TransferCharacteristics,
+// MatrixCoefficients, VideoFramePackingType or ChannelConfiguration.
+type CICPValue interface {
+	fmt.Stringer
+	// Scheme returns schemeIdUri of descriptors carrying the value.
+	Scheme() string
+	code() int
+}
+
+// NewCICPDescriptor returns Descriptor carrying v.
+func NewCICPDescriptor(v CICPValue) Descriptor {
+	return NewDescriptor(v.Scheme(), strconv.Itoa(v.code()))
+}
+
+// ColourPrimaries is ISO/IEC 23091-2 ColourPrimaries code point.
+type ColourPrimaries int
+
+// ColourPrimaries values.
+const (
+	ColourPrimariesBT709       ColourPrimaries = 1
+	ColourPrimariesUnspecified ColourPrimaries = 2
+	ColourPrimariesBT470M      ColourPrimaries = 4
+	ColourPrimariesBT470BG     ColourPrimaries = 5
+	ColourPrimariesSMPTE170M   ColourPrimaries = 6
+	ColourPrimariesSMPTE240M   ColourPrimaries = 7
+	ColourPrimariesFilm        ColourPrimaries = 8
+	ColourPrimariesBT2020      ColourPrimaries = 9
+	ColourPrimariesSMPTE428    ColourPrimaries = 10
+	ColourPrimariesDCIP3       ColourPrimaries = 11
+	ColourPrimariesDisplayP3   ColourPrimaries = 12
+	ColourPrimariesEBU3213     ColourPrimaries = 22
+)
+
+var colourPrimariesNames = map[ColourPrimaries]string{
+	ColourPrimariesBT709: "BT.709", ColourPrimariesUnspecified: "unspecified", ColourPrimariesBT470M: "BT.470 System M",
+	ColourPrimariesBT470BG: "BT.470 System B, G", ColourPrimariesSMPTE170M: "SMPTE 170M", ColourPrimariesSMPTE240M: "SMPTE 240M",
+	ColourPrimariesFilm: "film", ColourPrimariesBT2020: "BT.2020", ColourPrimariesSMPTE428: "SMPTE ST 428-1",
+	ColourPrimariesDCIP3: "DCI-P3", ColourPrimariesDisplayP3: "Display P3", ColourPrimariesEBU3213: "EBU Tech. 3213-E",
+}
+
+// String implements fmt.Stringer interface.
+func (v ColourPrimaries) String() string {
+	return cicpName(colourPrimariesNames[v], "ColourPrimaries", int(v))
+}
+
+// Scheme implements CICPValue interface.
+func (v ColourPrimaries) Scheme() string { return ColourPrimariesScheme }
+
+func (v ColourPrimaries) code() int { return int(v) }
+
+// TransferCharacteristics is ISO/IEC 23091-2 TransferCharacteristics code point.
+type TransferCharacteristics int
+
+// TransferCharacteristics values.
+const (
+	TransferBT709       TransferCharacteristics = 1
+	TransferUnspecified TransferCharacteristics = 2
+	TransferBT470M      TransferCharacteristics = 4
+	TransferBT470BG     TransferCharacteristics = 5
+	TransferSMPTE170M   TransferCharacteristics = 6
+	TransferSMPTE240M   TransferCharacteristics = 7
+	TransferLinear      TransferCharacteristics = 8
+	TransferLog100      TransferCharacteristics = 9
+	TransferLog316      TransferCharacteristics = 10
+	TransferIEC61966_24 TransferCharacteristics = 11
+	TransferBT1361      TransferCharacteristics = 12
+	TransferSRGB        TransferCharacteristics = 13
+	TransferBT2020      TransferCharacteristics = 14
+	TransferBT2020_12   TransferCharacteristics = 15
+	TransferPQ          TransferCharacteristics = 16
+	TransferSMPTE428    TransferCharacteristics = 17
+	TransferHLG         TransferCharacteristics = 18
+)
+
+var transferCharacteristicsNames = map[TransferCharacteristics]string{
+	TransferBT709: "BT.709", TransferUnspecified: "unspecified", TransferBT470M: "BT.470 System M",
+	TransferBT470BG: "BT.470 System B, G", TransferSMPTE170M: "SMPTE 170M", TransferSMPTE240M: "SMPTE 240M",
+	TransferLinear: "linear", TransferLog100: "logarithmic 100:1", TransferLog316: "logarithmic 316:1",
+	TransferIEC61966_24: "IEC 61966-2-4", TransferBT1361: "BT.1361", TransferSRGB: "sRGB",
+	TransferBT2020: "BT.2020 10-bit", TransferBT2020_12: "BT.2020 12-bit", TransferPQ: "PQ",
+	TransferSMPTE428: "SMPTE ST 428-1", TransferHLG: "HLG",
+}
+
+// String implements fmt.Stringer interface.
+func (v TransferCharacteristics) String() string {
+	return cicpName(transferCharacteristicsNames[v], "TransferCharacteristics", int(v))
+}
+
+// Scheme implements CICPValue interface.
+func (v TransferCharacteristics) Scheme() string { return TransferCharacteristicsScheme }
+
+func (v TransferCharacteristics) code() int { return int(v) }
+
+// MatrixCoefficients is ISO/IEC 23091-2 MatrixCoefficients code point.
+type MatrixCoefficients int
+
+// MatrixCoefficients values.
+const (
+	MatrixIdentity         MatrixCoefficients = 0
+	MatrixBT709            MatrixCoefficients = 1
+	MatrixUnspecified      MatrixCoefficients = 2
+	MatrixFCC              MatrixCoefficients = 4
+	MatrixBT470BG          MatrixCoefficients = 5
+	MatrixSMPTE170M        MatrixCoefficients = 6
+	MatrixSMPTE240M        MatrixCoefficients = 7
+	MatrixYCgCo            MatrixCoefficients = 8
+	MatrixBT2020NCL        MatrixCoefficients = 9
+	MatrixBT2020CL         MatrixCoefficients = 10
+	MatrixSMPTE2085        MatrixCoefficients = 11
+	MatrixChromaDerivedNCL MatrixCoefficients = 12
+	MatrixChromaDerivedCL  MatrixCoefficients = 13
+	MatrixICtCp            MatrixCoefficients = 14
+)
+
+var matrixCoefficientsNames = map[MatrixCoefficients]string{
+	MatrixIdentity: "identity", MatrixBT709: "BT.709", MatrixUnspecified: "unspecified", MatrixFCC: "FCC",
+	MatrixBT470BG: "BT.470 System B, G", MatrixSMPTE170M: "SMPTE 170M", MatrixSMPTE240M: "SMPTE 240M",
+	MatrixYCgCo: "YCgCo", MatrixBT2020NCL: "BT.2020 non-constant luminance", MatrixBT2020CL: "BT.2020 constant luminance",
+	MatrixSMPTE2085: "SMPTE ST 2085", MatrixChromaDerivedNCL: "chromaticity-derived non-constant luminance",
+	MatrixChromaDerivedCL: "chromaticity-derived constant luminance", MatrixICtCp: "ICtCp",
+}
+
+// String implements fmt.Stringer interface.
+func (v MatrixCoefficients) String() string {
+	return cicpName(matrixCoefficientsNames[v], "MatrixCoefficients", int(v))
+}
+
+// Scheme implements CICPValue interface.
+func (v MatrixCoefficients) Scheme() string { return MatrixCoefficientsScheme }
+
+func (v MatrixCoefficients) code() int { return int(v) }
+
+// VideoFramePackingType is ISO/IEC 23091-2 VideoFramePackingType code point,
+// e.g. FramePackingSideBySide.
+type VideoFramePackingType int
+
+var videoFramePackingTypeNames = map[VideoFramePackingType]string{
+	0: "checkerboard", 1: "column interleaving", 2: "row interleaving", FramePackingSideBySide: "side by side",
+	FramePackingTopBottom: "top-bottom", FramePackingFrameSequential: "frame sequential", 6: "2D",
+}
+
+// String implements fmt.Stringer interface.
+func (v VideoFramePackingType) String() string {
+	return cicpName(videoFramePackingTypeNames[v], "VideoFramePackingType", int(v))
+}
+
+// Scheme implements CICPValue interface.
+func (v VideoFramePackingType) Scheme() string { return VideoFramePackingTypeScheme }
+
+func (v VideoFramePackingType) code() int { return int(v) }
+
+// ChannelConfiguration is ISO/IEC 23091-3 ChannelConfiguration code point.
+type ChannelConfiguration int
+
+// Common ChannelConfiguration values.
+const (
+	ChannelConfigurationMono   ChannelConfiguration = 1
+	ChannelConfigurationStereo ChannelConfiguration = 2
+	ChannelConfiguration51     ChannelConfiguration = 6
+	ChannelConfiguration71     ChannelConfiguration = 12
+	ChannelConfiguration222    ChannelConfiguration = 13
+	ChannelConfiguration514    ChannelConfiguration = 16
+	ChannelConfiguration714    ChannelConfiguration = 19
+)
+
+var channelConfigurationNames = map[ChannelConfiguration]string{
+	ChannelConfigurationMono: "mono", ChannelConfigurationStereo: "stereo", ChannelConfiguration51: "5.1",
+	ChannelConfiguration71: "7.1", ChannelConfiguration222: "22.2", ChannelConfiguration514: "5.1.4",
+	ChannelConfiguration714: "7.1.4",
+}
+
+// String implements fmt.Stringer interface.
+func (v ChannelConfiguration) String() string {
+	return cicpName(channelConfigurationNames[v], "ChannelConfiguration", int(v))
+}
+
+// Scheme implements CICPValue interface.
+func (v ChannelConfiguration) Scheme() string { return CICPChannelConfigurationScheme }
+
+func (v ChannelConfiguration) code() int { return int(v) }
+
+// Channels returns number of channels (including LFE) of v, or 0 if v is unknown.
+func (v ChannelConfiguration) Channels() int {
+	return cicpChannels[uint64(v)]
+}
+
+// cicpName returns name, or type name with code if name is empty.
+func cicpName(name, typ string, code int) string {
+	if name == "" {
+		return fmt.Sprintf("%s(%d)", typ, code)
+	}
+	return name
+}
+
+// parseCICPCode parses CICP value s as a code point.
+func parseCICPCode(s string) (int, error) {
+	v, err := strconv.ParseUint(s, 10, 8)
+	return int(v), err
+}
@@ -0,0 +1,44 @@
+package mpd
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestCICP(c *C) {
+	for _, t := range []struct {
+		v      CICPValue
+		scheme string
+		value  string
+		name   string
+	}{
+		{ColourPrimariesBT2020, ColourPrimariesScheme, "9", "BT.2020"},
+		{TransferPQ, TransferCharacteristicsScheme, "16", "PQ"},
+		{MatrixBT2020NCL, MatrixCoefficientsScheme, "9", "BT.2020 non-constant luminance"},
+		{VideoFramePackingType(FramePackingTopBottom), VideoFramePackingTypeScheme, "4", "top-bottom"},
+		{ChannelConfiguration51, CICPChannelConfigurationScheme, "6", "5.1"},
+		{ColourPrimaries(99), ColourPrimariesScheme, "99", "ColourPrimaries(99)"},
+	} {
+		d := NewCICPDescriptor(t.v)
+		c.Check(*d.SchemeIDURI, Equals, t.scheme)
+		c.Check(*d.Value, Equals, t.value)
+		c.Check(t.v.String(), Equals, t.name)
+
+		v, err := d.ParseValue()
+		c.Check(err, IsNil)
+		c.Check(v, Equals, t.v)
+	}
+
+	c.Check(ChannelConfiguration222.Channels(), Equals, 24)
+	c.Check(ChannelConfiguration(99).Channels(), Equals, 0)
+
+	acc := NewCICPChannelConfiguration(19)
+	cc, err := acc.CICPChannelConfiguration()
+	c.Check(err, IsNil)
+	c.Check(cc, Equals, ChannelConfiguration714)
+	acc = NewChannelCountConfiguration(2)
+	_, err = acc.CICPChannelConfiguration()
+	c.Check(err, ErrorMatches, "CICPChannelConfiguration: scheme is not urn:mpeg:mpegB:cicp:ChannelConfiguration")
+	acc = newAudioChannelConfiguration(CICPChannelConfigurationScheme, "x")
+	_, err = acc.CICPChannelConfiguration()
+	c.Check(err, ErrorMatches, `CICPChannelConfiguration: invalid value "x"`)
+}
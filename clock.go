@@ -0,0 +1,147 @@
+package mpd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// UTCTiming schemes.
+const (
+	UTCTimingDirectScheme     = "urn:mpeg:dash:utc:direct:2014"
+	UTCTimingHTTPISOScheme    = "urn:mpeg:dash:utc:http-iso:2014"
+	UTCTimingHTTPXSDateScheme = "urn:mpeg:dash:utc:http-xsdate:2014"
+	UTCTimingHTTPHeadScheme   = "urn:mpeg:dash:utc:http-head:2014"
+	UTCTimingNTPScheme        = "urn:mpeg:dash:utc:ntp:2014"
+)
+
+// Clock provides wall-clock time for live computations.
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock is a Clock using system time.
+type systemClock struct{}
+
+// Now implements Clock interface.
+func (systemClock) Now() time.Time {
+	return now()
+}
+
+// DefaultClock is used by MPDs without their own Clock.
+var DefaultClock Clock = systemClock{}
+
+// OffsetClock is a Clock which adds Offset to Base time, e.g. to follow server clock synchronized with UTCTiming.
+type OffsetClock struct {
+	Base   Clock
+	Offset time.Duration
+}
+
+// Now implements Clock interface.
+func (c *OffsetClock) Now() time.Time {
+	return c.Base.Now().Add(c.Offset)
+}
+
+// SyncClock returns OffsetClock following serverTime which was observed at base.Now().
+func SyncClock(base Clock, serverTime time.Time) *OffsetClock {
+	return &OffsetClock{Base: base, Offset: serverTime.Sub(base.Now())}
+}
+
+// DirectUTCTimingClock returns Clock synchronized with the first UTCTiming of m with direct scheme,
+// or nil if there is none. Other schemes require fetching server time: use ParseDateTime and SyncClock.
+func (m *MPD) DirectUTCTimingClock(base Clock) (Clock, error) {
+	d := findDescriptor(m.UTCTiming, UTCTimingDirectScheme)
+	if d == nil {
+		return nil, nil
+	}
+	if d.Value == nil {
+		return nil, fmt.Errorf("DirectUTCTimingClock: UTCTiming without value")
+	}
+	t, err := ParseDateTime(*d.Value)
+	if err != nil {
+		return nil, err
+	}
+	return SyncClock(base, t), nil
+}
+
+// SetClock sets Clock used by m for wall-clock computations. Nil restores DefaultClock.
+func (m *MPD) SetClock(c Clock) {
+	m.clock = c
+}
+
+// Clock returns Clock used by m for wall-clock computations.
+func (m *MPD) Clock() Clock {
+	if m.clock == nil {
+		return DefaultClock
+	}
+	return m.clock
+}
+
+// AvailabilityStart returns parsed availabilityStartTime of m, or zero time if it's absent.
+// It can't be named after the attribute, since MPD.AvailabilityStartTime is the field holding it.
+func (m *MPD) AvailabilityStart() (time.Time, error) {
+	if m.AvailabilityStartTime == nil {
+		return time.Time{}, nil
+	}
+	return ParseDateTime(*m.AvailabilityStartTime)
+}
+
+// SetAvailabilityStart sets availabilityStartTime of m. Zero time removes it.
+func (m *MPD) SetAvailabilityStart(t time.Time) {
+	if t.IsZero() {
+		m.AvailabilityStartTime = nil
+		return
+	}
+	m.AvailabilityStartTime = stringPtr(FormatDateTime(t))
+}
+
+// SinceAvailabilityStart returns time elapsed since availabilityStartTime of m according to m's Clock.
+func (m *MPD) SinceAvailabilityStart() (time.Duration, error) {
+	ast, err := m.AvailabilityStart()
+	if err != nil {
+		return 0, err
+	}
+	if ast.IsZero() {
+		return 0, newError("SinceAvailabilityStart", ErrNoAvailabilityStartTime, "no availabilityStartTime")
+	}
+	return m.Clock().Now().Sub(ast), nil
+}
+
+// dateTimeLayouts are accepted by ParseDateTime, values without time zone are treated as UTC.
+var dateTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+}
+
+// ParseDateTime parses xs:dateTime value like "2015-09-07T05:45:54Z". Values without time zone are treated as UTC.
+func ParseDateTime(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range dateTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, newError("ParseDateTime", ErrInvalidDateTime, "can't parse %q", s)
+}
+
+// FormatDateTime formats t as xs:dateTime value in UTC.
+func FormatDateTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// TouchPublishTime sets publishTime of m to now, as required when a live MPD is updated.
+// It returns an error and keeps m intact if now is before current publishTime,
+// since publishTime of consecutive manifests should never decrease.
+func (m *MPD) TouchPublishTime(now time.Time) error {
+	if m.PublishTime != nil {
+		prev, err := ParseDateTime(*m.PublishTime)
+		if err != nil {
+			return fmt.Errorf("TouchPublishTime: %w", err)
+		}
+		if now.Before(prev) {
+			return fmt.Errorf("TouchPublishTime: %s is before previous publishTime %s", FormatDateTime(now), *m.PublishTime)
+		}
+	}
+	m.PublishTime = stringPtr(FormatDateTime(now))
+	return nil
+}
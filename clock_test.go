@@ -0,0 +1,82 @@
+package mpd
+
+import (
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time { return time.Time(c) }
+
+func (s *MPDSuite) TestAvailabilityStart(c *C) {
+	m := &MPD{AvailabilityStartTime: stringPtr("2015-09-07T05:45:54")}
+	ast, err := m.AvailabilityStart()
+	c.Assert(err, IsNil)
+	c.Check(ast.Equal(time.Date(2015, 9, 7, 5, 45, 54, 0, time.UTC)), Equals, true)
+
+	m.SetAvailabilityStart(time.Date(2015, 9, 7, 14, 45, 54, 500000000, time.FixedZone("JST", 9*3600)))
+	c.Check(*m.AvailabilityStartTime, Equals, "2015-09-07T05:45:54.5Z")
+
+	m.SetClock(fixedClock(time.Date(2015, 9, 7, 5, 46, 0, 0, time.UTC)))
+	d, err := m.SinceAvailabilityStart()
+	c.Assert(err, IsNil)
+	c.Check(d, Equals, 5500*time.Millisecond)
+
+	m.SetAvailabilityStart(time.Time{})
+	c.Check(m.AvailabilityStartTime, IsNil)
+	_, err = m.SinceAvailabilityStart()
+	c.Check(err, ErrorMatches, "SinceAvailabilityStart: no availabilityStartTime")
+
+	m.AvailabilityStartTime = stringPtr("yesterday")
+	_, err = m.AvailabilityStart()
+	c.Check(err, ErrorMatches, `ParseDateTime: can't parse "yesterday"`)
+}
+
+func (s *MPDSuite) TestClock(c *C) {
+	local := fixedClock(time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	m := new(MPD)
+	c.Check(m.Clock(), Equals, DefaultClock)
+	clock, err := m.DirectUTCTimingClock(local)
+	c.Check(clock, IsNil)
+	c.Check(err, IsNil)
+
+	m.UTCTiming = []Descriptor{
+		NewDescriptor(UTCTimingHTTPISOScheme, "https://time.akamai.com/?iso"),
+		NewDescriptor(UTCTimingDirectScheme, "2016-01-01T00:00:02Z"),
+	}
+	clock, err = m.DirectUTCTimingClock(local)
+	c.Assert(err, IsNil)
+	c.Check(clock.(*OffsetClock).Offset, Equals, 2*time.Second)
+
+	m.SetClock(clock)
+	m.Type = stringPtr("dynamic")
+	c.Assert(m.Finalize(), IsNil)
+	c.Check(*m.PublishTime, Equals, "2016-01-01T00:00:02Z")
+
+	b, err := m.Encode()
+	c.Assert(err, IsNil)
+	c.Check(string(b), Equals, `<?xml version="1.0" encoding="utf-8"?>
+<MPD type="dynamic" publishTime="2016-01-01T00:00:02Z" profiles="">
+  <UTCTiming schemeIdUri="urn:mpeg:dash:utc:http-iso:2014" value="https://time.akamai.com/?iso"/>
+  <UTCTiming schemeIdUri="urn:mpeg:dash:utc:direct:2014" value="2016-01-01T00:00:02Z"/>
+</MPD>
+`)
+}
+
+func (s *MPDSuite) TestTouchPublishTime(c *C) {
+	m := new(MPD)
+	t := time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+	c.Assert(m.TouchPublishTime(t), IsNil)
+	c.Check(*m.PublishTime, Equals, "2016-01-01T00:00:00Z")
+
+	c.Assert(m.TouchPublishTime(t), IsNil)
+	c.Assert(m.TouchPublishTime(t.Add(2*time.Second)), IsNil)
+	c.Check(*m.PublishTime, Equals, "2016-01-01T00:00:02Z")
+
+	err := m.TouchPublishTime(t.Add(time.Second))
+	c.Check(err, ErrorMatches, "TouchPublishTime: 2016-01-01T00:00:01Z is before previous publishTime 2016-01-01T00:00:02Z")
+	c.Check(*m.PublishTime, Equals, "2016-01-01T00:00:02Z")
+}
@@ -0,0 +1,64 @@
+package mpd
+
+import (
+	"reflect"
+)
+
+// Clone returns deep copy of m. Unexported fields, like ConditionalUint values, are copied shallowly.
+func (m *MPD) Clone() *MPD {
+	return deepCopy(reflect.ValueOf(m)).Interface().(*MPD)
+}
+
+// deepCopy returns deep copy of v's exported data.
+func deepCopy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		res := reflect.New(v.Type().Elem())
+		res.Elem().Set(deepCopy(v.Elem()))
+		return res
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		res := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			res.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return res
+
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		res := reflect.MakeMap(v.Type())
+		for _, k := range v.MapKeys() {
+			res.SetMapIndex(k, deepCopy(v.MapIndex(k)))
+		}
+		return res
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		res := reflect.New(v.Type()).Elem()
+		res.Set(deepCopy(v.Elem()))
+		return res
+
+	case reflect.Struct:
+		res := reflect.New(v.Type()).Elem()
+		res.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if f := res.Field(i); f.CanSet() {
+				f.Set(deepCopy(v.Field(i)))
+			}
+		}
+		return res
+
+	default:
+		return v
+	}
+}
@@ -0,0 +1,30 @@
+package mpd
+
+import (
+	"encoding/xml"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestClone(c *C) {
+	m := NewStaticMPD(ProfileISOFFOnDemand, 0)
+	m.NamespacePrefixes = map[string]string{MPDNamespace: "dash"}
+	m.Extensions = []Extension{{XMLName: xml.Name{Local: "Custom"}, Raw: []xml.Token{xml.StartElement{Name: xml.Name{Local: "Custom"}}, xml.EndElement{Name: xml.Name{Local: "Custom"}}}}}
+	m.Periods[0].AdaptationSets = []*AdaptationSet{{
+		MimeType:        "video/mp4",
+		SegmentTemplate: &SegmentTemplate{Timescale: uint64Ptr(1000)},
+		Representations: []Representation{{ID: stringPtr("1")}},
+	}}
+
+	clone := m.Clone()
+	c.Check(clone, DeepEquals, m)
+
+	clone.Periods[0].AdaptationSets[0].Representations[0].ID = stringPtr("2")
+	*clone.Periods[0].AdaptationSets[0].SegmentTemplate.Timescale = 90000
+	clone.NamespacePrefixes[MPDNamespace] = "mpd"
+	clone.Extensions[0].Raw[0] = xml.Comment("x")
+	c.Check(*m.Periods[0].AdaptationSets[0].Representations[0].ID, Equals, "1")
+	c.Check(*m.Periods[0].AdaptationSets[0].SegmentTemplate.Timescale, Equals, uint64(1000))
+	c.Check(m.NamespacePrefixes[MPDNamespace], Equals, "dash")
+	c.Check(m.Extensions[0].Raw[0], FitsTypeOf, xml.StartElement{})
+}
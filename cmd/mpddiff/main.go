@@ -0,0 +1,142 @@
+// Command mpddiff compares MPEG-DASH manifests semantically.
+//
+// Usage:
+//
+//	mpddiff old new
+//	mpddiff -poll interval [-n count] URL
+//
+// The first form prints differences between two manifests read from files, URLs or standard input ("-").
+// The second one fetches URL every interval and prints differences from the previous fetch as they appear,
+// count times or until interrupted if count is 0.
+//
+// Changed attributes and elements are printed with their paths, media segments added to or removed from
+// Representations are printed prefixed with "+" and "-".
+//
+// Exit code is 0 if manifests are equal, 1 if they differ and 2 on usage or I/O errors.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/jun-oku/mpd"
+	"github.com/jun-oku/mpd/internal/source"
+)
+
+// Exit codes.
+const (
+	exitEqual     = 0
+	exitDifferent = 1
+	exitError     = 2
+)
+
+// sleep waits between polls; tests replace it.
+var sleep = time.Sleep
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run runs the command with given arguments and returns exit code.
+func run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("mpddiff", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	poll := flags.Duration("poll", 0, "fetch a single URL with given interval and print changes")
+	count := flags.Int("n", 0, "number of polls, 0 to poll until interrupted")
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: mpddiff old new\n       mpddiff -poll interval [-n count] URL\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+	if *poll > 0 && flags.NArg() != 1 || *poll <= 0 && flags.NArg() != 2 {
+		flags.Usage()
+		return exitError
+	}
+
+	old, err := read(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintf(stderr, "mpddiff: %s\n", err)
+		return exitError
+	}
+
+	if *poll <= 0 {
+		m, err := read(flags.Arg(1))
+		if err != nil {
+			fmt.Fprintf(stderr, "mpddiff: %s\n", err)
+			return exitError
+		}
+		d, err := mpd.Diff(old, m)
+		if err != nil {
+			fmt.Fprintf(stderr, "mpddiff: %s\n", err)
+			return exitError
+		}
+		printDiff(stdout, d)
+		if d.Empty() {
+			return exitEqual
+		}
+		return exitDifferent
+	}
+
+	code := exitEqual
+	for i := 0; *count == 0 || i < *count; i++ {
+		sleep(*poll)
+		m, err := read(flags.Arg(0))
+		if err != nil {
+			fmt.Fprintf(stderr, "mpddiff: %s\n", err)
+			return exitError
+		}
+		d, err := mpd.Diff(old, m)
+		if err != nil {
+			fmt.Fprintf(stderr, "mpddiff: %s\n", err)
+			return exitError
+		}
+		if !d.Empty() {
+			fmt.Fprintf(stdout, "# %s\n", time.Now().UTC().Format(time.RFC3339))
+			printDiff(stdout, d)
+			code = exitDifferent
+		}
+		old = m
+	}
+	return code
+}
+
+// read reads and decodes MPD name.
+func read(name string) (*mpd.MPD, error) {
+	b, err := source.Read(name)
+	if err != nil {
+		return nil, err
+	}
+	m := new(mpd.MPD)
+	if err = m.Decode(b); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return m, nil
+}
+
+// printDiff prints differences d.
+func printDiff(w io.Writer, d *mpd.ManifestDiff) {
+	for _, c := range d.Changes {
+		fmt.Fprintln(w, c)
+	}
+	for _, c := range d.Segments {
+		for _, s := range c.Removed {
+			fmt.Fprintf(w, "- %s: %s\n", c.Path, segmentName(s))
+		}
+		for _, s := range c.Added {
+			fmt.Fprintf(w, "+ %s: %s\n", c.Path, segmentName(s))
+		}
+	}
+}
+
+// segmentName returns URL of s with its byte range if any.
+func segmentName(s mpd.Segment) string {
+	if s.ByteRange != "" {
+		return s.URL + " " + s.ByteRange
+	}
+	return s.URL
+}
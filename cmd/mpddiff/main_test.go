@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MPDDiffSuite struct{}
+
+var _ = Suite(&MPDDiffSuite{})
+
+const liveMPD = `<MPD xmlns="urn:mpeg:dash:schema:mpd:2011" type="dynamic" profiles="urn:mpeg:dash:profile:isoff-live:2011" availabilityStartTime="2020-01-01T00:00:00Z" publishTime="2020-01-01T00:00:%02dZ">
+  <Period id="p0" start="PT0S">
+    <AdaptationSet id="1" mimeType="video/mp4">
+      <SegmentTemplate timescale="1" media="$Time$.m4s">
+        <SegmentTimeline>
+          <S t="%d" d="2" r="1"/>
+        </SegmentTimeline>
+      </SegmentTemplate>
+      <Representation id="v" bandwidth="1000"/>
+    </AdaptationSet>
+  </Period>
+</MPD>`
+
+func runDiff(c *C, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func (s *MPDDiffSuite) TestDiff(c *C) {
+	dir := c.MkDir()
+	a := filepath.Join(dir, "a.mpd")
+	c.Assert(ioutil.WriteFile(a, []byte(fmt.Sprintf(liveMPD, 4, 0)), 0644), IsNil)
+	b := filepath.Join(dir, "b.mpd")
+	c.Assert(ioutil.WriteFile(b, []byte(fmt.Sprintf(liveMPD, 6, 2)), 0644), IsNil)
+
+	code, stdout, stderr := runDiff(c, a, a)
+	c.Check(code, Equals, exitEqual)
+	c.Check(stdout, Equals, "")
+	c.Check(stderr, Equals, "")
+
+	code, stdout, stderr = runDiff(c, a, b)
+	c.Check(code, Equals, exitDifferent)
+	c.Check(stdout, Equals, `MPD/@publishTime: changed "2020-01-01T00:00:04Z" to "2020-01-01T00:00:06Z"
+- MPD/Period[0]/AdaptationSet[0]/Representation[0]: 0.m4s
++ MPD/Period[0]/AdaptationSet[0]/Representation[0]: 4.m4s
+`)
+	c.Check(stderr, Equals, "")
+}
+
+func (s *MPDDiffSuite) TestPoll(c *C) {
+	var n int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the manifest is updated on every other request
+		fmt.Fprintf(w, liveMPD, 4+n/2*2, n/2*2)
+		n++
+	}))
+	defer srv.Close()
+	defer func(f func(time.Duration)) { sleep = f }(sleep)
+	var slept []time.Duration
+	sleep = func(d time.Duration) { slept = append(slept, d) }
+
+	code, stdout, stderr := runDiff(c, "-poll", "2s", "-n", "3", srv.URL)
+	c.Check(code, Equals, exitDifferent)
+	c.Check(stderr, Equals, "")
+	c.Check(slept, DeepEquals, []time.Duration{2 * time.Second, 2 * time.Second, 2 * time.Second})
+	c.Check(stdout, Matches, `# \S+
+MPD/@publishTime: changed "2020-01-01T00:00:04Z" to "2020-01-01T00:00:06Z"
+- MPD/Period\[0\]/AdaptationSet\[0\]/Representation\[0\]: 0.m4s
+\+ MPD/Period\[0\]/AdaptationSet\[0\]/Representation\[0\]: 4.m4s
+`)
+}
+
+func (s *MPDDiffSuite) TestUsage(c *C) {
+	code, _, stderr := runDiff(c, "a.mpd")
+	c.Check(code, Equals, exitError)
+	c.Check(stderr, Matches, "Usage: mpddiff(.|\n)*")
+
+	code, _, stderr = runDiff(c, "missing.mpd", "missing.mpd")
+	c.Check(code, Equals, exitError)
+	c.Check(stderr, Matches, "mpddiff: open missing.mpd: .*\n")
+}
@@ -0,0 +1,108 @@
+// Command mpdfmt formats MPEG-DASH manifests canonically, see MPD.Canonicalize.
+//
+// Usage:
+//
+//	mpdfmt [-l] [-w] [file ...]
+//
+// Without files it formats standard input to standard output.
+// Exit code is 0 on success and 2 on usage, parse or I/O errors.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/jun-oku/mpd"
+	"github.com/jun-oku/mpd/internal/source"
+)
+
+// Exit codes.
+const (
+	exitOK    = 0
+	exitError = 2
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run runs the command with given arguments and returns exit code.
+func run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("mpdfmt", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	list := flags.Bool("l", false, "list files whose formatting differs from mpdfmt's")
+	write := flags.Bool("w", false, "write result to source file instead of stdout")
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: mpdfmt [flags] [file ...]\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+
+	files := flags.Args()
+	if len(files) == 0 {
+		if *write {
+			fmt.Fprintf(stderr, "mpdfmt: can't use -w with standard input\n")
+			return exitError
+		}
+		files = []string{"-"}
+	}
+
+	code := exitOK
+	for _, name := range files {
+		if err := process(name, *list, *write, stdout); err != nil {
+			fmt.Fprintf(stderr, "mpdfmt: %s: %s\n", name, err)
+			code = exitError
+		}
+	}
+	return code
+}
+
+// process formats file name, listing it if it has changed and list is set,
+// and writing the result back to it if write is set or to stdout otherwise.
+func process(name string, list, write bool, stdout io.Writer) error {
+	b, err := source.Read(name)
+	if err != nil {
+		return err
+	}
+	res, err := format(b)
+	if err != nil {
+		return err
+	}
+
+	changed := !bytes.Equal(b, res)
+	if list && changed {
+		fmt.Fprintln(stdout, name)
+	}
+	switch {
+	case write:
+		if !changed {
+			return nil
+		}
+		fi, err := os.Stat(name)
+		if err != nil {
+			return err
+		}
+		return ioutil.WriteFile(name, res, fi.Mode().Perm())
+	case !list:
+		_, err = stdout.Write(res)
+	}
+	return err
+}
+
+// format returns canonical form of MPD b.
+func format(b []byte) ([]byte, error) {
+	m := new(mpd.MPD)
+	if err := m.Decode(b); err != nil {
+		return nil, err
+	}
+	if err := m.Canonicalize(); err != nil {
+		return nil, err
+	}
+	return m.Encode()
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/jun-oku/mpd/internal/source"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MPDFmtSuite struct{}
+
+var _ = Suite(&MPDFmtSuite{})
+
+const unformatted = `<MPD xmlns="urn:mpeg:dash:schema:mpd:2011" minBufferTime="PT2.0S" profiles="urn:mpeg:dash:profile:isoff-live:2011">
+<Period start="PT0H0M0S"><AdaptationSet mimeType="video/mp4">
+<Representation id="high" bandwidth="3000000"/><Representation id="low" bandwidth="500000"/>
+</AdaptationSet></Period></MPD>`
+
+const formatted = `<?xml version="1.0" encoding="utf-8"?>
+<MPD xmlns="urn:mpeg:dash:schema:mpd:2011" minBufferTime="PT2S" profiles="urn:mpeg:dash:profile:isoff-live:2011">
+  <Period start="PT0S">
+    <AdaptationSet mimeType="video/mp4">
+      <Representation id="low" bandwidth="500000"/>
+      <Representation id="high" bandwidth="3000000"/>
+    </AdaptationSet>
+  </Period>
+</MPD>
+`
+
+func runFmt(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func (s *MPDFmtSuite) TestFormat(c *C) {
+	source.Stdin = strings.NewReader(unformatted)
+	code, stdout, stderr := runFmt()
+	c.Check(code, Equals, exitOK)
+	c.Check(stdout, Equals, formatted)
+	c.Check(stderr, Equals, "")
+
+	dir := c.MkDir()
+	a, b := filepath.Join(dir, "a.mpd"), filepath.Join(dir, "b.mpd")
+	c.Assert(ioutil.WriteFile(a, []byte(unformatted), 0600), IsNil)
+	c.Assert(ioutil.WriteFile(b, []byte(formatted), 0600), IsNil)
+
+	code, stdout, _ = runFmt("-l", a, b)
+	c.Check(code, Equals, exitOK)
+	c.Check(stdout, Equals, a+"\n")
+
+	code, stdout, _ = runFmt("-w", a, b)
+	c.Check(code, Equals, exitOK)
+	c.Check(stdout, Equals, "")
+	res, err := ioutil.ReadFile(a)
+	c.Assert(err, IsNil)
+	c.Check(string(res), Equals, formatted)
+
+	code, stdout, _ = runFmt("-l", a, b)
+	c.Check(code, Equals, exitOK)
+	c.Check(stdout, Equals, "")
+}
+
+func (s *MPDFmtSuite) TestErrors(c *C) {
+	dir := c.MkDir()
+	bad := filepath.Join(dir, "bad.mpd")
+	c.Assert(ioutil.WriteFile(bad, []byte(`<MPD minBufferTime="2s"/>`), 0600), IsNil)
+
+	code, _, stderr := runFmt(bad)
+	c.Check(code, Equals, exitError)
+	c.Check(stderr, Equals, "mpdfmt: "+bad+": Canonicalize: ParseDuration: can't parse \"2s\"\n")
+
+	code, _, stderr = runFmt("-w")
+	c.Check(code, Equals, exitError)
+	c.Check(stderr, Equals, "mpdfmt: can't use -w with standard input\n")
+}
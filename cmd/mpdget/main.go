@@ -0,0 +1,260 @@
+// Command mpdget follows an MPEG-DASH manifest and archives its versions.
+//
+// Usage:
+//
+//	mpdget [-o dir] [-n count] [-segments] [-download] URL
+//
+// The manifest is fetched, saved into dir as manifest-0001.mpd, manifest-0002.mpd and so on,
+// and fetched again after MPD@minimumUpdatePeriod until it becomes static, has no minimumUpdatePeriod
+// or count manifests were fetched. MPD validity expiration Events bring the next fetch forward to the time
+// they signal; with zero minimumUpdatePeriod the manifest is fetched only when such an Event expires it,
+// or every few seconds when it has none.
+//
+// With -segments, URLs of media segments which appear in the manifest are printed as they appear,
+// resolved against manifest URL and BaseURLs. With -download, the segments (and initialization segments)
+// are also saved into dir, at their URL paths.
+//
+// Interrupt stops pending requests. Exit code is 0 on success and 2 on usage or I/O errors and interrupts.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jun-oku/mpd"
+	"github.com/jun-oku/mpd/internal/source"
+)
+
+// Exit codes.
+const (
+	exitOK    = 0
+	exitError = 2
+)
+
+// minUpdateDelay is the shortest wait between manifest fetches.
+const minUpdateDelay = 2 * time.Second
+
+// sleep waits d between updates, or until ctx is done; tests replace it.
+var sleep = func(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
+
+// run runs the command with given arguments until ctx is done and returns exit code.
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("mpdget", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	dir := flags.String("o", ".", "output directory")
+	count := flags.Int("n", 0, "maximum number of manifest fetches, 0 for no limit")
+	printSegments := flags.Bool("segments", false, "print URLs of new media segments")
+	download := flags.Bool("download", false, "download new segments")
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: mpdget [flags] URL\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return exitError
+	}
+
+	f := &follower{
+		manifestURL:   flags.Arg(0),
+		dir:           *dir,
+		printSegments: *printSegments,
+		download:      *download,
+		stdout:        stdout,
+		stderr:        stderr,
+		seen:          make(map[string]bool),
+		failed:        make(map[string]bool),
+	}
+	for n := 1; *count == 0 || n <= *count; n++ {
+		m, err := f.fetch(ctx, n)
+		if err != nil {
+			fmt.Fprintf(stderr, "mpdget: %s\n", err)
+			return exitError
+		}
+		if m.Type == nil || *m.Type != "dynamic" || m.MinimumUpdatePeriod == nil {
+			break
+		}
+		if n == *count {
+			break
+		}
+		d, err := updateDelay(m)
+		if err != nil {
+			fmt.Fprintf(stderr, "mpdget: %s\n", err)
+			return exitError
+		}
+		if err = sleep(ctx, d); err != nil {
+			fmt.Fprintf(stderr, "mpdget: %s\n", err)
+			return exitError
+		}
+	}
+	return exitOK
+}
+
+// updateDelay returns time to wait before fetching the next version of dynamic MPD m:
+// MPD@minimumUpdatePeriod, unless an MPD validity expiration Event expires m earlier,
+// but at least minUpdateDelay. Zero minimumUpdatePeriod means m changes only with such Events.
+func updateDelay(m *mpd.MPD) (time.Duration, error) {
+	d, err := mpd.ParseDuration(*m.MinimumUpdatePeriod)
+	if err != nil {
+		return 0, fmt.Errorf("minimumUpdatePeriod: %w", err)
+	}
+	expires, err := m.ExpiresAt()
+	if err != nil {
+		return 0, err
+	}
+	if now := m.Clock().Now(); expires.After(now) {
+		if e := expires.Sub(now); d <= 0 || e < d {
+			d = e
+		}
+	}
+	if d < minUpdateDelay {
+		d = minUpdateDelay
+	}
+	return d, nil
+}
+
+// follower keeps state between manifest fetches.
+type follower struct {
+	manifestURL   string
+	dir           string
+	printSegments bool
+	download      bool
+	stdout        io.Writer
+	stderr        io.Writer
+
+	// seen are keys of segments already handled
+	seen map[string]bool
+	// failed are paths of Representations which segments can't be enumerated, reported once
+	failed map[string]bool
+}
+
+// fetch fetches, archives and decodes manifest version n and handles its new segments.
+func (f *follower) fetch(ctx context.Context, n int) (*mpd.MPD, error) {
+	b, err := source.ReadContext(ctx, f.manifestURL)
+	if err != nil {
+		return nil, err
+	}
+	if err = os.MkdirAll(f.dir, 0755); err != nil {
+		return nil, err
+	}
+	if err = ioutil.WriteFile(filepath.Join(f.dir, fmt.Sprintf("manifest-%04d.mpd", n)), b, 0644); err != nil {
+		return nil, err
+	}
+	m := new(mpd.MPD)
+	if err = m.DecodeContext(ctx, b, mpd.DecodeOptions{}); err != nil {
+		return nil, fmt.Errorf("%s: %w", f.manifestURL, err)
+	}
+	if f.printSegments || f.download {
+		if err = f.segments(ctx, m); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// segments prints and downloads segments of m which were not handled yet.
+func (f *follower) segments(ctx context.Context, m *mpd.MPD) error {
+	for i, p := range m.Periods {
+		for j, as := range p.AdaptationSets {
+			for k := range as.Representations {
+				loc := fmt.Sprintf("Periods[%d].AdaptationSets[%d].Representations[%d]", i, j, k)
+				list, err := mpd.Segments(f.manifestURL, m, p, as, &as.Representations[k])
+				if err != nil {
+					if !f.failed[loc] {
+						fmt.Fprintf(f.stderr, "mpdget: %s: %s\n", loc, err)
+						f.failed[loc] = true
+					}
+					continue
+				}
+				for _, s := range list {
+					key := s.URL + " " + s.ByteRange
+					if f.seen[key] {
+						continue
+					}
+					f.seen[key] = true
+					if f.printSegments && s.Kind == mpd.MediaSegment {
+						fmt.Fprintln(f.stdout, strings.TrimSpace(key))
+					}
+					if f.download {
+						if err = f.save(ctx, s); err != nil {
+							return err
+						}
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// save downloads segment s into a file at its URL path within dir, suffixed with its byte range if any.
+func (f *follower) save(ctx context.Context, s mpd.Segment) error {
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		return err
+	}
+	if !u.IsAbs() {
+		return fmt.Errorf("%s: can't download relative URL", s.URL)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.URL, nil)
+	if err != nil {
+		return err
+	}
+	if s.ByteRange != "" {
+		req.Header.Set("Range", "bytes="+s.ByteRange)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+		return fmt.Errorf("%s: %s", s.URL, resp.Status)
+	}
+
+	name := filepath.Join(f.dir, filepath.FromSlash(strings.TrimPrefix(path.Clean("/"+u.Path), "/")))
+	if s.ByteRange != "" {
+		name += "." + s.ByteRange
+	}
+	if err = os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		return err
+	}
+	out, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(out, resp.Body); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
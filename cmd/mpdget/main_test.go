@@ -0,0 +1,191 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jun-oku/mpd"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MPDGetSuite struct{}
+
+var _ = Suite(&MPDGetSuite{})
+
+// manifest returns version n of a live manifest which ends after version 2.
+func manifest(n int) string {
+	typ := `type="dynamic" minimumUpdatePeriod="PT2S"`
+	if n >= 2 {
+		typ = `type="static" mediaPresentationDuration="PT6S"`
+	}
+	return fmt.Sprintf(`<MPD xmlns="urn:mpeg:dash:schema:mpd:2011" %s profiles="urn:mpeg:dash:profile:isoff-live:2011" availabilityStartTime="2020-01-01T00:00:00Z">
+  <Period id="p0" start="PT0S">
+    <BaseURL>video/</BaseURL>
+    <AdaptationSet id="1" mimeType="video/mp4">
+      <SegmentTemplate timescale="1" initialization="init.mp4" media="$Time$.m4s">
+        <SegmentTimeline>
+          <S t="%d" d="2" r="1"/>
+        </SegmentTimeline>
+      </SegmentTemplate>
+      <Representation id="v" bandwidth="1000"/>
+    </AdaptationSet>
+  </Period>
+</MPD>`, typ, 2*(n-1))
+}
+
+func runGet(c *C, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func (s *MPDGetSuite) TestFollow(c *C) {
+	var n int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/live/manifest.mpd" {
+			n++
+			fmt.Fprint(w, manifest(n))
+			return
+		}
+		fmt.Fprint(w, r.URL.Path)
+	}))
+	defer srv.Close()
+	defer func(f func(context.Context, time.Duration) error) { sleep = f }(sleep)
+	var slept []time.Duration
+	sleep = func(_ context.Context, d time.Duration) error {
+		slept = append(slept, d)
+		return nil
+	}
+
+	dir := c.MkDir()
+	code, stdout, stderr := runGet(c, "-o", dir, "-segments", "-download", srv.URL+"/live/manifest.mpd")
+	c.Check(code, Equals, exitOK)
+	c.Check(stderr, Equals, "")
+	c.Check(n, Equals, 2)
+	c.Check(slept, DeepEquals, []time.Duration{2 * time.Second})
+	c.Check(stdout, Equals, strings.Join([]string{
+		srv.URL + "/live/video/0.m4s",
+		srv.URL + "/live/video/2.m4s",
+		srv.URL + "/live/video/4.m4s",
+		"",
+	}, "\n"))
+
+	for i, name := range []string{"manifest-0001.mpd", "manifest-0002.mpd"} {
+		b, err := ioutil.ReadFile(filepath.Join(dir, name))
+		c.Assert(err, IsNil)
+		c.Check(string(b), Equals, manifest(i+1))
+	}
+	for _, name := range []string{"init.mp4", "0.m4s", "2.m4s", "4.m4s"} {
+		b, err := ioutil.ReadFile(filepath.Join(dir, "live", "video", name))
+		c.Assert(err, IsNil)
+		c.Check(string(b), Equals, "/live/video/"+name)
+	}
+}
+
+func (s *MPDGetSuite) TestCount(c *C) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, manifest(1))
+	}))
+	defer srv.Close()
+	defer func(f func(context.Context, time.Duration) error) { sleep = f }(sleep)
+	var slept int
+	sleep = func(context.Context, time.Duration) error {
+		slept++
+		return nil
+	}
+
+	dir := c.MkDir()
+	code, stdout, _ := runGet(c, "-o", dir, "-n", "3", srv.URL)
+	c.Check(code, Equals, exitOK)
+	c.Check(stdout, Equals, "")
+	c.Check(slept, Equals, 2)
+	files, err := ioutil.ReadDir(dir)
+	c.Assert(err, IsNil)
+	c.Check(files, HasLen, 3)
+}
+
+func (s *MPDGetSuite) TestUsage(c *C) {
+	code, _, stderr := runGet(c)
+	c.Check(code, Equals, exitError)
+	c.Check(stderr, Matches, "Usage: mpdget(.|\n)*")
+}
+
+func (s *MPDGetSuite) TestCancel(c *C) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, manifest(1))
+	}))
+	defer srv.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func(f func(context.Context, time.Duration) error) { sleep = f }(sleep)
+	sleep = func(ctx context.Context, d time.Duration) error {
+		cancel()
+		return ctx.Err()
+	}
+
+	dir := c.MkDir()
+	var stdout, stderr bytes.Buffer
+	code := run(ctx, []string{"-o", dir, srv.URL}, &stdout, &stderr)
+	c.Check(code, Equals, exitError)
+	c.Check(stderr.String(), Equals, "mpdget: context canceled\n")
+	files, err := ioutil.ReadDir(dir)
+	c.Assert(err, IsNil)
+	c.Check(files, HasLen, 1)
+}
+
+func (s *MPDGetSuite) TestSleep(c *C) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	c.Check(sleep(ctx, time.Hour), Equals, context.Canceled)
+	c.Check(sleep(context.Background(), time.Millisecond), IsNil)
+}
+
+// fixedClock is a Clock always returning the same time.
+type fixedClock time.Time
+
+func (f fixedClock) Now() time.Time { return time.Time(f) }
+
+func (s *MPDGetSuite) TestExpiration(c *C) {
+	defer func(clock mpd.Clock) { mpd.DefaultClock = clock }(mpd.DefaultClock)
+	mpd.DefaultClock = fixedClock(time.Date(2020, 1, 1, 0, 0, 10, 0, time.UTC))
+	decode := func(mup, events string) *mpd.MPD {
+		m := new(mpd.MPD)
+		c.Assert(m.Decode([]byte(fmt.Sprintf(`<MPD xmlns="urn:mpeg:dash:schema:mpd:2011" type="dynamic" minimumUpdatePeriod="%s" availabilityStartTime="2020-01-01T00:00:00Z">
+  <Period id="p0" start="PT0S">%s</Period>
+</MPD>`, mup, events))), IsNil)
+		return m
+	}
+	expiration := func(at ...int) string {
+		var events string
+		for i, t := range at {
+			events += fmt.Sprintf(`<Event id="%d" presentationTime="%d" messageData="2020-01-01T00:00:%02dZ"/>`, i, t, t)
+		}
+		return `<EventStream schemeIdUri="urn:mpeg:dash:event:2012" value="1" timescale="1">` + events + `</EventStream>`
+	}
+
+	for _, t := range []struct {
+		mup, events string
+		delay       time.Duration
+	}{
+		{"PT30S", "", 30 * time.Second},
+		{"PT30S", expiration(40, 20), 10 * time.Second},
+		{"PT5S", expiration(20), 5 * time.Second},
+		{"PT0S", expiration(25), 15 * time.Second},
+		{"PT0S", expiration(5), minUpdateDelay},
+		{"PT0S", "", minUpdateDelay},
+		{"PT0.5S", "", minUpdateDelay},
+	} {
+		d, err := updateDelay(decode(t.mup, t.events))
+		c.Assert(err, IsNil)
+		c.Check(d, Equals, t.delay, Commentf("%s %s", t.mup, t.events))
+	}
+}
@@ -0,0 +1,167 @@
+// Command mpdlint validates MPEG-DASH manifests.
+//
+// Usage:
+//
+//	mpdlint [-rules schema,dashif,profile] [-json] file|URL|- ...
+//
+// Rule sets are:
+//
+//	schema   MPD is well-formed and its elements are in XSD order
+//	dashif   semantic checks of MPD.Validate based on DASH-IF guidelines
+//	profile  restrictions of profiles listed in MPD@profiles
+//
+// Exit code is 0 if no problems were found, 1 if there were problems and 2 on usage or I/O errors.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/jun-oku/mpd"
+	"github.com/jun-oku/mpd/internal/source"
+)
+
+// Exit codes.
+const (
+	exitOK       = 0
+	exitProblems = 1
+	exitError    = 2
+)
+
+// ruleSets lists known rule sets in order they are run.
+var ruleSets = []string{"schema", "dashif", "profile"}
+
+// Problem is a single problem found in a manifest.
+type Problem struct {
+	Rule    string `json:"rule"`
+	Path    string `json:"path,omitempty"`
+	Message string `json:"message"`
+}
+
+// Report lists problems found in a manifest.
+type Report struct {
+	Source   string    `json:"source"`
+	Problems []Problem `json:"problems"`
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run runs the command with given arguments and returns exit code.
+func run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("mpdlint", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	rules := flags.String("rules", strings.Join(ruleSets, ","), "comma-separated rule sets: "+strings.Join(ruleSets, ", "))
+	asJSON := flags.Bool("json", false, "print JSON report")
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: mpdlint [flags] file|URL|- ...\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return exitError
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return exitError
+	}
+
+	enabled := make(map[string]bool)
+	for _, r := range strings.Split(*rules, ",") {
+		r = strings.TrimSpace(r)
+		if !contains(ruleSets, r) {
+			fmt.Fprintf(stderr, "mpdlint: unknown rule set %q\n", r)
+			return exitError
+		}
+		enabled[r] = true
+	}
+
+	code := exitOK
+	reports := []Report{}
+	for _, name := range flags.Args() {
+		b, err := source.Read(name)
+		if err != nil {
+			fmt.Fprintf(stderr, "mpdlint: %s\n", err)
+			return exitError
+		}
+		r := lint(name, b, enabled)
+		if len(r.Problems) > 0 {
+			code = exitProblems
+		}
+		reports = append(reports, r)
+	}
+
+	if *asJSON {
+		e := json.NewEncoder(stdout)
+		e.SetIndent("", "  ")
+		if err := e.Encode(reports); err != nil {
+			fmt.Fprintf(stderr, "mpdlint: %s\n", err)
+			return exitError
+		}
+		return code
+	}
+	for _, r := range reports {
+		for _, p := range r.Problems {
+			msg := p.Message
+			if p.Path != "" {
+				msg = p.Path + ": " + msg
+			}
+			fmt.Fprintf(stdout, "%s: %s: %s\n", r.Source, p.Rule, msg)
+		}
+	}
+	return code
+}
+
+// lint checks manifest b with enabled rule sets. Manifests which can't be decoded are reported
+// as schema problems whether it is enabled or not.
+func lint(name string, b []byte, enabled map[string]bool) Report {
+	res := Report{Source: name, Problems: []Problem{}}
+	add := func(rule string, err error) {
+		if err == nil {
+			return
+		}
+		var errs mpd.ValidationErrors
+		if !errors.As(err, &errs) {
+			res.Problems = append(res.Problems, Problem{Rule: rule, Message: err.Error()})
+			return
+		}
+		for _, e := range errs {
+			res.Problems = append(res.Problems, Problem{Rule: rule, Path: e.Path, Message: e.Message})
+		}
+	}
+
+	m := new(mpd.MPD)
+	if err := m.Decode(b); err != nil {
+		add("schema", err)
+		return res
+	}
+	for _, rule := range ruleSets {
+		if !enabled[rule] {
+			continue
+		}
+		switch rule {
+		case "schema":
+			add(rule, mpd.ValidateElementOrder(b))
+		case "dashif":
+			add(rule, m.Validate())
+		case "profile":
+			add(rule, m.ValidateProfiles())
+		}
+	}
+	return res
+}
+
+// contains returns true if list contains s.
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MPDLintSuite struct{}
+
+var _ = Suite(&MPDLintSuite{})
+
+const validMPD = `<MPD xmlns="urn:mpeg:dash:schema:mpd:2011" type="static" profiles="urn:mpeg:dash:profile:isoff-live:2011">
+  <Period>
+    <AdaptationSet mimeType="video/mp4" lang="en">
+      <SegmentTemplate media="$Number$.m4s" duration="2"/>
+      <Representation id="1" bandwidth="1000"/>
+    </AdaptationSet>
+  </Period>
+</MPD>`
+
+const invalidMPD = `<MPD xmlns="urn:mpeg:dash:schema:mpd:2011" type="static" profiles="urn:mpeg:dash:profile:isoff-live:2011">
+  <Period>
+    <AdaptationSet mimeType="video/mp4" lang="e">
+      <Representation id="1" bandwidth="1000">
+        <SegmentBase indexRange="0-100"/>
+      </Representation>
+      <ContentProtection schemeIdUri="urn:mpeg:dash:mp4protection:2011"/>
+    </AdaptationSet>
+  </Period>
+</MPD>`
+
+func runLint(c *C, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func (s *MPDLintSuite) TestLint(c *C) {
+	dir := c.MkDir()
+	valid := filepath.Join(dir, "valid.mpd")
+	c.Assert(ioutil.WriteFile(valid, []byte(validMPD), 0644), IsNil)
+	invalid := filepath.Join(dir, "invalid.mpd")
+	c.Assert(ioutil.WriteFile(invalid, []byte(invalidMPD), 0644), IsNil)
+
+	code, stdout, stderr := runLint(c, valid)
+	c.Check(code, Equals, exitOK)
+	c.Check(stdout, Equals, "")
+	c.Check(stderr, Equals, "")
+
+	code, stdout, _ = runLint(c, invalid)
+	c.Check(code, Equals, exitProblems)
+	c.Check(stdout, Equals, invalid+": schema: Periods[0].AdaptationSets[0]: ContentProtection must precede Representation\n"+
+		invalid+`: dashif: Periods[0].AdaptationSets[0]: invalid lang "e"`+"\n"+
+		invalid+": profile: Periods[0].AdaptationSets[0].Representations[0]: SegmentBase addressing is not allowed by @profiles\n")
+
+	code, stdout, _ = runLint(c, "-rules", "profile", "-json", invalid)
+	c.Check(code, Equals, exitProblems)
+	var reports []Report
+	c.Assert(json.Unmarshal([]byte(stdout), &reports), IsNil)
+	c.Check(reports, DeepEquals, []Report{{Source: invalid, Problems: []Problem{{
+		Rule:    "profile",
+		Path:    "Periods[0].AdaptationSets[0].Representations[0]",
+		Message: "SegmentBase addressing is not allowed by @profiles",
+	}}}})
+
+	garbage := filepath.Join(dir, "garbage.mpd")
+	c.Assert(ioutil.WriteFile(garbage, []byte("<MPD"), 0644), IsNil)
+	code, stdout, _ = runLint(c, "-rules", "dashif", garbage)
+	c.Check(code, Equals, exitProblems)
+	c.Check(stdout, Matches, ".*garbage.mpd: schema: XML syntax error.*\n")
+}
+
+func (s *MPDLintSuite) TestErrors(c *C) {
+	code, _, stderr := runLint(c)
+	c.Check(code, Equals, exitError)
+	c.Check(stderr, Matches, "Usage: mpdlint(.|\n)*")
+
+	code, _, stderr = runLint(c, "-rules", "xsd", "a.mpd")
+	c.Check(code, Equals, exitError)
+	c.Check(stderr, Equals, "mpdlint: unknown rule set \"xsd\"\n")
+
+	code, _, stderr = runLint(c, filepath.Join(os.TempDir(), "missing", "a.mpd"))
+	c.Check(code, Equals, exitError)
+	c.Check(stderr, Matches, "mpdlint: open .*: no such file or directory\n")
+}
@@ -0,0 +1,105 @@
+package codecs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// H.264 profile_idc values.
+const (
+	AVCBaseline = 66
+	AVCMain     = 77
+	AVCExtended = 88
+	AVCHigh     = 100
+	AVCHigh10   = 110
+	AVCHigh422  = 122
+	AVCHigh444  = 244
+)
+
+// H.265 general_profile_idc values.
+const (
+	HEVCMain             = 1
+	HEVCMain10           = 2
+	HEVCMainStillPicture = 3
+	HEVCRangeExtensions  = 4
+)
+
+// AAC audio object types.
+const (
+	AACMain = 1
+	AACLC   = 2
+	HEAAC   = 5
+	HEAACv2 = 29
+	XHEAAC  = 42
+)
+
+// AV1 seq_profile values.
+const (
+	AV1Main         = 0
+	AV1High         = 1
+	AV1Professional = 2
+)
+
+// String returns original codec string.
+func (c *Codec) String() string {
+	return c.Raw
+}
+
+// Join formats list of codecs as @codecs attribute value.
+func Join(list ...*Codec) string {
+	s := make([]string, len(list))
+	for i, c := range list {
+		s[i] = c.Raw
+	}
+	return strings.Join(s, ",")
+}
+
+// NewAVC returns avc1 codec with given profile_idc, constraint flags and level_idc (31 for level 3.1).
+func NewAVC(profileIDC, constraintFlags, levelIDC byte) (*Codec, error) {
+	return Parse(fmt.Sprintf("avc1.%02x%02x%02x", profileIDC, constraintFlags, levelIDC))
+}
+
+// NewHEVC returns hvc1 codec with given general_profile_idc, tier and general_level_idc (93 for level 3.1).
+// Compatibility flags are derived from profile: Main is signaled as compatible with Main 10.
+// Trailing zero constraint bytes are omitted.
+func NewHEVC(profileIDC byte, highTier bool, levelIDC byte, constraintFlags ...byte) (*Codec, error) {
+	flags := uint32(1) << profileIDC
+	if profileIDC == HEVCMain {
+		flags |= 1 << HEVCMain10
+	}
+	tier := "L"
+	if highTier {
+		tier = "H"
+	}
+
+	s := fmt.Sprintf("hvc1.%d.%X.%s%d", profileIDC, flags, tier, levelIDC)
+	for len(constraintFlags) > 0 && constraintFlags[len(constraintFlags)-1] == 0 {
+		constraintFlags = constraintFlags[:len(constraintFlags)-1]
+	}
+	for _, b := range constraintFlags {
+		s += fmt.Sprintf(".%X", b)
+	}
+	return Parse(s)
+}
+
+// NewAAC returns mp4a codec for MPEG-4 audio with given audio object type, e.g. "mp4a.40.2" for AAC LC.
+func NewAAC(audioObjectType byte) (*Codec, error) {
+	return Parse(fmt.Sprintf("mp4a.40.%d", audioObjectType))
+}
+
+// NewAV1 returns av01 codec with given seq_profile, seq_level_idx, tier and bit depth.
+func NewAV1(profile, level byte, highTier bool, bitDepth byte) (*Codec, error) {
+	tier := "M"
+	if highTier {
+		tier = "H"
+	}
+	return Parse(fmt.Sprintf("av01.%d.%02d%s.%02d", profile, level, tier, bitDepth))
+}
+
+// NewDolbyVision returns Dolby Vision codec with given sample entry type (e.g. "dvh1"), profile and level.
+func NewDolbyVision(fourCC string, profile, level byte) (*Codec, error) {
+	if f := families[fourCC].family; f != "dvhevc" && f != "dvavc" && f != "dvav1" {
+		return nil, fmt.Errorf("codecs: %q is not Dolby Vision sample entry type", fourCC)
+	}
+	return Parse(fmt.Sprintf("%s.%02d.%02d", fourCC, profile, level))
+}
@@ -0,0 +1,40 @@
+package codecs
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *CodecsSuite) TestBuild(c *C) {
+	avc, err := NewAVC(AVCHigh, 0, 31)
+	c.Assert(err, IsNil)
+	c.Check(avc.String(), Equals, "avc1.64001f")
+
+	hevc, err := NewHEVC(HEVCMain, false, 93, 0xB0, 0, 0)
+	c.Assert(err, IsNil)
+	c.Check(hevc.String(), Equals, "hvc1.1.6.L93.B0")
+
+	hevc, err = NewHEVC(HEVCMain10, true, 150)
+	c.Assert(err, IsNil)
+	c.Check(hevc.String(), Equals, "hvc1.2.4.H150")
+
+	aac, err := NewAAC(AACLC)
+	c.Assert(err, IsNil)
+	c.Check(aac.String(), Equals, "mp4a.40.2")
+
+	av1, err := NewAV1(AV1Main, 8, false, 10)
+	c.Assert(err, IsNil)
+	c.Check(av1.String(), Equals, "av01.0.08M.10")
+
+	dv, err := NewDolbyVision("dvh1", 8, 6)
+	c.Assert(err, IsNil)
+	c.Check(dv.String(), Equals, "dvh1.08.06")
+
+	c.Check(Join(avc, aac), Equals, "avc1.64001f,mp4a.40.2")
+
+	_, err = NewAVC(AVCHigh, 0, 33)
+	c.Check(err, NotNil)
+	_, err = NewAV1(AV1Main, 8, false, 9)
+	c.Check(err, NotNil)
+	_, err = NewDolbyVision("avc1", 8, 6)
+	c.Check(err, ErrorMatches, `codecs: "avc1" is not Dolby Vision sample entry type`)
+}
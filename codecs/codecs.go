@@ -0,0 +1,433 @@
+// Package codecs implements parsing and validation of RFC 6381 codec strings used in @codecs attributes.
+package codecs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Type is a media type of the codec.
+type Type int
+
+// Media types.
+const (
+	Unknown Type = iota
+	Video
+	Audio
+	Text
+	Image
+)
+
+// String returns DASH contentType value for t.
+func (t Type) String() string {
+	switch t {
+	case Video:
+		return "video"
+	case Audio:
+		return "audio"
+	case Text:
+		return "text"
+	case Image:
+		return "image"
+	}
+	return "unknown"
+}
+
+// families maps sample entry types to codec families and media types.
+var families = map[string]struct {
+	family string
+	typ    Type
+}{
+	"avc1": {"avc", Video},
+	"avc2": {"avc", Video},
+	"avc3": {"avc", Video},
+	"avc4": {"avc", Video},
+	"hvc1": {"hevc", Video},
+	"hev1": {"hevc", Video},
+	"dvh1": {"dvhevc", Video},
+	"dvhe": {"dvhevc", Video},
+	"dva1": {"dvavc", Video},
+	"dvav": {"dvavc", Video},
+	"dav1": {"dvav1", Video},
+	"av01": {"av1", Video},
+	"vp08": {"vp8", Video},
+	"vp09": {"vp9", Video},
+	"mp4a": {"aac", Audio},
+	"ac-3": {"ac-3", Audio},
+	"ec-3": {"ec-3", Audio},
+	"ac-4": {"ac-4", Audio},
+	"mhm1": {"mpegh", Audio},
+	"mhm2": {"mpegh", Audio},
+	"mha1": {"mpegh", Audio},
+	"mha2": {"mpegh", Audio},
+	"opus": {"opus", Audio},
+	"Opus": {"opus", Audio},
+	"fLaC": {"flac", Audio},
+	"flac": {"flac", Audio},
+	"dtsc": {"dts", Audio},
+	"dtsh": {"dts", Audio},
+	"dtse": {"dts", Audio},
+	"dtsx": {"dts", Audio},
+	"stpp": {"ttml", Text},
+	"wvtt": {"webvtt", Text},
+	"tx3g": {"tx3g", Text},
+	"jpeg": {"jpeg", Image},
+	"png":  {"png", Image},
+}
+
+// Codec represents single parsed codec string.
+type Codec struct {
+	// Raw is the original codec string.
+	Raw string
+	// FourCC is a sample entry type, e.g. "avc1" or "mp4a".
+	FourCC string
+	// Family groups sample entry types of the same codec, e.g. "avc" for both avc1 and avc3.
+	// It's empty for unknown sample entry types.
+	Family string
+	// Type is a media type of the codec.
+	Type Type
+
+	// Only one of following is set, depending on FourCC.
+	AVC  *AVC
+	HEVC *HEVC
+	AV1  *AV1
+	MP4A *MP4A
+	// DolbyVision is set for Dolby Vision sample entry types.
+	DolbyVision *DolbyVision
+}
+
+// AVC represents H.264 parameters of avc1/avc3 codec strings.
+type AVC struct {
+	ProfileIDC      byte
+	ConstraintFlags byte
+	LevelIDC        byte
+}
+
+// HEVC represents H.265 parameters of hvc1/hev1 codec strings.
+type HEVC struct {
+	// ProfileSpace is 0 for no prefix, 1 for "A", 2 for "B" and 3 for "C".
+	ProfileSpace byte
+	ProfileIDC   byte
+	// CompatibilityFlags are stored in the reversed bit order, as written in codec string.
+	CompatibilityFlags uint32
+	HighTier           bool
+	LevelIDC           byte
+	ConstraintFlags    []byte
+}
+
+// AV1 represents parameters of av01 codec strings.
+type AV1 struct {
+	Profile  byte
+	Level    byte
+	HighTier bool
+	BitDepth byte
+
+	// Optional fields, Optional is false if they are absent in codec string.
+	Optional                bool
+	Monochrome              bool
+	ChromaSubsampling       string
+	ColorPrimaries          byte
+	TransferCharacteristics byte
+	MatrixCoefficients      byte
+	FullRange               bool
+}
+
+// DolbyVision represents parameters of dvh1/dvhe/dva1/dvav/dav1 codec strings.
+type DolbyVision struct {
+	Profile byte
+	Level   byte
+}
+
+// MP4A represents parameters of mp4a codec strings.
+type MP4A struct {
+	ObjectTypeIndication byte
+	// AudioObjectType is 0 if absent.
+	AudioObjectType byte
+}
+
+var (
+	avcProfiles = map[byte]bool{44: true, 66: true, 77: true, 83: true, 86: true, 88: true, 100: true, 110: true,
+		118: true, 122: true, 128: true, 134: true, 135: true, 138: true, 139: true, 244: true}
+	avcLevels = map[byte]bool{9: true, 10: true, 11: true, 12: true, 13: true, 20: true, 21: true, 22: true,
+		30: true, 31: true, 32: true, 40: true, 41: true, 42: true, 50: true, 51: true, 52: true, 60: true, 61: true, 62: true}
+)
+
+// ParseList parses comma-separated list of codec strings.
+func ParseList(s string) ([]*Codec, error) {
+	var res []*Codec
+	for _, part := range strings.Split(s, ",") {
+		c, err := Parse(part)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, c)
+	}
+	return res, nil
+}
+
+// Parse parses and validates single codec string.
+// Unknown sample entry types are accepted with Unknown type.
+func Parse(s string) (*Codec, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, fmt.Errorf("codecs: empty codec string")
+	}
+
+	parts := strings.Split(s, ".")
+	c := &Codec{Raw: s, FourCC: parts[0]}
+	if f, ok := families[c.FourCC]; ok {
+		c.Family = f.family
+		c.Type = f.typ
+	}
+
+	var err error
+	switch c.Family {
+	case "avc":
+		c.AVC, err = parseAVC(parts[1:])
+	case "hevc":
+		c.HEVC, err = parseHEVC(parts[1:])
+	case "av1":
+		c.AV1, err = parseAV1(parts[1:])
+	case "aac":
+		c.MP4A, err = parseMP4A(parts[1:])
+	case "dvhevc", "dvavc", "dvav1":
+		c.DolbyVision, err = parseDolbyVision(parts[1:])
+	}
+	if err != nil {
+		return nil, fmt.Errorf("codecs: can't parse %q: %w", s, err)
+	}
+	return c, nil
+}
+
+// Profile returns profile number of video codec, or AudioObjectType for mp4a.
+func (c *Codec) Profile() int {
+	switch {
+	case c.AVC != nil:
+		return int(c.AVC.ProfileIDC)
+	case c.HEVC != nil:
+		return int(c.HEVC.ProfileIDC)
+	case c.AV1 != nil:
+		return int(c.AV1.Profile)
+	case c.MP4A != nil:
+		return int(c.MP4A.AudioObjectType)
+	case c.DolbyVision != nil:
+		return int(c.DolbyVision.Profile)
+	}
+	return 0
+}
+
+// Level returns level of video codec in its natural notation: 3.1 for AVC level_idc 31,
+// 3.1 for HEVC level_idc 93, and 3.1 for AV1 seq_level_idx 5.
+func (c *Codec) Level() float64 {
+	switch {
+	case c.AVC != nil:
+		return float64(c.AVC.LevelIDC) / 10
+	case c.HEVC != nil:
+		return float64(c.HEVC.LevelIDC) / 30
+	case c.AV1 != nil:
+		return 2 + float64(c.AV1.Level/4) + float64(c.AV1.Level%4)/10
+	case c.DolbyVision != nil:
+		return float64(c.DolbyVision.Level)
+	}
+	return 0
+}
+
+func parseAVC(parts []string) (*AVC, error) {
+	switch len(parts) {
+	case 1:
+		// avc1.PPCCLL
+		if len(parts[0]) != 6 {
+			return nil, fmt.Errorf("expected 6 hex digits, got %q", parts[0])
+		}
+		b, err := parseHex(parts[0], 24)
+		if err != nil {
+			return nil, err
+		}
+		res := &AVC{ProfileIDC: byte(b >> 16), ConstraintFlags: byte(b >> 8), LevelIDC: byte(b)}
+		return res, res.validate()
+
+	case 2:
+		// legacy avc1.66.30
+		p, err := strconv.ParseUint(parts[0], 10, 8)
+		if err != nil {
+			return nil, err
+		}
+		l, err := strconv.ParseUint(parts[1], 10, 8)
+		if err != nil {
+			return nil, err
+		}
+		res := &AVC{ProfileIDC: byte(p), LevelIDC: byte(l)}
+		return res, res.validate()
+	}
+	return nil, fmt.Errorf("expected profile and level")
+}
+
+func (a *AVC) validate() error {
+	if !avcProfiles[a.ProfileIDC] {
+		return fmt.Errorf("unknown AVC profile_idc %d", a.ProfileIDC)
+	}
+	if !avcLevels[a.LevelIDC] {
+		return fmt.Errorf("unknown AVC level_idc %d", a.LevelIDC)
+	}
+	return nil
+}
+
+func parseHEVC(parts []string) (*HEVC, error) {
+	if len(parts) < 3 {
+		return nil, fmt.Errorf("expected at least profile, compatibility flags and tier/level")
+	}
+	res := new(HEVC)
+
+	profile := parts[0]
+	if profile != "" && profile[0] >= 'A' && profile[0] <= 'C' {
+		res.ProfileSpace = profile[0] - 'A' + 1
+		profile = profile[1:]
+	}
+	p, err := strconv.ParseUint(profile, 10, 8)
+	if err != nil {
+		return nil, err
+	}
+	if p < 1 || p > 11 {
+		return nil, fmt.Errorf("unknown HEVC general_profile_idc %d", p)
+	}
+	res.ProfileIDC = byte(p)
+
+	f, err := parseHex(parts[1], 32)
+	if err != nil {
+		return nil, err
+	}
+	res.CompatibilityFlags = uint32(f)
+
+	tl := parts[2]
+	if tl == "" || (tl[0] != 'L' && tl[0] != 'H') {
+		return nil, fmt.Errorf("expected tier L or H, got %q", tl)
+	}
+	res.HighTier = tl[0] == 'H'
+	l, err := strconv.ParseUint(tl[1:], 10, 8)
+	if err != nil {
+		return nil, err
+	}
+	if l == 0 || l%3 != 0 {
+		return nil, fmt.Errorf("invalid HEVC general_level_idc %d", l)
+	}
+	res.LevelIDC = byte(l)
+
+	if len(parts) > 9 {
+		return nil, fmt.Errorf("too many constraint bytes")
+	}
+	for _, cb := range parts[3:] {
+		b, err := parseHex(cb, 8)
+		if err != nil {
+			return nil, err
+		}
+		res.ConstraintFlags = append(res.ConstraintFlags, byte(b))
+	}
+	return res, nil
+}
+
+func parseAV1(parts []string) (*AV1, error) {
+	if len(parts) != 3 && len(parts) != 9 {
+		return nil, fmt.Errorf("expected 3 or 9 fields, got %d", len(parts))
+	}
+	res := new(AV1)
+
+	p, err := strconv.ParseUint(parts[0], 10, 8)
+	if err != nil {
+		return nil, err
+	}
+	if p > 2 {
+		return nil, fmt.Errorf("invalid AV1 profile %d", p)
+	}
+	res.Profile = byte(p)
+
+	lt := parts[1]
+	if len(lt) != 3 || (lt[2] != 'M' && lt[2] != 'H') {
+		return nil, fmt.Errorf("expected two-digit level and tier M or H, got %q", lt)
+	}
+	l, err := strconv.ParseUint(lt[:2], 10, 8)
+	if err != nil {
+		return nil, err
+	}
+	if l > 31 {
+		return nil, fmt.Errorf("invalid AV1 seq_level_idx %d", l)
+	}
+	res.Level = byte(l)
+	res.HighTier = lt[2] == 'H'
+
+	d, err := strconv.ParseUint(parts[2], 10, 8)
+	if err != nil {
+		return nil, err
+	}
+	if d != 8 && d != 10 && d != 12 {
+		return nil, fmt.Errorf("invalid AV1 bit depth %d", d)
+	}
+	res.BitDepth = byte(d)
+
+	if len(parts) == 3 {
+		return res, nil
+	}
+
+	res.Optional = true
+	res.Monochrome = parts[3] == "1"
+	if len(parts[4]) != 3 {
+		return nil, fmt.Errorf("expected three-digit chroma subsampling, got %q", parts[4])
+	}
+	res.ChromaSubsampling = parts[4]
+	for i, dst := range []*byte{&res.ColorPrimaries, &res.TransferCharacteristics, &res.MatrixCoefficients} {
+		v, err := strconv.ParseUint(parts[5+i], 10, 8)
+		if err != nil {
+			return nil, err
+		}
+		*dst = byte(v)
+	}
+	res.FullRange = parts[8] == "1"
+	return res, nil
+}
+
+func parseDolbyVision(parts []string) (*DolbyVision, error) {
+	if len(parts) != 2 || len(parts[0]) != 2 || len(parts[1]) != 2 {
+		return nil, fmt.Errorf("expected two-digit profile and level")
+	}
+	p, err := strconv.ParseUint(parts[0], 10, 8)
+	if err != nil {
+		return nil, err
+	}
+	l, err := strconv.ParseUint(parts[1], 10, 8)
+	if err != nil {
+		return nil, err
+	}
+	if l < 1 || l > 13 {
+		return nil, fmt.Errorf("invalid Dolby Vision level %d", l)
+	}
+	return &DolbyVision{Profile: byte(p), Level: byte(l)}, nil
+}
+
+func parseMP4A(parts []string) (*MP4A, error) {
+	if len(parts) < 1 || len(parts) > 2 {
+		return nil, fmt.Errorf("expected object type indication and optional audio object type")
+	}
+	oti, err := parseHex(parts[0], 8)
+	if err != nil {
+		return nil, err
+	}
+	res := &MP4A{ObjectTypeIndication: byte(oti)}
+	if len(parts) == 2 {
+		aot, err := strconv.ParseUint(parts[1], 10, 8)
+		if err != nil {
+			return nil, err
+		}
+		if aot == 0 {
+			return nil, fmt.Errorf("invalid audio object type 0")
+		}
+		res.AudioObjectType = byte(aot)
+	}
+	return res, nil
+}
+
+func parseHex(s string, bitSize int) (uint64, error) {
+	if s == "" {
+		return 0, fmt.Errorf("empty hex value")
+	}
+	return strconv.ParseUint(s, 16, bitSize)
+}
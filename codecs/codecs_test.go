@@ -0,0 +1,89 @@
+package codecs
+
+import (
+	"testing"
+
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type CodecsSuite struct{}
+
+var _ = Suite(&CodecsSuite{})
+
+func (s *CodecsSuite) TestParse(c *C) {
+	codec, err := Parse("avc1.64001f")
+	c.Assert(err, IsNil)
+	c.Check(codec.Family, Equals, "avc")
+	c.Check(codec.Type, Equals, Video)
+	c.Check(*codec.AVC, Equals, AVC{ProfileIDC: 100, ConstraintFlags: 0, LevelIDC: 31})
+	c.Check(codec.Level(), Equals, 3.1)
+
+	codec, err = Parse("hvc1.1.6.L93.B0")
+	c.Assert(err, IsNil)
+	c.Check(codec.Family, Equals, "hevc")
+	c.Check(codec.HEVC.ProfileIDC, Equals, byte(1))
+	c.Check(codec.HEVC.CompatibilityFlags, Equals, uint32(6))
+	c.Check(codec.HEVC.HighTier, Equals, false)
+	c.Check(codec.HEVC.LevelIDC, Equals, byte(93))
+	c.Check(codec.HEVC.ConstraintFlags, DeepEquals, []byte{0xB0})
+	c.Check(codec.Level(), Equals, 3.1)
+
+	codec, err = Parse("av01.0.08M.08")
+	c.Assert(err, IsNil)
+	c.Check(*codec.AV1, Equals, AV1{Profile: 0, Level: 8, BitDepth: 8})
+	c.Check(codec.Level(), Equals, 4.0)
+
+	codec, err = Parse("av01.0.04M.10.0.112.09.16.09.0")
+	c.Assert(err, IsNil)
+	c.Check(codec.AV1.Optional, Equals, true)
+	c.Check(codec.AV1.ChromaSubsampling, Equals, "112")
+	c.Check(codec.AV1.TransferCharacteristics, Equals, byte(16))
+
+	codec, err = Parse("mp4a.40.2")
+	c.Assert(err, IsNil)
+	c.Check(codec.Type, Equals, Audio)
+	c.Check(*codec.MP4A, Equals, MP4A{ObjectTypeIndication: 0x40, AudioObjectType: 2})
+
+	codec, err = Parse("ec-3")
+	c.Assert(err, IsNil)
+	c.Check(codec.Family, Equals, "ec-3")
+	c.Check(codec.Type, Equals, Audio)
+
+	codec, err = Parse("dvh1.05.06")
+	c.Assert(err, IsNil)
+	c.Check(*codec.DolbyVision, Equals, DolbyVision{Profile: 5, Level: 6})
+
+	codec, err = Parse("xyz1.2")
+	c.Assert(err, IsNil)
+	c.Check(codec.Type, Equals, Unknown)
+}
+
+func (s *CodecsSuite) TestParseList(c *C) {
+	list, err := ParseList("avc1.4D401E, mp4a.40.5")
+	c.Assert(err, IsNil)
+	c.Assert(list, HasLen, 2)
+	c.Check(list[0].Raw, Equals, "avc1.4D401E")
+	c.Check(list[1].Profile(), Equals, 5)
+}
+
+func (s *CodecsSuite) TestParseErrors(c *C) {
+	for _, str := range []string{
+		"",
+		"avc1.64001",
+		"avc1.FF001f",
+		"avc1.640017",
+		"hvc1.1.6",
+		"hvc1.1.6.X93",
+		"hvc1.1.6.L94",
+		"av01.3.08M.08",
+		"av01.0.08X.08",
+		"av01.0.08M.09",
+		"mp4a.zz.2",
+		"dvhe.05.99",
+	} {
+		_, err := Parse(str)
+		c.Check(err, NotNil, Commentf("%q", str))
+	}
+}
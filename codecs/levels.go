@@ -0,0 +1,101 @@
+package codecs
+
+// Limits are maximum values allowed by profile, tier and level of a video codec.
+type Limits struct {
+	// MaxBitrate is maximum bitrate in bits per second, including NAL overhead for AVC and HEVC.
+	MaxBitrate uint64
+	// MaxLumaPictureSize is maximum number of luma samples per picture.
+	MaxLumaPictureSize uint64
+}
+
+// level is a row of level limits table: maximum picture size and maximum bitrates
+// of main and high tiers in kbit/s (or in codec-specific units for AVC).
+type level struct {
+	maxPictureSize      uint64
+	maxBitrate, maxHigh uint64
+}
+
+var (
+	// avcLevelLimits maps level_idc to MaxFS in macroblocks and MaxBR in 1000 bits/s units of H.264 Table A-1.
+	// Level 1b is keyed 9.
+	avcLevelLimits = map[byte]level{
+		9: {99, 128, 0}, 10: {99, 64, 0}, 11: {396, 192, 0}, 12: {396, 384, 0}, 13: {396, 768, 0},
+		20: {396, 2000, 0}, 21: {792, 4000, 0}, 22: {1620, 4000, 0},
+		30: {1620, 10000, 0}, 31: {3600, 14000, 0}, 32: {5120, 20000, 0},
+		40: {8192, 20000, 0}, 41: {8192, 50000, 0}, 42: {8704, 50000, 0},
+		50: {22080, 135000, 0}, 51: {36864, 240000, 0}, 52: {36864, 240000, 0},
+		60: {139264, 240000, 0}, 61: {139264, 480000, 0}, 62: {139264, 800000, 0},
+	}
+
+	// hevcLevelLimits maps level_idc to MaxLumaPs and MaxBR of main and high tiers of H.265 Table A.8.
+	hevcLevelLimits = map[byte]level{
+		30: {36864, 128, 0}, 60: {122880, 1500, 0}, 63: {245760, 3000, 0},
+		90: {552960, 6000, 0}, 93: {983040, 10000, 0},
+		120: {2228224, 12000, 30000}, 123: {2228224, 20000, 50000},
+		150: {8912896, 25000, 100000}, 153: {8912896, 40000, 160000}, 156: {8912896, 60000, 240000},
+		180: {35651584, 60000, 240000}, 183: {35651584, 120000, 480000}, 186: {35651584, 240000, 800000},
+	}
+
+	// av1LevelLimits maps seq_level_idx to MaxPicSize and MainMbps and HighMbps (in kbit/s) of AV1 Annex A.
+	av1LevelLimits = map[byte]level{
+		0: {147456, 1500, 0}, 1: {278784, 3000, 0},
+		4: {665856, 6000, 0}, 5: {1065024, 10000, 0},
+		8: {2359296, 12000, 30000}, 9: {2359296, 20000, 50000},
+		12: {8912896, 30000, 100000}, 13: {8912896, 40000, 160000}, 14: {8912896, 60000, 240000}, 15: {8912896, 60000, 240000},
+		16: {35651584, 60000, 240000}, 17: {35651584, 100000, 480000}, 18: {35651584, 160000, 800000}, 19: {35651584, 160000, 800000},
+	}
+)
+
+// Limits returns limits of AVC, HEVC and AV1 codecs, or false for other codecs and unknown levels.
+func (c *Codec) Limits() (Limits, bool) {
+	switch {
+	case c.AVC != nil:
+		idc := c.AVC.LevelIDC
+		// level 1b of Baseline, Main and Extended profiles is level_idc 11 with constraint_set3_flag
+		if idc == 11 && c.AVC.ConstraintFlags&0x10 != 0 && (c.AVC.ProfileIDC == 66 || c.AVC.ProfileIDC == 77 || c.AVC.ProfileIDC == 88) {
+			idc = 9
+		}
+		l, ok := avcLevelLimits[idc]
+		if !ok {
+			return Limits{}, false
+		}
+		return Limits{MaxBitrate: l.maxBitrate * avcBitrateFactor(c.AVC.ProfileIDC), MaxLumaPictureSize: l.maxPictureSize * 256}, true
+	case c.HEVC != nil:
+		l, ok := hevcLevelLimits[c.HEVC.LevelIDC]
+		if !ok {
+			return Limits{}, false
+		}
+		br := l.maxBitrate
+		if c.HEVC.HighTier && l.maxHigh != 0 {
+			br = l.maxHigh
+		}
+		return Limits{MaxBitrate: br * 1100, MaxLumaPictureSize: l.maxPictureSize}, true
+	case c.AV1 != nil:
+		l, ok := av1LevelLimits[c.AV1.Level]
+		if !ok {
+			return Limits{}, false
+		}
+		br := l.maxBitrate
+		if c.AV1.HighTier && l.maxHigh != 0 {
+			br = l.maxHigh
+		}
+		// BitrateProfileFactor is 1, 2 and 3 for Main, High and Professional profiles
+		return Limits{MaxBitrate: br * 1000 * uint64(c.AV1.Profile+1), MaxLumaPictureSize: l.maxPictureSize}, true
+	default:
+		return Limits{}, false
+	}
+}
+
+// avcBitrateFactor returns cpbBrNalFactor of H.264 Table A-2 for profile_idc.
+func avcBitrateFactor(profileIDC byte) uint64 {
+	switch profileIDC {
+	case 100:
+		return 1500
+	case 110:
+		return 3600
+	case 122, 244, 44:
+		return 4800
+	default:
+		return 1200
+	}
+}
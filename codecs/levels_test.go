@@ -0,0 +1,31 @@
+package codecs
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *CodecsSuite) TestLimits(c *C) {
+	for _, t := range []struct {
+		codec  string
+		limits Limits
+	}{
+		{"avc1.64001f", Limits{MaxBitrate: 21000000, MaxLumaPictureSize: 921600}},
+		{"avc1.4d401e", Limits{MaxBitrate: 12000000, MaxLumaPictureSize: 414720}},
+		{"avc1.42500b", Limits{MaxBitrate: 153600, MaxLumaPictureSize: 25344}},
+		{"hvc1.1.6.L93.B0", Limits{MaxBitrate: 11000000, MaxLumaPictureSize: 983040}},
+		{"hvc1.2.4.H150.B0", Limits{MaxBitrate: 110000000, MaxLumaPictureSize: 8912896}},
+		{"av01.0.08M.08", Limits{MaxBitrate: 12000000, MaxLumaPictureSize: 2359296}},
+		{"av01.1.12H.10", Limits{MaxBitrate: 200000000, MaxLumaPictureSize: 8912896}},
+	} {
+		codec, err := Parse(t.codec)
+		c.Assert(err, IsNil, Commentf("%s", t.codec))
+		l, ok := codec.Limits()
+		c.Check(ok, Equals, true, Commentf("%s", t.codec))
+		c.Check(l, Equals, t.limits, Commentf("%s", t.codec))
+	}
+
+	codec, err := Parse("mp4a.40.2")
+	c.Assert(err, IsNil)
+	_, ok := codec.Limits()
+	c.Check(ok, Equals, false)
+}
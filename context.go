@@ -0,0 +1,36 @@
+package mpd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"io"
+)
+
+// contextReader reads from r until ctx is done, then fails with ctx.Err().
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+// Read implements io.Reader interface.
+func (cr *contextReader) Read(p []byte) (int, error) {
+	if err := cr.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return cr.r.Read(p)
+}
+
+// newContextDecoder returns xml.Decoder of b which stops reading when ctx is done.
+// encoding/xml buffers input, so cancellation is noticed within a few kilobytes.
+func newContextDecoder(ctx context.Context, b []byte) *xml.Decoder {
+	if ctx.Done() == nil {
+		return xml.NewDecoder(bytes.NewReader(b))
+	}
+	return xml.NewDecoder(&contextReader{ctx: ctx, r: bytes.NewReader(b)})
+}
+
+// unmarshalContext is like xml.Unmarshal, but fails with ctx.Err() when ctx is done.
+func unmarshalContext(ctx context.Context, b []byte, v interface{}) error {
+	return newContextDecoder(ctx, b).Decode(v)
+}
@@ -0,0 +1,45 @@
+package mpd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+// expiringContext is done after its Err was called n times.
+type expiringContext struct {
+	context.Context
+	n int
+}
+
+func (ctx *expiringContext) Err() error {
+	if ctx.n--; ctx.n < 0 {
+		return context.DeadlineExceeded
+	}
+	return nil
+}
+
+func (s *MPDSuite) TestDecodeContext(c *C) {
+	var periods []string
+	for i := 0; i < 200; i++ {
+		periods = append(periods, fmt.Sprintf(`  <Period id="%d"><AdaptationSet mimeType="video/mp4"><Representation id="v%d" bandwidth="1000"/></AdaptationSet></Period>`, i, i))
+	}
+	b := []byte(`<MPD xmlns="urn:mpeg:dash:schema:mpd:2011" type="static">` + "\n" + strings.Join(periods, "\n") + "\n</MPD>\n")
+
+	m := new(MPD)
+	c.Assert(m.DecodeContext(context.Background(), b, DecodeOptions{}), IsNil)
+	c.Check(m.Periods, HasLen, 200)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, o := range []DecodeOptions{{}, {Concurrency: 4}} {
+		c.Check(new(MPD).DecodeContext(ctx, b, o), Equals, context.Canceled)
+	}
+
+	// deadline passes in the middle of parsing
+	live, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c.Check(new(MPD).DecodeContext(&expiringContext{Context: live, n: 2}, b, DecodeOptions{}), Equals, context.DeadlineExceeded)
+}
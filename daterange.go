@@ -0,0 +1,334 @@
+package mpd
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Client attributes of DateRanges converted from Events of schemes other than SCTE35BinScheme,
+// which carry the scheme in CLASS.
+const (
+	DateRangeValueAttribute       = "X-DASH-VALUE"
+	DateRangeMessageDataAttribute = "X-DASH-MESSAGE-DATA"
+	DateRangeContentAttribute     = "X-DASH-CONTENT"
+)
+
+// DateRange is an HLS EXT-X-DATERANGE tag.
+type DateRange struct {
+	ID        string
+	Class     string
+	StartDate time.Time
+	// Duration is DURATION, or the difference of END-DATE and START-DATE when parsed; nil if unknown.
+	Duration        *time.Duration
+	PlannedDuration *time.Duration
+	// SCTE35Cmd, SCTE35Out and SCTE35In are splice_info_sections of SCTE35-CMD, SCTE35-OUT and SCTE35-IN.
+	SCTE35Cmd []byte
+	SCTE35Out []byte
+	SCTE35In  []byte
+	// ClientAttributes are X- attributes, encoded as quoted strings.
+	ClientAttributes map[string]string
+}
+
+// String returns EXT-X-DATERANGE tag line of dr.
+func (dr *DateRange) String() string {
+	attrs := []string{`ID="` + dr.ID + `"`}
+	if dr.Class != "" {
+		attrs = append(attrs, `CLASS="`+dr.Class+`"`)
+	}
+	attrs = append(attrs, `START-DATE="`+FormatDateTime(dr.StartDate)+`"`)
+	if dr.Duration != nil {
+		attrs = append(attrs, "DURATION="+formatDateRangeDuration(*dr.Duration))
+	}
+	if dr.PlannedDuration != nil {
+		attrs = append(attrs, "PLANNED-DURATION="+formatDateRangeDuration(*dr.PlannedDuration))
+	}
+	for _, a := range []struct {
+		name  string
+		value []byte
+	}{{"SCTE35-CMD", dr.SCTE35Cmd}, {"SCTE35-OUT", dr.SCTE35Out}, {"SCTE35-IN", dr.SCTE35In}} {
+		if a.value != nil {
+			attrs = append(attrs, a.name+"=0x"+strings.ToUpper(hex.EncodeToString(a.value)))
+		}
+	}
+	names := make([]string, 0, len(dr.ClientAttributes))
+	for name := range dr.ClientAttributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		attrs = append(attrs, name+`="`+dr.ClientAttributes[name]+`"`)
+	}
+	return "#EXT-X-DATERANGE:" + strings.Join(attrs, ",")
+}
+
+// ParseDateRange parses EXT-X-DATERANGE tag line. Unquoted client attribute values are kept as written.
+func ParseDateRange(line string) (*DateRange, error) {
+	s := strings.TrimSpace(line)
+	if !strings.HasPrefix(s, "#EXT-X-DATERANGE:") {
+		return nil, fmt.Errorf("ParseDateRange: not EXT-X-DATERANGE tag: %q", line)
+	}
+	s = strings.TrimPrefix(s, "#EXT-X-DATERANGE:")
+
+	dr := new(DateRange)
+	var endDate time.Time
+	for s != "" {
+		eq := strings.IndexByte(s, '=')
+		if eq <= 0 {
+			return nil, fmt.Errorf("ParseDateRange: malformed attribute list %q", s)
+		}
+		name, value := s[:eq], ""
+		s = s[eq+1:]
+		if strings.HasPrefix(s, `"`) {
+			end := strings.IndexByte(s[1:], '"')
+			if end < 0 {
+				return nil, fmt.Errorf("ParseDateRange: unterminated quoted string in %s", name)
+			}
+			value, s = s[1:end+1], s[end+2:]
+		} else if comma := strings.IndexByte(s, ','); comma >= 0 {
+			value, s = s[:comma], s[comma:]
+		} else {
+			value, s = s, ""
+		}
+		if s != "" {
+			if s[0] != ',' {
+				return nil, fmt.Errorf("ParseDateRange: malformed attribute list after %s", name)
+			}
+			s = s[1:]
+		}
+
+		var err error
+		switch {
+		case name == "ID":
+			dr.ID = value
+		case name == "CLASS":
+			dr.Class = value
+		case name == "START-DATE":
+			dr.StartDate, err = ParseDateTime(value)
+		case name == "END-DATE":
+			endDate, err = ParseDateTime(value)
+		case name == "DURATION":
+			dr.Duration, err = parseDateRangeDuration(value)
+		case name == "PLANNED-DURATION":
+			dr.PlannedDuration, err = parseDateRangeDuration(value)
+		case name == "SCTE35-CMD":
+			dr.SCTE35Cmd, err = parseHexSequence(value)
+		case name == "SCTE35-OUT":
+			dr.SCTE35Out, err = parseHexSequence(value)
+		case name == "SCTE35-IN":
+			dr.SCTE35In, err = parseHexSequence(value)
+		case strings.HasPrefix(name, "X-"):
+			if dr.ClientAttributes == nil {
+				dr.ClientAttributes = make(map[string]string)
+			}
+			dr.ClientAttributes[name] = value
+		}
+		if err != nil {
+			return nil, fmt.Errorf("ParseDateRange: %s: %w", name, err)
+		}
+	}
+
+	if dr.ID == "" {
+		return nil, fmt.Errorf("ParseDateRange: no ID")
+	}
+	if dr.StartDate.IsZero() {
+		return nil, fmt.Errorf("ParseDateRange: no START-DATE")
+	}
+	if dr.Duration == nil && !endDate.IsZero() {
+		d := endDate.Sub(dr.StartDate)
+		dr.Duration = &d
+	}
+	return dr, nil
+}
+
+// EventDateRange converts Event e of EventStream es of Period p in dynamic MPD m to DateRange with ID of e
+// (or its presentationTime if e has no id). SCTE-35 splice_insert commands become SCTE35-OUT or SCTE35-IN
+// depending on out_of_network_indicator, other SCTE-35 commands become SCTE35-CMD. Events of other schemes
+// keep schemeIdUri in CLASS, and value, messageData and content in DateRange*Attribute client attributes.
+func EventDateRange(m *MPD, p *Period, es *EventStream, e *Event) (*DateRange, error) {
+	start, end, err := EventWallClock(m, p, es, e)
+	if err != nil {
+		return nil, fmt.Errorf("EventDateRange: %w", err)
+	}
+	dr := &DateRange{StartDate: start}
+	if e.Duration != nil {
+		d := end.Sub(start)
+		dr.Duration = &d
+	}
+	switch {
+	case e.ID != nil:
+		dr.ID = *e.ID
+	case e.PresentationTime != nil:
+		dr.ID = strconv.FormatInt(*e.PresentationTime, 10)
+	default:
+		dr.ID = "0"
+	}
+
+	var scheme string
+	if es.SchemeIDURI != nil {
+		scheme = *es.SchemeIDURI
+	}
+	if scheme == SCTE35BinScheme {
+		b, err := e.SpliceInfo()
+		if err != nil {
+			return nil, fmt.Errorf("EventDateRange: Event %s: %w", dr.ID, err)
+		}
+		if b == nil {
+			return nil, fmt.Errorf("EventDateRange: Event %s has no Signal", dr.ID)
+		}
+		switch insert, out := spliceInsertOut(b); {
+		case insert && out:
+			dr.SCTE35Out = b
+		case insert:
+			dr.SCTE35In = b
+		default:
+			dr.SCTE35Cmd = b
+		}
+		return dr, nil
+	}
+
+	dr.Class = scheme
+	content := strings.TrimSpace(e.Content)
+	attrs := make(map[string]string)
+	if es.Value != nil {
+		attrs[DateRangeValueAttribute] = *es.Value
+	}
+	if e.MessageData != nil {
+		attrs[DateRangeMessageDataAttribute] = *e.MessageData
+	}
+	if content != "" {
+		attrs[DateRangeContentAttribute] = content
+	}
+	for name, v := range attrs {
+		if strings.ContainsAny(v, "\"\r\n") {
+			return nil, fmt.Errorf("EventDateRange: Event %s: %s can't be a quoted string", dr.ID, name)
+		}
+	}
+	if len(attrs) > 0 {
+		dr.ClientAttributes = attrs
+	}
+	return dr, nil
+}
+
+// DateRanges converts Events of all EventStreams of dynamic MPD m, except MPDEventScheme ones, with EventDateRange.
+func (m *MPD) DateRanges() ([]*DateRange, error) {
+	var res []*DateRange
+	for _, p := range m.Periods {
+		for i := range p.EventStreams {
+			es := &p.EventStreams[i]
+			if es.SchemeIDURI != nil && *es.SchemeIDURI == MPDEventScheme {
+				continue
+			}
+			for j := range es.Events {
+				dr, err := EventDateRange(m, p, es, &es.Events[j])
+				if err != nil {
+					return nil, err
+				}
+				res = append(res, dr)
+			}
+		}
+	}
+	return res, nil
+}
+
+// AddDateRange converts dr back to Event and adds it to the EventStream of the Period of dynamic MPD m
+// containing dr START-DATE, creating the EventStream if needed. SCTE-35 attributes (SCTE35-OUT first)
+// become SCTE35BinScheme Events; otherwise CLASS and client attributes are used as EventDateRange sets them.
+func (m *MPD) AddDateRange(dr *DateRange) (*Event, error) {
+	scheme, timescale := dr.Class, int64(1000)
+	value := dr.ClientAttributes[DateRangeValueAttribute]
+	var splice []byte
+	for _, b := range [][]byte{dr.SCTE35Out, dr.SCTE35In, dr.SCTE35Cmd} {
+		if b != nil {
+			scheme, timescale, value, splice = SCTE35BinScheme, scte35Timescale, "", b
+			break
+		}
+	}
+	if scheme == "" {
+		return nil, fmt.Errorf("AddDateRange: DateRange %s has neither CLASS nor SCTE-35 attributes", dr.ID)
+	}
+
+	var p *Period
+	for _, period := range m.Periods {
+		base, err := eventStreamBase(m, period)
+		if err != nil {
+			return nil, fmt.Errorf("AddDateRange: %w", err)
+		}
+		if !base.After(dr.StartDate) {
+			p = period
+		}
+	}
+	if p == nil {
+		return nil, fmt.Errorf("AddDateRange: no Period contains %s", FormatDateTime(dr.StartDate))
+	}
+
+	es := p.eventStream(scheme, value, timescale)
+	var d time.Duration
+	if dr.Duration != nil {
+		d = *dr.Duration
+	}
+	e, err := ScheduleEvent(m, p, es, dr.StartDate, d)
+	if err != nil {
+		return nil, fmt.Errorf("AddDateRange: %w", err)
+	}
+	if dr.ID != "" {
+		e.ID = stringPtr(dr.ID)
+	}
+	if splice != nil {
+		e.SetSpliceInfo(splice)
+	} else {
+		if md, ok := dr.ClientAttributes[DateRangeMessageDataAttribute]; ok {
+			e.MessageData = &md
+		}
+		e.Content = dr.ClientAttributes[DateRangeContentAttribute]
+	}
+	es.Events = append(es.Events, *e)
+	return &es.Events[len(es.Events)-1], nil
+}
+
+// spliceInsertOut reports whether splice_info_section b carries splice_insert command,
+// and whether the command is an uncancelled out of network one.
+func spliceInsertOut(b []byte) (insert, out bool) {
+	// splice_command_type follows 13 bytes of section header, splice_event_cancel_indicator
+	// follows 4 bytes of splice_event_id, out_of_network_indicator is in the next byte
+	if len(b) < 19 || b[13] != 0x05 {
+		return false, false
+	}
+	if b[18]&0x80 != 0 || len(b) < 20 {
+		return true, false
+	}
+	return true, b[19]&0x80 != 0
+}
+
+// formatDateRangeDuration formats d as decimal-floating-point seconds.
+func formatDateRangeDuration(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64)
+}
+
+// parseDateRangeDuration parses decimal-floating-point seconds.
+func parseDateRangeDuration(s string) (*time.Duration, error) {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, err
+	}
+	if f < 0 {
+		return nil, fmt.Errorf("negative duration %s", s)
+	}
+	d := time.Duration(f*float64(time.Second) + 0.5)
+	return &d, nil
+}
+
+// parseHexSequence parses hexadecimal-sequence like 0xFC30.
+func parseHexSequence(s string) ([]byte, error) {
+	if !strings.HasPrefix(s, "0x") && !strings.HasPrefix(s, "0X") {
+		return nil, fmt.Errorf("%q is not a hexadecimal sequence", s)
+	}
+	s = s[2:]
+	if len(s)%2 != 0 {
+		s = "0" + s
+	}
+	return hex.DecodeString(s)
+}
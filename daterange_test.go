@@ -0,0 +1,75 @@
+package mpd
+
+import (
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestDateRange(c *C) {
+	newMPD := func() *MPD {
+		return &MPD{
+			Type:                  stringPtr("dynamic"),
+			AvailabilityStartTime: stringPtr("2024-01-01T00:00:00Z"),
+			Periods:               []*Period{{ID: stringPtr("p0"), Start: stringPtr("PT0S")}, {ID: stringPtr("p1"), Start: stringPtr("PT1H")}},
+		}
+	}
+	// splice_insert with out_of_network_indicator
+	out := []byte{0xFC, 0x30, 0x16, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xFF, 0xFF, 0xF0, 0x05, 0x05, 0x00, 0x00, 0x00, 0x01, 0x7F, 0xEF}
+	in := append([]byte{}, out...)
+	in[19] = 0x6F
+
+	m := newMPD()
+	_, err := InsertAdBreak(m, 10*time.Second, 30*time.Second, out)
+	c.Assert(err, IsNil)
+	_, err = InsertAdBreak(m, time.Hour+5*time.Second, 0, in)
+	c.Assert(err, IsNil)
+	m.Periods[0].EventStreams = append(m.Periods[0].EventStreams, EventStream{
+		SchemeIDURI: stringPtr("urn:example:ad"),
+		Value:       stringPtr("v1"),
+		Timescale:   int64Ptr(1000),
+		Events:      []Event{{ID: stringPtr("a"), PresentationTime: int64Ptr(20500), MessageData: stringPtr("hello")}},
+	})
+
+	drs, err := m.DateRanges()
+	c.Assert(err, IsNil)
+	var lines []string
+	for _, dr := range drs {
+		lines = append(lines, dr.String())
+	}
+	c.Check(lines, DeepEquals, []string{
+		`#EXT-X-DATERANGE:ID="1",START-DATE="2024-01-01T00:00:10Z",DURATION=30,SCTE35-OUT=0xFC3016000000000000FFFFF00505000000017FEF`,
+		`#EXT-X-DATERANGE:ID="a",CLASS="urn:example:ad",START-DATE="2024-01-01T00:00:20.5Z",X-DASH-MESSAGE-DATA="hello",X-DASH-VALUE="v1"`,
+		`#EXT-X-DATERANGE:ID="1",START-DATE="2024-01-01T01:00:05Z",DURATION=0,SCTE35-IN=0xFC3016000000000000FFFFF00505000000017F6F`,
+	})
+
+	back := newMPD()
+	for _, line := range lines {
+		dr, err := ParseDateRange(line)
+		c.Assert(err, IsNil)
+		c.Check(dr.String(), Equals, line)
+		_, err = back.AddDateRange(dr)
+		c.Assert(err, IsNil)
+	}
+	c.Check(back.Periods[0].EventStreams, HasLen, 2)
+	c.Check(back.Periods[0].EventStreams[1], DeepEquals, m.Periods[0].EventStreams[1])
+	c.Check(back.Periods[0].EventStreams[0], DeepEquals, m.Periods[0].EventStreams[0])
+	c.Check(back.Periods[1].EventStreams[0].Events[0].Signal, DeepEquals, m.Periods[1].EventStreams[0].Events[0].Signal)
+
+	dr, err := ParseDateRange(`#EXT-X-DATERANGE:ID="x,y",START-DATE="2024-01-01T00:00:00Z",END-DATE="2024-01-01T00:00:15.5Z",X-COM-EXAMPLE=0x1F`)
+	c.Assert(err, IsNil)
+	c.Check(dr.ID, Equals, "x,y")
+	c.Check(*dr.Duration, Equals, 15500*time.Millisecond)
+	c.Check(dr.ClientAttributes, DeepEquals, map[string]string{"X-COM-EXAMPLE": "0x1F"})
+	_, err = back.AddDateRange(dr)
+	c.Check(err, ErrorMatches, "AddDateRange: DateRange x,y has neither CLASS nor SCTE-35 attributes")
+
+	_, err = ParseDateRange(`#EXT-X-DATERANGE:ID="x",START-DATE="2024-01-01T00:00:00Z",SCTE35-OUT=FC30`)
+	c.Check(err, ErrorMatches, `ParseDateRange: SCTE35-OUT: "FC30" is not a hexadecimal sequence`)
+	_, err = ParseDateRange(`#EXT-X-DATERANGE:ID="x`)
+	c.Check(err, ErrorMatches, "ParseDateRange: unterminated quoted string in ID")
+	_, err = ParseDateRange(`#EXT-X-DATERANGE:START-DATE="2024-01-01T00:00:00Z"`)
+	c.Check(err, ErrorMatches, "ParseDateRange: no ID")
+	_, err = ParseDateRange(`#EXTINF:2.0,`)
+	c.Check(err, ErrorMatches, "ParseDateRange: not EXT-X-DATERANGE tag: .*")
+}
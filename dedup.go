@@ -0,0 +1,103 @@
+package mpd
+
+import (
+	"reflect"
+)
+
+// ContentProtectionPlacement selects where DeduplicateContentProtections puts ContentProtection elements.
+type ContentProtectionPlacement int
+
+// ContentProtection placements.
+const (
+	// Elements repeated on every Representation are moved to the AdaptationSet.
+	ContentProtectionOnAdaptationSet ContentProtectionPlacement = iota
+	// AdaptationSet elements are copied to every Representation which doesn't have them yet.
+	ContentProtectionOnRepresentations
+)
+
+// ContentProtectionMove describes ContentProtection elements moved by DeduplicateContentProtections.
+type ContentProtectionMove struct {
+	// Path locates AdaptationSet, e.g. "Periods[0].AdaptationSets[1]".
+	Path               string
+	ContentProtections []ContentProtection
+}
+
+// DeduplicateContentProtections consolidates ContentProtection elements of m's AdaptationSets and their
+// Representations according to placement. It returns performed moves; m is not modified if dryRun is true.
+func (m *MPD) DeduplicateContentProtections(placement ContentProtectionPlacement, dryRun bool) []ContentProtectionMove {
+	var res []ContentProtectionMove
+	for i, p := range m.Periods {
+		for j, as := range p.AdaptationSets {
+			if len(as.Representations) == 0 {
+				continue
+			}
+
+			var moved []ContentProtection
+			switch placement {
+			case ContentProtectionOnAdaptationSet:
+				for _, cp := range as.Representations[0].ContentProtections {
+					common := true
+					for _, r := range as.Representations[1:] {
+						if indexContentProtection(r.ContentProtections, cp) < 0 {
+							common = false
+							break
+						}
+					}
+					if common && indexContentProtection(moved, cp) < 0 {
+						moved = append(moved, cp)
+					}
+				}
+				if len(moved) == 0 || dryRun {
+					break
+				}
+				for k := range as.Representations {
+					r := &as.Representations[k]
+					var rest []ContentProtection
+					for _, cp := range r.ContentProtections {
+						if indexContentProtection(moved, cp) < 0 {
+							rest = append(rest, cp)
+						}
+					}
+					r.ContentProtections = rest
+				}
+				for _, cp := range moved {
+					if indexContentProtection(as.ContentProtections, cp) < 0 {
+						as.ContentProtections = append(as.ContentProtections, cp)
+					}
+				}
+
+			case ContentProtectionOnRepresentations:
+				moved = as.ContentProtections
+				if len(moved) == 0 || dryRun {
+					break
+				}
+				for k := range as.Representations {
+					r := &as.Representations[k]
+					var list []ContentProtection
+					for _, cp := range moved {
+						if indexContentProtection(r.ContentProtections, cp) < 0 {
+							list = append(list, cp)
+						}
+					}
+					r.ContentProtections = append(list, r.ContentProtections...)
+				}
+				as.ContentProtections = nil
+			}
+
+			if len(moved) > 0 {
+				res = append(res, ContentProtectionMove{Path: adaptationSetPath(i, j), ContentProtections: moved})
+			}
+		}
+	}
+	return res
+}
+
+// indexContentProtection returns index of element of list equal to cp, or -1.
+func indexContentProtection(list []ContentProtection, cp ContentProtection) int {
+	for i := range list {
+		if reflect.DeepEqual(list[i], cp) {
+			return i
+		}
+	}
+	return -1
+}
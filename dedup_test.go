@@ -0,0 +1,34 @@
+package mpd
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestDeduplicateContentProtections(c *C) {
+	cenc := ContentProtection{SchemeIDURI: stringPtr("urn:mpeg:dash:mp4protection:2011"), Value: stringPtr("cenc")}
+	widevine := ContentProtection{SchemeIDURI: stringPtr("urn:uuid:edef8ba9-79d6-4ace-a3c8-27dcd51d21ed")}
+	playready := ContentProtection{SchemeIDURI: stringPtr("urn:uuid:9a04f079-9840-4286-ab92-e65be0885f95")}
+	m := &MPD{Periods: []*Period{{AdaptationSets: []*AdaptationSet{
+		{Representations: []Representation{
+			{RepresentationBase: RepresentationBase{ContentProtections: []ContentProtection{cenc, widevine, playready}}},
+			{RepresentationBase: RepresentationBase{ContentProtections: []ContentProtection{widevine, cenc}}},
+		}},
+		{Representations: []Representation{{}}},
+	}}}}
+
+	expected := []ContentProtectionMove{{Path: "Periods[0].AdaptationSets[0]", ContentProtections: []ContentProtection{cenc, widevine}}}
+	c.Check(m.DeduplicateContentProtections(ContentProtectionOnAdaptationSet, true), DeepEquals, expected)
+	c.Check(m.Periods[0].AdaptationSets[0].ContentProtections, IsNil)
+
+	c.Check(m.DeduplicateContentProtections(ContentProtectionOnAdaptationSet, false), DeepEquals, expected)
+	as := m.Periods[0].AdaptationSets[0]
+	c.Check(as.ContentProtections, DeepEquals, []ContentProtection{cenc, widevine})
+	c.Check(as.Representations[0].ContentProtections, DeepEquals, []ContentProtection{playready})
+	c.Check(as.Representations[1].ContentProtections, IsNil)
+	c.Check(m.DeduplicateContentProtections(ContentProtectionOnAdaptationSet, false), IsNil)
+
+	c.Check(m.DeduplicateContentProtections(ContentProtectionOnRepresentations, false), DeepEquals, expected)
+	c.Check(as.ContentProtections, IsNil)
+	c.Check(as.Representations[0].ContentProtections, DeepEquals, []ContentProtection{cenc, widevine, playready})
+	c.Check(as.Representations[1].ContentProtections, DeepEquals, []ContentProtection{cenc, widevine})
+}
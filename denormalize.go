@@ -0,0 +1,64 @@
+package mpd
+
+import "reflect"
+
+// Denormalize pushes segment addressing, RepresentationBase elements (ContentProtections, AudioChannelConfigurations,
+// InbandEventStreams and others), codecs and frameRate of Periods and AdaptationSets down to their Representations,
+// producing MPD without inheritance for players which don't support it. AdaptationSets without Representations
+// are left as is. Representations may share pointed values afterwards.
+func (m *MPD) Denormalize() {
+	for _, p := range m.Periods {
+		// Period's segment addressing is kept for AdaptationSets without Representations
+		pushed := true
+		for _, as := range p.AdaptationSets {
+			if len(as.Representations) == 0 {
+				pushed = false
+				continue
+			}
+			for k := range as.Representations {
+				r := &as.Representations[k]
+				switch {
+				case r.SegmentTemplate != nil || as.SegmentTemplate != nil || p.SegmentTemplate != nil:
+					r.SegmentTemplate = EffectiveSegmentTemplate(p, as, r)
+				case r.SegmentList != nil || as.SegmentList != nil || p.SegmentList != nil:
+					r.SegmentList = EffectiveSegmentList(p, as, r)
+				case r.SegmentBase != nil || as.SegmentBase != nil || p.SegmentBase != nil:
+					r.SegmentBase = EffectiveSegmentBase(p, as, r)
+				}
+				inheritRepresentationBase(&r.RepresentationBase, &as.RepresentationBase)
+				if r.Codecs == nil {
+					r.Codecs = as.Codecs
+				}
+				if r.FrameRate == nil {
+					r.FrameRate = as.FrameRate
+				}
+			}
+			as.SegmentTemplate, as.SegmentList, as.SegmentBase = nil, nil, nil
+			as.RepresentationBase = RepresentationBase{}
+			as.Codecs, as.FrameRate = nil, nil
+		}
+		if pushed {
+			p.SegmentTemplate, p.SegmentList, p.SegmentBase = nil, nil, nil
+		}
+	}
+}
+
+// inheritRepresentationBase prepends elements of parent to repeated elements of rb and sets its absent
+// optional elements from parent. Every field of RepresentationBase is covered, including future ones.
+func inheritRepresentationBase(rb, parent *RepresentationBase) {
+	dst, src := reflect.ValueOf(rb).Elem(), reflect.ValueOf(parent).Elem()
+	for i := 0; i < src.NumField(); i++ {
+		d, s := dst.Field(i), src.Field(i)
+		switch s.Kind() {
+		case reflect.Slice:
+			if s.Len() > 0 {
+				res := reflect.MakeSlice(s.Type(), 0, s.Len()+d.Len())
+				d.Set(reflect.AppendSlice(reflect.AppendSlice(res, s), d))
+			}
+		case reflect.Ptr:
+			if d.IsNil() {
+				d.Set(s)
+			}
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package mpd
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestDenormalize(c *C) {
+	cenc := ContentProtection{SchemeIDURI: stringPtr("urn:mpeg:dash:mp4protection:2011"), Value: stringPtr("cenc")}
+	m := &MPD{Periods: []*Period{{
+		SegmentTemplate: &SegmentTemplate{Timescale: uint64Ptr(1000)},
+		AdaptationSets: []*AdaptationSet{{
+			MimeType:  "video/mp4",
+			FrameRate: stringPtr("25"),
+			RepresentationBase: RepresentationBase{
+				ContentProtections:  []ContentProtection{cenc},
+				EssentialProperties: []Descriptor{NewDescriptor("urn:example:essential", "1")},
+			},
+			SegmentTemplate: &SegmentTemplate{Media: stringPtr("$RepresentationID$/$Number$.m4s")},
+			Representations: []Representation{
+				{ID: stringPtr("1")},
+				{ID: stringPtr("2"), FrameRate: stringPtr("50"), SegmentTemplate: &SegmentTemplate{StartNumber: uint64Ptr(5)}},
+			},
+		}},
+	}}}
+
+	m.Denormalize()
+	p := m.Periods[0]
+	as := p.AdaptationSets[0]
+	c.Check(p.SegmentTemplate, IsNil)
+	c.Check(as.SegmentTemplate, IsNil)
+	c.Check(as.ContentProtections, IsNil)
+	c.Check(as.EssentialProperties, IsNil)
+	c.Check(as.FrameRate, IsNil)
+
+	r := as.Representations
+	c.Check(r[0].SegmentTemplate, DeepEquals, &SegmentTemplate{Timescale: uint64Ptr(1000), Media: stringPtr("$RepresentationID$/$Number$.m4s")})
+	c.Check(r[1].SegmentTemplate, DeepEquals, &SegmentTemplate{Timescale: uint64Ptr(1000), Media: stringPtr("$RepresentationID$/$Number$.m4s"), StartNumber: uint64Ptr(5)})
+	c.Check(r[0].ContentProtections, DeepEquals, []ContentProtection{cenc})
+	c.Check(r[1].EssentialProperties, HasLen, 1)
+	c.Check(*r[0].FrameRate, Equals, "25")
+	c.Check(*r[1].FrameRate, Equals, "50")
+
+	// all RepresentationBase elements are inherited
+	m = &MPD{Periods: []*Period{{AdaptationSets: []*AdaptationSet{{
+		RepresentationBase: RepresentationBase{
+			FramePackings:              []Descriptor{NewDescriptor("urn:mpeg:mpegB:cicp:VideoFramePackingType", "3")},
+			AudioChannelConfigurations: []AudioChannelConfiguration{{SchemeIDURI: stringPtr(MPEGChannelConfigurationScheme), Value: stringPtr("2")}},
+			InbandEventStreams:         []InbandEventStream{{SchemeIDURI: stringPtr("urn:mpeg:dash:event:2012"), Value: stringPtr("1")}},
+			OutputProtection:           &Descriptor{SchemeIDURI: stringPtr("urn:example:hdcp")},
+			Labels:                     []Label{{Value: "main"}},
+		},
+		Representations: []Representation{{}, {RepresentationBase: RepresentationBase{Labels: []Label{{Value: "own"}}}}},
+	}}}}}
+	m.Denormalize()
+	as = m.Periods[0].AdaptationSets[0]
+	c.Check(as.RepresentationBase, DeepEquals, RepresentationBase{})
+	r = as.Representations
+	c.Check(r[0].FramePackings, HasLen, 1)
+	c.Check(r[0].AudioChannelConfigurations, HasLen, 1)
+	c.Check(r[0].InbandEventStreams, HasLen, 1)
+	c.Check(r[0].OutputProtection, NotNil)
+	c.Check(r[0].Labels, DeepEquals, []Label{{Value: "main"}})
+	c.Check(r[1].Labels, DeepEquals, []Label{{Value: "main"}, {Value: "own"}})
+	r[0].Labels[0].Value = "changed"
+	c.Check(r[1].Labels[0].Value, Equals, "main")
+
+	// Period's template is kept for AdaptationSet without Representations
+	m = &MPD{Periods: []*Period{{
+		SegmentTemplate: &SegmentTemplate{Timescale: uint64Ptr(1000)},
+		AdaptationSets:  []*AdaptationSet{{}, {Representations: []Representation{{}}}},
+	}}}
+	m.Denormalize()
+	c.Check(m.Periods[0].SegmentTemplate, NotNil)
+	c.Check(m.Periods[0].AdaptationSets[1].Representations[0].SegmentTemplate, DeepEquals, &SegmentTemplate{Timescale: uint64Ptr(1000)})
+}
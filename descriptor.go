@@ -0,0 +1,35 @@
+package mpd
+
+// NewDescriptor returns Descriptor with given schemeIdUri and value.
+func NewDescriptor(schemeIDURI, value string) Descriptor {
+	return Descriptor{SchemeIDURI: &schemeIDURI, Value: &value}
+}
+
+// Is returns true if d has given schemeIdUri.
+func (d *Descriptor) Is(schemeIDURI string) bool {
+	return d.SchemeIDURI != nil && *d.SchemeIDURI == schemeIDURI
+}
+
+// findDescriptor returns the first descriptor from list with given schemeIdUri, or nil.
+func findDescriptor(list []Descriptor, schemeIDURI string) *Descriptor {
+	for i := range list {
+		if list[i].Is(schemeIDURI) {
+			return &list[i]
+		}
+	}
+	return nil
+}
+
+// removeDescriptors removes all descriptors with given schemeIdUri from list.
+func removeDescriptors(list *[]Descriptor, schemeIDURI string) {
+	res := (*list)[:0]
+	for _, d := range *list {
+		if !d.Is(schemeIDURI) {
+			res = append(res, d)
+		}
+	}
+	if len(res) == 0 {
+		res = nil
+	}
+	*list = res
+}
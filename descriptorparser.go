@@ -0,0 +1,89 @@
+package mpd
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Role values defined by MPEG-DASH for RoleScheme.
+var roleValues = map[string]bool{
+	"main": true, "alternate": true, "supplementary": true, "commentary": true, "dub": true,
+	"emergency": true, "caption": true, "subtitle": true, "sign": true, "description": true,
+	"enhanced-audio-intelligibility": true, "karaoke": true, RoleForcedSubtitle: true,
+	"metadata": true, "easyreader": true,
+}
+
+// DescriptorParser converts Descriptor value into a typed value.
+type DescriptorParser func(value string) (interface{}, error)
+
+var (
+	descriptorParsersM sync.RWMutex
+	descriptorParsers  = make(map[string]DescriptorParser)
+)
+
+func init() {
+	RegisterDescriptorParser(RoleScheme, parseRole)
+	for scheme, typed := range map[string]func(v int) CICPValue{
+		ColourPrimariesScheme:          func(v int) CICPValue { return ColourPrimaries(v) },
+		TransferCharacteristicsScheme:  func(v int) CICPValue { return TransferCharacteristics(v) },
+		MatrixCoefficientsScheme:       func(v int) CICPValue { return MatrixCoefficients(v) },
+		VideoFramePackingTypeScheme:    func(v int) CICPValue { return VideoFramePackingType(v) },
+		CICPChannelConfigurationScheme: func(v int) CICPValue { return ChannelConfiguration(v) },
+	} {
+		RegisterDescriptorParser(scheme, parseCICP(typed))
+	}
+	for _, scheme := range thumbnailTileSchemes {
+		RegisterDescriptorParser(scheme, func(value string) (interface{}, error) { return ParseThumbnailTile(value) })
+	}
+	RegisterDescriptorParser(SRDScheme, func(value string) (interface{}, error) { return ParseSRD(value) })
+}
+
+// RegisterDescriptorParser registers parser for Descriptors with given schemeIdUri.
+// Registered parsers:
+//   - RoleScheme: string, one of values defined by MPEG-DASH;
+//   - CICP schemes: ColourPrimaries, TransferCharacteristics, MatrixCoefficients, VideoFramePackingType
+//     and ChannelConfiguration;
+//   - ThumbnailTileScheme: *ThumbnailTile;
+//   - SRDScheme: *SRD.
+func RegisterDescriptorParser(schemeIDURI string, parser DescriptorParser) {
+	descriptorParsersM.Lock()
+	descriptorParsers[schemeIDURI] = parser
+	descriptorParsersM.Unlock()
+}
+
+// ParseValue returns d's value converted by parser registered for d's schemeIdUri.
+// It returns nil value and nil error if there is no parser for that scheme.
+func (d *Descriptor) ParseValue() (interface{}, error) {
+	if d.SchemeIDURI == nil {
+		return nil, nil
+	}
+	descriptorParsersM.RLock()
+	parser := descriptorParsers[*d.SchemeIDURI]
+	descriptorParsersM.RUnlock()
+	if parser == nil {
+		return nil, nil
+	}
+	if d.Value == nil {
+		return nil, fmt.Errorf("ParseValue: %s descriptor without value", *d.SchemeIDURI)
+	}
+	return parser(*d.Value)
+}
+
+func parseRole(value string) (interface{}, error) {
+	if !roleValues[value] {
+		return nil, fmt.Errorf("ParseValue: unknown Role value %q", value)
+	}
+	return value, nil
+}
+
+// parseCICP returns parser of CICP code points converted to typed values.
+func parseCICP(typed func(v int) CICPValue) DescriptorParser {
+	return func(value string) (interface{}, error) {
+		v, err := parseCICPCode(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("ParseValue: invalid CICP value %q", value)
+		}
+		return typed(v), nil
+	}
+}
@@ -0,0 +1,43 @@
+package mpd
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestDescriptorParseValue(c *C) {
+	for _, t := range []struct {
+		d        Descriptor
+		expected interface{}
+	}{
+		{NewDescriptor(RoleScheme, "commentary"), "commentary"},
+		{NewDescriptor(TransferCharacteristicsScheme, "16"), TransferPQ},
+		{NewDescriptor(VideoFramePackingTypeScheme, "3"), VideoFramePackingType(FramePackingSideBySide)},
+		{NewDescriptor(CICPChannelConfigurationScheme, "6"), ChannelConfiguration51},
+		{NewDescriptor("urn:mpeg:dash:thumbnail_tile", "10x5"), &ThumbnailTile{Columns: 10, Rows: 5}},
+		{NewDescriptor(SRDScheme, "0,0,0,1920,1080"), &SRD{ObjectWidth: 1920, ObjectHeight: 1080}},
+		{NewDescriptor("urn:example:unknown", "x"), nil},
+	} {
+		v, err := t.d.ParseValue()
+		c.Check(err, IsNil)
+		c.Check(v, DeepEquals, t.expected, Commentf("%s", *t.d.SchemeIDURI))
+	}
+
+	for _, t := range []struct {
+		d   Descriptor
+		err string
+	}{
+		{NewDescriptor(RoleScheme, "director"), `ParseValue: unknown Role value "director"`},
+		{NewDescriptor(ColourPrimariesScheme, "BT.709"), `ParseValue: invalid CICP value "BT.709"`},
+		{NewDescriptor(ThumbnailTileScheme, "10"), `ThumbnailTile: can't parse "10"`},
+		{Descriptor{SchemeIDURI: NewDescriptor(RoleScheme, "").SchemeIDURI}, "ParseValue: urn:mpeg:dash:role:2011 descriptor without value"},
+	} {
+		_, err := t.d.ParseValue()
+		c.Check(err, ErrorMatches, t.err)
+	}
+
+	RegisterDescriptorParser("urn:example:bool", func(value string) (interface{}, error) { return value == "true", nil })
+	d := NewDescriptor("urn:example:bool", "true")
+	v, err := d.ParseValue()
+	c.Check(err, IsNil)
+	c.Check(v, Equals, true)
+}
@@ -0,0 +1,287 @@
+package mpd
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+)
+
+// XLinkNamespace is a namespace of xlink attributes, declared by many packagers whether used or not.
+const XLinkNamespace = "http://www.w3.org/1999/xlink"
+
+// Dialect describes output conventions of a packager. Set EncodeOptions.Dialect to one of Dialect* presets
+// to make Encode output byte-comparable with output of that packager.
+type Dialect struct {
+	Name string
+	// Declaration replaces XML declaration if not empty.
+	Declaration string
+	// Indent is one level of indentation, defaults to two spaces.
+	Indent string
+	// Prefixes maps namespaces to prefixes which are declared on MPD element whether used or not.
+	// MPD.NamespacePrefixes take precedence.
+	Prefixes map[string]string
+	// AttributeOrder maps element names to attribute names (with prefixes) emitted first in given order.
+	// Other attributes follow in Encode order.
+	AttributeOrder map[string][]string
+	// ElementOrder maps element names to names of their children in order they should be emitted in.
+	// Unlisted children keep their positions. Encode emits XSD order, so only orders of siblings which
+	// XSD doesn't order among themselves keep the output valid for ValidateElementOrder.
+	ElementOrder map[string][]string
+}
+
+// Dialect presets.
+var (
+	// DialectShaka follows Shaka Packager.
+	DialectShaka = &Dialect{
+		Name:        "shaka",
+		Declaration: `<?xml version="1.0" encoding="UTF-8"?>`,
+		Prefixes: map[string]string{
+			XSINamespace:   "xsi",
+			XLinkNamespace: "xlink",
+			CENCNamespace:  "cenc",
+		},
+		AttributeOrder: map[string][]string{
+			"MPD": {
+				"xmlns", "xmlns:xsi", "xmlns:xlink", "xsi:schemaLocation", "xmlns:cenc", "profiles", "minBufferTime",
+				"type", "mediaPresentationDuration", "availabilityStartTime", "timeShiftBufferDepth",
+				"minimumUpdatePeriod", "publishTime",
+			},
+			"AdaptationSet":  {"id", "contentType", "width", "height", "frameRate", "segmentAlignment", "subsegmentAlignment", "par", "lang"},
+			"Representation": {"id", "bandwidth", "codecs", "mimeType", "width", "height", "frameRate", "sar", "audioSamplingRate"},
+			"SegmentTemplate": {
+				"timescale", "presentationTimeOffset", "initialization", "media", "startNumber", "duration",
+			},
+		},
+	}
+
+	// DialectFFmpeg follows FFmpeg's dash muxer.
+	DialectFFmpeg = &Dialect{
+		Name:   "ffmpeg",
+		Indent: "\t",
+		Prefixes: map[string]string{
+			XSINamespace:   "xsi",
+			XLinkNamespace: "xlink",
+		},
+		AttributeOrder: map[string][]string{
+			"MPD": {
+				"xmlns:xsi", "xmlns", "xmlns:xlink", "xsi:schemaLocation", "profiles", "type",
+				"mediaPresentationDuration", "availabilityStartTime", "publishTime", "minimumUpdatePeriod",
+				"timeShiftBufferDepth", "maxSegmentDuration", "minBufferTime",
+			},
+			"AdaptationSet": {
+				"id", "contentType", "startWithSAP", "segmentAlignment", "bitstreamSwitching", "frameRate",
+				"maxWidth", "maxHeight", "par", "lang",
+			},
+			"Representation":  {"id", "mimeType", "codecs", "bandwidth", "width", "height", "sar", "audioSamplingRate"},
+			"SegmentTemplate": {"timescale", "duration", "initialization", "media", "startNumber"},
+		},
+	}
+
+	// DialectUSP follows Unified Streaming Platform.
+	DialectUSP = &Dialect{
+		Name: "usp",
+		Prefixes: map[string]string{
+			CENCNamespace: "cenc",
+		},
+		AttributeOrder: map[string][]string{
+			"MPD": {
+				"xmlns", "xmlns:cenc", "type", "availabilityStartTime", "publishTime", "minimumUpdatePeriod",
+				"timeShiftBufferDepth", "mediaPresentationDuration", "maxSegmentDuration", "minBufferTime",
+				"suggestedPresentationDelay", "profiles",
+			},
+			"AdaptationSet": {
+				"id", "group", "contentType", "lang", "par", "segmentAlignment", "width", "height", "sar",
+				"mimeType", "codecs", "audioSamplingRate", "startWithSAP",
+			},
+			"Representation":  {"id", "bandwidth", "width", "height", "codecs", "scanType"},
+			"SegmentTemplate": {"timescale", "initialization", "media", "startNumber", "duration", "presentationTimeOffset"},
+		},
+	}
+)
+
+// prefixes returns namespace prefixes of d overridden by own ones.
+func (d *Dialect) prefixes(own map[string]string) map[string]string {
+	if len(d.Prefixes) == 0 {
+		return own
+	}
+	res := make(map[string]string, len(d.Prefixes)+len(own))
+	for ns, prefix := range d.Prefixes {
+		res[ns] = prefix
+	}
+	for ns, prefix := range own {
+		res[ns] = prefix
+	}
+	return res
+}
+
+var attrRE = regexp.MustCompile(`\s+([\w.:-]+)="[^"]*"`)
+
+// apply rewrites MPD b encoded with two-space indentation to follow d.
+func (d *Dialect) apply(b []byte) []byte {
+	if len(d.AttributeOrder) > 0 {
+		b = tagRE.ReplaceAllFunc(b, func(tag []byte) []byte {
+			m := tagRE.FindSubmatch(tag)
+			order := d.AttributeOrder[localName(string(m[2]))]
+			if len(m[1]) > 0 || len(order) == 0 {
+				return tag
+			}
+			return []byte("<" + string(m[2]) + reorderAttributes(string(m[3]), order) + ">")
+		})
+	}
+
+	if len(d.ElementOrder) > 0 || (d.Indent != "" && d.Indent != "  ") {
+		if lines, ok := parseLines(b); ok {
+			indent := d.Indent
+			if indent == "" {
+				indent = "  "
+			}
+			res := new(bytes.Buffer)
+			for _, l := range lines {
+				l.write(res, d, indent, 0)
+			}
+			b = res.Bytes()
+		}
+	}
+
+	if d.Declaration != "" && bytes.HasPrefix(b, []byte("<?xml")) {
+		if i := bytes.Index(b, []byte("?>")); i >= 0 {
+			b = append([]byte(d.Declaration), b[i+2:]...)
+		}
+	}
+	return b
+}
+
+// localName returns element name without prefix.
+func localName(name string) string {
+	return name[strings.LastIndex(name, ":")+1:]
+}
+
+// reorderAttributes returns attributes of start tag (with trailing "/" of self-closing one)
+// with ones listed in order first.
+func reorderAttributes(attrs string, order []string) string {
+	loc := attrRE.FindAllStringSubmatchIndex(attrs, -1)
+	if len(loc) < 2 {
+		return attrs
+	}
+	tail := attrs[loc[len(loc)-1][1]:]
+	rank := func(name string) int {
+		for i, n := range order {
+			if n == name {
+				return i
+			}
+		}
+		return len(order)
+	}
+
+	list := make([]string, len(loc))
+	ranks := make([]int, len(loc))
+	for i, l := range loc {
+		list[i] = " " + strings.TrimSpace(attrs[l[0]:l[1]])
+		ranks[i] = rank(attrs[l[2]:l[3]])
+	}
+	// stable insertion sort: lists are short
+	for i := 1; i < len(list); i++ {
+		for j := i; j > 0 && ranks[j] < ranks[j-1]; j-- {
+			list[j], list[j-1] = list[j-1], list[j]
+			ranks[j], ranks[j-1] = ranks[j-1], ranks[j]
+		}
+	}
+	return strings.Join(list, "") + tail
+}
+
+// encodedLine is an element of Encode output with its children.
+type encodedLine struct {
+	name     string
+	start    string // start tag, or the whole element if it takes a single line
+	end      string // end tag, empty for single-line elements
+	children []*encodedLine
+}
+
+// parseLines splits Encode output into elements, or returns false if it is not one element per line.
+func parseLines(b []byte) ([]*encodedLine, bool) {
+	var top []*encodedLine
+	var stack []*encodedLine
+	for _, s := range strings.Split(strings.TrimRight(string(b), "\n"), "\n") {
+		s = strings.TrimSpace(s)
+		var l *encodedLine
+		switch {
+		case s == "":
+			continue
+		case strings.HasPrefix(s, "</"):
+			if len(stack) == 0 {
+				return nil, false
+			}
+			stack[len(stack)-1].end = s
+			stack = stack[:len(stack)-1]
+			continue
+		case strings.HasPrefix(s, "<?") || strings.HasPrefix(s, "<!--"):
+			l = &encodedLine{start: s}
+		case strings.HasPrefix(s, "<"):
+			m := tagRE.FindStringSubmatch(s)
+			if m == nil {
+				return nil, false
+			}
+			l = &encodedLine{name: localName(m[2]), start: s}
+		default:
+			return nil, false
+		}
+
+		if len(stack) > 0 {
+			parent := stack[len(stack)-1]
+			parent.children = append(parent.children, l)
+		} else {
+			top = append(top, l)
+		}
+		if l.name != "" && !strings.HasSuffix(s, "/>") && !strings.HasSuffix(s, "</"+tagName(s)+">") {
+			stack = append(stack, l)
+		}
+	}
+	return top, len(stack) == 0
+}
+
+// tagName returns name of element starting line s.
+func tagName(s string) string {
+	return tagRE.FindStringSubmatch(s)[2]
+}
+
+// write writes l and its children reordered according to d at given depth.
+func (l *encodedLine) write(w *bytes.Buffer, d *Dialect, indent string, depth int) {
+	w.WriteString(strings.Repeat(indent, depth))
+	w.WriteString(l.start)
+	w.WriteByte('\n')
+	if order := d.ElementOrder[l.name]; len(order) > 0 {
+		reorderLines(l.children, order)
+	}
+	for _, c := range l.children {
+		c.write(w, d, indent, depth+1)
+	}
+	if l.end != "" {
+		w.WriteString(strings.Repeat(indent, depth))
+		w.WriteString(l.end)
+		w.WriteByte('\n')
+	}
+}
+
+// reorderLines sorts children listed in order, keeping positions of unlisted ones.
+func reorderLines(children []*encodedLine, order []string) {
+	var positions []int
+	var listed []*encodedLine
+	for i, c := range children {
+		for _, name := range order {
+			if c.name == name {
+				positions = append(positions, i)
+				listed = append(listed, c)
+				break
+			}
+		}
+	}
+	var k int
+	for _, name := range order {
+		for _, c := range listed {
+			if c.name == name {
+				children[positions[k]] = c
+				k++
+			}
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package mpd
+
+import (
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func dialectMPD() *MPD {
+	m := NewStaticMPD(ProfileISOFFLive, time.Minute)
+	m.Periods[0].AdaptationSets = []*AdaptationSet{{
+		ID:          uint64Ptr(1),
+		MimeType:    MimeTypeAudioMP4,
+		ContentType: stringPtr("audio"),
+		Lang:        stringPtr("en"),
+		RepresentationBase: RepresentationBase{ContentProtections: []ContentProtection{
+			{SchemeIDURI: stringPtr(MP4ProtectionScheme), Value: stringPtr("cenc"), DefaultKID: stringPtr("10000000-1000-1000-1000-100000000000")},
+		}},
+		Roles:           []Descriptor{NewDescriptor(RoleScheme, "main")},
+		SegmentTemplate: &SegmentTemplate{Timescale: uint64Ptr(48000), Duration: uint32Ptr(96000), Media: stringPtr("$Number$.m4s"), Initialization: stringPtr("init.mp4"), StartNumber: uint64Ptr(1)},
+		Representations: []Representation{{ID: stringPtr("a"), Bandwidth: uint64Ptr(128000), Codecs: stringPtr("mp4a.40.2"), AudioSamplingRate: stringPtr("48000")}},
+	}}
+	return m
+}
+
+func (s *MPDSuite) TestDialects(c *C) {
+	b, err := dialectMPD().EncodeWithOptions(EncodeOptions{Dialect: DialectShaka})
+	c.Assert(err, IsNil)
+	c.Check(string(b), Equals, `<?xml version="1.0" encoding="UTF-8"?>
+<MPD xmlns="urn:mpeg:dash:schema:mpd:2011" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xmlns:xlink="http://www.w3.org/1999/xlink" xmlns:cenc="urn:mpeg:cenc:2013" profiles="urn:mpeg:dash:profile:isoff-live:2011" minBufferTime="PT2S" type="static" mediaPresentationDuration="PT1M">
+  <Period start="PT0S" id="0">
+    <AdaptationSet id="1" contentType="audio" lang="en" mimeType="audio/mp4">
+      <ContentProtection schemeIdUri="urn:mpeg:dash:mp4protection:2011" value="cenc" cenc:default_KID="10000000-1000-1000-1000-100000000000"/>
+      <Role schemeIdUri="urn:mpeg:dash:role:2011" value="main"/>
+      <SegmentTemplate timescale="48000" initialization="init.mp4" media="$Number$.m4s" startNumber="1" duration="96000"/>
+      <Representation id="a" bandwidth="128000" codecs="mp4a.40.2" audioSamplingRate="48000"/>
+    </AdaptationSet>
+  </Period>
+</MPD>
+`)
+
+	b, err = dialectMPD().EncodeWithOptions(EncodeOptions{Dialect: DialectFFmpeg})
+	c.Assert(err, IsNil)
+	c.Check(string(b), Matches, `(?s)<\?xml version="1.0" encoding="utf-8"\?>
+<MPD xmlns:xsi="[^"]+" xmlns="urn:mpeg:dash:schema:mpd:2011" xmlns:xlink="[^"]+" profiles=.*
+\t\t<AdaptationSet .*
+\t\t\t<ContentProtection .*
+\t\t\t<Role .*
+\t\t\t<SegmentTemplate timescale="48000" duration="96000" initialization="init.mp4" media="\$Number\$.m4s" startNumber="1"/>
+\t\t\t<Representation id="a" codecs="mp4a.40.2" bandwidth="128000" audioSamplingRate="48000"/>
+.*`)
+
+	// dialects change only representation
+	expected, err := dialectMPD().Encode()
+	c.Assert(err, IsNil)
+	for _, d := range []*Dialect{DialectShaka, DialectFFmpeg, DialectUSP} {
+		b, err = dialectMPD().EncodeWithOptions(EncodeOptions{Dialect: d})
+		c.Assert(err, IsNil)
+		c.Check(ValidateElementOrder(b), IsNil, Commentf("%s", d.Name))
+		m := new(MPD)
+		c.Assert(m.Decode(b), IsNil, Commentf("%s", d.Name))
+		m.NamespacePrefixes = nil
+		m.XSI, m.Cenc = nil, nil
+		b, err = m.Encode()
+		c.Assert(err, IsNil)
+		c.Check(string(b), Equals, string(expected), Commentf("%s", d.Name))
+	}
+}
+
+func (s *MPDSuite) TestDialectElementOrder(c *C) {
+	m := dialectMPD()
+	as := m.Periods[0].AdaptationSets[0]
+	as.Roles = append(as.Roles, NewDescriptor(RoleScheme, "dub"))
+	as.Accessibility = []Descriptor{NewDescriptor(RoleScheme, "description")}
+	b, err := m.EncodeWithOptions(EncodeOptions{Dialect: &Dialect{Name: "roles", ElementOrder: map[string][]string{
+		"AdaptationSet": {"Role", "Accessibility"},
+	}}})
+	c.Assert(err, IsNil)
+	c.Check(string(b), Matches, `(?s).*<ContentProtection [^\n]*/>
+      <Role [^\n]*"main"/>
+      <Role [^\n]*"dub"/>
+      <Accessibility .*`)
+	c.Check(ValidateElementOrder(b), ErrorMatches, ".*Accessibility must precede Role")
+}
+
+func (s *MPDSuite) TestReorderAttributes(c *C) {
+	c.Check(reorderAttributes(` a="1" b="2" c="3"/`, []string{"c", "a"}), Equals, ` c="3" a="1" b="2"/`)
+	c.Check(reorderAttributes(` a="1"`, []string{"b"}), Equals, ` a="1"`)
+}
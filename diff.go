@@ -0,0 +1,371 @@
+package mpd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// DifferenceKind is a kind of Difference.
+type DifferenceKind int
+
+// Kinds of Difference.
+const (
+	// DiffRemoved means that attribute or element of old MPD is missing in new one.
+	DiffRemoved DifferenceKind = iota
+	// DiffAdded means that new MPD has attribute or element missing in old one.
+	DiffAdded
+	// DiffChanged means that value of attribute or text of element differs.
+	DiffChanged
+)
+
+// String implements fmt.Stringer interface.
+func (k DifferenceKind) String() string {
+	switch k {
+	case DiffRemoved:
+		return "removed"
+	case DiffAdded:
+		return "added"
+	case DiffChanged:
+		return "changed"
+	default:
+		return fmt.Sprintf("DifferenceKind(%d)", int(k))
+	}
+}
+
+// Difference is a single semantic difference between two MPDs.
+type Difference struct {
+	Kind DifferenceKind
+	// Path locates element or attribute, e.g. "MPD/Period[0]/AdaptationSet[1]/@lang".
+	// Elements are numbered among siblings with the same name, in new MPD unless removed.
+	Path string
+	// Old and New are attribute values or element texts; they are empty for missing ones.
+	Old string
+	New string
+}
+
+// String implements fmt.Stringer interface.
+func (d Difference) String() string {
+	switch d.Kind {
+	case DiffRemoved:
+		return fmt.Sprintf("%s: removed %q", d.Path, d.Old)
+	case DiffAdded:
+		return fmt.Sprintf("%s: added %q", d.Path, d.New)
+	default:
+		return fmt.Sprintf("%s: changed %q to %q", d.Path, d.Old, d.New)
+	}
+}
+
+// SegmentChange lists media segments of a Representation added or removed between two MPDs.
+type SegmentChange struct {
+	// Path locates Representation in new MPD, e.g. "MPD/Period[0]/AdaptationSet[1]/Representation[0]".
+	Path    string
+	Added   []Segment
+	Removed []Segment
+}
+
+// ManifestDiff is a result of Diff.
+type ManifestDiff struct {
+	// Changes lists differences of elements and attributes except SegmentTimelines.
+	Changes []Difference
+	// Segments lists changes of media segments of Representations present in both MPDs.
+	Segments []SegmentChange
+}
+
+// Empty returns true if there are no differences.
+func (d *ManifestDiff) Empty() bool {
+	return len(d.Changes) == 0 && len(d.Segments) == 0
+}
+
+// Diff compares MPDs a and b semantically, ignoring the same details as RoundTrip.
+// Media segments of Representations are compared by URL instead of SegmentTimelines, so an update
+// of live MPD is reported as added and removed segments. Periods, AdaptationSets and Representations
+// are matched by their ids, or by positions if ids are absent, in both Changes and Segments; segments
+// of ones which can't be enumerated with Segments are not compared.
+func Diff(a, b *MPD) (*ManifestDiff, error) {
+	ea, err := a.Encode()
+	if err != nil {
+		return nil, fmt.Errorf("Diff: %w", err)
+	}
+	eb, err := b.Encode()
+	if err != nil {
+		return nil, fmt.Errorf("Diff: %w", err)
+	}
+	na, err := parseXMLNode(ea)
+	if err != nil {
+		return nil, fmt.Errorf("Diff: %w", err)
+	}
+	nb, err := parseXMLNode(eb)
+	if err != nil {
+		return nil, fmt.Errorf("Diff: %w", err)
+	}
+
+	res := new(ManifestDiff)
+	for _, d := range diffXMLNodes(qualifiedName(na.name), na, nb, nil) {
+		if !strings.Contains(d.Path, "/SegmentTimeline[") {
+			res.Changes = append(res.Changes, d)
+		}
+	}
+
+	for i, pb := range b.Periods {
+		pa := matchPeriod(a, i, pb)
+		if pa == nil {
+			continue
+		}
+		for j, asb := range pb.AdaptationSets {
+			asa := matchAdaptationSet(pa, j, asb)
+			if asa == nil {
+				continue
+			}
+			for k := range asb.Representations {
+				rb := &asb.Representations[k]
+				ra := matchRepresentation(asa, k, rb)
+				if ra == nil {
+					continue
+				}
+				sa, err := Segments("", a, pa, asa, ra)
+				if err != nil {
+					continue
+				}
+				sb, err := Segments("", b, pb, asb, rb)
+				if err != nil {
+					continue
+				}
+				c := SegmentChange{
+					Path:    fmt.Sprintf("MPD/Period[%d]/AdaptationSet[%d]/Representation[%d]", i, j, k),
+					Added:   subtractSegments(sb, sa),
+					Removed: subtractSegments(sa, sb),
+				}
+				if len(c.Added) > 0 || len(c.Removed) > 0 {
+					res.Segments = append(res.Segments, c)
+				}
+			}
+		}
+	}
+	return res, nil
+}
+
+// matchPeriod returns Period of m with p's id, or Period i of m if p has no id.
+func matchPeriod(m *MPD, i int, p *Period) *Period {
+	for k, v := range m.Periods {
+		if p.ID != nil && v.ID != nil && *v.ID == *p.ID || p.ID == nil && k == i {
+			return v
+		}
+	}
+	return nil
+}
+
+// matchAdaptationSet returns AdaptationSet of p with as's id, or AdaptationSet j of p if as has no id.
+func matchAdaptationSet(p *Period, j int, as *AdaptationSet) *AdaptationSet {
+	for k, v := range p.AdaptationSets {
+		if as.ID != nil && v.ID != nil && *v.ID == *as.ID || as.ID == nil && k == j {
+			return v
+		}
+	}
+	return nil
+}
+
+// matchRepresentation returns Representation of as with r's id, or Representation k of as if r has no id.
+func matchRepresentation(as *AdaptationSet, k int, r *Representation) *Representation {
+	for n := range as.Representations {
+		v := &as.Representations[n]
+		if r.ID != nil && v.ID != nil && *v.ID == *r.ID || r.ID == nil && n == k {
+			return v
+		}
+	}
+	return nil
+}
+
+// subtractSegments returns media segments of a which are not in b.
+func subtractSegments(a, b []Segment) []Segment {
+	type key struct{ url, byteRange string }
+	set := make(map[key]bool, len(b))
+	for _, s := range b {
+		set[key{s.URL, s.ByteRange}] = true
+	}
+	var res []Segment
+	for _, s := range a {
+		if s.Kind == MediaSegment && !set[key{s.URL, s.ByteRange}] {
+			res = append(res, s)
+		}
+	}
+	return res
+}
+
+// xmlNode is a generic XML element.
+type xmlNode struct {
+	name     xml.Name
+	attrs    map[xml.Name]string
+	children []*xmlNode
+	text     string
+}
+
+// parseXMLNode returns root element of XML document b.
+func parseXMLNode(b []byte) (*xmlNode, error) {
+	d := xml.NewDecoder(bytes.NewReader(b))
+	var stack []*xmlNode
+	var text []string
+	for {
+		t, err := d.Token()
+		if err == io.EOF {
+			return nil, fmt.Errorf("no root element")
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := t.(type) {
+		case xml.StartElement:
+			n := &xmlNode{name: t.Name, attrs: make(map[xml.Name]string)}
+			for _, a := range t.Attr {
+				if a.Name.Space != "xmlns" && !(a.Name.Space == "" && a.Name.Local == "xmlns") {
+					n.attrs[a.Name] = a.Value
+				}
+			}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, n)
+			}
+			stack = append(stack, n)
+			text = append(text, "")
+		case xml.CharData:
+			if len(text) > 0 {
+				text[len(text)-1] += string(t)
+			}
+		case xml.EndElement:
+			n := stack[len(stack)-1]
+			n.text = strings.TrimSpace(text[len(text)-1])
+			stack, text = stack[:len(stack)-1], text[:len(text)-1]
+			if len(stack) == 0 {
+				return n, nil
+			}
+		}
+	}
+}
+
+// qualifiedName returns name with prefix Encode uses for its namespace.
+func qualifiedName(name xml.Name) string {
+	if prefix, ok := canonicalPrefixes[name.Space]; ok {
+		if prefix == "" {
+			return name.Local
+		}
+		return prefix + ":" + name.Local
+	}
+	if name.Space == "" {
+		return name.Local
+	}
+	return name.Space + ":" + name.Local
+}
+
+// diffXMLNodes appends to res differences of elements a and b at path.
+func diffXMLNodes(path string, a, b *xmlNode, res []Difference) []Difference {
+	if a.text != b.text {
+		res = append(res, Difference{Kind: kindOf(a.text, b.text), Path: path, Old: a.text, New: b.text})
+	}
+
+	var names []xml.Name
+	for name := range a.attrs {
+		names = append(names, name)
+	}
+	for name := range b.attrs {
+		if _, ok := a.attrs[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool { return qualifiedName(names[i]) < qualifiedName(names[j]) })
+	for _, name := range names {
+		va, oka := a.attrs[name]
+		vb, okb := b.attrs[name]
+		attrPath := path + "/@" + qualifiedName(name)
+		switch {
+		case !okb:
+			res = append(res, Difference{Kind: DiffRemoved, Path: attrPath, Old: va})
+		case !oka:
+			res = append(res, Difference{Kind: DiffAdded, Path: attrPath, New: vb})
+		case va != vb:
+			res = append(res, Difference{Kind: DiffChanged, Path: attrPath, Old: va, New: vb})
+		}
+	}
+
+	// children are compared in order among siblings with the same name, or by ids, see matchXMLNodes
+	var order []xml.Name
+	ga, gb := make(map[xml.Name][]*xmlNode), make(map[xml.Name][]*xmlNode)
+	group := func(groups map[xml.Name][]*xmlNode, list []*xmlNode) {
+		for _, n := range list {
+			if ga[n.name] == nil && gb[n.name] == nil {
+				order = append(order, n.name)
+			}
+			groups[n.name] = append(groups[n.name], n)
+		}
+	}
+	group(ga, a.children)
+	group(gb, b.children)
+	for _, name := range order {
+		la, lb := ga[name], gb[name]
+		match := matchXMLNodes(name, la, lb)
+		matched := make(map[int]bool, len(match))
+		for _, i := range match {
+			matched[i] = true
+		}
+		for i := 0; i < len(la) || i < len(lb); i++ {
+			childPath := fmt.Sprintf("%s/%s[%d]", path, qualifiedName(name), i)
+			if i < len(la) && !matched[i] {
+				res = append(res, Difference{Kind: DiffRemoved, Path: childPath, Old: la[i].text})
+			}
+			if i >= len(lb) {
+				continue
+			}
+			if k, ok := match[i]; ok {
+				res = diffXMLNodes(childPath, la[k], lb[i], res)
+			} else {
+				res = append(res, Difference{Kind: DiffAdded, Path: childPath, New: lb[i].text})
+			}
+		}
+	}
+	return res
+}
+
+// idMatchedElements lists elements of MPD namespace which are matched by @id.
+var idMatchedElements = map[string]bool{"Period": true, "AdaptationSet": true, "Representation": true}
+
+// matchXMLNodes maps indexes of siblings lb to indexes of their counterparts in la. Periods, AdaptationSets
+// and Representations with @id match ones with the same id, others match ones without id at the same position.
+func matchXMLNodes(name xml.Name, la, lb []*xmlNode) map[int]int {
+	res := make(map[int]int)
+	if name.Space != MPDNamespace || !idMatchedElements[name.Local] {
+		for i := 0; i < len(la) && i < len(lb); i++ {
+			res[i] = i
+		}
+		return res
+	}
+
+	id := xml.Name{Local: "id"}
+	used := make(map[int]bool)
+	for i, b := range lb {
+		idb, ok := b.attrs[id]
+		for k, a := range la {
+			ida, oka := a.attrs[id]
+			if !used[k] && (ok && oka && ida == idb || !ok && !oka && k == i) {
+				res[i] = k
+				used[k] = true
+				break
+			}
+		}
+	}
+	return res
+}
+
+// kindOf returns kind of difference between values a and b.
+func kindOf(a, b string) DifferenceKind {
+	switch {
+	case b == "":
+		return DiffRemoved
+	case a == "":
+		return DiffAdded
+	default:
+		return DiffChanged
+	}
+}
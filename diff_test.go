@@ -0,0 +1,74 @@
+package mpd
+
+import (
+	"fmt"
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+const diffLiveMPD = `<MPD xmlns="urn:mpeg:dash:schema:mpd:2011" type="dynamic" profiles="urn:mpeg:dash:profile:isoff-live:2011" availabilityStartTime="2020-01-01T00:00:00Z" publishTime="%s">
+  <Period id="p0" start="PT0S">
+    <AdaptationSet id="1" mimeType="video/mp4">
+      <SegmentTemplate timescale="1" media="$Time$.m4s">
+        <SegmentTimeline>
+          <S t="%d" d="2" r="%d"/>
+        </SegmentTimeline>
+      </SegmentTemplate>
+      <Representation id="v" bandwidth="1000"/>
+    </AdaptationSet>
+  </Period>
+</MPD>`
+
+func decodeDiffMPD(c *C, s string) *MPD {
+	m := new(MPD)
+	c.Assert(m.Decode([]byte(s)), IsNil)
+	return m
+}
+
+func (s *MPDSuite) TestDiff(c *C) {
+	a := decodeDiffMPD(c, fmt.Sprintf(diffLiveMPD, "2020-01-01T00:00:06Z", 0, 2))
+	b := decodeDiffMPD(c, fmt.Sprintf(diffLiveMPD, "2020-01-01T00:00:10Z", 4, 2))
+
+	d, err := Diff(a, a)
+	c.Assert(err, IsNil)
+	c.Check(d.Empty(), Equals, true)
+
+	d, err = Diff(a, b)
+	c.Assert(err, IsNil)
+	c.Check(d.Changes, DeepEquals, []Difference{
+		{Kind: DiffChanged, Path: "MPD/@publishTime", Old: "2020-01-01T00:00:06Z", New: "2020-01-01T00:00:10Z"},
+	})
+	c.Assert(d.Segments, HasLen, 1)
+	c.Check(d.Segments[0].Path, Equals, "MPD/Period[0]/AdaptationSet[0]/Representation[0]")
+	var added, removed []string
+	for _, s := range d.Segments[0].Added {
+		added = append(added, s.URL)
+	}
+	for _, s := range d.Segments[0].Removed {
+		removed = append(removed, s.URL)
+	}
+	c.Check(added, DeepEquals, []string{"6.m4s", "8.m4s"})
+	c.Check(removed, DeepEquals, []string{"0.m4s", "2.m4s"})
+	c.Check(d.Empty(), Equals, false)
+}
+
+func (s *MPDSuite) TestDiffMatchesIDs(c *C) {
+	period := func(id, lang string) string {
+		return fmt.Sprintf(`<Period id="%s" duration="PT10S"><AdaptationSet id="1" lang="%s"/><AdaptationSet id="2"/></Period>`, id, lang)
+	}
+	decode := func(periods ...string) *MPD {
+		return decodeDiffMPD(c, `<MPD xmlns="urn:mpeg:dash:schema:mpd:2011" type="static">`+strings.Join(periods, "")+`</MPD>`)
+	}
+	a := decode(period("p0", "en"), period("p1", "en"))
+	b := decode(period("p1", "fr"), period("p2", "en"))
+	b.Periods[0].AdaptationSets[0], b.Periods[0].AdaptationSets[1] = b.Periods[0].AdaptationSets[1], b.Periods[0].AdaptationSets[0]
+
+	d, err := Diff(a, b)
+	c.Assert(err, IsNil)
+	c.Check(d.Changes, DeepEquals, []Difference{
+		{Kind: DiffRemoved, Path: "MPD/Period[0]"},
+		{Kind: DiffChanged, Path: "MPD/Period[0]/AdaptationSet[1]/@lang", Old: "en", New: "fr"},
+		{Kind: DiffAdded, Path: "MPD/Period[1]"},
+	})
+}
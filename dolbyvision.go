@@ -0,0 +1,193 @@
+package mpd
+
+import (
+	"fmt"
+
+	"github.com/jun-oku/mpd/codecs"
+)
+
+// SCTE214Namespace is a namespace of SCTE 214-1 extensions like @scte214:supplementalCodecs.
+const SCTE214Namespace = "urn:scte:dash:scte214-extensions"
+
+// DolbyVision describes Dolby Vision signaling of a Representation.
+type DolbyVision struct {
+	// Profile is one of 4, 5, 7, 8 or 9. Profiles 4 and 7 are dual-layer.
+	Profile int
+	Level   int
+	// Compatibility is a base layer signal compatibility ID of profile 8:
+	// 1 for HDR10 (8.1), 2 for SDR (8.2) or 4 for HLG (8.4). It's ignored for other profiles.
+	Compatibility int
+}
+
+// DualLayer returns true for dual-layer profiles, where enhancement layer is a separate Representation.
+func (dv DolbyVision) DualLayer() bool {
+	return dv.Profile == 4 || dv.Profile == 7
+}
+
+// SetDolbyVision sets up Dolby Vision signaling of r:
+// codecs for single-layer profile 5, scte214:supplementalCodecs and CICP descriptors of backward compatible
+// base layer for profiles 8 and 9, enhancement layer codecs and @dependencyId for dual-layer profiles.
+// For dual-layer profiles r is an enhancement layer and bl is a base layer, bl should be nil otherwise.
+// Resulting signaling is validated as a whole.
+func SetDolbyVision(r *Representation, dv DolbyVision, bl *Representation) error {
+	if dv.Level < 1 || dv.Level > 13 {
+		return fmt.Errorf("SetDolbyVision: invalid level %d", dv.Level)
+	}
+	if dv.DualLayer() != (bl != nil) {
+		return fmt.Errorf("SetDolbyVision: base layer Representation should be given for dual-layer profiles only")
+	}
+
+	set := func(dst **string, fourCC string) error {
+		c, err := codecs.NewDolbyVision(fourCC, byte(dv.Profile), byte(dv.Level))
+		if err != nil {
+			return err
+		}
+		s := c.String()
+		*dst = &s
+		return nil
+	}
+
+	var err error
+	switch dv.Profile {
+	case 4, 7:
+		if bl.ID == nil {
+			return fmt.Errorf("SetDolbyVision: base layer Representation without id")
+		}
+		if family(bl.Codecs) != "hevc" {
+			return fmt.Errorf("SetDolbyVision: base layer Representation should be HEVC")
+		}
+		if err = set(&r.Codecs, "dvhe"); err != nil {
+			return err
+		}
+		id := *bl.ID
+		r.DependencyID = &id
+		if dv.Profile == 7 {
+			bl.SetColourInfo(HDR10)
+		} else {
+			bl.SetColourInfo(SDR)
+		}
+
+	case 5:
+		if err = set(&r.Codecs, "dvh1"); err != nil {
+			return err
+		}
+		r.SupplementalCodecs = nil
+
+	case 8:
+		fourCC := "dvh1"
+		switch r.codecsFourCC() {
+		case "hvc1":
+		case "hev1":
+			fourCC = "dvhe"
+		default:
+			return fmt.Errorf("SetDolbyVision: profile 8 requires HEVC codecs")
+		}
+		switch dv.Compatibility {
+		case 1:
+			r.SetColourInfo(HDR10)
+		case 2:
+			r.SetColourInfo(SDR)
+		case 4:
+			r.SetColourInfo(HLG)
+		default:
+			return fmt.Errorf("SetDolbyVision: invalid profile 8 compatibility %d", dv.Compatibility)
+		}
+		if err = set(&r.SupplementalCodecs, fourCC); err != nil {
+			return err
+		}
+
+	case 9:
+		if family(r.Codecs) != "avc" {
+			return fmt.Errorf("SetDolbyVision: profile 9 requires AVC codecs")
+		}
+		r.SetColourInfo(SDR)
+		if err = set(&r.SupplementalCodecs, "dva1"); err != nil {
+			return err
+		}
+
+	default:
+		return fmt.Errorf("SetDolbyVision: unsupported profile %d", dv.Profile)
+	}
+
+	return ValidateDolbyVision(r, bl)
+}
+
+// ValidateDolbyVision checks that Dolby Vision signaling of r (and base layer bl for dual-layer profiles)
+// is consistent. It returns nil if r doesn't signal Dolby Vision at all.
+func ValidateDolbyVision(r *Representation, bl *Representation) error {
+	dv, err := dolbyVisionCodec(r)
+	if err != nil || dv == nil {
+		return err
+	}
+
+	profile := dv.DolbyVision.Profile
+	switch profile {
+	case 4, 7:
+		if r.DependencyID == nil {
+			return fmt.Errorf("ValidateDolbyVision: dual-layer enhancement layer without dependencyId")
+		}
+		if bl == nil || bl.ID == nil || *bl.ID != *r.DependencyID {
+			return fmt.Errorf("ValidateDolbyVision: dependencyId %q doesn't match base layer", *r.DependencyID)
+		}
+		if family(bl.Codecs) != "hevc" {
+			return fmt.Errorf("ValidateDolbyVision: base layer should be HEVC")
+		}
+	case 5:
+		if r.SupplementalCodecs != nil {
+			return fmt.Errorf("ValidateDolbyVision: profile 5 is not backward compatible, but has supplementalCodecs")
+		}
+	case 8, 9:
+		if r.SupplementalCodecs == nil {
+			return fmt.Errorf("ValidateDolbyVision: profile %d should be signaled in supplementalCodecs", profile)
+		}
+		if ci, err := r.ColourInfo(); err != nil || ci == nil {
+			return fmt.Errorf("ValidateDolbyVision: profile %d requires colour signaling of base layer", profile)
+		}
+	default:
+		return fmt.Errorf("ValidateDolbyVision: unsupported profile %d", profile)
+	}
+	return nil
+}
+
+// dolbyVisionCodec returns Dolby Vision codec from codecs or supplementalCodecs of r, or nil.
+func dolbyVisionCodec(r *Representation) (*codecs.Codec, error) {
+	for _, s := range []*string{r.Codecs, r.SupplementalCodecs} {
+		if s == nil {
+			continue
+		}
+		list, err := codecs.ParseList(*s)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range list {
+			if c.DolbyVision != nil {
+				return c, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// family returns codec family of the first codec in the list, or empty string.
+func family(s *string) string {
+	if s == nil {
+		return ""
+	}
+	list, err := codecs.ParseList(*s)
+	if err != nil {
+		return ""
+	}
+	return list[0].Family
+}
+
+// codecsFourCC returns sample entry type of the first codec of r, or empty string.
+func (r *Representation) codecsFourCC() string {
+	if r.Codecs == nil {
+		return ""
+	}
+	list, err := codecs.ParseList(*r.Codecs)
+	if err != nil {
+		return ""
+	}
+	return list[0].FourCC
+}
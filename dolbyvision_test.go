@@ -0,0 +1,47 @@
+package mpd
+
+import (
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestDolbyVisionProfile8(c *C) {
+	r := Representation{ID: stringPtr("1"), Codecs: stringPtr("hvc1.2.4.L153.B0")}
+	c.Assert(SetDolbyVision(&r, DolbyVision{Profile: 8, Level: 6, Compatibility: 1}, nil), IsNil)
+	c.Check(*r.Codecs, Equals, "hvc1.2.4.L153.B0")
+	c.Check(*r.SupplementalCodecs, Equals, "dvh1.08.06")
+	ci, err := r.ColourInfo()
+	c.Assert(err, IsNil)
+	c.Check(*ci, Equals, HDR10)
+
+	m := &MPD{Periods: []*Period{{AdaptationSets: []*AdaptationSet{{MimeType: MimeTypeVideoMP4, Representations: []Representation{r}}}}}}
+	b, err := m.Encode()
+	c.Assert(err, IsNil)
+	c.Check(strings.Contains(string(b), `<MPD xmlns:scte214="urn:scte:dash:scte214-extensions"`), Equals, true, Commentf("%s", b))
+	c.Check(strings.Contains(string(b), ` scte214:supplementalCodecs="dvh1.08.06"`), Equals, true, Commentf("%s", b))
+
+	decoded := new(MPD)
+	c.Assert(decoded.Decode(b), IsNil)
+	c.Check(*decoded.Periods[0].AdaptationSets[0].Representations[0].SupplementalCodecs, Equals, "dvh1.08.06")
+
+	r = Representation{ID: stringPtr("1"), Codecs: stringPtr("avc1.640028")}
+	c.Check(SetDolbyVision(&r, DolbyVision{Profile: 8, Level: 6, Compatibility: 1}, nil), ErrorMatches, "SetDolbyVision: profile 8 requires HEVC codecs")
+}
+
+func (s *MPDSuite) TestDolbyVisionDualLayer(c *C) {
+	bl := Representation{ID: stringPtr("bl"), Codecs: stringPtr("hvc1.2.4.L153.B0")}
+	el := Representation{ID: stringPtr("el")}
+	dv := DolbyVision{Profile: 7, Level: 6}
+
+	c.Check(SetDolbyVision(&el, dv, nil), ErrorMatches, "SetDolbyVision: base layer Representation should be given for dual-layer profiles only")
+	c.Assert(SetDolbyVision(&el, dv, &bl), IsNil)
+	c.Check(*el.Codecs, Equals, "dvhe.07.06")
+	c.Check(*el.DependencyID, Equals, "bl")
+	ci, err := bl.ColourInfo()
+	c.Assert(err, IsNil)
+	c.Check(*ci, Equals, HDR10)
+
+	c.Check(ValidateDolbyVision(&el, &Representation{ID: stringPtr("other")}), ErrorMatches, `ValidateDolbyVision: dependencyId "bl" doesn't match base layer`)
+	c.Check(ValidateDolbyVision(&bl, nil), IsNil)
+}
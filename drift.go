@@ -0,0 +1,112 @@
+package mpd
+
+import (
+	"fmt"
+	"time"
+)
+
+// DriftReport compares timing of a dynamic MPD with wall-clock time to detect encoder clock drift and stalls.
+type DriftReport struct {
+	// Now is the time of m's Clock the MPD was checked at.
+	Now time.Time
+	// PublishTime is MPD@publishTime, or zero.
+	PublishTime time.Time
+	// NewestSegment is availability start time of the newest segment announced by SegmentTimelines,
+	// or zero if there are none.
+	NewestSegment time.Time
+
+	// PublishAge is Now minus PublishTime, or zero if PublishTime is.
+	PublishAge time.Duration
+	// EdgeAge is Now minus NewestSegment, or zero if NewestSegment is. Negative value means that
+	// the encoder produces segments ahead of the clock.
+	EdgeAge time.Duration
+
+	// Problems lists detected drift and stalls.
+	Problems ValidationErrors
+}
+
+// AnalyzeDrift checks publishTime and availability time of the newest segment of dynamic MPD m against
+// m's Clock, which may be synchronized with UTCTiming (see SetClock). Differences up to tolerance are ignored.
+// The encoder is considered stalled if the newest segment is older than the longest segment duration
+// and minimumUpdatePeriod together.
+func AnalyzeDrift(m *MPD, tolerance time.Duration) (*DriftReport, error) {
+	if m.Type == nil || *m.Type != "dynamic" {
+		return nil, fmt.Errorf("AnalyzeDrift: MPD is not dynamic")
+	}
+	res := &DriftReport{Now: m.Clock().Now()}
+
+	var err error
+	if m.PublishTime != nil {
+		if res.PublishTime, err = ParseDateTime(*m.PublishTime); err != nil {
+			return nil, fmt.Errorf("AnalyzeDrift: %w", err)
+		}
+		res.PublishAge = res.Now.Sub(res.PublishTime)
+	}
+	var updatePeriod time.Duration
+	if m.MinimumUpdatePeriod != nil {
+		if updatePeriod, err = ParseDuration(*m.MinimumUpdatePeriod); err != nil {
+			return nil, fmt.Errorf("AnalyzeDrift: %w", err)
+		}
+	}
+	ast, err := m.AvailabilityStart()
+	if err != nil {
+		return nil, fmt.Errorf("AnalyzeDrift: %w", err)
+	}
+
+	var segmentDuration time.Duration
+	for _, p := range m.Periods {
+		var start time.Duration
+		if p.Start != nil {
+			if start, err = ParseDuration(*p.Start); err != nil {
+				return nil, fmt.Errorf("AnalyzeDrift: %w", err)
+			}
+		}
+		for _, as := range p.AdaptationSets {
+			templates := []*SegmentTemplate{p.SegmentTemplate, as.SegmentTemplate}
+			for _, r := range as.Representations {
+				templates = append(templates, r.SegmentTemplate)
+			}
+			for _, t := range templates {
+				if t == nil {
+					continue
+				}
+				if d := maxSegmentDuration(t); d > segmentDuration {
+					segmentDuration = d
+				}
+				end, ok := timelineEnd(t)
+				if !ok || ast.IsZero() {
+					continue
+				}
+				available := ast.Add(start + end)
+				if t.AvailabilityTimeOffset != nil {
+					available = available.Add(-time.Duration(*t.AvailabilityTimeOffset * float64(time.Second)))
+				}
+				if available.After(res.NewestSegment) {
+					res.NewestSegment = available
+				}
+			}
+		}
+	}
+	if !res.NewestSegment.IsZero() {
+		res.EdgeAge = res.Now.Sub(res.NewestSegment)
+	}
+
+	problem := func(format string, args ...interface{}) {
+		res.Problems = append(res.Problems, newValidationError("", format, args...))
+	}
+	if !res.PublishTime.IsZero() && -res.PublishAge > tolerance {
+		problem("publishTime is %s ahead of clock", FormatDuration(-res.PublishAge))
+	}
+	if !res.NewestSegment.IsZero() {
+		switch {
+		case -res.EdgeAge > tolerance:
+			problem("newest segment becomes available %s ahead of clock", FormatDuration(-res.EdgeAge))
+		case res.EdgeAge > segmentDuration+updatePeriod+tolerance:
+			problem("newest segment became available %s ago, encoder may have stalled", FormatDuration(res.EdgeAge))
+		}
+		if d := res.NewestSegment.Sub(res.PublishTime); !res.PublishTime.IsZero() && d > tolerance {
+			problem("newest segment becomes available %s after publishTime", FormatDuration(d))
+		}
+	}
+	return res, nil
+}
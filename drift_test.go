@@ -0,0 +1,55 @@
+package mpd
+
+import (
+	"strings"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestAnalyzeDrift(c *C) {
+	m := &MPD{
+		Type:                  stringPtr("dynamic"),
+		AvailabilityStartTime: stringPtr("2016-01-01T00:00:00Z"),
+		PublishTime:           stringPtr("2016-01-01T00:00:10Z"),
+		MinimumUpdatePeriod:   stringPtr("PT2S"),
+		Periods: []*Period{{Start: stringPtr("PT0S"), AdaptationSets: []*AdaptationSet{{
+			SegmentTemplate: &SegmentTemplate{Timescale: uint64Ptr(1000), SegmentTimeline: []SegmentTimeline{{
+				Segments: []SegmentTimelineSegment{{T: uint64Ptr(0), D: 2000, R: int64Ptr(4)}},
+			}}},
+		}}}},
+	}
+	at := func(sec int) {
+		m.SetClock(fixedClock(time.Date(2016, 1, 1, 0, 0, sec, 0, time.UTC)))
+	}
+
+	at(11)
+	r, err := AnalyzeDrift(m, 500*time.Millisecond)
+	c.Assert(err, IsNil)
+	c.Check(r.NewestSegment, Equals, time.Date(2016, 1, 1, 0, 0, 10, 0, time.UTC))
+	c.Check(r.PublishAge, Equals, time.Second)
+	c.Check(r.EdgeAge, Equals, time.Second)
+	c.Check(r.Problems, HasLen, 0)
+
+	at(20)
+	r, err = AnalyzeDrift(m, 500*time.Millisecond)
+	c.Assert(err, IsNil)
+	c.Check(r.Problems.Error(), Equals, "newest segment became available PT10S ago, encoder may have stalled")
+
+	at(8)
+	r, err = AnalyzeDrift(m, 500*time.Millisecond)
+	c.Assert(err, IsNil)
+	c.Check(r.Problems.Error(), Equals, strings.Join([]string{
+		"publishTime is PT2S ahead of clock",
+		"newest segment becomes available PT2S ahead of clock",
+	}, "\n"))
+
+	at(11)
+	m.PublishTime = stringPtr("2016-01-01T00:00:05Z")
+	r, err = AnalyzeDrift(m, 500*time.Millisecond)
+	c.Assert(err, IsNil)
+	c.Check(r.Problems.Error(), Equals, "newest segment becomes available PT5S after publishTime")
+
+	_, err = AnalyzeDrift(&MPD{}, 0)
+	c.Check(err, ErrorMatches, "AnalyzeDrift: MPD is not dynamic")
+}
@@ -0,0 +1,94 @@
+package mpd
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+// maxDRMPayloadSize is an upper bound of plausible cenc:pssh and mspr:pro payload size.
+const maxDRMPayloadSize = 64 * 1024
+
+// decodeBase64Payload decodes base64 element content, ignoring whitespace.
+func decodeBase64Payload(s string) ([]byte, error) {
+	return base64.StdEncoding.DecodeString(strings.Join(strings.Fields(s), ""))
+}
+
+// isUUID reports whether s is UUID in 8-4-4-4-12 hex digits form.
+func isUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i, r := range s {
+		switch i {
+		case 8, 13, 18, 23:
+			if r != '-' {
+				return false
+			}
+		default:
+			if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// checkDRM returns problems of ContentProtection's schemeIdUri, cenc:pssh and mspr:pro.
+func (cp *ContentProtection) checkDRM() []string {
+	var res []string
+	if cp.SchemeIDURI != nil && strings.HasPrefix(strings.ToLower(*cp.SchemeIDURI), "urn:uuid:") {
+		if !isUUID((*cp.SchemeIDURI)[len("urn:uuid:"):]) {
+			res = append(res, fmt.Sprintf("invalid UUID in schemeIdUri %q", *cp.SchemeIDURI))
+		}
+	}
+
+	if cp.Pssh != nil && cp.Pssh.Value != nil {
+		b, err := decodeBase64Payload(*cp.Pssh.Value)
+		switch {
+		case err != nil:
+			res = append(res, fmt.Sprintf("cenc:pssh is not valid base64: %s", err))
+		case len(b) < 32 || len(b) > maxDRMPayloadSize || string(b[4:8]) != "pssh":
+			res = append(res, fmt.Sprintf("cenc:pssh is not a pssh box (%d bytes)", len(b)))
+		case int(binary.BigEndian.Uint32(b)) != len(b):
+			res = append(res, fmt.Sprintf("cenc:pssh box size %d doesn't match payload size %d", binary.BigEndian.Uint32(b), len(b)))
+		}
+	}
+
+	if cp.Pro != nil && cp.Pro.Value != nil {
+		// PlayReady Object starts with little-endian length and record count, followed by records
+		b, err := decodeBase64Payload(*cp.Pro.Value)
+		switch {
+		case err != nil:
+			res = append(res, fmt.Sprintf("mspr:pro is not valid base64: %s", err))
+		case len(b) < 10 || len(b) > maxDRMPayloadSize:
+			res = append(res, fmt.Sprintf("mspr:pro has implausible size %d", len(b)))
+		case int(binary.LittleEndian.Uint32(b)) != len(b):
+			res = append(res, fmt.Sprintf("mspr:pro length %d doesn't match payload size %d", binary.LittleEndian.Uint32(b), len(b)))
+		}
+	}
+	return res
+}
+
+// validateDRM checks that ContentProtection schemeIdUri UUIDs are well-formed and that
+// cenc:pssh and mspr:pro values are valid base64 of plausible size.
+func validateDRM(m *MPD) ValidationErrors {
+	var res ValidationErrors
+	check := func(path string, list []ContentProtection) {
+		for n := range list {
+			for _, msg := range list[n].checkDRM() {
+				res = append(res, newValidationError(fmt.Sprintf("%s.ContentProtections[%d]", path, n), "%s", msg))
+			}
+		}
+	}
+	for i, p := range m.Periods {
+		for j, as := range p.AdaptationSets {
+			check(adaptationSetPath(i, j), as.ContentProtections)
+			for k := range as.Representations {
+				check(representationPath(i, j, k), as.Representations[k].ContentProtections)
+			}
+		}
+	}
+	return res
+}
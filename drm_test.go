@@ -0,0 +1,68 @@
+package mpd
+
+import (
+	"encoding/base64"
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestValidateDRM(c *C) {
+	pro := func(length byte, size int) *Pro {
+		b := make([]byte, size)
+		b[0] = length
+		v := base64.StdEncoding.EncodeToString(b)
+		return &Pro{Value: &v}
+	}
+
+	m := &MPD{Periods: []*Period{{AdaptationSets: []*AdaptationSet{{
+		RepresentationBase: RepresentationBase{ContentProtections: []ContentProtection{
+			{SchemeIDURI: stringPtr("urn:mpeg:dash:mp4protection:2011"), Value: stringPtr("cenc")},
+			{SchemeIDURI: stringPtr("urn:uuid:EDEF8BA9-79D6-4ACE-A3C8-27DCD51D21ED"), Pssh: pssh("10000000-1000-1000-1000-100000000000")},
+			{SchemeIDURI: stringPtr("urn:uuid:9a04f079-9840-4286-ab92-e65be0885f95"), Pro: pro(12, 12)},
+		}},
+		Representations: []Representation{{}},
+	}}}}}
+	c.Check(m.Validate(), IsNil)
+
+	as := m.Periods[0].AdaptationSets[0]
+	as.ContentProtections[1].SchemeIDURI = stringPtr("urn:uuid:edef8ba979d64acea3c827dcd51d21ed")
+	as.ContentProtections[2].Pro = pro(20, 12)
+	as.Representations[0].ContentProtections = []ContentProtection{
+		{Pssh: &Pssh{Value: stringPtr("AAAA!")}},
+		{Pssh: &Pssh{Value: stringPtr("AAAAIHBzc2g=")}},
+		{Pro: pro(4, 4)},
+	}
+	err := m.Validate()
+	c.Assert(err, NotNil)
+	c.Check(err.Error(), Equals, strings.Join([]string{
+		`Periods[0].AdaptationSets[0].ContentProtections[1]: invalid UUID in schemeIdUri "urn:uuid:edef8ba979d64acea3c827dcd51d21ed"`,
+		`Periods[0].AdaptationSets[0].ContentProtections[2]: mspr:pro length 20 doesn't match payload size 12`,
+		`Periods[0].AdaptationSets[0].Representations[0].ContentProtections[0]: cenc:pssh is not valid base64: illegal base64 data at input byte 4`,
+		`Periods[0].AdaptationSets[0].Representations[0].ContentProtections[1]: cenc:pssh is not a pssh box (8 bytes)`,
+		`Periods[0].AdaptationSets[0].Representations[0].ContentProtections[2]: mspr:pro has implausible size 4`,
+	}, "\n"))
+}
+
+func (s *MPDSuite) TestDecodeValidateDRM(c *C) {
+	b := []byte(`<?xml version="1.0" encoding="utf-8"?>
+<MPD xmlns="urn:mpeg:dash:schema:mpd:2011" xmlns:cenc="urn:mpeg:cenc:2013">
+  <Period>
+    <AdaptationSet>
+      <ContentProtection schemeIdUri="urn:uuid:edef8ba9-79d6-4ace-a3c8-27dcd51d21ed">
+        <cenc:pssh>bm90IGEgcHNzaCBib3g=</cenc:pssh>
+      </ContentProtection>
+    </AdaptationSet>
+  </Period>
+</MPD>
+`)
+
+	m := new(MPD)
+	c.Check(m.Decode(b), IsNil)
+
+	m = new(MPD)
+	err := m.DecodeWithOptions(b, DecodeOptions{ValidateDRM: true})
+	c.Assert(err, FitsTypeOf, ValidationErrors{})
+	c.Check(err.Error(), Equals, "Periods[0].AdaptationSets[0].ContentProtections[0]: cenc:pssh is not a pssh box (14 bytes)")
+	c.Check(m.Periods, HasLen, 1)
+}
@@ -0,0 +1,73 @@
+package mpd
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// durationRE matches xs:duration values without years and months, which have no fixed length.
+var durationRE = regexp.MustCompile(`^(-)?P(?:([0-9]+)D)?(?:T(?:([0-9]+)H)?(?:([0-9]+)M)?(?:([0-9]+(?:\.[0-9]*)?)S)?)?$`)
+
+// now is used instead of time.Now to make tests deterministic.
+var now = time.Now
+
+// ParseDuration parses xs:duration value like "PT1H2M3.5S" or "P1DT2H".
+// Years and months are not supported.
+func ParseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	m := durationRE.FindStringSubmatch(s)
+	if m == nil || s == "P" || strings.HasSuffix(s, "T") {
+		return 0, newError("ParseDuration", ErrInvalidDuration, "can't parse %q", s)
+	}
+
+	var res float64
+	for i, unit := range []float64{24 * 3600, 3600, 60, 1} {
+		if m[i+2] == "" {
+			continue
+		}
+		f, err := strconv.ParseFloat(m[i+2], 64)
+		if err != nil {
+			return 0, newError("ParseDuration", ErrInvalidDuration, "can't parse %q: %s", s, err)
+		}
+		res += f * unit
+	}
+	if res*float64(time.Second) > math.MaxInt64 {
+		return 0, newError("ParseDuration", ErrInvalidDuration, "%q is too large", s)
+	}
+
+	d := time.Duration(res*float64(time.Second) + 0.5)
+	if m[1] != "" {
+		d = -d
+	}
+	return d, nil
+}
+
+// FormatDuration formats d as xs:duration value like "PT1H2M3.5S" with millisecond precision.
+func FormatDuration(d time.Duration) string {
+	var sign string
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+	d = (d + time.Millisecond/2) / time.Millisecond * time.Millisecond
+
+	h := d / time.Hour
+	d -= h * time.Hour
+	m := d / time.Minute
+	d -= m * time.Minute
+
+	res := sign + "PT"
+	if h > 0 {
+		res += strconv.FormatInt(int64(h), 10) + "H"
+	}
+	if m > 0 {
+		res += strconv.FormatInt(int64(m), 10) + "M"
+	}
+	if d > 0 || (h == 0 && m == 0) {
+		res += strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "S"
+	}
+	return res
+}
@@ -0,0 +1,47 @@
+package mpd
+
+import (
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestParseDuration(c *C) {
+	for in, expected := range map[string]time.Duration{
+		"PT0.00S":      0,
+		"PT10S":        10 * time.Second,
+		"PT25.00S":     25 * time.Second,
+		"PT1H2M3.5S":   time.Hour + 2*time.Minute + 3500*time.Millisecond,
+		"P1DT2H":       26 * time.Hour,
+		"P2D":          48 * time.Hour,
+		"-PT1.001S":    -1001 * time.Millisecond,
+		"PT0.0333333S": 33333300 * time.Nanosecond,
+	} {
+		d, err := ParseDuration(in)
+		c.Check(err, IsNil, Commentf("%s", in))
+		c.Check(d, Equals, expected, Commentf("%s", in))
+	}
+
+	for _, in := range []string{"", "P", "PT", "10S", "P1Y", "P1M", "PT1.5.5S", "PT1S2M"} {
+		_, err := ParseDuration(in)
+		c.Check(err, NotNil, Commentf("%s", in))
+	}
+}
+
+func (s *MPDSuite) TestFormatDuration(c *C) {
+	for d, expected := range map[time.Duration]string{
+		0:                "PT0S",
+		10 * time.Second: "PT10S",
+		time.Hour + 2*time.Minute + 3500*time.Millisecond: "PT1H2M3.5S",
+		26 * time.Hour:             "PT26H",
+		-1001 * time.Millisecond:   "-PT1.001S",
+		33333333 * time.Nanosecond: "PT0.033S",
+	} {
+		c.Check(FormatDuration(d), Equals, expected)
+		if d != 33333333*time.Nanosecond {
+			parsed, err := ParseDuration(expected)
+			c.Check(err, IsNil)
+			c.Check(parsed, Equals, d)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package mpd
+
+import (
+	"fmt"
+)
+
+// DVBNamespace is a namespace of DVB-DASH (ETSI TS 103 285) extension attributes.
+const DVBNamespace = "urn:dvb:dash-extensions:2014-1"
+
+// DVBFontDownloadScheme is a schemeIdUri of descriptors signaling fonts for subtitles.
+const DVBFontDownloadScheme = "urn:dvb:dash:fontdownload:2014"
+
+// Font mimeTypes allowed by DVB font download.
+const (
+	MimeTypeFontSFNT = "application/font-sfnt"
+	MimeTypeFontWOFF = "application/font-woff"
+)
+
+// DVBFont is a downloadable font of a subtitle AdaptationSet.
+type DVBFont struct {
+	URL string
+	// Family is a font family name referenced by tts:fontFamily of subtitles.
+	Family string
+	// MimeType is MimeTypeFontSFNT or MimeTypeFontWOFF.
+	MimeType string
+	// Essential signals the font with EssentialProperty, so players which can't download it
+	// ignore the AdaptationSet. Otherwise SupplementalProperty is used.
+	Essential bool
+}
+
+// NewDVBFontDescriptor returns font download descriptor of f.
+func NewDVBFontDescriptor(f DVBFont) (Descriptor, error) {
+	if f.URL == "" || f.Family == "" {
+		return Descriptor{}, fmt.Errorf("NewDVBFontDescriptor: url and font family are required")
+	}
+	if f.MimeType != MimeTypeFontSFNT && f.MimeType != MimeTypeFontWOFF {
+		return Descriptor{}, fmt.Errorf("NewDVBFontDescriptor: unsupported mimeType %q", f.MimeType)
+	}
+	d := NewDescriptor(DVBFontDownloadScheme, "1")
+	d.FontURL, d.FontFamily, d.FontMimeType = &f.URL, &f.Family, &f.MimeType
+	return d, nil
+}
+
+// AddDVBFont adds font download descriptor of f to as.
+func (as *AdaptationSet) AddDVBFont(f DVBFont) error {
+	d, err := NewDVBFontDescriptor(f)
+	if err != nil {
+		return err
+	}
+	if f.Essential {
+		as.EssentialProperties = append(as.EssentialProperties, d)
+	} else {
+		as.SupplementalProperties = append(as.SupplementalProperties, d)
+	}
+	return nil
+}
+
+// DVBFonts returns fonts signaled by font download descriptors of as.
+func (as *AdaptationSet) DVBFonts() ([]DVBFont, error) {
+	var res []DVBFont
+	for _, list := range []struct {
+		descriptors []Descriptor
+		essential   bool
+	}{
+		{as.EssentialProperties, true},
+		{as.SupplementalProperties, false},
+	} {
+		for _, d := range list.descriptors {
+			if !d.Is(DVBFontDownloadScheme) {
+				continue
+			}
+			if d.FontURL == nil || d.FontFamily == nil || d.FontMimeType == nil {
+				return nil, fmt.Errorf("DVBFonts: font download descriptor requires dvb:url, dvb:fontFamily and dvb:mimeType")
+			}
+			res = append(res, DVBFont{URL: *d.FontURL, Family: *d.FontFamily, MimeType: *d.FontMimeType, Essential: list.essential})
+		}
+	}
+	return res, nil
+}
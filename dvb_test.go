@@ -0,0 +1,68 @@
+package mpd
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestDVBSubtitles(c *C) {
+	media := "sub_$Number$.m4s"
+	as, err := NewSubtitleAdaptationSet(SubtitleOptions{
+		Format:          EBUTTD,
+		ID:              "sub_de",
+		Lang:            "de",
+		SegmentTemplate: &SegmentTemplate{Media: &media},
+	})
+	c.Assert(err, IsNil)
+	c.Check(as.MimeType, Equals, MimeTypeApplicationMP4)
+	c.Check(*as.Representations[0].Codecs, Equals, "stpp.ttml.etd1")
+
+	c.Assert(as.AddDVBFont(DVBFont{URL: "fonts/tiresias.woff", Family: "Tiresias", MimeType: MimeTypeFontWOFF}), IsNil)
+	c.Assert(as.AddDVBFont(DVBFont{URL: "fonts/symbols.ttf", Family: "Symbols", MimeType: MimeTypeFontSFNT, Essential: true}), IsNil)
+	c.Check(as.AddDVBFont(DVBFont{URL: "fonts/x.otf", Family: "X", MimeType: "font/otf"}), ErrorMatches,
+		`NewDVBFontDescriptor: unsupported mimeType "font/otf"`)
+	c.Check(as.AddDVBFont(DVBFont{MimeType: MimeTypeFontWOFF}), ErrorMatches, "NewDVBFontDescriptor: url and font family are required")
+
+	m := &MPD{Profiles: ProfileDVBDASH, Periods: []*Period{{AdaptationSets: []*AdaptationSet{as}}}}
+	b, err := m.Encode()
+	c.Assert(err, IsNil)
+	c.Check(string(b), Matches, `(?s).*<MPD xmlns:dvb="urn:dvb:dash-extensions:2014-1" profiles="urn:dvb:dash:profile:dvb-dash:2014">.*`)
+	c.Check(string(b), Matches, `(?s).*<EssentialProperty schemeIdUri="urn:dvb:dash:fontdownload:2014" value="1" dvb:url="fonts/symbols.ttf" dvb:fontFamily="Symbols" dvb:mimeType="application/font-sfnt"/>.*`)
+	c.Check(string(b), Matches, `(?s).*<SupplementalProperty schemeIdUri="urn:dvb:dash:fontdownload:2014" value="1" dvb:url="fonts/tiresias.woff" dvb:fontFamily="Tiresias" dvb:mimeType="application/font-woff"/>.*`)
+
+	decoded := new(MPD)
+	c.Assert(decoded.Decode(b), IsNil)
+	fonts, err := decoded.Periods[0].AdaptationSets[0].DVBFonts()
+	c.Assert(err, IsNil)
+	c.Check(fonts, DeepEquals, []DVBFont{
+		{URL: "fonts/symbols.ttf", Family: "Symbols", MimeType: MimeTypeFontSFNT, Essential: true},
+		{URL: "fonts/tiresias.woff", Family: "Tiresias", MimeType: MimeTypeFontWOFF},
+	})
+	c.Check(decoded.Periods[0].AdaptationSets[0].MimeType, Equals, MimeTypeApplicationMP4)
+
+	r, err := RoundTrip(b)
+	c.Assert(err, IsNil)
+	c.Check(r.Diffs, HasLen, 0)
+
+	// attributes without DVB namespace are not font download attributes, other prefixes are kept
+	decoded = new(MPD)
+	c.Assert(decoded.Decode([]byte(`<MPD xmlns="urn:mpeg:dash:schema:mpd:2011" xmlns:ext="urn:dvb:dash-extensions:2014-1">
+  <Period>
+    <AdaptationSet mimeType="application/mp4">
+      <SupplementalProperty schemeIdUri="urn:example:other" url="a.html" mimeType="text/html"/>
+      <SupplementalProperty schemeIdUri="urn:dvb:dash:fontdownload:2014" value="1" ext:url="f.woff" ext:fontFamily="F" ext:mimeType="application/font-woff"/>
+    </AdaptationSet>
+  </Period>
+</MPD>`)), IsNil)
+	properties := decoded.Periods[0].AdaptationSets[0].SupplementalProperties
+	c.Check(properties[0].FontURL, IsNil)
+	c.Check(properties[0].FontMimeType, IsNil)
+	c.Check(*properties[1].FontURL, Equals, "f.woff")
+	b, err = decoded.Encode()
+	c.Assert(err, IsNil)
+	c.Check(string(b), Matches, `(?s).*<SupplementalProperty schemeIdUri="urn:dvb:dash:fontdownload:2014" value="1" ext:url="f.woff" ext:fontFamily="F" ext:mimeType="application/font-woff"/>.*`)
+
+	as = new(AdaptationSet)
+	as.SupplementalProperties = []Descriptor{NewDescriptor(DVBFontDownloadScheme, "1")}
+	_, err = as.DVBFonts()
+	c.Check(err, ErrorMatches, "DVBFonts: font download descriptor requires dvb:url, dvb:fontFamily and dvb:mimeType")
+}
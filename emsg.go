@@ -0,0 +1,238 @@
+package mpd
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// emsgUnknownDuration is event_duration of emsg boxes of events with unknown duration.
+const emsgUnknownDuration = 0xFFFFFFFF
+
+// Emsg represents DASH Event Message Box of ISO/IEC 23009-1.
+type Emsg struct {
+	// Version is 0 for presentation time relative to the segment, 1 for absolute presentation time.
+	Version     byte
+	SchemeIDURI string
+	Value       string
+	Timescale   uint32
+	// PresentationTime is presentation_time of version 1 or presentation_time_delta of version 0 box.
+	PresentationTime uint64
+	// EventDuration is 0xFFFFFFFF for unknown duration.
+	EventDuration uint32
+	ID            uint32
+	MessageData   []byte
+}
+
+// ParseEmsg parses emsg box b, starting with box header.
+func ParseEmsg(b []byte) (*Emsg, error) {
+	short := fmt.Errorf("ParseEmsg: box is too short")
+	if len(b) < 12 {
+		return nil, short
+	}
+	if string(b[4:8]) != "emsg" {
+		return nil, fmt.Errorf("ParseEmsg: unexpected box type %q", b[4:8])
+	}
+	size := uint64(binary.BigEndian.Uint32(b))
+	pos := 8
+	if size == 1 {
+		if len(b) < 20 {
+			return nil, short
+		}
+		size = binary.BigEndian.Uint64(b[8:])
+		pos = 16
+	}
+	if size == 0 {
+		size = uint64(len(b))
+	}
+	if uint64(len(b)) < size {
+		return nil, short
+	}
+	b = b[:size]
+	if len(b) < pos+4 {
+		return nil, short
+	}
+
+	em := &Emsg{Version: b[pos]}
+	pos += 4
+	cstring := func() (string, error) {
+		end := bytes.IndexByte(b[pos:], 0)
+		if end < 0 {
+			return "", short
+		}
+		s := string(b[pos : pos+end])
+		pos += end + 1
+		return s, nil
+	}
+	var err error
+	switch em.Version {
+	case 0:
+		if em.SchemeIDURI, err = cstring(); err != nil {
+			return nil, err
+		}
+		if em.Value, err = cstring(); err != nil {
+			return nil, err
+		}
+		if len(b) < pos+16 {
+			return nil, short
+		}
+		em.Timescale = binary.BigEndian.Uint32(b[pos:])
+		em.PresentationTime = uint64(binary.BigEndian.Uint32(b[pos+4:]))
+		em.EventDuration = binary.BigEndian.Uint32(b[pos+8:])
+		em.ID = binary.BigEndian.Uint32(b[pos+12:])
+		pos += 16
+	case 1:
+		if len(b) < pos+20 {
+			return nil, short
+		}
+		em.Timescale = binary.BigEndian.Uint32(b[pos:])
+		em.PresentationTime = binary.BigEndian.Uint64(b[pos+4:])
+		em.EventDuration = binary.BigEndian.Uint32(b[pos+12:])
+		em.ID = binary.BigEndian.Uint32(b[pos+16:])
+		pos += 20
+		if em.SchemeIDURI, err = cstring(); err != nil {
+			return nil, err
+		}
+		if em.Value, err = cstring(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("ParseEmsg: unsupported version %d", em.Version)
+	}
+	em.MessageData = append([]byte{}, b[pos:]...)
+	return em, nil
+}
+
+// Bytes returns emsg box of em.
+func (em *Emsg) Bytes() []byte {
+	b := make([]byte, 12, 32+len(em.SchemeIDURI)+len(em.Value)+len(em.MessageData))
+	copy(b[4:], "emsg")
+	b[8] = em.Version
+	var buf [8]byte
+	put32 := func(v uint32) {
+		binary.BigEndian.PutUint32(buf[:], v)
+		b = append(b, buf[:4]...)
+	}
+	cstrings := func() {
+		b = append(append(b, em.SchemeIDURI...), 0)
+		b = append(append(b, em.Value...), 0)
+	}
+	if em.Version == 0 {
+		cstrings()
+		put32(em.Timescale)
+		put32(uint32(em.PresentationTime))
+	} else {
+		put32(em.Timescale)
+		binary.BigEndian.PutUint64(buf[:], em.PresentationTime)
+		b = append(b, buf[:]...)
+	}
+	put32(em.EventDuration)
+	put32(em.ID)
+	if em.Version != 0 {
+		cstrings()
+	}
+	b = append(b, em.MessageData...)
+	binary.BigEndian.PutUint32(b, uint32(len(b)))
+	return b
+}
+
+// AddEmsg adds Event carried by em to EventStream of p with the same scheme and value, creating one with
+// timescale of em if needed. segmentTime is the earliest presentation time of the segment carrying em,
+// in em timescale, which presentation_time_delta of version 0 box is relative to. Presentation time of
+// the Event is on the media timeline, so EventStream@presentationTimeOffset should match the Representation's.
+// Message data becomes base64-encoded Event content, or Signal of SCTE35BinScheme Event for SCTE35InbandScheme box.
+func (p *Period) AddEmsg(em *Emsg, segmentTime uint64) (*Event, error) {
+	if em.Timescale == 0 {
+		return nil, fmt.Errorf("AddEmsg: zero timescale")
+	}
+	scte35 := em.SchemeIDURI == SCTE35InbandScheme
+	scheme := em.SchemeIDURI
+	if scte35 {
+		scheme = SCTE35BinScheme
+	}
+	es := p.eventStream(scheme, em.Value, int64(em.Timescale))
+	ts := eventTimescale(es)
+
+	pt := em.PresentationTime
+	if em.Version == 0 {
+		pt += segmentTime
+	}
+	rescale := func(v uint64) (int64, error) {
+		if uint64(ts) != uint64(em.Timescale) {
+			v = uint64(unscaleEventTime(timescaled(v, uint64Ptr(uint64(em.Timescale))), ts))
+		}
+		if v > math.MaxInt64 {
+			return 0, fmt.Errorf("AddEmsg: time %d is out of range", v)
+		}
+		return int64(v), nil
+	}
+	e := Event{ID: stringPtr(strconv.FormatUint(uint64(em.ID), 10))}
+	t, err := rescale(pt)
+	if err != nil {
+		return nil, err
+	}
+	e.PresentationTime = &t
+	if em.EventDuration != emsgUnknownDuration {
+		d, err := rescale(uint64(em.EventDuration))
+		if err != nil {
+			return nil, err
+		}
+		e.Duration = &d
+	}
+	if scte35 {
+		e.SetSpliceInfo(em.MessageData)
+	} else {
+		e.SetPayload(em.MessageData)
+	}
+	es.Events = append(es.Events, e)
+	return &es.Events[len(es.Events)-1], nil
+}
+
+// NewEmsg returns version 1 emsg box carrying Event e of EventStream es, with Event payload as message data.
+// SCTE35BinScheme Events become SCTE35InbandScheme boxes with splice_info_section of Signal.
+// Event@id must be a number, as emsg ids are.
+func NewEmsg(es *EventStream, e *Event) (*Emsg, error) {
+	em := &Emsg{Version: 1, EventDuration: emsgUnknownDuration}
+	if es.SchemeIDURI != nil {
+		em.SchemeIDURI = *es.SchemeIDURI
+	}
+	if es.Value != nil {
+		em.Value = *es.Value
+	}
+	ts := eventTimescale(es)
+	if ts > math.MaxUint32 {
+		return nil, fmt.Errorf("NewEmsg: timescale %d is out of range", ts)
+	}
+	em.Timescale = uint32(ts)
+	if e.PresentationTime != nil {
+		if *e.PresentationTime < 0 {
+			return nil, fmt.Errorf("NewEmsg: negative presentationTime %d", *e.PresentationTime)
+		}
+		em.PresentationTime = uint64(*e.PresentationTime)
+	}
+	if e.Duration != nil {
+		if *e.Duration < 0 || *e.Duration >= emsgUnknownDuration {
+			return nil, fmt.Errorf("NewEmsg: duration %d is out of range", *e.Duration)
+		}
+		em.EventDuration = uint32(*e.Duration)
+	}
+	if e.ID != nil {
+		id, err := strconv.ParseUint(*e.ID, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("NewEmsg: Event id %q is not a number", *e.ID)
+		}
+		em.ID = uint32(id)
+	}
+	b, err := e.Payload()
+	if em.SchemeIDURI == SCTE35BinScheme {
+		em.SchemeIDURI = SCTE35InbandScheme
+		b, err = e.SpliceInfo()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("NewEmsg: %w", err)
+	}
+	em.MessageData = b
+	return em, nil
+}
@@ -0,0 +1,69 @@
+package mpd
+
+import (
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestEmsg(c *C) {
+	tag := []byte("ID3\x04\x00\x00\x00\x00\x00\x00")
+	for _, em := range []*Emsg{
+		{Version: 0, SchemeIDURI: ID3Scheme, Value: "1", Timescale: 1000, PresentationTime: 500, EventDuration: 2000, ID: 7, MessageData: tag},
+		{Version: 1, SchemeIDURI: ID3Scheme, Timescale: 1000, PresentationTime: 1 << 40, EventDuration: emsgUnknownDuration, ID: 8, MessageData: []byte{}},
+	} {
+		b := em.Bytes()
+		c.Check(string(b[4:8]), Equals, "emsg")
+		parsed, err := ParseEmsg(b)
+		c.Assert(err, IsNil)
+		c.Check(parsed, DeepEquals, em)
+		_, err = ParseEmsg(b[:len(b)-len(em.MessageData)-1])
+		c.Check(err, ErrorMatches, "ParseEmsg: box is too short")
+	}
+	_, err := ParseEmsg([]byte("\x00\x00\x00\x0cmoof\x00\x00\x00\x00"))
+	c.Check(err, ErrorMatches, `ParseEmsg: unexpected box type "moof"`)
+	for _, b := range []string{
+		"\x00\x00\x00\x08emsg\x00\x00\x00\x00",
+		"\x00\x00\x00\x01emsg\x00\x00\x00\x00\x00\x00\x00\x10\x00\x00\x00\x00",
+	} {
+		_, err = ParseEmsg([]byte(b))
+		c.Check(err, ErrorMatches, "ParseEmsg: box is too short")
+	}
+
+	p := &Period{EventStreams: []EventStream{{SchemeIDURI: stringPtr(ID3Scheme), Value: stringPtr("1"), Timescale: int64Ptr(90000)}}}
+	em := &Emsg{Version: 0, SchemeIDURI: ID3Scheme, Value: "1", Timescale: 1000, PresentationTime: 500, EventDuration: 2000, ID: 7, MessageData: tag}
+	e, err := p.AddEmsg(em, 10000)
+	c.Assert(err, IsNil)
+	c.Check(*e.ID, Equals, "7")
+	c.Check(*e.PresentationTime, Equals, int64(945000))
+	c.Check(*e.Duration, Equals, int64(180000))
+	payload, err := e.Payload()
+	c.Assert(err, IsNil)
+	c.Check(payload, DeepEquals, tag)
+
+	back, err := NewEmsg(&p.EventStreams[0], e)
+	c.Assert(err, IsNil)
+	c.Check(back, DeepEquals, &Emsg{Version: 1, SchemeIDURI: ID3Scheme, Value: "1", Timescale: 90000, PresentationTime: 945000, EventDuration: 180000, ID: 7, MessageData: tag})
+
+	e.ID = stringPtr("ad")
+	_, err = NewEmsg(&p.EventStreams[0], e)
+	c.Check(err, ErrorMatches, `NewEmsg: Event id "ad" is not a number`)
+
+	m := &MPD{Periods: []*Period{{Start: stringPtr("PT0S")}}}
+	splice := []byte{0xFC, 0x30, 0x11}
+	e, err = InsertAdBreak(m, time.Second, 0, splice)
+	c.Assert(err, IsNil)
+	back, err = NewEmsg(&m.Periods[0].EventStreams[0], e)
+	c.Assert(err, IsNil)
+	c.Check(back.SchemeIDURI, Equals, SCTE35InbandScheme)
+	c.Check(back.MessageData, DeepEquals, splice)
+	c.Check(back.PresentationTime, Equals, uint64(90000))
+
+	p = new(Period)
+	e, err = p.AddEmsg(back, 0)
+	c.Assert(err, IsNil)
+	c.Check(*p.EventStreams[0].SchemeIDURI, Equals, SCTE35BinScheme)
+	spliceInfo, err := e.SpliceInfo()
+	c.Assert(err, IsNil)
+	c.Check(spliceInfo, DeepEquals, splice)
+}
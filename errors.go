@@ -0,0 +1,41 @@
+package mpd
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Causes of errors returned by this package, check them with errors.Is.
+var (
+	ErrInvalidDuration         = errors.New("invalid duration")
+	ErrInvalidDateTime         = errors.New("invalid dateTime")
+	ErrNoAvailabilityStartTime = errors.New("no availabilityStartTime")
+	ErrUnknownPeriodDuration   = errors.New("unknown Period duration")
+	// ErrUnknownAddressingMode means that segments can't be determined from segment addressing elements.
+	ErrUnknownAddressingMode = errors.New("unknown addressing mode")
+)
+
+// Error is an error of a function of this package with a cause, which is one of Err* values.
+// Use errors.As to get it and errors.Is to check the cause.
+type Error struct {
+	// Op is a function name, e.g. "ParseDuration".
+	Op  string
+	Err error
+	// Msg describes the problem in details.
+	Msg string
+}
+
+// Error implements error interface.
+func (e *Error) Error() string {
+	return e.Op + ": " + e.Msg
+}
+
+// Unwrap returns the cause of e.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// newError returns Error with formatted message.
+func newError(op string, cause error, format string, args ...interface{}) *Error {
+	return &Error{Op: op, Err: cause, Msg: fmt.Sprintf(format, args...)}
+}
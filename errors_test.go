@@ -0,0 +1,38 @@
+package mpd
+
+import (
+	"errors"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestErrors(c *C) {
+	_, err := ParseDuration("P1Y")
+	c.Check(err, ErrorMatches, `ParseDuration: can't parse "P1Y"`)
+	c.Check(errors.Is(err, ErrInvalidDuration), Equals, true)
+	var e *Error
+	c.Assert(errors.As(err, &e), Equals, true)
+	c.Check(e.Op, Equals, "ParseDuration")
+
+	// causes are kept by wrapping functions
+	m := &MPD{AvailabilityStartTime: stringPtr("2016-01-01T00:00:00Z"), Periods: []*Period{{Start: stringPtr("bad")}}}
+	_, err = FindPeriodGaps(m)
+	c.Check(err, ErrorMatches, `FindPeriodGaps: Periods\[0\]@start: ParseDuration: can't parse "bad"`)
+	c.Check(errors.Is(err, ErrInvalidDuration), Equals, true)
+
+	_, err = FindPeriodGaps(new(MPD))
+	c.Check(errors.Is(err, ErrNoAvailabilityStartTime), Equals, true)
+
+	p := &Period{AdaptationSets: []*AdaptationSet{{SegmentTemplate: &SegmentTemplate{}, Representations: []Representation{{}}}}}
+	as := p.AdaptationSets[0]
+	_, err = Segments("", &MPD{Periods: []*Period{p}}, p, as, &as.Representations[0])
+	c.Check(err, ErrorMatches, "Segments: SegmentTemplate without media")
+	c.Check(errors.Is(err, ErrUnknownAddressingMode), Equals, true)
+
+	// ValidationErrors
+	m = &MPD{Periods: []*Period{{AdaptationSets: []*AdaptationSet{{Lang: stringPtr("en_US")}}}}}
+	err = m.Validate()
+	var ve *ValidationError
+	c.Assert(errors.As(err, &ve), Equals, true)
+	c.Check(ve.Path, Equals, "Periods[0].AdaptationSets[0]")
+}
@@ -0,0 +1,150 @@
+package mpd
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// EventWallClock returns wall-clock start and end of Event e from EventStream es of Period p in dynamic MPD m.
+// Events without duration end at their start.
+func EventWallClock(m *MPD, p *Period, es *EventStream, e *Event) (start, end time.Time, err error) {
+	base, err := eventStreamBase(m, p)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("EventWallClock: %w", err)
+	}
+	ts := eventTimescale(es)
+
+	var pt int64
+	if e.PresentationTime != nil {
+		pt = *e.PresentationTime
+	}
+	if es.PresentationTimeOffset != nil {
+		pt -= int64(*es.PresentationTimeOffset)
+	}
+	start = base.Add(scaleEventTime(pt, ts))
+	end = start
+	if e.Duration != nil {
+		end = start.Add(scaleEventTime(*e.Duration, ts))
+	}
+	return start, end, nil
+}
+
+// ScheduleEvent returns Event of EventStream es of Period p in dynamic MPD m starting at wall-clock time at
+// and lasting for duration (which may be zero). The Event is not added to es.
+func ScheduleEvent(m *MPD, p *Period, es *EventStream, at time.Time, duration time.Duration) (*Event, error) {
+	base, err := eventStreamBase(m, p)
+	if err != nil {
+		return nil, fmt.Errorf("ScheduleEvent: %w", err)
+	}
+	if at.Before(base) {
+		return nil, fmt.Errorf("ScheduleEvent: %s is before Period start %s", FormatDateTime(at), FormatDateTime(base))
+	}
+	ts := eventTimescale(es)
+
+	pt := unscaleEventTime(at.Sub(base), ts)
+	if es.PresentationTimeOffset != nil {
+		pt += int64(*es.PresentationTimeOffset)
+	}
+	e := &Event{PresentationTime: &pt}
+	if duration > 0 {
+		d := unscaleEventTime(duration, ts)
+		e.Duration = &d
+	}
+	return e, nil
+}
+
+// eventStreamBase returns wall-clock start of Period p in dynamic MPD m.
+func eventStreamBase(m *MPD, p *Period) (time.Time, error) {
+	ast, err := m.AvailabilityStart()
+	if err != nil {
+		return time.Time{}, err
+	}
+	if ast.IsZero() {
+		return time.Time{}, ErrNoAvailabilityStartTime
+	}
+	var start time.Duration
+	if p.Start != nil {
+		if start, err = ParseDuration(*p.Start); err != nil {
+			return time.Time{}, err
+		}
+	}
+	return ast.Add(start), nil
+}
+
+// eventTimescale returns es @timescale, which defaults to 1.
+func eventTimescale(es *EventStream) int64 {
+	if es.Timescale == nil || *es.Timescale <= 0 {
+		return 1
+	}
+	return *es.Timescale
+}
+
+// scaleEventTime converts v in timescale units to time.Duration.
+func scaleEventTime(v, timescale int64) time.Duration {
+	return time.Duration(v/timescale)*time.Second + time.Duration(v%timescale)*time.Second/time.Duration(timescale)
+}
+
+// unscaleEventTime converts d to timescale units, rounding to the nearest unit.
+func unscaleEventTime(d time.Duration, timescale int64) int64 {
+	return int64(d/time.Second)*timescale + (int64(d%time.Second)*timescale+int64(time.Second)/2)/int64(time.Second)
+}
+
+// eventStream returns p's EventStream with given scheme and value, creating one with given timescale if needed.
+// Empty value matches EventStreams without value.
+func (p *Period) eventStream(scheme, value string, timescale int64) *EventStream {
+	for i := range p.EventStreams {
+		es := &p.EventStreams[i]
+		var v string
+		if es.Value != nil {
+			v = *es.Value
+		}
+		if es.SchemeIDURI != nil && *es.SchemeIDURI == scheme && v == value {
+			return es
+		}
+	}
+
+	es := EventStream{SchemeIDURI: stringPtr(scheme), Timescale: &timescale}
+	if value != "" {
+		es.Value = stringPtr(value)
+	}
+	p.EventStreams = append(p.EventStreams, es)
+	return &p.EventStreams[len(p.EventStreams)-1]
+}
+
+// nextEventID returns @id for a new Event of es: one more than the largest numeric @id,
+// so ids stay unique after Events are removed.
+func (es *EventStream) nextEventID() string {
+	var max uint64
+	for _, e := range es.Events {
+		if e.ID == nil {
+			continue
+		}
+		if n, err := strconv.ParseUint(*e.ID, 10, 64); err == nil && n > max {
+			max = n
+		}
+	}
+	return strconv.FormatUint(max+1, 10)
+}
+
+// Payload returns message payload of e: decoded Content if @contentEncoding is base64, Content as is otherwise.
+func (e *Event) Payload() ([]byte, error) {
+	if e.ContentEncoding == nil {
+		return []byte(e.Content), nil
+	}
+	if *e.ContentEncoding != "base64" {
+		return nil, fmt.Errorf("Payload: unsupported contentEncoding %q", *e.ContentEncoding)
+	}
+	b, err := decodeBase64Payload(e.Content)
+	if err != nil {
+		return nil, fmt.Errorf("Payload: %w", err)
+	}
+	return b, nil
+}
+
+// SetPayload sets Content of e to base64-encoded binary payload b.
+func (e *Event) SetPayload(b []byte) {
+	e.Content = base64.StdEncoding.EncodeToString(b)
+	e.ContentEncoding = stringPtr("base64")
+}
@@ -0,0 +1,36 @@
+package mpd
+
+import (
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestEventWallClock(c *C) {
+	m := &MPD{Type: stringPtr("dynamic"), AvailabilityStartTime: stringPtr("2016-01-01T00:00:00Z")}
+	p := &Period{Start: stringPtr("PT1H")}
+	es := &EventStream{Timescale: int64Ptr(90000), PresentationTimeOffset: uint64Ptr(900000)}
+	e := &Event{PresentationTime: int64Ptr(900000 + 45000), Duration: int64Ptr(2700000)}
+
+	start, end, err := EventWallClock(m, p, es, e)
+	c.Assert(err, IsNil)
+	c.Check(start.Equal(time.Date(2016, 1, 1, 1, 0, 0, 500000000, time.UTC)), Equals, true)
+	c.Check(end.Equal(time.Date(2016, 1, 1, 1, 0, 30, 500000000, time.UTC)), Equals, true)
+
+	scheduled, err := ScheduleEvent(m, p, es, start, 30*time.Second)
+	c.Assert(err, IsNil)
+	c.Check(*scheduled.PresentationTime, Equals, *e.PresentationTime)
+	c.Check(*scheduled.Duration, Equals, *e.Duration)
+
+	scheduled, err = ScheduleEvent(m, p, &EventStream{}, start, 0)
+	c.Assert(err, IsNil)
+	c.Check(*scheduled.PresentationTime, Equals, int64(1))
+	c.Check(scheduled.Duration, IsNil)
+
+	_, err = ScheduleEvent(m, p, es, time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC), 0)
+	c.Check(err, ErrorMatches, "ScheduleEvent: 2016-01-01T00:00:00Z is before Period start 2016-01-01T01:00:00Z")
+
+	m.AvailabilityStartTime = nil
+	_, _, err = EventWallClock(m, p, es, e)
+	c.Check(err, ErrorMatches, "EventWallClock: no availabilityStartTime")
+}
@@ -0,0 +1,161 @@
+package mpd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// SegmentRow is a row of segment table returned by SegmentTable.
+type SegmentRow struct {
+	// Period, AdaptationSet and Representation are ids, or paths like Representation of ValidationError for
+	// elements without id.
+	Period         string `json:"period"`
+	AdaptationSet  string `json:"adaptationSet"`
+	Representation string `json:"representation"`
+	Bandwidth      uint64 `json:"bandwidth"`
+	Kind           string `json:"kind"`
+
+	// Number, Time, Duration and Timescale are set for media segments with known timing, zero otherwise.
+	Number    uint64 `json:"number"`
+	Time      uint64 `json:"time"`
+	Duration  uint64 `json:"duration"`
+	Timescale uint64 `json:"timescale"`
+
+	URL       string `json:"url"`
+	ByteRange string `json:"byteRange,omitempty"`
+
+	// AvailabilityStart and AvailabilityEnd bound availability window of media segments of dynamic MPD.
+	// AvailabilityEnd is nil if timeShiftBufferDepth is infinite.
+	AvailabilityStart *time.Time `json:"availabilityStart,omitempty"`
+	AvailabilityEnd   *time.Time `json:"availabilityEnd,omitempty"`
+}
+
+// segmentTableHeader is a header of CSV written by WriteSegmentTableCSV.
+var segmentTableHeader = []string{"period", "adaptationSet", "representation", "bandwidth", "kind",
+	"number", "time", "duration", "timescale", "url", "byteRange", "availabilityStart", "availabilityEnd"}
+
+// SegmentTable returns segments of all Representations of m, as enumerated by Segments with given manifestURL.
+func SegmentTable(manifestURL string, m *MPD) ([]SegmentRow, error) {
+	var ast time.Time
+	var tsbd time.Duration
+	infinite := true
+	dynamic := m.Type != nil && *m.Type == "dynamic"
+	if dynamic {
+		var err error
+		if ast, err = m.AvailabilityStart(); err != nil {
+			return nil, fmt.Errorf("SegmentTable: %w", err)
+		}
+		if m.TimeShiftBufferDepth != nil {
+			if tsbd, err = ParseDuration(*m.TimeShiftBufferDepth); err != nil {
+				return nil, fmt.Errorf("SegmentTable: timeShiftBufferDepth: %w", err)
+			}
+			infinite = false
+		}
+	}
+
+	var res []SegmentRow
+	for i, p := range m.Periods {
+		periodStart, _, err := periodRange(m, i)
+		if err != nil {
+			return nil, fmt.Errorf("SegmentTable: %s: %w", periodPath(i), err)
+		}
+		periodID := periodPath(i)
+		if p.ID != nil {
+			periodID = *p.ID
+		}
+		for j, as := range p.AdaptationSets {
+			asID := adaptationSetPath(i, j)
+			if as.ID != nil {
+				asID = strconv.FormatUint(*as.ID, 10)
+			}
+			for k := range as.Representations {
+				r := &as.Representations[k]
+				repID := representationPath(i, j, k)
+				if r.ID != nil {
+					repID = *r.ID
+				}
+				segments, err := Segments(manifestURL, m, p, as, r)
+				if err != nil {
+					return nil, fmt.Errorf("SegmentTable: %s: %w", representationPath(i, j, k), err)
+				}
+
+				pto := EffectivePresentationTimeOffset(p, as, r)
+				var ato time.Duration
+				if t := EffectiveSegmentTemplate(p, as, r); t != nil && t.AvailabilityTimeOffset != nil {
+					ato = time.Duration(*t.AvailabilityTimeOffset * float64(time.Second))
+				}
+
+				for _, s := range segments {
+					row := SegmentRow{
+						Period:         periodID,
+						AdaptationSet:  asID,
+						Representation: repID,
+						Bandwidth:      r.GetBandwidth(),
+						Kind:           s.Kind.String(),
+						URL:            s.URL,
+						ByteRange:      s.ByteRange,
+					}
+					if s.Kind == MediaSegment && s.Timescale != 0 && s.Duration != 0 {
+						row.Number, row.Time, row.Duration, row.Timescale = s.Number, s.Time, s.Duration, s.Timescale
+						if dynamic && !ast.IsZero() {
+							ts := uint64Ptr(s.Timescale)
+							end := periodStart + timescaled(s.Time+s.Duration, ts) - timescaled(pto, ts)
+							start := ast.Add(end - ato)
+							row.AvailabilityStart = &start
+							if !infinite {
+								until := start.Add(tsbd + timescaled(s.Duration, ts))
+								row.AvailabilityEnd = &until
+							}
+						}
+					}
+					res = append(res, row)
+				}
+			}
+		}
+	}
+	return res, nil
+}
+
+// WriteSegmentTableCSV writes rows to w as CSV with a header line. Times are formatted like FormatDateTime.
+func WriteSegmentTableCSV(w io.Writer, rows []SegmentRow) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(segmentTableHeader); err != nil {
+		return err
+	}
+	number := func(v uint64, set bool) string {
+		if !set {
+			return ""
+		}
+		return strconv.FormatUint(v, 10)
+	}
+	dateTime := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return FormatDateTime(*t)
+	}
+	for _, r := range rows {
+		timed := r.Timescale != 0
+		if err := cw.Write([]string{r.Period, r.AdaptationSet, r.Representation, strconv.FormatUint(r.Bandwidth, 10), r.Kind,
+			number(r.Number, timed), number(r.Time, timed), number(r.Duration, timed), number(r.Timescale, timed),
+			r.URL, r.ByteRange, dateTime(r.AvailabilityStart), dateTime(r.AvailabilityEnd)}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteSegmentTableJSON writes rows to w as indented JSON array.
+func WriteSegmentTableJSON(w io.Writer, rows []SegmentRow) error {
+	if rows == nil {
+		rows = []SegmentRow{}
+	}
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	return e.Encode(rows)
+}
@@ -0,0 +1,87 @@
+package mpd
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestSegmentTable(c *C) {
+	repeat := int64(1)
+	m := &MPD{
+		Type:                  stringPtr("dynamic"),
+		AvailabilityStartTime: stringPtr("2024-01-01T00:00:00Z"),
+		TimeShiftBufferDepth:  stringPtr("PT30S"),
+		Periods: []*Period{{ID: stringPtr("p0"), Start: stringPtr("PT10S"), AdaptationSets: []*AdaptationSet{{
+			ID:       uint64Ptr(1),
+			MimeType: MimeTypeVideoMP4,
+			SegmentTemplate: &SegmentTemplate{
+				Timescale:              uint64Ptr(1000),
+				PresentationTimeOffset: uint64Ptr(1000),
+				Initialization:         stringPtr("$RepresentationID$/init.mp4"),
+				Media:                  stringPtr("$RepresentationID$/$Time$.m4s"),
+				SegmentTimeline:        []SegmentTimeline{{Segments: []SegmentTimelineSegment{{T: uint64Ptr(1000), D: 2000, R: &repeat}}}},
+			},
+			Representations: []Representation{{ID: stringPtr("v"), Bandwidth: uint64Ptr(1000000)}},
+		}}}},
+	}
+
+	rows, err := SegmentTable("http://example.com/live/manifest.mpd", m)
+	c.Assert(err, IsNil)
+	c.Assert(rows, HasLen, 3)
+
+	var b bytes.Buffer
+	c.Assert(WriteSegmentTableCSV(&b, rows), IsNil)
+	c.Check(b.String(), Equals, `period,adaptationSet,representation,bandwidth,kind,number,time,duration,timescale,url,byteRange,availabilityStart,availabilityEnd
+p0,1,v,1000000,initialization,,,,,http://example.com/live/v/init.mp4,,,
+p0,1,v,1000000,media,1,1000,2000,1000,http://example.com/live/v/1000.m4s,,2024-01-01T00:00:12Z,2024-01-01T00:00:44Z
+p0,1,v,1000000,media,2,3000,2000,1000,http://example.com/live/v/3000.m4s,,2024-01-01T00:00:14Z,2024-01-01T00:00:46Z
+`)
+
+	b.Reset()
+	c.Assert(WriteSegmentTableJSON(&b, rows), IsNil)
+	var decoded []map[string]interface{}
+	c.Assert(json.Unmarshal(b.Bytes(), &decoded), IsNil)
+	c.Assert(decoded, HasLen, 3)
+	c.Check(decoded[0]["availabilityStart"], IsNil)
+	c.Check(decoded[1]["time"], Equals, float64(1000))
+	c.Check(decoded[1]["availabilityStart"], Equals, "2024-01-01T00:00:12Z")
+
+	m.Type, m.TimeShiftBufferDepth = nil, nil
+	m.MediaPresentationDuration = stringPtr("PT14S")
+	rows, err = SegmentTable("", m)
+	c.Assert(err, IsNil)
+	c.Check(rows[1].AvailabilityStart, IsNil)
+	c.Check(rows[1].URL, Equals, "v/1000.m4s")
+
+	b.Reset()
+	c.Assert(WriteSegmentTableJSON(&b, nil), IsNil)
+	c.Check(b.String(), Equals, "[]\n")
+}
+
+func (s *MPDSuite) TestSegmentTablePresentationTimeOffset(c *C) {
+	m := &MPD{
+		Type:                  stringPtr("dynamic"),
+		AvailabilityStartTime: stringPtr("2024-01-01T00:00:00Z"),
+		Periods: []*Period{{ID: stringPtr("p0"), Start: stringPtr("PT0S"), Duration: stringPtr("PT4S"), AdaptationSets: []*AdaptationSet{{
+			SegmentTemplate: &SegmentTemplate{
+				Timescale:              uint64Ptr(1000),
+				Duration:               uint32Ptr(2000),
+				PresentationTimeOffset: uint64Ptr(10000),
+				Media:                  stringPtr("$Time$.m4s"),
+			},
+			Representations: []Representation{{ID: stringPtr("v")}},
+		}}}},
+	}
+
+	rows, err := SegmentTable("", m)
+	c.Assert(err, IsNil)
+	c.Assert(rows, HasLen, 2)
+	c.Check(rows[0].Time, Equals, uint64(10000))
+	c.Check(rows[0].URL, Equals, "10000.m4s")
+	c.Check(rows[0].AvailabilityStart.Format(time.RFC3339), Equals, "2024-01-01T00:00:02Z")
+	c.Check(rows[1].AvailabilityStart.Format(time.RFC3339), Equals, "2024-01-01T00:00:04Z")
+	c.Check(rows[1].AvailabilityEnd, IsNil)
+}
@@ -0,0 +1,117 @@
+package mpd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"sync"
+)
+
+var (
+	extensionsM sync.RWMutex
+	extensions  = make(map[xml.Name]func() interface{})
+)
+
+// RegisterExtension registers Go type for custom child elements with given name.
+// Empty name.Space matches elements in any namespace. newValue should return a pointer to a new value,
+// which is decoded with encoding/xml and stored in Extension.Value.
+func RegisterExtension(name xml.Name, newValue func() interface{}) {
+	extensionsM.Lock()
+	extensions[name] = newValue
+	extensionsM.Unlock()
+}
+
+// lookupExtension returns registered constructor for element name, or nil.
+func lookupExtension(name xml.Name) func() interface{} {
+	extensionsM.RLock()
+	defer extensionsM.RUnlock()
+	if f := extensions[name]; f != nil {
+		return f
+	}
+	return extensions[xml.Name{Local: name.Local}]
+}
+
+// Extension is a custom or unrecognized child element.
+type Extension struct {
+	XMLName xml.Name
+	// Value is a pointer to a value of type registered by RegisterExtension, or nil for unregistered elements.
+	Value interface{}
+	// Raw contains all tokens of unregistered element, starting with xml.StartElement,
+	// which are re-emitted on encoding. Whitespace-only character data is dropped.
+	Raw []xml.Token
+}
+
+// UnmarshalXML implements xml.Unmarshaler interface.
+func (e *Extension) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	e.XMLName = start.Name
+	if f := lookupExtension(start.Name); f != nil {
+		e.Value = f()
+		return d.DecodeElement(e.Value, &start)
+	}
+
+	e.Raw = []xml.Token{rawStartElement(start)}
+	for depth := 1; depth > 0; {
+		t, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := t.(type) {
+		case xml.StartElement:
+			depth++
+			e.Raw = append(e.Raw, rawStartElement(t))
+			continue
+		case xml.EndElement:
+			depth--
+			t.Name = rawName(t.Name)
+			e.Raw = append(e.Raw, t)
+			continue
+		case xml.CharData:
+			if len(bytes.TrimSpace(t)) == 0 {
+				continue
+			}
+		case xml.ProcInst, xml.Directive:
+			continue
+		}
+		e.Raw = append(e.Raw, xml.CopyToken(t))
+	}
+	return nil
+}
+
+// MarshalXML implements xml.Marshaler interface.
+func (e Extension) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	if e.Value == nil {
+		for _, t := range e.Raw {
+			if err := enc.EncodeToken(t); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return enc.EncodeElement(e.Value, xml.StartElement{Name: rawName(e.XMLName)})
+}
+
+// rawStartElement returns copy of t suitable for re-encoding: namespace declarations are dropped,
+// as encoding/xml declares namespaces of element and attribute names itself.
+func rawStartElement(t xml.StartElement) xml.StartElement {
+	res := xml.StartElement{Name: rawName(t.Name)}
+	for _, a := range t.Attr {
+		if a.Name.Space == "xmlns" || (a.Name.Space == "" && a.Name.Local == "xmlns") {
+			continue
+		}
+		res.Attr = append(res.Attr, a)
+	}
+	return res
+}
+
+// rawName removes MPD namespace from name, as it's inherited from MPD element.
+func rawName(name xml.Name) xml.Name {
+	if name.Space == MPDNamespace {
+		name.Space = ""
+	}
+	return name
+}
+
+// check interfaces
+var (
+	_ xml.Marshaler   = Extension{}
+	_ xml.Unmarshaler = &Extension{}
+)
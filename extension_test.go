@@ -0,0 +1,106 @@
+package mpd
+
+import (
+	"encoding/xml"
+
+	. "gopkg.in/check.v1"
+)
+
+type testVendorInfo struct {
+	Owner string `xml:"owner,attr"`
+	Note  string `xml:",chardata"`
+}
+
+// unregisterExtension removes extension registered by a test.
+func unregisterExtension(name xml.Name) {
+	extensionsM.Lock()
+	delete(extensions, name)
+	extensionsM.Unlock()
+}
+
+func (s *MPDSuite) TestExtensions(c *C) {
+	vendorInfo := xml.Name{Space: "urn:example:vendor", Local: "VendorInfo"}
+	RegisterExtension(vendorInfo, func() interface{} { return new(testVendorInfo) })
+	defer unregisterExtension(vendorInfo)
+
+	m := new(MPD)
+	c.Assert(m.Decode([]byte(`<MPD xmlns="urn:mpeg:dash:schema:mpd:2011" xmlns:v="urn:example:vendor">
+  <Period>
+    <ProgramEventStream schemeIdUri="urn:example:program" timescale="1000">
+      <Event id="1" presentationTime="0"/>
+    </ProgramEventStream>
+    <AdaptationSet mimeType="video/mp4">
+      <v:VendorInfo owner="studio">internal</v:VendorInfo>
+      <v:Unknown/>
+    </AdaptationSet>
+  </Period>
+</MPD>`)), IsNil)
+
+	p := m.Periods[0]
+	pes := p.ProgramEventStreams
+	c.Assert(pes, HasLen, 1)
+	c.Check(*pes[0].SchemeIDURI, Equals, "urn:example:program")
+	c.Check(pes[0].Events, HasLen, 1)
+
+	ext := p.AdaptationSets[0].Extensions
+	c.Assert(ext, HasLen, 2)
+	c.Check(ext[0].Value, DeepEquals, &testVendorInfo{Owner: "studio", Note: "internal"})
+	c.Check(ext[1].Value, IsNil)
+	c.Check(ext[1].Raw, HasLen, 2)
+
+	p.ProgramEventStreams = append(p.ProgramEventStreams, ProgramEventStream{SchemeIDURI: stringPtr("urn:example:other")})
+
+	b, err := m.Encode()
+	c.Assert(err, IsNil)
+	c.Check(string(b), Equals, `<?xml version="1.0" encoding="utf-8"?>
+<MPD xmlns="urn:mpeg:dash:schema:mpd:2011" xmlns:v="urn:example:vendor" profiles="">
+  <Period>
+    <ProgramEventStream schemeIdUri="urn:example:program" timescale="1000">
+      <Event id="1" presentationTime="0"/>
+    </ProgramEventStream>
+    <ProgramEventStream schemeIdUri="urn:example:other"/>
+    <AdaptationSet mimeType="video/mp4">
+      <v:VendorInfo owner="studio">internal</v:VendorInfo>
+      <v:Unknown/>
+    </AdaptationSet>
+  </Period>
+</MPD>
+`)
+}
+
+func (s *MPDSuite) TestRawExtensions(c *C) {
+	m := new(MPD)
+	c.Assert(m.Decode([]byte(`<MPD xmlns="urn:mpeg:dash:schema:mpd:2011">
+  <Custom></Custom>
+  <Period>
+    <AdaptationSet mimeType="video/mp4">
+      <SegmentTemplate media="$Number$.m4s">
+        <Custom a="1"><Inner>text</Inner><!-- note --></Custom>
+      </SegmentTemplate>
+      <Representation id="1">
+        <Label>HD</Label>
+      </Representation>
+    </AdaptationSet>
+  </Period>
+</MPD>`)), IsNil)
+
+	b, err := m.Encode()
+	c.Assert(err, IsNil)
+	c.Check(string(b), Equals, `<?xml version="1.0" encoding="utf-8"?>
+<MPD xmlns="urn:mpeg:dash:schema:mpd:2011" profiles="">
+  <Period>
+    <AdaptationSet mimeType="video/mp4">
+      <SegmentTemplate media="$Number$.m4s">
+        <Custom a="1">
+          <Inner>text</Inner><!-- note -->
+        </Custom>
+      </SegmentTemplate>
+      <Representation id="1">
+        <Label>HD</Label>
+      </Representation>
+    </AdaptationSet>
+  </Period>
+  <Custom/>
+</MPD>
+`)
+}
@@ -0,0 +1,109 @@
+package mpd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ExtractPeriod returns new static MPD containing only a copy of m's Period with given id, starting at zero.
+// MPD and Period BaseURLs are combined into MPD BaseURL, and inherited values are pushed down to Representations
+// with Denormalize, so the result doesn't depend on m. Period duration is taken from Period@duration,
+// next Period's start, or its segments.
+func ExtractPeriod(m *MPD, id string) (*MPD, error) {
+	i := -1
+	for n, p := range m.Periods {
+		if p.ID != nil && *p.ID == id {
+			i = n
+			break
+		}
+	}
+	if i < 0 {
+		return nil, fmt.Errorf("ExtractPeriod: no Period with id %q", id)
+	}
+	p := m.Periods[i]
+
+	base, err := effectiveBaseURL(relativeBase, m, p, new(AdaptationSet), new(Representation))
+	if err != nil {
+		return nil, fmt.Errorf("ExtractPeriod: %s: %w", periodPath(i), err)
+	}
+
+	var duration time.Duration
+	switch {
+	case p.Duration != nil:
+		if duration, err = ParseDuration(*p.Duration); err != nil {
+			return nil, fmt.Errorf("ExtractPeriod: %s@duration: %w", periodPath(i), err)
+		}
+	case i+1 < len(m.Periods) && p.Start != nil && m.Periods[i+1].Start != nil:
+		start, err := ParseDuration(*p.Start)
+		if err != nil {
+			return nil, fmt.Errorf("ExtractPeriod: %s@start: %w", periodPath(i), err)
+		}
+		next, err := ParseDuration(*m.Periods[i+1].Start)
+		if err != nil {
+			return nil, fmt.Errorf("ExtractPeriod: %s@start: %w", periodPath(i+1), err)
+		}
+		duration = next - start
+	default:
+		duration = p.segmentsDuration()
+	}
+
+	// copy MPD without other Periods
+	mm := *m
+	mm.Periods = []*Period{p}
+	res := mm.Clone()
+
+	res.Type = stringPtr("static")
+	res.MinimumUpdatePeriod, res.AvailabilityStartTime, res.PublishTime = nil, nil, nil
+	res.TimeShiftBufferDepth, res.SuggestedPresentationDelay = nil, nil
+	res.MediaPresentationDuration = stringPtr(FormatDuration(duration))
+	res.BaseURL = strings.TrimPrefix(base.String(), relativeBase)
+	var extensions []Extension
+	for _, e := range res.Extensions {
+		if e.XMLName.Local != "Location" && e.XMLName.Local != "PatchLocation" {
+			extensions = append(extensions, e)
+		}
+	}
+	res.Extensions = extensions
+
+	rp := res.Periods[0]
+	rp.Start = stringPtr("PT0S")
+	rp.Duration = stringPtr(FormatDuration(duration))
+	rp.BaseURL = ""
+	res.Denormalize()
+	return res, nil
+}
+
+// ExtractRepresentation returns new MPD containing only a copy of Representation with given id and its Period
+// and AdaptationSet, for isolating playback problems. Empty periodID matches any Period.
+// MPD attributes are kept as is, so dynamic MPD stays dynamic. Other AdaptationSets, EmptyAdaptationSets
+// and Preselections are dropped, and so are associations with other Representations. @dependencyId is kept.
+func ExtractRepresentation(m *MPD, periodID, representationID string) (*MPD, error) {
+	for _, p := range m.Periods {
+		if periodID != "" && (p.ID == nil || *p.ID != periodID) {
+			continue
+		}
+		for _, as := range p.AdaptationSets {
+			for k := range as.Representations {
+				r := &as.Representations[k]
+				if r.ID == nil || *r.ID != representationID {
+					continue
+				}
+
+				// copy only ancestors of r
+				pp, aa := *p, *as
+				aa.Representations = []Representation{*r}
+				pp.AdaptationSets = []*AdaptationSet{&aa}
+				pp.EmptyAdaptationSets, pp.Preselections = nil, nil
+				mm := *m
+				mm.Periods = []*Period{&pp}
+				res := mm.Clone()
+
+				rr := &res.Periods[0].AdaptationSets[0].Representations[0]
+				rr.AssociationID, rr.AssociationType = nil, nil
+				return res, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("ExtractRepresentation: no Representation with id %q", representationID)
+}
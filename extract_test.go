@@ -0,0 +1,90 @@
+package mpd
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestExtractPeriod(c *C) {
+	m := &MPD{
+		XMLNS:                 stringPtr(MPDNamespace),
+		Type:                  stringPtr("dynamic"),
+		AvailabilityStartTime: stringPtr("2016-01-01T00:00:00Z"),
+		MinimumUpdatePeriod:   stringPtr("PT2S"),
+		BaseURL:               "https://cdn.example.com/live/",
+		Periods: []*Period{
+			{ID: stringPtr("content"), Start: stringPtr("PT0S")},
+			{
+				ID: stringPtr("ad"), Start: stringPtr("PT30S"), BaseURL: "ads/",
+				SegmentTemplate: &SegmentTemplate{Timescale: uint64Ptr(1000), Media: stringPtr("$Number$.m4s")},
+				AdaptationSets: []*AdaptationSet{{
+					MimeType:        "video/mp4",
+					SegmentTemplate: &SegmentTemplate{Duration: uint32Ptr(2000)},
+					Representations: []Representation{{ID: stringPtr("v1")}},
+				}},
+			},
+			{ID: stringPtr("after"), Start: stringPtr("PT45S")},
+		},
+	}
+
+	res, err := ExtractPeriod(m, "ad")
+	c.Assert(err, IsNil)
+	c.Check(*res.Type, Equals, "static")
+	c.Check(res.AvailabilityStartTime, IsNil)
+	c.Check(res.MinimumUpdatePeriod, IsNil)
+	c.Check(*res.MediaPresentationDuration, Equals, "PT15S")
+	c.Check(res.BaseURL, Equals, "https://cdn.example.com/live/ads/")
+	c.Assert(res.Periods, HasLen, 1)
+	p := res.Periods[0]
+	c.Check(*p.ID, Equals, "ad")
+	c.Check(*p.Start, Equals, "PT0S")
+	c.Check(*p.Duration, Equals, "PT15S")
+	c.Check(p.BaseURL, Equals, "")
+	c.Check(p.SegmentTemplate, IsNil)
+	c.Check(p.AdaptationSets[0].SegmentTemplate, IsNil)
+	c.Check(p.AdaptationSets[0].Representations[0].SegmentTemplate, DeepEquals,
+		&SegmentTemplate{Timescale: uint64Ptr(1000), Media: stringPtr("$Number$.m4s"), Duration: uint32Ptr(2000)})
+	c.Check(res.Validate(), IsNil)
+
+	// m is intact
+	c.Check(m.Periods, HasLen, 3)
+	c.Check(*m.Periods[1].Start, Equals, "PT30S")
+	c.Check(m.Periods[1].AdaptationSets[0].SegmentTemplate, NotNil)
+
+	_, err = ExtractPeriod(m, "missing")
+	c.Check(err, ErrorMatches, `ExtractPeriod: no Period with id "missing"`)
+}
+
+func (s *MPDSuite) TestExtractRepresentation(c *C) {
+	m := &MPD{
+		Type: stringPtr("dynamic"),
+		Periods: []*Period{
+			{ID: stringPtr("1"), AdaptationSets: []*AdaptationSet{
+				{ID: uint64Ptr(1), MimeType: "video/mp4", Representations: []Representation{{ID: stringPtr("v1")}, {ID: stringPtr("v2")}}},
+			}},
+			{ID: stringPtr("2"), AdaptationSets: []*AdaptationSet{
+				{ID: uint64Ptr(1), MimeType: "video/mp4", Representations: []Representation{{ID: stringPtr("v1")}, {ID: stringPtr("v2")}}},
+				{ID: uint64Ptr(2), MimeType: "audio/mp4", Representations: []Representation{
+					{ID: stringPtr("a1"), AssociationID: stringPtr("v1"), AssociationType: stringPtr("cdsc")},
+				}},
+			}, Preselections: []Preselection{{PreselectionComponents: "1 2"}}},
+		},
+	}
+
+	res, err := ExtractRepresentation(m, "2", "v2")
+	c.Assert(err, IsNil)
+	c.Check(*res.Type, Equals, "dynamic")
+	c.Assert(res.Periods, HasLen, 1)
+	c.Check(*res.Periods[0].ID, Equals, "2")
+	c.Check(res.Periods[0].Preselections, IsNil)
+	c.Assert(res.Periods[0].AdaptationSets, HasLen, 1)
+	c.Check(res.Periods[0].AdaptationSets[0].Representations, DeepEquals, []Representation{{ID: stringPtr("v2")}})
+	c.Check(res.Validate(), IsNil)
+
+	res, err = ExtractRepresentation(m, "", "a1")
+	c.Assert(err, IsNil)
+	c.Check(res.Periods[0].AdaptationSets[0].Representations, DeepEquals, []Representation{{ID: stringPtr("a1")}})
+	c.Check(m.Periods[1].AdaptationSets[1].Representations[0].AssociationID, NotNil)
+
+	_, err = ExtractRepresentation(m, "1", "a1")
+	c.Check(err, ErrorMatches, `ExtractRepresentation: no Representation with id "a1"`)
+}
@@ -0,0 +1,152 @@
+package mpd
+
+import (
+	"fmt"
+	"time"
+)
+
+// TerminatePeriod ends the last, open Period of m at media time at (relative to Period start), as encoders do on
+// failover: it writes Period@duration, drops SegmentTimeline segments starting at or after at and shortens the
+// segment crossing it. Then it appends and returns new open Period with given id starting where terminated one ends.
+// AdaptationSets of new Period should be filled by the caller.
+func TerminatePeriod(m *MPD, at time.Duration, id string) (*Period, error) {
+	n := len(m.Periods)
+	if n == 0 {
+		return nil, fmt.Errorf("TerminatePeriod: no Periods")
+	}
+	p := m.Periods[n-1]
+	if p.Duration != nil {
+		return nil, fmt.Errorf("TerminatePeriod: %s is not open", periodPath(n-1))
+	}
+	if at <= 0 {
+		return nil, fmt.Errorf("TerminatePeriod: non-positive media time %s", FormatDuration(at))
+	}
+
+	var start time.Duration
+	switch {
+	case p.Start != nil:
+		var err error
+		if start, err = ParseDuration(*p.Start); err != nil {
+			return nil, fmt.Errorf("TerminatePeriod: %s@start: %w", periodPath(n-1), err)
+		}
+	case n > 1:
+		return nil, fmt.Errorf("TerminatePeriod: %s has no start", periodPath(n-1))
+	}
+
+	truncate := func(t *SegmentTemplate) {
+		if t == nil || len(t.SegmentTimeline) == 0 {
+			return
+		}
+		end := uint64(unscaleEventTime(at, int64(t.GetTimescale()))) + t.GetPresentationTimeOffset()
+		truncateTimeline(t, end)
+	}
+	truncate(p.SegmentTemplate)
+	for _, as := range p.AdaptationSets {
+		truncate(as.SegmentTemplate)
+		for k := range as.Representations {
+			truncate(as.Representations[k].SegmentTemplate)
+		}
+	}
+	p.Duration = stringPtr(FormatDuration(at))
+
+	next := &Period{
+		ID:    stringPtr(id),
+		Start: stringPtr(FormatDuration(start + at)),
+	}
+	m.Periods = append(m.Periods, next)
+	return next, nil
+}
+
+// truncateTimeline removes segments of t starting at or after end and shortens the segment crossing it.
+// end is in t's timescale units, including presentationTimeOffset.
+func truncateTimeline(t *SegmentTemplate, end uint64) {
+	var cur uint64
+	for i := range t.SegmentTimeline {
+		tl := &t.SegmentTimeline[i]
+		var res []SegmentTimelineSegment
+		for _, s := range tl.Segments {
+			if s.T != nil {
+				cur = *s.T
+			}
+			if cur >= end || s.D == 0 {
+				break
+			}
+
+			count := uint64(s.GetRepeat()) + 1
+			if s.GetRepeat() < 0 {
+				count = (end - cur + s.D - 1) / s.D
+			}
+			whole := (end - cur) / s.D
+			crossing := whole < count
+			if crossing {
+				count = whole
+			}
+			if count > 0 {
+				s.R = nil
+				if count > 1 {
+					r := int64(count - 1)
+					s.R = &r
+				}
+				res = append(res, s)
+				cur += count * s.D
+			}
+
+			if crossing && cur < end {
+				partial := SegmentTimelineSegment{D: end - cur}
+				if count == 0 {
+					partial.T = s.T
+				}
+				res = append(res, partial)
+				cur = end
+			}
+		}
+		tl.Segments = res
+	}
+}
+
+// validateOrphanSegments checks that SegmentTimelines of Periods with @duration have no segments
+// starting at or after the end of Period, e.g. left by TerminatePeriod with a wrong media time.
+func validateOrphanSegments(m *MPD) ValidationErrors {
+	var res ValidationErrors
+	for i, p := range m.Periods {
+		if p.Duration == nil {
+			continue
+		}
+		duration, err := ParseDuration(*p.Duration)
+		if err != nil {
+			continue
+		}
+
+		check := func(path string, t *SegmentTemplate) {
+			if t == nil || hasOpenRepeat(t) {
+				return
+			}
+			end := uint64(unscaleEventTime(duration, int64(t.GetTimescale()))) + t.GetPresentationTimeOffset()
+			var cur uint64
+			for _, tl := range t.SegmentTimeline {
+				for _, s := range tl.Segments {
+					if s.T != nil {
+						cur = *s.T
+					}
+					last := cur + uint64(s.GetRepeat())*s.D
+					if last >= end {
+						if cur < end {
+							cur += (end - cur + s.D - 1) / s.D * s.D
+						}
+						res = append(res, newValidationError(path, "segment at %d starts at or after Period end %d", cur, end))
+						return
+					}
+					cur = last + s.D
+				}
+			}
+		}
+		check(periodPath(i), p.SegmentTemplate)
+		for j, as := range p.AdaptationSets {
+			check(adaptationSetPath(i, j), as.SegmentTemplate)
+			for k := range as.Representations {
+				check(representationPath(i, j, k), as.Representations[k].SegmentTemplate)
+			}
+		}
+	}
+	return res
+}
@@ -0,0 +1,64 @@
+package mpd
+
+import (
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestTerminatePeriod(c *C) {
+	video := &SegmentTemplate{Timescale: uint64Ptr(1000), PresentationTimeOffset: uint64Ptr(10000), SegmentTimeline: []SegmentTimeline{{Segments: []SegmentTimelineSegment{
+		{T: uint64Ptr(10000), D: 2000, R: int64Ptr(9)},
+		{D: 1000},
+	}}}}
+	audio := &SegmentTemplate{Timescale: uint64Ptr(48000), SegmentTimeline: []SegmentTimeline{{Segments: []SegmentTimelineSegment{
+		{T: uint64Ptr(0), D: 96000, R: int64Ptr(-1)},
+	}}}}
+	m := &MPD{Periods: []*Period{{ID: stringPtr("p0"), Start: stringPtr("PT1M"), AdaptationSets: []*AdaptationSet{
+		{SegmentTemplate: video, Representations: []Representation{{ID: stringPtr("v")}}},
+		{Representations: []Representation{{ID: stringPtr("a"), SegmentTemplate: audio}}},
+	}}}}
+
+	next, err := TerminatePeriod(m, 7*time.Second, "p1")
+	c.Assert(err, IsNil)
+	c.Assert(m.Periods, HasLen, 2)
+	c.Check(m.Periods[1], Equals, next)
+	c.Check(*next.ID, Equals, "p1")
+	c.Check(*next.Start, Equals, "PT1M7S")
+	c.Check(next.Duration, IsNil)
+	c.Check(*m.Periods[0].Duration, Equals, "PT7S")
+
+	c.Check(video.SegmentTimeline[0].Segments, DeepEquals, []SegmentTimelineSegment{
+		{T: uint64Ptr(10000), D: 2000, R: int64Ptr(2)},
+		{D: 1000},
+	})
+	c.Check(audio.SegmentTimeline[0].Segments, DeepEquals, []SegmentTimelineSegment{
+		{T: uint64Ptr(0), D: 96000, R: int64Ptr(2)},
+		{D: 48000},
+	})
+	c.Check(m.Validate(), IsNil)
+
+	_, err = TerminatePeriod(m, 0, "p2")
+	c.Check(err, ErrorMatches, "TerminatePeriod: non-positive media time PT0S")
+	m.Periods[1].Duration = stringPtr("PT10S")
+	_, err = TerminatePeriod(m, time.Second, "p2")
+	c.Check(err, ErrorMatches, `TerminatePeriod: Periods\[1\] is not open`)
+}
+
+func (s *MPDSuite) TestValidateOrphanSegments(c *C) {
+	m := &MPD{Periods: []*Period{{Start: stringPtr("PT0S"), Duration: stringPtr("PT6S"), AdaptationSets: []*AdaptationSet{{
+		Representations: []Representation{{SegmentTemplate: &SegmentTemplate{Timescale: uint64Ptr(1000), SegmentTimeline: []SegmentTimeline{{
+			Segments: []SegmentTimelineSegment{{T: uint64Ptr(0), D: 2000, R: int64Ptr(2)}},
+		}}}}},
+	}}}}}
+	c.Check(validateOrphanSegments(m), HasLen, 0)
+
+	m.Periods[0].Duration = stringPtr("PT5S")
+	res := validateOrphanSegments(m)
+	c.Assert(res, HasLen, 0)
+
+	m.Periods[0].Duration = stringPtr("PT3S")
+	res = validateOrphanSegments(m)
+	c.Assert(res, HasLen, 1)
+	c.Check(res[0].Error(), Equals, "Periods[0].AdaptationSets[0].Representations[0]: segment at 4000 starts at or after Period end 3000")
+}
@@ -0,0 +1,161 @@
+package mpd
+
+import (
+	"fmt"
+	"time"
+)
+
+// Finalize computes and writes derivable attributes of m, so builders don't have to maintain them:
+//   - missing Period@start from previous Period's start and duration (first Period starts at zero);
+//   - missing Period@duration from next Period's start, or from segments for the last Period of static MPD;
+//   - mediaPresentationDuration of static MPD from the last Period;
+//   - maxSegmentDuration from SegmentTemplates;
+//   - publishTime of dynamic MPD.
+func (m *MPD) Finalize() error {
+	starts := make([]*time.Duration, len(m.Periods))
+	durations := make([]*time.Duration, len(m.Periods))
+	parse := func(i int, name string, s *string) (*time.Duration, error) {
+		if s == nil {
+			return nil, nil
+		}
+		d, err := ParseDuration(*s)
+		if err != nil {
+			return nil, fmt.Errorf("Finalize: %s@%s: %w", periodPath(i), name, err)
+		}
+		return &d, nil
+	}
+
+	for i, p := range m.Periods {
+		var err error
+		if starts[i], err = parse(i, "start", p.Start); err != nil {
+			return err
+		}
+		if durations[i], err = parse(i, "duration", p.Duration); err != nil {
+			return err
+		}
+	}
+
+	static := m.Type == nil || *m.Type != "dynamic"
+	for i, p := range m.Periods {
+		if starts[i] == nil {
+			var start time.Duration
+			if i > 0 {
+				if starts[i-1] == nil || durations[i-1] == nil {
+					continue
+				}
+				start = *starts[i-1] + *durations[i-1]
+			}
+			starts[i] = &start
+			p.Start = stringPtr(FormatDuration(start))
+		}
+
+		if durations[i] == nil {
+			var d time.Duration
+			switch {
+			case i+1 < len(m.Periods):
+				next, err := parse(i+1, "start", m.Periods[i+1].Start)
+				if err != nil || next == nil {
+					continue
+				}
+				d = *next - *starts[i]
+			case static:
+				d = p.segmentsDuration()
+				if d == 0 {
+					continue
+				}
+			default:
+				continue
+			}
+			durations[i] = &d
+			p.Duration = stringPtr(FormatDuration(d))
+		}
+	}
+
+	if n := len(m.Periods); static && n > 0 && starts[n-1] != nil && durations[n-1] != nil {
+		m.MediaPresentationDuration = stringPtr(FormatDuration(*starts[n-1] + *durations[n-1]))
+	}
+
+	var max time.Duration
+	for _, p := range m.Periods {
+		for _, as := range p.AdaptationSets {
+			for k := range as.Representations {
+				if d := maxSegmentDuration(EffectiveSegmentTemplate(p, as, &as.Representations[k])); d > max {
+					max = d
+				}
+			}
+		}
+	}
+	if max > 0 {
+		m.MaxSegmentDuration = stringPtr(FormatDuration(max))
+	}
+
+	if !static {
+		m.PublishTime = stringPtr(FormatDateTime(m.Clock().Now()))
+	}
+	return nil
+}
+
+// segmentsDuration returns the longest SegmentTimeline duration of p's Representations, or zero.
+func (p *Period) segmentsDuration() time.Duration {
+	var res time.Duration
+	for _, as := range p.AdaptationSets {
+		for k := range as.Representations {
+			if d := timelineDuration(EffectiveSegmentTemplate(p, as, &as.Representations[k])); d > res {
+				res = d
+			}
+		}
+	}
+	return res
+}
+
+// timelineDuration returns total duration of t's SegmentTimeline.
+// Segments repeated until the end of Period (@r is -1) are counted once.
+func timelineDuration(t *SegmentTemplate) time.Duration {
+	if t == nil {
+		return 0
+	}
+	var total uint64
+	for _, tl := range t.SegmentTimeline {
+		for _, s := range tl.Segments {
+			n := uint64(1)
+			if s.R != nil && *s.R > 0 {
+				n += uint64(*s.R)
+			}
+			total += s.D * n
+		}
+	}
+	return timescaled(total, t.Timescale)
+}
+
+// maxSegmentDuration returns the longest segment duration described by t.
+func maxSegmentDuration(t *SegmentTemplate) time.Duration {
+	if t == nil {
+		return 0
+	}
+	var max uint64
+	if t.Duration != nil {
+		max = uint64(*t.Duration)
+	}
+	for _, tl := range t.SegmentTimeline {
+		for _, s := range tl.Segments {
+			if s.D > max {
+				max = s.D
+			}
+		}
+	}
+	return timescaled(max, t.Timescale)
+}
+
+// timescaled converts v in timescale units to time.Duration. Timescale defaults to 1.
+func timescaled(v uint64, timescale *uint64) time.Duration {
+	ts := uint64(1)
+	if timescale != nil && *timescale > 0 {
+		ts = *timescale
+	}
+	return time.Duration(v/ts)*time.Second + time.Duration(v%ts)*time.Second/time.Duration(ts)
+}
+
+// stringPtr returns pointer to s.
+func stringPtr(s string) *string {
+	return &s
+}
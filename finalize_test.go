@@ -0,0 +1,43 @@
+package mpd
+
+import (
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestFinalize(c *C) {
+	m := &MPD{
+		Type: stringPtr("static"),
+		Periods: []*Period{
+			{Duration: stringPtr("PT30S")},
+			{ID: stringPtr("2")},
+			{Start: stringPtr("PT1M"), AdaptationSets: []*AdaptationSet{{
+				SegmentTemplate: &SegmentTemplate{Timescale: uint64Ptr(90000), SegmentTimeline: []SegmentTimeline{{
+					Segments: []SegmentTimelineSegment{{D: 360000, R: int64Ptr(2)}, {D: 450000}},
+				}}},
+				Representations: []Representation{{}, {SegmentTemplate: &SegmentTemplate{Duration: uint32Ptr(540000)}}},
+			}}},
+		},
+	}
+	c.Assert(m.Finalize(), IsNil)
+	c.Check(*m.Periods[0].Start, Equals, "PT0S")
+	c.Check(*m.Periods[1].Start, Equals, "PT30S")
+	c.Check(*m.Periods[1].Duration, Equals, "PT30S")
+	c.Check(*m.Periods[2].Duration, Equals, "PT17S")
+	c.Check(*m.MediaPresentationDuration, Equals, "PT1M17S")
+	c.Check(*m.MaxSegmentDuration, Equals, "PT6S")
+	c.Check(m.PublishTime, IsNil)
+
+	now = func() time.Time { return time.Date(2016, 1, 2, 3, 4, 5, 0, time.FixedZone("JST", 9*3600)) }
+	defer func() { now = time.Now }()
+	m = &MPD{Type: stringPtr("dynamic"), Periods: []*Period{{}}}
+	c.Assert(m.Finalize(), IsNil)
+	c.Check(*m.Periods[0].Start, Equals, "PT0S")
+	c.Check(m.Periods[0].Duration, IsNil)
+	c.Check(m.MediaPresentationDuration, IsNil)
+	c.Check(*m.PublishTime, Equals, "2016-01-01T18:04:05Z")
+
+	m = &MPD{Periods: []*Period{{Start: stringPtr("1 minute")}}}
+	c.Check(m.Finalize(), ErrorMatches, `Finalize: Periods\[0\]@start: ParseDuration: can't parse "1 minute"`)
+}
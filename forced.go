@@ -0,0 +1,39 @@
+package mpd
+
+// Role values of forced-narrative subtitles. MPEG-DASH defines "forced-subtitle",
+// but "forced_subtitle" spelling is also used by packagers and players.
+const (
+	RoleForcedSubtitle    = "forced-subtitle"
+	roleForcedSubtitleAlt = "forced_subtitle"
+)
+
+// MarkForcedSubtitle marks subtitle AdaptationSet as as forced-narrative subtitles.
+// "subtitle" Role is replaced, so clients don't select the set as regular subtitles.
+func MarkForcedSubtitle(as *AdaptationSet) {
+	res := as.Roles[:0]
+	for _, r := range as.Roles {
+		if !r.Is(RoleScheme) || r.Value == nil || (*r.Value != "subtitle" && !isForcedSubtitleRole(*r.Value)) {
+			res = append(res, r)
+		}
+	}
+	as.Roles = append(res, NewDescriptor(RoleScheme, RoleForcedSubtitle))
+}
+
+// IsForcedSubtitle returns true if as is marked as forced-narrative subtitles with any known spelling.
+func IsForcedSubtitle(as *AdaptationSet) bool {
+	for _, r := range as.Roles {
+		if r.Is(RoleScheme) && r.Value != nil && isForcedSubtitleRole(*r.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+// ForcedSubtitles returns forced-narrative subtitle AdaptationSets of p.
+func (p *Period) ForcedSubtitles() []*AdaptationSet {
+	return p.filterAdaptationSets(IsForcedSubtitle)
+}
+
+func isForcedSubtitleRole(v string) bool {
+	return v == RoleForcedSubtitle || v == roleForcedSubtitleAlt
+}
@@ -0,0 +1,23 @@
+package mpd
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestForcedSubtitles(c *C) {
+	as, err := NewSubtitleAdaptationSet(SubtitleOptions{Format: WebVTTSidecar, Lang: "en", URL: "forced.vtt"})
+	c.Assert(err, IsNil)
+	regular, err := NewSubtitleAdaptationSet(SubtitleOptions{Format: WebVTTSidecar, Lang: "en", URL: "full.vtt"})
+	c.Assert(err, IsNil)
+	legacy := &AdaptationSet{Roles: []Descriptor{NewDescriptor(RoleScheme, "forced_subtitle")}}
+	p := &Period{AdaptationSets: []*AdaptationSet{as, regular, legacy}}
+
+	c.Check(IsForcedSubtitle(as), Equals, false)
+	MarkForcedSubtitle(as)
+	c.Check(as.Roles, DeepEquals, []Descriptor{NewDescriptor(RoleScheme, RoleForcedSubtitle)})
+	c.Check(IsForcedSubtitle(as), Equals, true)
+	c.Check(p.ForcedSubtitles(), DeepEquals, []*AdaptationSet{as, legacy})
+
+	MarkForcedSubtitle(legacy)
+	c.Check(legacy.Roles, HasLen, 1)
+}
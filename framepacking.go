@@ -0,0 +1,61 @@
+package mpd
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// FramePacking descriptor schemes.
+const (
+	// value is ISO/IEC 23091-2 VideoFramePackingType
+	VideoFramePackingTypeScheme = "urn:mpeg:mpegB:cicp:VideoFramePackingType"
+	// value is frame_packing_arrangement_type of H.264 SEI message
+	FramePackingArrangementScheme = "urn:mpeg:dash:14496:10:frame_packing_arrangement_type:2011"
+)
+
+// Common VideoFramePackingType values for stereoscopic 3D video.
+const (
+	FramePackingSideBySide      = 3
+	FramePackingTopBottom       = 4
+	FramePackingFrameSequential = 5
+)
+
+// SetFramePacking replaces FramePacking descriptors of as with VideoFramePackingType one.
+func (as *AdaptationSet) SetFramePacking(packingType int) {
+	as.FramePackings = []Descriptor{NewDescriptor(VideoFramePackingTypeScheme, strconv.Itoa(packingType))}
+}
+
+// FramePacking returns VideoFramePackingType (or H.264 frame_packing_arrangement_type) of as,
+// or nil if there is no FramePacking descriptor.
+func (as *AdaptationSet) FramePacking() (*int, error) {
+	return framePacking(as.FramePackings)
+}
+
+// SetFramePacking replaces FramePacking descriptors of r with VideoFramePackingType one.
+func (r *Representation) SetFramePacking(packingType int) {
+	r.FramePackings = []Descriptor{NewDescriptor(VideoFramePackingTypeScheme, strconv.Itoa(packingType))}
+}
+
+// FramePacking returns VideoFramePackingType (or H.264 frame_packing_arrangement_type) of r,
+// or nil if there is no FramePacking descriptor.
+func (r *Representation) FramePacking() (*int, error) {
+	return framePacking(r.FramePackings)
+}
+
+func framePacking(list []Descriptor) (*int, error) {
+	d := findDescriptor(list, VideoFramePackingTypeScheme)
+	if d == nil {
+		d = findDescriptor(list, FramePackingArrangementScheme)
+	}
+	if d == nil {
+		return nil, nil
+	}
+	if d.Value == nil {
+		return nil, fmt.Errorf("FramePacking: %s descriptor without value", *d.SchemeIDURI)
+	}
+	v, err := strconv.Atoi(*d.Value)
+	if err != nil {
+		return nil, fmt.Errorf("FramePacking: invalid %s value %q", *d.SchemeIDURI, *d.Value)
+	}
+	return &v, nil
+}
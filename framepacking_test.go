@@ -0,0 +1,37 @@
+package mpd
+
+import (
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestFramePacking(c *C) {
+	as := new(AdaptationSet)
+	fp, err := as.FramePacking()
+	c.Check(err, IsNil)
+	c.Check(fp, IsNil)
+
+	as.SetFramePacking(FramePackingTopBottom)
+	fp, err = as.FramePacking()
+	c.Assert(err, IsNil)
+	c.Check(*fp, Equals, FramePackingTopBottom)
+
+	r := &Representation{RepresentationBase: RepresentationBase{FramePackings: []Descriptor{NewDescriptor(FramePackingArrangementScheme, "3")}}}
+	fp, err = r.FramePacking()
+	c.Assert(err, IsNil)
+	c.Check(*fp, Equals, FramePackingSideBySide)
+
+	r.SetFramePacking(FramePackingSideBySide)
+	r.FramePackings[0].Value = nil
+	_, err = r.FramePacking()
+	c.Check(err, ErrorMatches, "FramePacking: urn:mpeg:mpegB:cicp:VideoFramePackingType descriptor without value")
+
+	m := &MPD{Periods: []*Period{{AdaptationSets: []*AdaptationSet{as}}}}
+	as.Representations = []Representation{{}}
+	b, err := m.Encode()
+	c.Assert(err, IsNil)
+	c.Check(strings.Contains(string(b), `<AdaptationSet mimeType="">
+      <FramePacking schemeIdUri="urn:mpeg:mpegB:cicp:VideoFramePackingType" value="4"/>
+      <Representation/>`), Equals, true, Commentf("%s", b))
+}
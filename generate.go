@@ -0,0 +1,335 @@
+package mpd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jun-oku/mpd/codecs"
+)
+
+// SegmentedRepresentation describes a single Representation produced by packaging step
+// as a list of segment durations and sizes. It is an input of GenerateStatic.
+type SegmentedRepresentation struct {
+	ID                string
+	MimeType          string
+	Codecs            string
+	Lang              string // optional, sets with different languages are separated
+	Width             uint64 // optional
+	Height            uint64 // optional
+	FrameRate         string // optional
+	AudioSamplingRate string // optional
+
+	// Initialization and Media are SegmentTemplate URLs, e.g. "video/init.mp4" and "video/$Number$.m4s".
+	Initialization string
+	Media          string
+
+	// Timescale is a number of Durations units per second.
+	Timescale uint64
+	// Durations lists segment durations in Timescale units.
+	Durations []uint64
+	// Sizes lists segment sizes in bytes, used to compute bandwidth.
+	Sizes []uint64
+	// Bandwidth overrides computed value if not zero.
+	Bandwidth uint64
+}
+
+// GenerateStatic returns complete static MPD with given profiles describing reps with SegmentTimeline.
+// Representations with the same mime type and language are grouped into one AdaptationSet.
+// Bandwidth of each Representation is computed as the peak bitrate of its segments.
+func GenerateStatic(profiles string, reps []SegmentedRepresentation) (*MPD, error) {
+	if len(reps) == 0 {
+		return nil, fmt.Errorf("GenerateStatic: no representations")
+	}
+
+	m := NewStaticMPD(profiles, 0)
+	p := m.Periods[0]
+	sets := make(map[string]*AdaptationSet)
+	for _, sr := range reps {
+		r, err := sr.representation()
+		if err != nil {
+			return nil, fmt.Errorf("GenerateStatic: representation %q: %w", sr.ID, err)
+		}
+
+		key := sr.MimeType + "\x00" + sr.Lang
+		as := sets[key]
+		if as == nil {
+			id := uint64(len(p.AdaptationSets))
+			as = &AdaptationSet{ID: &id, MimeType: sr.MimeType, SegmentAlignment: ConditionalUint{b: boolPtr(true)}, StartWithSAP: uint64Ptr(1)}
+			if sr.Lang != "" {
+				as.Lang = stringPtr(sr.Lang)
+			}
+			as.SetContentType()
+			sets[key] = as
+			p.AdaptationSets = append(p.AdaptationSets, as)
+		}
+		as.Representations = append(as.Representations, *r)
+	}
+
+	if err := m.Finalize(); err != nil {
+		return nil, fmt.Errorf("GenerateStatic: %w", err)
+	}
+	return m, nil
+}
+
+// Track describes media metadata of a single encoded track. It is an input of Generate.
+type Track struct {
+	ID    string
+	Codec string
+	// Type is inferred from Codec if not set.
+	Type    codecs.Type
+	Bitrate uint64
+	// Language is optional, tracks with different languages are put into separate AdaptationSets.
+	Language string
+	// Roles lists Role values, e.g. "main" or "commentary". Optional.
+	Roles []string
+
+	// video
+	Width     uint64
+	Height    uint64
+	FrameRate string
+
+	// audio
+	SampleRate uint64
+	Channels   int
+}
+
+// SegmentNaming describes segment URL templates. Identifiers like $RepresentationID$ and $Number$ are allowed.
+type SegmentNaming struct {
+	Initialization string // defaults to "$RepresentationID$/init.mp4"
+	Media          string // defaults to "$RepresentationID$/$Number$.m4s"
+}
+
+// Presentation describes a VOD presentation with fixed-duration segments. It is an input of Generate.
+type Presentation struct {
+	Profiles        string // defaults to ProfileISOFFLive
+	Duration        time.Duration
+	SegmentDuration time.Duration
+	Naming          SegmentNaming
+	Tracks          []Track
+}
+
+// Generate returns complete static MPD for p using number-based SegmentTemplates.
+// Tracks with the same type, codec family, language and roles are grouped into one AdaptationSet.
+func Generate(p Presentation) (*MPD, error) {
+	if len(p.Tracks) == 0 {
+		return nil, fmt.Errorf("Generate: no tracks")
+	}
+	if p.Duration <= 0 || p.SegmentDuration <= 0 {
+		return nil, fmt.Errorf("Generate: duration and segment duration should be positive")
+	}
+	if p.Profiles == "" {
+		p.Profiles = ProfileISOFFLive
+	}
+	if p.Naming.Initialization == "" {
+		p.Naming.Initialization = "$RepresentationID$/init.mp4"
+	}
+	if p.Naming.Media == "" {
+		p.Naming.Media = "$RepresentationID$/$Number$.m4s"
+	}
+
+	m := NewStaticMPD(p.Profiles, p.Duration)
+	period := m.Periods[0]
+	period.Duration = stringPtr(FormatDuration(p.Duration))
+	m.MaxSegmentDuration = stringPtr(FormatDuration(p.SegmentDuration))
+
+	g := newTrackGrouper(period)
+	for _, t := range p.Tracks {
+		as, r, err := g.add(t)
+		if err != nil {
+			return nil, fmt.Errorf("Generate: %w", err)
+		}
+		if as.SegmentTemplate == nil {
+			as.SegmentAlignment = ConditionalUint{b: boolPtr(true)}
+			as.StartWithSAP = uint64Ptr(1)
+			as.SegmentTemplate = &SegmentTemplate{
+				Timescale:      uint64Ptr(1000),
+				Duration:       uint32Ptr(uint32(p.SegmentDuration / time.Millisecond)),
+				Media:          stringPtr(p.Naming.Media),
+				Initialization: stringPtr(p.Naming.Initialization),
+				StartNumber:    uint64Ptr(1),
+			}
+		}
+		as.Representations = append(as.Representations, *r)
+	}
+	return m, nil
+}
+
+// trackGrouper puts Tracks into AdaptationSets of a Period.
+type trackGrouper struct {
+	period *Period
+	sets   map[string]*AdaptationSet
+}
+
+func newTrackGrouper(p *Period) *trackGrouper {
+	return &trackGrouper{period: p, sets: make(map[string]*AdaptationSet)}
+}
+
+// add returns AdaptationSet for t, creating it if needed, and a new Representation for t,
+// which is not added to the set yet.
+// Tracks with the same type, codec family, language and roles share AdaptationSet.
+func (g *trackGrouper) add(t Track) (*AdaptationSet, *Representation, error) {
+	if t.ID == "" {
+		return nil, nil, fmt.Errorf("track with codec %q has empty id", t.Codec)
+	}
+	c, err := codecs.Parse(t.Codec)
+	if err != nil {
+		return nil, nil, fmt.Errorf("track %q: %w", t.ID, err)
+	}
+	if t.Type == codecs.Unknown {
+		t.Type = c.Type
+	}
+
+	var mimeType string
+	switch t.Type {
+	case codecs.Video:
+		mimeType = MimeTypeVideoMP4
+	case codecs.Audio:
+		mimeType = MimeTypeAudioMP4
+	case codecs.Text:
+		mimeType = MimeTypeApplicationMP4
+	default:
+		return nil, nil, fmt.Errorf("track %q: unsupported track type %s", t.ID, t.Type)
+	}
+
+	key := fmt.Sprintf("%s\x00%s\x00%s\x00%q", t.Type, c.Family, t.Language, t.Roles)
+	as := g.sets[key]
+	if as == nil {
+		id := uint64(len(g.period.AdaptationSets))
+		as = &AdaptationSet{
+			ID:          &id,
+			MimeType:    mimeType,
+			ContentType: stringPtr(t.Type.String()),
+		}
+		if t.Language != "" {
+			as.Lang = stringPtr(t.Language)
+		}
+		for _, role := range t.Roles {
+			as.Roles = append(as.Roles, NewDescriptor(RoleScheme, role))
+		}
+		g.sets[key] = as
+		g.period.AdaptationSets = append(g.period.AdaptationSets, as)
+	}
+
+	r := &Representation{
+		ID:        stringPtr(t.ID),
+		Bandwidth: uint64Ptr(t.Bitrate),
+		Codecs:    stringPtr(t.Codec),
+	}
+	if t.Width != 0 {
+		r.Width = uint64Ptr(t.Width)
+	}
+	if t.Height != 0 {
+		r.Height = uint64Ptr(t.Height)
+	}
+	if t.FrameRate != "" {
+		r.FrameRate = stringPtr(t.FrameRate)
+	}
+	if t.SampleRate != 0 {
+		r.AudioSamplingRate = stringPtr(strconv.FormatUint(t.SampleRate, 10))
+	}
+	if t.Channels != 0 {
+		r.AudioChannelConfigurations = []AudioChannelConfiguration{NewChannelCountConfiguration(t.Channels)}
+	}
+	return as, r, nil
+}
+
+// representation returns Representation with SegmentTimeline described by sr.
+func (sr *SegmentedRepresentation) representation() (*Representation, error) {
+	if sr.ID == "" {
+		return nil, fmt.Errorf("empty id")
+	}
+	if sr.Timescale == 0 {
+		return nil, fmt.Errorf("zero timescale")
+	}
+	if len(sr.Durations) == 0 {
+		return nil, fmt.Errorf("no segments")
+	}
+	if sr.Bandwidth == 0 && len(sr.Sizes) != len(sr.Durations) {
+		return nil, fmt.Errorf("got %d sizes for %d segments", len(sr.Sizes), len(sr.Durations))
+	}
+
+	bandwidth := sr.Bandwidth
+	if bandwidth == 0 {
+		for i, d := range sr.Durations {
+			if d == 0 {
+				return nil, fmt.Errorf("segment %d has zero duration", i)
+			}
+			// round up so declared bandwidth is never below actual
+			if b := (sr.Sizes[i]*8*sr.Timescale + d - 1) / d; b > bandwidth {
+				bandwidth = b
+			}
+		}
+	}
+
+	r := &Representation{
+		ID:        stringPtr(sr.ID),
+		Bandwidth: &bandwidth,
+		Codecs:    stringPtr(sr.Codecs),
+		SegmentTemplate: &SegmentTemplate{
+			Timescale:       uint64Ptr(sr.Timescale),
+			Media:           stringPtr(sr.Media),
+			Initialization:  stringPtr(sr.Initialization),
+			StartNumber:     uint64Ptr(1),
+			SegmentTimeline: []SegmentTimeline{{Segments: compressTimeline(sr.Durations)}},
+		},
+	}
+	if sr.Width != 0 {
+		r.Width = uint64Ptr(sr.Width)
+	}
+	if sr.Height != 0 {
+		r.Height = uint64Ptr(sr.Height)
+	}
+	if sr.FrameRate != "" {
+		r.FrameRate = stringPtr(sr.FrameRate)
+	}
+	if sr.AudioSamplingRate != "" {
+		r.AudioSamplingRate = stringPtr(sr.AudioSamplingRate)
+	}
+	return r, nil
+}
+
+// compressTimeline returns S elements for segment durations, using @r for runs of equal durations.
+func compressTimeline(durations []uint64) []SegmentTimelineSegment {
+	var res []SegmentTimelineSegment
+	for i, d := range durations {
+		if n := len(res); n > 0 && res[n-1].D == d {
+			if res[n-1].R == nil {
+				res[n-1].R = new(int64)
+			}
+			*res[n-1].R++
+			continue
+		}
+		s := SegmentTimelineSegment{D: d}
+		if i == 0 {
+			s.T = uint64Ptr(0)
+		}
+		res = append(res, s)
+	}
+	return res
+}
+
+// uint64Ptr returns pointer to v.
+func uint64Ptr(v uint64) *uint64 {
+	return &v
+}
+
+// uint32Ptr returns pointer to v.
+func uint32Ptr(v uint32) *uint32 {
+	return &v
+}
+
+// boolPtr returns pointer to v.
+func boolPtr(v bool) *bool {
+	return &v
+}
+
+// int64Ptr returns pointer to v.
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+// float64Ptr returns pointer to v.
+func float64Ptr(v float64) *float64 {
+	return &v
+}
@@ -0,0 +1,100 @@
+package mpd
+
+import (
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestGenerateStatic(c *C) {
+	m, err := GenerateStatic(ProfileISOFFLive, []SegmentedRepresentation{
+		{
+			ID: "v1", MimeType: MimeTypeVideoMP4, Codecs: "avc1.64001f", Width: 1280, Height: 720, FrameRate: "25",
+			Initialization: "v1/init.mp4", Media: "v1/$Number$.m4s",
+			Timescale: 1000, Durations: []uint64{4000, 4000, 4000, 2000}, Sizes: []uint64{1000000, 1500000, 1000000, 250000},
+		},
+		{
+			ID: "a1", MimeType: MimeTypeAudioMP4, Codecs: "mp4a.40.2", Lang: "en", AudioSamplingRate: "48000",
+			Initialization: "a1/init.mp4", Media: "a1/$Number$.m4s",
+			Timescale: 48000, Durations: []uint64{192512, 191488, 192512, 96000}, Bandwidth: 128000,
+		},
+	})
+	c.Assert(err, IsNil)
+	b, err := m.Encode()
+	c.Assert(err, IsNil)
+	c.Check(string(b), Equals, `<?xml version="1.0" encoding="utf-8"?>
+<MPD xmlns="urn:mpeg:dash:schema:mpd:2011" type="static" mediaPresentationDuration="PT14.011S" minBufferTime="PT2S" maxSegmentDuration="PT4.011S" profiles="urn:mpeg:dash:profile:isoff-live:2011">
+  <Period start="PT0S" id="0" duration="PT14.011S">
+    <AdaptationSet id="0" mimeType="video/mp4" segmentAlignment="true" startWithSAP="1" contentType="video">
+      <Representation id="v1" width="1280" height="720" frameRate="25" bandwidth="3000000" codecs="avc1.64001f">
+        <SegmentTemplate timescale="1000" media="v1/$Number$.m4s" initialization="v1/init.mp4" startNumber="1">
+          <SegmentTimeline>
+            <S t="0" d="4000" r="2"/>
+            <S d="2000"/>
+          </SegmentTimeline>
+        </SegmentTemplate>
+      </Representation>
+    </AdaptationSet>
+    <AdaptationSet id="1" mimeType="audio/mp4" segmentAlignment="true" startWithSAP="1" lang="en" contentType="audio">
+      <Representation id="a1" bandwidth="128000" audioSamplingRate="48000" codecs="mp4a.40.2">
+        <SegmentTemplate timescale="48000" media="a1/$Number$.m4s" initialization="a1/init.mp4" startNumber="1">
+          <SegmentTimeline>
+            <S t="0" d="192512"/>
+            <S d="191488"/>
+            <S d="192512"/>
+            <S d="96000"/>
+          </SegmentTimeline>
+        </SegmentTemplate>
+      </Representation>
+    </AdaptationSet>
+  </Period>
+</MPD>
+`)
+
+	_, err = GenerateStatic(ProfileISOFFLive, []SegmentedRepresentation{{ID: "v1", Timescale: 1, Durations: []uint64{1}}})
+	c.Check(err, ErrorMatches, `GenerateStatic: representation "v1": got 0 sizes for 1 segments`)
+	_, err = GenerateStatic(ProfileISOFFLive, nil)
+	c.Check(err, ErrorMatches, `GenerateStatic: no representations`)
+}
+
+func (s *MPDSuite) TestGenerate(c *C) {
+	m, err := Generate(Presentation{
+		Duration:        time.Minute,
+		SegmentDuration: 4 * time.Second,
+		Tracks: []Track{
+			{ID: "v720", Codec: "avc1.64001f", Bitrate: 3000000, Width: 1280, Height: 720, FrameRate: "25"},
+			{ID: "v360", Codec: "avc1.64001e", Bitrate: 800000, Width: 640, Height: 360, FrameRate: "25"},
+			{ID: "a-en", Codec: "mp4a.40.2", Bitrate: 128000, Language: "en", SampleRate: 48000, Channels: 2},
+			{ID: "s-en", Codec: "stpp.ttml.im1t", Bitrate: 2000, Language: "en", Roles: []string{"subtitle"}},
+		},
+	})
+	c.Assert(err, IsNil)
+	b, err := m.Encode()
+	c.Assert(err, IsNil)
+	c.Check(string(b), Equals, `<?xml version="1.0" encoding="utf-8"?>
+<MPD xmlns="urn:mpeg:dash:schema:mpd:2011" type="static" mediaPresentationDuration="PT1M" minBufferTime="PT2S" maxSegmentDuration="PT4S" profiles="urn:mpeg:dash:profile:isoff-live:2011">
+  <Period start="PT0S" id="0" duration="PT1M">
+    <AdaptationSet id="0" mimeType="video/mp4" segmentAlignment="true" startWithSAP="1" contentType="video">
+      <SegmentTemplate timescale="1000" media="$RepresentationID$/$Number$.m4s" initialization="$RepresentationID$/init.mp4" startNumber="1" duration="4000"/>
+      <Representation id="v720" width="1280" height="720" frameRate="25" bandwidth="3000000" codecs="avc1.64001f"/>
+      <Representation id="v360" width="640" height="360" frameRate="25" bandwidth="800000" codecs="avc1.64001e"/>
+    </AdaptationSet>
+    <AdaptationSet id="1" mimeType="audio/mp4" segmentAlignment="true" startWithSAP="1" lang="en" contentType="audio">
+      <SegmentTemplate timescale="1000" media="$RepresentationID$/$Number$.m4s" initialization="$RepresentationID$/init.mp4" startNumber="1" duration="4000"/>
+      <Representation id="a-en" bandwidth="128000" audioSamplingRate="48000" codecs="mp4a.40.2">
+        <AudioChannelConfiguration schemeIdUri="urn:mpeg:dash:23003:3:audio_channel_configuration:2011" value="2"/>
+      </Representation>
+    </AdaptationSet>
+    <AdaptationSet id="2" mimeType="application/mp4" segmentAlignment="true" startWithSAP="1" lang="en" contentType="text">
+      <Role schemeIdUri="urn:mpeg:dash:role:2011" value="subtitle"/>
+      <SegmentTemplate timescale="1000" media="$RepresentationID$/$Number$.m4s" initialization="$RepresentationID$/init.mp4" startNumber="1" duration="4000"/>
+      <Representation id="s-en" bandwidth="2000" codecs="stpp.ttml.im1t"/>
+    </AdaptationSet>
+  </Period>
+</MPD>
+`)
+	c.Check(m.Validate(), IsNil)
+
+	_, err = Generate(Presentation{Duration: time.Minute, SegmentDuration: time.Second, Tracks: []Track{{ID: "x", Codec: "xxxx"}}})
+	c.Check(err, ErrorMatches, `Generate: track "x": unsupported track type .*`)
+}
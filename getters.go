@@ -0,0 +1,98 @@
+package mpd
+
+// Accessors below return attribute values with schema defaults applied when attributes are absent.
+
+// GetTimescale returns @timescale, which defaults to 1. Zero is treated as absent.
+func (t *SegmentTemplate) GetTimescale() uint64 {
+	return timescaleOrDefault(t.Timescale)
+}
+
+// GetStartNumber returns @startNumber, which defaults to 1.
+func (t *SegmentTemplate) GetStartNumber() uint64 {
+	if t.StartNumber == nil {
+		return 1
+	}
+	return *t.StartNumber
+}
+
+// GetPresentationTimeOffset returns @presentationTimeOffset, which defaults to 0.
+func (t *SegmentTemplate) GetPresentationTimeOffset() uint64 {
+	if t.PresentationTimeOffset == nil {
+		return 0
+	}
+	return *t.PresentationTimeOffset
+}
+
+// GetTimescale returns @timescale, which defaults to 1. Zero is treated as absent.
+func (l *SegmentList) GetTimescale() uint64 {
+	return timescaleOrDefault(l.Timescale)
+}
+
+// GetStartNumber returns @startNumber, which defaults to 1.
+func (l *SegmentList) GetStartNumber() uint64 {
+	if l.StartNumber == nil {
+		return 1
+	}
+	return *l.StartNumber
+}
+
+// GetPresentationTimeOffset returns @presentationTimeOffset, which defaults to 0.
+func (l *SegmentList) GetPresentationTimeOffset() uint64 {
+	if l.PresentationTimeOffset == nil {
+		return 0
+	}
+	return *l.PresentationTimeOffset
+}
+
+// GetTimescale returns @timescale, which defaults to 1. Zero is treated as absent.
+func (b *SegmentBase) GetTimescale() uint64 {
+	return timescaleOrDefault(b.Timescale)
+}
+
+// GetPresentationTimeOffset returns @presentationTimeOffset, which defaults to 0.
+func (b *SegmentBase) GetPresentationTimeOffset() uint64 {
+	if b.PresentationTimeOffset == nil {
+		return 0
+	}
+	return *b.PresentationTimeOffset
+}
+
+// GetRepeat returns @r, which defaults to 0. Negative value means repeating until the next S element
+// or the end of Period.
+func (s *SegmentTimelineSegment) GetRepeat() int64 {
+	if s.R == nil {
+		return 0
+	}
+	return *s.R
+}
+
+// GetSelectionPriority returns @selectionPriority, which defaults to 1.
+func (as *AdaptationSet) GetSelectionPriority() uint64 {
+	if as.SelectionPriority == nil {
+		return 1
+	}
+	return *as.SelectionPriority
+}
+
+// GetSelectionPriority returns @selectionPriority, which defaults to 1.
+func (ps *Preselection) GetSelectionPriority() uint64 {
+	if ps.SelectionPriority == nil {
+		return 1
+	}
+	return *ps.SelectionPriority
+}
+
+// GetBandwidth returns @bandwidth, or zero if it is absent.
+func (r *Representation) GetBandwidth() uint64 {
+	if r.Bandwidth == nil {
+		return 0
+	}
+	return *r.Bandwidth
+}
+
+func timescaleOrDefault(v *uint64) uint64 {
+	if v == nil || *v == 0 {
+		return 1
+	}
+	return *v
+}
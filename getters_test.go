@@ -0,0 +1,33 @@
+package mpd
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestGetters(c *C) {
+	t := new(SegmentTemplate)
+	c.Check(t.GetTimescale(), Equals, uint64(1))
+	c.Check(t.GetStartNumber(), Equals, uint64(1))
+	c.Check(t.GetPresentationTimeOffset(), Equals, uint64(0))
+	t = &SegmentTemplate{Timescale: uint64Ptr(90000), StartNumber: uint64Ptr(0), PresentationTimeOffset: uint64Ptr(10)}
+	c.Check(t.GetTimescale(), Equals, uint64(90000))
+	c.Check(t.GetStartNumber(), Equals, uint64(0))
+	c.Check(t.GetPresentationTimeOffset(), Equals, uint64(10))
+	t.Timescale = uint64Ptr(0)
+	c.Check(t.GetTimescale(), Equals, uint64(1))
+
+	l := &SegmentList{Timescale: uint64Ptr(1000)}
+	c.Check(l.GetTimescale(), Equals, uint64(1000))
+	c.Check(l.GetStartNumber(), Equals, uint64(1))
+	b := new(SegmentBase)
+	c.Check(b.GetTimescale(), Equals, uint64(1))
+	c.Check(b.GetPresentationTimeOffset(), Equals, uint64(0))
+
+	seg := SegmentTimelineSegment{D: 2000}
+	c.Check(seg.GetRepeat(), Equals, int64(0))
+	seg.R = int64Ptr(-1)
+	c.Check(seg.GetRepeat(), Equals, int64(-1))
+
+	c.Check(new(AdaptationSet).GetSelectionPriority(), Equals, uint64(1))
+	c.Check((&Preselection{SelectionPriority: uint64Ptr(5)}).GetSelectionPriority(), Equals, uint64(5))
+}
@@ -0,0 +1,107 @@
+package mpd
+
+import (
+	"fmt"
+)
+
+// CICP colour descriptor schemes (ISO/IEC 23001-8).
+const (
+	ColourPrimariesScheme         = "urn:mpeg:mpegB:cicp:ColourPrimaries"
+	TransferCharacteristicsScheme = "urn:mpeg:mpegB:cicp:TransferCharacteristics"
+	MatrixCoefficientsScheme      = "urn:mpeg:mpegB:cicp:MatrixCoefficients"
+)
+
+// ColourInfo describes colour signaling with ISO/IEC 23091-2 code points. Zero value of a field means absent.
+type ColourInfo struct {
+	ColourPrimaries         ColourPrimaries
+	TransferCharacteristics TransferCharacteristics
+	MatrixCoefficients      MatrixCoefficients
+}
+
+// Common colour signaling combinations.
+var (
+	SDR   = ColourInfo{ColourPrimaries: 1, TransferCharacteristics: 1, MatrixCoefficients: 1}
+	HDR10 = ColourInfo{ColourPrimaries: 9, TransferCharacteristics: 16, MatrixCoefficients: 9}
+	HLG   = ColourInfo{ColourPrimaries: 9, TransferCharacteristics: 18, MatrixCoefficients: 9}
+)
+
+// SetColourInfo replaces CICP colour descriptors of as with ci.
+func (as *AdaptationSet) SetColourInfo(ci ColourInfo) {
+	setColourInfo(&as.EssentialProperties, &as.SupplementalProperties, ci)
+}
+
+// ColourInfo returns CICP colour signaling of as, or nil if there is none.
+func (as *AdaptationSet) ColourInfo() (*ColourInfo, error) {
+	return colourInfo(as.EssentialProperties, as.SupplementalProperties)
+}
+
+// SetColourInfo replaces CICP colour descriptors of r with ci.
+func (r *Representation) SetColourInfo(ci ColourInfo) {
+	setColourInfo(&r.EssentialProperties, &r.SupplementalProperties, ci)
+}
+
+// ColourInfo returns CICP colour signaling of r, or nil if there is none.
+func (r *Representation) ColourInfo() (*ColourInfo, error) {
+	return colourInfo(r.EssentialProperties, r.SupplementalProperties)
+}
+
+// setColourInfo writes ci as EssentialProperty descriptors. HLG transfer characteristics is written
+// as SupplementalProperty with BT.2020 EssentialProperty fallback, so legacy clients still can play it.
+func setColourInfo(essential, supplemental *[]Descriptor, ci ColourInfo) {
+	for _, scheme := range []string{ColourPrimariesScheme, TransferCharacteristicsScheme, MatrixCoefficientsScheme} {
+		removeDescriptors(essential, scheme)
+		removeDescriptors(supplemental, scheme)
+	}
+
+	if ci.ColourPrimaries != 0 {
+		*essential = append(*essential, NewCICPDescriptor(ci.ColourPrimaries))
+	}
+	switch ci.TransferCharacteristics {
+	case 0:
+	case TransferHLG:
+		// HLG is signaled as backward compatible with BT.2020
+		*essential = append(*essential, NewCICPDescriptor(TransferBT2020))
+		*supplemental = append(*supplemental, NewCICPDescriptor(TransferHLG))
+	default:
+		*essential = append(*essential, NewCICPDescriptor(ci.TransferCharacteristics))
+	}
+	if ci.MatrixCoefficients != 0 {
+		*essential = append(*essential, NewCICPDescriptor(ci.MatrixCoefficients))
+	}
+}
+
+// colourInfo reads CICP colour descriptors. SupplementalProperty takes precedence over EssentialProperty,
+// as it carries the actual value when EssentialProperty carries backward compatible one.
+func colourInfo(essential, supplemental []Descriptor) (*ColourInfo, error) {
+	var res ColourInfo
+	var found bool
+	for _, f := range []struct {
+		scheme string
+		set    func(v int)
+	}{
+		{ColourPrimariesScheme, func(v int) { res.ColourPrimaries = ColourPrimaries(v) }},
+		{TransferCharacteristicsScheme, func(v int) { res.TransferCharacteristics = TransferCharacteristics(v) }},
+		{MatrixCoefficientsScheme, func(v int) { res.MatrixCoefficients = MatrixCoefficients(v) }},
+	} {
+		d := findDescriptor(supplemental, f.scheme)
+		if d == nil {
+			d = findDescriptor(essential, f.scheme)
+		}
+		if d == nil {
+			continue
+		}
+		if d.Value == nil {
+			return nil, fmt.Errorf("ColourInfo: %s descriptor without value", f.scheme)
+		}
+		v, err := parseCICPCode(*d.Value)
+		if err != nil {
+			return nil, fmt.Errorf("ColourInfo: invalid %s value %q", f.scheme, *d.Value)
+		}
+		f.set(v)
+		found = true
+	}
+	if !found {
+		return nil, nil
+	}
+	return &res, nil
+}
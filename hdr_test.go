@@ -0,0 +1,34 @@
+package mpd
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestColourInfo(c *C) {
+	as := new(AdaptationSet)
+	ci, err := as.ColourInfo()
+	c.Check(err, IsNil)
+	c.Check(ci, IsNil)
+
+	as.SetColourInfo(HDR10)
+	c.Check(as.EssentialProperties, HasLen, 3)
+	c.Check(as.SupplementalProperties, HasLen, 0)
+	ci, err = as.ColourInfo()
+	c.Assert(err, IsNil)
+	c.Check(*ci, Equals, HDR10)
+
+	as.SetColourInfo(HLG)
+	c.Check(as.EssentialProperties, DeepEquals, []Descriptor{
+		NewDescriptor(ColourPrimariesScheme, "9"),
+		NewDescriptor(TransferCharacteristicsScheme, "14"),
+		NewDescriptor(MatrixCoefficientsScheme, "9"),
+	})
+	c.Check(as.SupplementalProperties, DeepEquals, []Descriptor{NewDescriptor(TransferCharacteristicsScheme, "18")})
+	ci, err = as.ColourInfo()
+	c.Assert(err, IsNil)
+	c.Check(*ci, Equals, HLG)
+
+	r := &Representation{RepresentationBase: RepresentationBase{EssentialProperties: []Descriptor{NewDescriptor(TransferCharacteristicsScheme, "PQ")}}}
+	_, err = r.ColourInfo()
+	c.Check(err, ErrorMatches, `ColourInfo: invalid urn:mpeg:mpegB:cicp:TransferCharacteristics value "PQ"`)
+}
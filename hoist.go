@@ -0,0 +1,55 @@
+package mpd
+
+import (
+	"reflect"
+)
+
+// HoistCommonValues moves codecs, frameRate and SegmentTemplate which are the same for all Representations
+// of AdaptationSet (with at least two Representations) up to the AdaptationSet. It is the inverse of Denormalize.
+func (m *MPD) HoistCommonValues() {
+	for _, p := range m.Periods {
+		for _, as := range p.AdaptationSets {
+			as.hoistCommonValues()
+		}
+	}
+}
+
+func (as *AdaptationSet) hoistCommonValues() {
+	reps := as.Representations
+	if len(reps) < 2 {
+		return
+	}
+
+	same := func(get func(r *Representation) interface{}) bool {
+		first := get(&reps[0])
+		if reflect.ValueOf(first).IsNil() {
+			return false
+		}
+		for k := range reps[1:] {
+			if !reflect.DeepEqual(get(&reps[k+1]), first) {
+				return false
+			}
+		}
+		return true
+	}
+
+	if same(func(r *Representation) interface{} { return r.Codecs }) {
+		as.Codecs = reps[0].Codecs
+		for k := range reps {
+			reps[k].Codecs = nil
+		}
+	}
+	if same(func(r *Representation) interface{} { return r.FrameRate }) {
+		as.FrameRate = reps[0].FrameRate
+		for k := range reps {
+			reps[k].FrameRate = nil
+		}
+	}
+	if same(func(r *Representation) interface{} { return r.SegmentTemplate }) {
+		// AdaptationSet's template may be partially overridden by Representations' ones
+		as.SegmentTemplate = EffectiveSegmentTemplate(new(Period), as, &reps[0])
+		for k := range reps {
+			reps[k].SegmentTemplate = nil
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package mpd
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestHoistCommonValues(c *C) {
+	template := func() *SegmentTemplate {
+		return &SegmentTemplate{Media: stringPtr("$RepresentationID$/$Number$.m4s"), StartNumber: uint64Ptr(1)}
+	}
+	m := &MPD{Periods: []*Period{{AdaptationSets: []*AdaptationSet{
+		{
+			SegmentTemplate: &SegmentTemplate{Timescale: uint64Ptr(1000), StartNumber: uint64Ptr(0)},
+			Representations: []Representation{
+				{ID: stringPtr("1"), Codecs: stringPtr("avc1.64001f"), FrameRate: stringPtr("25"), SegmentTemplate: template()},
+				{ID: stringPtr("2"), Codecs: stringPtr("avc1.64001f"), FrameRate: stringPtr("50"), SegmentTemplate: template()},
+			},
+		},
+		{Representations: []Representation{{ID: stringPtr("3"), Codecs: stringPtr("mp4a.40.2")}}},
+	}}}}
+
+	m.HoistCommonValues()
+	as := m.Periods[0].AdaptationSets[0]
+	c.Check(*as.Codecs, Equals, "avc1.64001f")
+	c.Check(as.FrameRate, IsNil)
+	c.Check(as.SegmentTemplate, DeepEquals, &SegmentTemplate{Timescale: uint64Ptr(1000), Media: stringPtr("$RepresentationID$/$Number$.m4s"), StartNumber: uint64Ptr(1)})
+	for _, r := range as.Representations {
+		c.Check(r.Codecs, IsNil)
+		c.Check(r.FrameRate, NotNil)
+		c.Check(r.SegmentTemplate, IsNil)
+	}
+
+	// single Representation is left as is
+	c.Check(m.Periods[0].AdaptationSets[1].Codecs, IsNil)
+
+	// round-trip
+	m.Denormalize()
+	c.Check(as.Representations[1].Codecs, DeepEquals, stringPtr("avc1.64001f"))
+	c.Check(as.Representations[1].SegmentTemplate, DeepEquals, as.Representations[0].SegmentTemplate)
+}
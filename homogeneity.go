@@ -0,0 +1,116 @@
+package mpd
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// validateHomogeneity checks that Representations of each AdaptationSet share codec family,
+// frame rate family and audio channel layout, as required for seamless switching.
+func validateHomogeneity(m *MPD) ValidationErrors {
+	var res ValidationErrors
+	for i, p := range m.Periods {
+		for j, as := range p.AdaptationSets {
+			path := adaptationSetPath(i, j)
+			res = append(res, checkHomogeneous(path, "codec family", as, func(r *Representation) string {
+				if f := family(r.Codecs); f != "" {
+					return f
+				}
+				return r.codecsFourCC()
+			})...)
+			res = append(res, checkHomogeneous(path, "frame rate family", as, func(r *Representation) string {
+				s := r.FrameRate
+				if s == nil {
+					s = as.FrameRate
+				}
+				if s == nil {
+					return ""
+				}
+				rate, err := parseFrameRate(*s)
+				if err != nil {
+					return *s
+				}
+				return frameRateFamily(rate)
+			})...)
+			res = append(res, checkHomogeneous(path, "audio channel layout", as, func(r *Representation) string {
+				list := r.AudioChannelConfigurations
+				if len(list) == 0 {
+					list = as.AudioChannelConfigurations
+				}
+				var layouts []string
+				for _, acc := range list {
+					if acc.SchemeIDURI != nil && acc.Value != nil {
+						layouts = append(layouts, *acc.SchemeIDURI+" "+*acc.Value)
+					}
+				}
+				return strings.Join(layouts, ", ")
+			})...)
+		}
+	}
+	return res
+}
+
+// checkHomogeneous reports Representations of as with property value different from the first one.
+// Representations without value are ignored.
+func checkHomogeneous(path, property string, as *AdaptationSet, value func(r *Representation) string) ValidationErrors {
+	var expected, expectedID string
+	var offending []string
+	var offendingValue string
+	for k := range as.Representations {
+		r := &as.Representations[k]
+		v := value(r)
+		if v == "" {
+			continue
+		}
+		id := strconv.Itoa(k)
+		if r.ID != nil {
+			id = *r.ID
+		}
+		switch {
+		case expected == "":
+			expected, expectedID = v, id
+		case v != expected:
+			if offendingValue == "" {
+				offendingValue = v
+			}
+			offending = append(offending, strconv.Quote(id))
+		}
+	}
+	if len(offending) == 0 {
+		return nil
+	}
+	return ValidationErrors{newValidationError(path, "%s of Representations %s (%s) differs from %q of Representation %q",
+		property, strings.Join(offending, ", "), offendingValue, expected, expectedID)}
+}
+
+// parseFrameRate parses @frameRate value like "25" or "30000/1001".
+func parseFrameRate(s string) (float64, error) {
+	parts := strings.SplitN(s, "/", 2)
+	num, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil {
+		return 0, fmt.Errorf("can't parse frame rate %q", s)
+	}
+	den := 1.0
+	if len(parts) == 2 {
+		if den, err = strconv.ParseFloat(strings.TrimSpace(parts[1]), 64); err != nil || den == 0 {
+			return 0, fmt.Errorf("can't parse frame rate %q", s)
+		}
+	}
+	if num <= 0 {
+		return 0, fmt.Errorf("invalid frame rate %q", s)
+	}
+	return num / den, nil
+}
+
+// frameRateFamily returns frame rate reduced to 15-30 range by powers of two: 50 and 12.5 are both in "25" family.
+func frameRateFamily(rate float64) string {
+	for rate > 31 {
+		rate /= 2
+	}
+	for rate < 15 {
+		rate *= 2
+	}
+	return strconv.FormatFloat(math.Floor(rate*100+0.5)/100, 'f', -1, 64)
+}
@@ -0,0 +1,39 @@
+package mpd
+
+import (
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestValidateHomogeneity(c *C) {
+	acc := func(channels int) []AudioChannelConfiguration {
+		return []AudioChannelConfiguration{NewChannelCountConfiguration(channels)}
+	}
+
+	m := &MPD{Periods: []*Period{{AdaptationSets: []*AdaptationSet{
+		{FrameRate: stringPtr("50"), Representations: []Representation{
+			{ID: stringPtr("v1"), Codecs: stringPtr("avc1.64001f")},
+			{ID: stringPtr("v2"), Codecs: stringPtr("avc3.64001e"), FrameRate: stringPtr("25")},
+			{ID: stringPtr("v3"), Codecs: stringPtr("avc1.42c00d"), FrameRate: stringPtr("12.5")},
+		}},
+		{Representations: []Representation{
+			{ID: stringPtr("a1"), Codecs: stringPtr("mp4a.40.2"), RepresentationBase: RepresentationBase{AudioChannelConfigurations: acc(2)}},
+			{ID: stringPtr("a2"), Codecs: stringPtr("mp4a.40.5"), RepresentationBase: RepresentationBase{AudioChannelConfigurations: acc(2)}},
+		}},
+	}}}}
+	c.Check(m.Validate(), IsNil)
+
+	as := m.Periods[0].AdaptationSets
+	as[0].Representations[1].Codecs = stringPtr("hvc1.1.6.L93.B0")
+	as[0].Representations[2].Codecs = stringPtr("hvc1.1.6.L63.B0")
+	as[0].Representations[2].FrameRate = stringPtr("30000/1001")
+	as[1].Representations[1].AudioChannelConfigurations = acc(6)
+	err := m.Validate()
+	c.Assert(err, NotNil)
+	c.Check(err.Error(), Equals, strings.Join([]string{
+		`Periods[0].AdaptationSets[0]: codec family of Representations "v2", "v3" (hevc) differs from "avc" of Representation "v1"`,
+		`Periods[0].AdaptationSets[0]: frame rate family of Representations "v3" (29.97) differs from "25" of Representation "v1"`,
+		`Periods[0].AdaptationSets[1]: audio channel layout of Representations "a2" (urn:mpeg:dash:23003:3:audio_channel_configuration:2011 6) differs from "urn:mpeg:dash:23003:3:audio_channel_configuration:2011 2" of Representation "a1"`,
+	}, "\n"))
+}
@@ -0,0 +1,71 @@
+package mpd
+
+import (
+	"fmt"
+	"time"
+)
+
+// ID3Scheme is a schemeIdUri of EventStreams and emsg boxes carrying ID3v2 tags as message data.
+const ID3Scheme = "https://aomedia.org/emsg/ID3"
+
+// id3Timescale is a timescale of ID3 EventStreams created by InsertID3Event, matching MPEG-2 TS clock.
+const id3Timescale = 90000
+
+// InsertID3Event adds Event with ID3v2 tag as base64-encoded content at presentation time start lasting
+// for duration (which may be zero) to the Period containing start. EventStream is created if needed.
+func InsertID3Event(m *MPD, start, duration time.Duration, tag []byte) (*Event, error) {
+	if !isID3Tag(tag) {
+		return nil, fmt.Errorf("InsertID3Event: not an ID3v2 tag")
+	}
+	i, err := periodAt(m, start)
+	if err != nil {
+		return nil, fmt.Errorf("InsertID3Event: %w", err)
+	}
+	p := m.Periods[i]
+	var periodStart time.Duration
+	if p.Start != nil {
+		periodStart, _ = ParseDuration(*p.Start)
+	}
+
+	es := p.eventStream(ID3Scheme, "", id3Timescale)
+	ts := eventTimescale(es)
+	pt := unscaleEventTime(start-periodStart, ts)
+	if es.PresentationTimeOffset != nil {
+		pt += int64(*es.PresentationTimeOffset)
+	}
+	e := Event{ID: stringPtr(es.nextEventID()), PresentationTime: &pt}
+	if duration > 0 {
+		d := unscaleEventTime(duration, ts)
+		e.Duration = &d
+	}
+	e.SetPayload(tag)
+	es.Events = append(es.Events, e)
+	return &es.Events[len(es.Events)-1], nil
+}
+
+// ID3Tags returns ID3v2 tags of Events of ID3Scheme EventStreams of p.
+func (p *Period) ID3Tags() ([][]byte, error) {
+	var res [][]byte
+	for i := range p.EventStreams {
+		es := &p.EventStreams[i]
+		if es.SchemeIDURI == nil || *es.SchemeIDURI != ID3Scheme {
+			continue
+		}
+		for j := range es.Events {
+			b, err := es.Events[j].Payload()
+			if err != nil {
+				return nil, fmt.Errorf("ID3Tags: %w", err)
+			}
+			if !isID3Tag(b) {
+				return nil, fmt.Errorf("ID3Tags: Event %d is not an ID3v2 tag", j)
+			}
+			res = append(res, b)
+		}
+	}
+	return res, nil
+}
+
+// isID3Tag reports whether b starts with ID3v2 tag header.
+func isID3Tag(b []byte) bool {
+	return len(b) >= 10 && string(b[:3]) == "ID3"
+}
@@ -0,0 +1,30 @@
+package mpd
+
+import (
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestInsertID3Event(c *C) {
+	m := &MPD{Periods: []*Period{{ID: stringPtr("p0"), Start: stringPtr("PT0S")}, {ID: stringPtr("p1"), Start: stringPtr("PT10S")}}}
+	tag := []byte("ID3\x04\x00\x00\x00\x00\x00\x00")
+
+	e, err := InsertID3Event(m, 12*time.Second, 0, tag)
+	c.Assert(err, IsNil)
+	c.Check(*e.PresentationTime, Equals, int64(180000))
+	c.Check(e.Duration, IsNil)
+	c.Check(e.Content, Equals, "SUQzBAAAAAAAAA==")
+	c.Check(*m.Periods[1].EventStreams[0].SchemeIDURI, Equals, ID3Scheme)
+
+	tags, err := m.Periods[1].ID3Tags()
+	c.Assert(err, IsNil)
+	c.Check(tags, DeepEquals, [][]byte{tag})
+
+	b, err := m.Encode()
+	c.Assert(err, IsNil)
+	c.Check(string(b), Matches, `(?s).*<Event id="1" presentationTime="180000" contentEncoding="base64">SUQzBAAAAAAAAA==</Event>.*`)
+
+	_, err = InsertID3Event(m, time.Second, 0, []byte("TAG"))
+	c.Check(err, ErrorMatches, "InsertID3Event: not an ID3v2 tag")
+}
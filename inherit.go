@@ -0,0 +1,137 @@
+package mpd
+
+// EffectiveSegmentTemplate returns SegmentTemplate of Representation r of AdaptationSet as in Period p
+// with attributes and elements inherited from upper levels: each one is taken from the lowest level
+// which has it. It returns nil if none of levels has SegmentTemplate.
+// Returned value shares pointed values and slices with levels' SegmentTemplates.
+func EffectiveSegmentTemplate(p *Period, as *AdaptationSet, r *Representation) *SegmentTemplate {
+	var res *SegmentTemplate
+	for _, t := range []*SegmentTemplate{p.SegmentTemplate, as.SegmentTemplate, r.SegmentTemplate} {
+		if t == nil {
+			continue
+		}
+		if res == nil {
+			res = new(SegmentTemplate)
+		}
+		if t.Timescale != nil {
+			res.Timescale = t.Timescale
+		}
+		if t.Media != nil {
+			res.Media = t.Media
+		}
+		if t.Initialization != nil {
+			res.Initialization = t.Initialization
+		}
+		if t.StartNumber != nil {
+			res.StartNumber = t.StartNumber
+		}
+		if t.PresentationTimeOffset != nil {
+			res.PresentationTimeOffset = t.PresentationTimeOffset
+		}
+		if t.Duration != nil {
+			res.Duration = t.Duration
+		}
+		if t.AvailabilityTimeOffset != nil {
+			res.AvailabilityTimeOffset = t.AvailabilityTimeOffset
+		}
+		if t.AvailabilityTimeComplete != nil {
+			res.AvailabilityTimeComplete = t.AvailabilityTimeComplete
+		}
+		if len(t.SegmentTimeline) > 0 {
+			res.SegmentTimeline = t.SegmentTimeline
+		}
+		if t.BitstreamSwitching != nil {
+			res.BitstreamSwitching = t.BitstreamSwitching
+		}
+		if len(t.Extensions) > 0 {
+			res.Extensions = t.Extensions
+		}
+	}
+	return res
+}
+
+// EffectiveSegmentList returns SegmentList of r with inherited attributes and elements,
+// like EffectiveSegmentTemplate.
+func EffectiveSegmentList(p *Period, as *AdaptationSet, r *Representation) *SegmentList {
+	var res *SegmentList
+	for _, l := range []*SegmentList{p.SegmentList, as.SegmentList, r.SegmentList} {
+		if l == nil {
+			continue
+		}
+		if res == nil {
+			res = new(SegmentList)
+		}
+		if l.Timescale != nil {
+			res.Timescale = l.Timescale
+		}
+		if l.PresentationTimeOffset != nil {
+			res.PresentationTimeOffset = l.PresentationTimeOffset
+		}
+		if l.Duration != nil {
+			res.Duration = l.Duration
+		}
+		if l.StartNumber != nil {
+			res.StartNumber = l.StartNumber
+		}
+		if l.Initialization != nil {
+			res.Initialization = l.Initialization
+		}
+		if len(l.SegmentTimeline) > 0 {
+			res.SegmentTimeline = l.SegmentTimeline
+		}
+		if l.BitstreamSwitching != nil {
+			res.BitstreamSwitching = l.BitstreamSwitching
+		}
+		if len(l.SegmentURLs) > 0 {
+			res.SegmentURLs = l.SegmentURLs
+		}
+	}
+	return res
+}
+
+// EffectiveSegmentBase returns SegmentBase of r with inherited attributes, like EffectiveSegmentTemplate.
+func EffectiveSegmentBase(p *Period, as *AdaptationSet, r *Representation) *SegmentBase {
+	var res *SegmentBase
+	for _, b := range []*SegmentBase{p.SegmentBase, as.SegmentBase, r.SegmentBase} {
+		if b == nil {
+			continue
+		}
+		if res == nil {
+			res = new(SegmentBase)
+		}
+		if b.Timescale != nil {
+			res.Timescale = b.Timescale
+		}
+		if b.PresentationTimeOffset != nil {
+			res.PresentationTimeOffset = b.PresentationTimeOffset
+		}
+		if b.IndexRange != nil {
+			res.IndexRange = b.IndexRange
+		}
+		if b.IndexRangeExact != nil {
+			res.IndexRangeExact = b.IndexRangeExact
+		}
+		if b.Initialization != nil {
+			res.Initialization = b.Initialization
+		}
+		if b.RepresentationIndex != nil {
+			res.RepresentationIndex = b.RepresentationIndex
+		}
+	}
+	return res
+}
+
+// EffectivePresentationTimeOffset returns @presentationTimeOffset of segment addressing of r with inherited
+// attributes, or 0. SegmentTemplate takes precedence over SegmentList and SegmentList over SegmentBase.
+func EffectivePresentationTimeOffset(p *Period, as *AdaptationSet, r *Representation) uint64 {
+	if t := EffectiveSegmentTemplate(p, as, r); t != nil {
+		return t.GetPresentationTimeOffset()
+	}
+	if l := EffectiveSegmentList(p, as, r); l != nil {
+		return l.GetPresentationTimeOffset()
+	}
+	if b := EffectiveSegmentBase(p, as, r); b != nil {
+		return b.GetPresentationTimeOffset()
+	}
+	return 0
+}
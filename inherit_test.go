@@ -0,0 +1,51 @@
+package mpd
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestEffectiveSegmentTemplate(c *C) {
+	timeline := []SegmentTimeline{{Segments: []SegmentTimelineSegment{{D: 2000}}}}
+	p := &Period{SegmentTemplate: &SegmentTemplate{Timescale: uint64Ptr(1000), StartNumber: uint64Ptr(1)}}
+	as := &AdaptationSet{
+		SegmentTemplate: &SegmentTemplate{Media: stringPtr("$RepresentationID$/$Number$.m4s"), SegmentTimeline: timeline},
+		Representations: []Representation{{}, {SegmentTemplate: &SegmentTemplate{StartNumber: uint64Ptr(10)}}},
+	}
+
+	c.Check(EffectiveSegmentTemplate(p, as, &as.Representations[0]), DeepEquals, &SegmentTemplate{
+		Timescale:       uint64Ptr(1000),
+		Media:           stringPtr("$RepresentationID$/$Number$.m4s"),
+		StartNumber:     uint64Ptr(1),
+		SegmentTimeline: timeline,
+	})
+	c.Check(EffectiveSegmentTemplate(p, as, &as.Representations[1]), DeepEquals, &SegmentTemplate{
+		Timescale:       uint64Ptr(1000),
+		Media:           stringPtr("$RepresentationID$/$Number$.m4s"),
+		StartNumber:     uint64Ptr(10),
+		SegmentTimeline: timeline,
+	})
+	c.Check(EffectiveSegmentTemplate(new(Period), as, &as.Representations[1]).Timescale, IsNil)
+	c.Check(EffectiveSegmentTemplate(new(Period), new(AdaptationSet), new(Representation)), IsNil)
+
+	p = &Period{SegmentBase: &SegmentBase{Timescale: uint64Ptr(90000)}}
+	r := &Representation{SegmentBase: &SegmentBase{IndexRange: stringPtr("0-99")}}
+	c.Check(EffectiveSegmentBase(p, new(AdaptationSet), r), DeepEquals, &SegmentBase{Timescale: uint64Ptr(90000), IndexRange: stringPtr("0-99")})
+	c.Check(EffectiveSegmentList(p, new(AdaptationSet), r), IsNil)
+}
+
+func (s *MPDSuite) TestEffectivePresentationTimeOffset(c *C) {
+	p := &Period{SegmentBase: &SegmentBase{PresentationTimeOffset: uint64Ptr(10)}}
+	as := new(AdaptationSet)
+	r := new(Representation)
+	c.Check(EffectivePresentationTimeOffset(p, as, r), Equals, uint64(10))
+
+	as.SegmentList = &SegmentList{PresentationTimeOffset: uint64Ptr(20)}
+	c.Check(EffectivePresentationTimeOffset(p, as, r), Equals, uint64(20))
+
+	r.SegmentTemplate = &SegmentTemplate{Media: stringPtr("$Number$.m4s")}
+	c.Check(EffectivePresentationTimeOffset(p, as, r), Equals, uint64(0))
+	p.SegmentTemplate = &SegmentTemplate{PresentationTimeOffset: uint64Ptr(30)}
+	c.Check(EffectivePresentationTimeOffset(p, as, r), Equals, uint64(30))
+
+	c.Check(EffectivePresentationTimeOffset(new(Period), new(AdaptationSet), new(Representation)), Equals, uint64(0))
+}
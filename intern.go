@@ -0,0 +1,48 @@
+package mpd
+
+import (
+	"reflect"
+)
+
+// internStrings makes equal strings of m share memory, e.g. codecs, mimeType and SegmentTemplate URLs
+// repeated across Representations and Periods. Pointers to strings are not shared, so values may still
+// be modified independently.
+func (m *MPD) internStrings() {
+	internValue(reflect.ValueOf(m).Elem(), make(map[string]string))
+}
+
+// internValue replaces settable strings reachable from v with equal ones from table, adding new ones to it.
+func internValue(v reflect.Value, table map[string]string) {
+	switch v.Kind() {
+	case reflect.String:
+		if !v.CanSet() {
+			return
+		}
+		s := v.String()
+		if res, ok := table[s]; ok {
+			v.SetString(res)
+			return
+		}
+		table[s] = s
+
+	case reflect.Ptr:
+		if !v.IsNil() {
+			internValue(v.Elem(), table)
+		}
+
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			internValue(v.Index(i), table)
+		}
+
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if f := v.Field(i); f.CanSet() {
+				internValue(f, table)
+			}
+		}
+	}
+}
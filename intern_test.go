@@ -0,0 +1,38 @@
+package mpd
+
+import (
+	"unsafe"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestDecodeInternStrings(c *C) {
+	b := []byte(`<MPD xmlns="urn:mpeg:dash:schema:mpd:2011" profiles="urn:mpeg:dash:profile:isoff-live:2011">
+  <Period id="1">
+    <AdaptationSet mimeType="video/mp4">
+      <Representation id="1" codecs="avc1.64001f" bandwidth="1000"/>
+    </AdaptationSet>
+  </Period>
+  <Period id="2">
+    <AdaptationSet mimeType="video/mp4">
+      <Representation id="1" codecs="avc1.64001f" bandwidth="1000"/>
+    </AdaptationSet>
+  </Period>
+</MPD>`)
+	expected := new(MPD)
+	c.Assert(expected.Decode(b), IsNil)
+
+	m := new(MPD)
+	c.Assert(m.DecodeWithOptions(b, DecodeOptions{InternStrings: true}), IsNil)
+	c.Check(m, DeepEquals, expected)
+
+	r1, r2 := &m.Periods[0].AdaptationSets[0].Representations[0], &m.Periods[1].AdaptationSets[0].Representations[0]
+	c.Check(unsafe.StringData(*r1.Codecs), Equals, unsafe.StringData(*r2.Codecs))
+	c.Check(unsafe.StringData(m.Periods[0].AdaptationSets[0].MimeType), Equals, unsafe.StringData(m.Periods[1].AdaptationSets[0].MimeType))
+	c.Check(unsafe.StringData(*expected.Periods[0].AdaptationSets[0].Representations[0].Codecs), Not(Equals),
+		unsafe.StringData(*expected.Periods[1].AdaptationSets[0].Representations[0].Codecs))
+
+	// values are still independent
+	*r1.Codecs = "avc1.640028"
+	c.Check(*r2.Codecs, Equals, "avc1.64001f")
+}
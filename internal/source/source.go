@@ -0,0 +1,47 @@
+// Package source reads MPDs for commands from files, URLs and standard input.
+package source
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// Stdin is read by Read for "-" name.
+var Stdin io.Reader = os.Stdin
+
+// Read returns contents of file name, of http(s) URL name or of Stdin if name is "-".
+func Read(name string) ([]byte, error) {
+	return ReadContext(context.Background(), name)
+}
+
+// ReadContext is like Read, but aborts HTTP requests when ctx is done.
+func ReadContext(ctx context.Context, name string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	switch {
+	case name == "-":
+		return ioutil.ReadAll(Stdin)
+	case strings.HasPrefix(name, "http://") || strings.HasPrefix(name, "https://"):
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, name, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("%s: %s", name, resp.Status)
+		}
+		return ioutil.ReadAll(resp.Body)
+	default:
+		return ioutil.ReadFile(name)
+	}
+}
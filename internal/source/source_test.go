@@ -0,0 +1,48 @@
+package source
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type SourceSuite struct{}
+
+var _ = Suite(&SourceSuite{})
+
+func (s *SourceSuite) TestRead(c *C) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/live.mpd" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, "<MPD/>")
+	}))
+	defer srv.Close()
+
+	b, err := Read(srv.URL + "/live.mpd")
+	c.Assert(err, IsNil)
+	c.Check(string(b), Equals, "<MPD/>")
+	_, err = Read(srv.URL + "/missing.mpd")
+	c.Check(err, ErrorMatches, ".*/missing.mpd: 404 Not Found")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = ReadContext(ctx, srv.URL+"/live.mpd")
+	c.Check(err, Equals, context.Canceled)
+
+	Stdin = strings.NewReader("<MPD/>")
+	b, err = Read("-")
+	c.Assert(err, IsNil)
+	c.Check(string(b), Equals, "<MPD/>")
+
+	b, err = Read("../../fixture_elemental_delta_vod.mpd")
+	c.Assert(err, IsNil)
+	c.Check(len(b) > 0, Equals, true)
+}
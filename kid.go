@@ -0,0 +1,142 @@
+package mpd
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// NormalizeKID validates key ID in UUID form with or without dashes, in any case,
+// and returns it in canonical lowercase form with dashes, e.g. "10000000-1000-1000-1000-100000000000".
+func NormalizeKID(kid string) (string, error) {
+	s := strings.Replace(strings.TrimSpace(kid), "-", "", -1)
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) != 16 {
+		return "", fmt.Errorf("NormalizeKID: invalid key ID %q", kid)
+	}
+	return formatKID(b), nil
+}
+
+// formatKID returns 16-byte key ID in canonical UUID form.
+func formatKID(b []byte) string {
+	s := hex.EncodeToString(b)
+	return s[:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:]
+}
+
+// PsshKIDs returns key IDs in canonical form listed in version 1 PSSH box of cp.
+// It returns nil for version 0 boxes and ContentProtections without cenc:pssh.
+func (cp *ContentProtection) PsshKIDs() ([]string, error) {
+	if cp.Pssh == nil || cp.Pssh.Value == nil {
+		return nil, nil
+	}
+	b, err := decodeBase64Payload(*cp.Pssh.Value)
+	if err != nil {
+		return nil, fmt.Errorf("PsshKIDs: can't decode base64: %w", err)
+	}
+
+	// size, type, version and flags, SystemID
+	if len(b) < 28 || string(b[4:8]) != "pssh" {
+		return nil, fmt.Errorf("PsshKIDs: not a pssh box")
+	}
+	if b[8] == 0 {
+		return nil, nil
+	}
+	if len(b) < 32 {
+		return nil, fmt.Errorf("PsshKIDs: truncated pssh box")
+	}
+	count := int(binary.BigEndian.Uint32(b[28:32]))
+	if count > (len(b)-32)/16 {
+		return nil, fmt.Errorf("PsshKIDs: truncated pssh box")
+	}
+	res := make([]string, count)
+	for i := range res {
+		res[i] = formatKID(b[32+i*16 : 48+i*16])
+	}
+	return res, nil
+}
+
+// NormalizeDefaultKIDs rewrites all cenc:default_KID values of m in canonical form.
+// It returns error for invalid values, leaving them unchanged.
+func (m *MPD) NormalizeDefaultKIDs() error {
+	var errs []string
+	normalize := func(list []ContentProtection) {
+		for k := range list {
+			if list[k].DefaultKID == nil {
+				continue
+			}
+			kid, err := NormalizeKID(*list[k].DefaultKID)
+			if err != nil {
+				errs = append(errs, err.Error())
+				continue
+			}
+			list[k].DefaultKID = &kid
+		}
+	}
+	for _, p := range m.Periods {
+		for _, as := range p.AdaptationSets {
+			normalize(as.ContentProtections)
+			for k := range as.Representations {
+				normalize(as.Representations[k].ContentProtections)
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("NormalizeDefaultKIDs: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// validateDefaultKIDs checks that cenc:default_KID values are well-formed, that all Representations of
+// AdaptationSet share the same default_KID, and that PSSH boxes list it.
+func validateDefaultKIDs(m *MPD) ValidationErrors {
+	var res ValidationErrors
+
+	// check returns default_KID of list, which should be equal to inherited one if it is set
+	check := func(path string, list []ContentProtection, inherited string) string {
+		kid := inherited
+		for _, cp := range list {
+			if cp.DefaultKID == nil {
+				continue
+			}
+			v, err := NormalizeKID(*cp.DefaultKID)
+			if err != nil {
+				res = append(res, newValidationError(path, "invalid cenc:default_KID %q", *cp.DefaultKID))
+				continue
+			}
+			if kid != "" && v != kid {
+				res = append(res, newValidationError(path, "cenc:default_KID %s differs from %s", v, kid))
+				continue
+			}
+			kid = v
+		}
+
+		for _, cp := range list {
+			// corrupt PSSH boxes are reported by validateDRM
+			kids, err := cp.PsshKIDs()
+			if err != nil || kid == "" || kids == nil {
+				continue
+			}
+			found := false
+			for _, k := range kids {
+				found = found || k == kid
+			}
+			if !found {
+				res = append(res, newValidationError(path, "cenc:pssh doesn't list cenc:default_KID %s", kid))
+			}
+		}
+		return kid
+	}
+
+	for i, p := range m.Periods {
+		for j, as := range p.AdaptationSets {
+			kid := check(adaptationSetPath(i, j), as.ContentProtections, "")
+			for k := range as.Representations {
+				if v := check(representationPath(i, j, k), as.Representations[k].ContentProtections, kid); kid == "" {
+					kid = v
+				}
+			}
+		}
+	}
+	return res
+}
@@ -0,0 +1,116 @@
+package mpd
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestNormalizeKID(c *C) {
+	for _, in := range []string{
+		"10000000-1000-1000-1000-100000000000",
+		"10000000100010001000100000000000",
+		" 10000000-1000-1000-1000-100000000000 ",
+	} {
+		res, err := NormalizeKID(in)
+		c.Check(err, IsNil, Commentf("%s", in))
+		c.Check(res, Equals, "10000000-1000-1000-1000-100000000000", Commentf("%s", in))
+	}
+	res, err := NormalizeKID("ABCDEF00-1000-1000-1000-10000000000A")
+	c.Check(err, IsNil)
+	c.Check(res, Equals, "abcdef00-1000-1000-1000-10000000000a")
+
+	for _, in := range []string{"", "1000000010001000100010000000000", "1000000010001000100010000000000g", "{10000000-1000-1000-1000-100000000000}"} {
+		_, err := NormalizeKID(in)
+		c.Check(err, ErrorMatches, "NormalizeKID: invalid key ID .*", Commentf("%s", in))
+	}
+}
+
+// pssh returns base64-encoded PSSH box with given key IDs (version 0 if there are none).
+func pssh(kids ...string) *Pssh {
+	var body []byte
+	version := byte(0)
+	if len(kids) > 0 {
+		version = 1
+		body = append(body, 0, 0, 0, byte(len(kids)))
+		for _, kid := range kids {
+			b, _ := hex.DecodeString(strings.Replace(kid, "-", "", -1))
+			body = append(body, b...)
+		}
+	}
+	body = append(body, 0, 0, 0, 0) // DataSize
+	box := append([]byte{0, 0, 0, byte(28 + len(body)), 'p', 's', 's', 'h', version, 0, 0, 0}, make([]byte, 16)...)
+	v := base64.StdEncoding.EncodeToString(append(box, body...))
+	return &Pssh{Value: &v}
+}
+
+func (s *MPDSuite) TestPsshKIDs(c *C) {
+	cp := ContentProtection{Pssh: pssh("10000000-1000-1000-1000-100000000000", "20000000-2000-2000-2000-200000000000")}
+	kids, err := cp.PsshKIDs()
+	c.Check(err, IsNil)
+	c.Check(kids, DeepEquals, []string{"10000000-1000-1000-1000-100000000000", "20000000-2000-2000-2000-200000000000"})
+
+	cp = ContentProtection{Pssh: pssh()}
+	kids, err = cp.PsshKIDs()
+	c.Check(err, IsNil)
+	c.Check(kids, IsNil)
+
+	cp = ContentProtection{Pssh: &Pssh{Value: stringPtr("not base64")}}
+	_, err = cp.PsshKIDs()
+	c.Check(err, ErrorMatches, "PsshKIDs: can't decode base64: .*")
+
+	cp = ContentProtection{Pssh: &Pssh{Value: stringPtr(base64.StdEncoding.EncodeToString([]byte("short")))}}
+	_, err = cp.PsshKIDs()
+	c.Check(err, ErrorMatches, "PsshKIDs: not a pssh box")
+}
+
+func (s *MPDSuite) TestNormalizeDefaultKIDs(c *C) {
+	m := &MPD{Periods: []*Period{{AdaptationSets: []*AdaptationSet{{
+		RepresentationBase: RepresentationBase{ContentProtections: []ContentProtection{{DefaultKID: stringPtr("10000000100010001000100000000000")}}},
+		Representations: []Representation{
+			{RepresentationBase: RepresentationBase{ContentProtections: []ContentProtection{{DefaultKID: stringPtr("10000000-1000-1000-1000-10000000000A")}}}},
+			{RepresentationBase: RepresentationBase{ContentProtections: []ContentProtection{{DefaultKID: stringPtr("bad")}}}},
+		},
+	}}}}}
+	c.Check(m.NormalizeDefaultKIDs(), ErrorMatches, `NormalizeDefaultKIDs: NormalizeKID: invalid key ID "bad"`)
+	as := m.Periods[0].AdaptationSets[0]
+	c.Check(*as.ContentProtections[0].DefaultKID, Equals, "10000000-1000-1000-1000-100000000000")
+	c.Check(*as.Representations[0].ContentProtections[0].DefaultKID, Equals, "10000000-1000-1000-1000-10000000000a")
+	c.Check(*as.Representations[1].ContentProtections[0].DefaultKID, Equals, "bad")
+}
+
+func (s *MPDSuite) TestValidateDefaultKIDs(c *C) {
+	const kid1, kid2 = "10000000-1000-1000-1000-100000000000", "20000000-2000-2000-2000-200000000000"
+
+	m := &MPD{Periods: []*Period{{AdaptationSets: []*AdaptationSet{
+		{
+			RepresentationBase: RepresentationBase{ContentProtections: []ContentProtection{
+				{DefaultKID: stringPtr(strings.ToUpper(kid1))},
+				{Pssh: pssh(kid1, kid2)},
+			}},
+			Representations: []Representation{
+				{RepresentationBase: RepresentationBase{ContentProtections: []ContentProtection{{DefaultKID: stringPtr("10000000100010001000100000000000")}}}},
+				{},
+			},
+		},
+		{Representations: []Representation{
+			{RepresentationBase: RepresentationBase{ContentProtections: []ContentProtection{{DefaultKID: stringPtr(kid2)}, {Pssh: pssh()}}}},
+			{RepresentationBase: RepresentationBase{ContentProtections: []ContentProtection{{DefaultKID: stringPtr(kid2)}}}},
+		}},
+	}}}}
+	c.Check(m.Validate(), IsNil)
+
+	as := m.Periods[0].AdaptationSets
+	as[0].Representations[1].ContentProtections = []ContentProtection{{DefaultKID: stringPtr(kid2)}}
+	as[1].Representations[0].ContentProtections[1].Pssh = pssh(kid1)
+	as[1].Representations[1].ContentProtections[0].DefaultKID = stringPtr("bad")
+	err := m.Validate()
+	c.Assert(err, NotNil)
+	c.Check(err.Error(), Equals, strings.Join([]string{
+		"Periods[0].AdaptationSets[0].Representations[1]: cenc:default_KID " + kid2 + " differs from " + kid1,
+		"Periods[0].AdaptationSets[1].Representations[0]: cenc:pssh doesn't list cenc:default_KID " + kid2,
+		`Periods[0].AdaptationSets[1].Representations[1]: invalid cenc:default_KID "bad"`,
+	}, "\n"))
+}
@@ -0,0 +1,97 @@
+package mpd
+
+import (
+	"sort"
+
+	"github.com/jun-oku/mpd/codecs"
+)
+
+// LadderOptions control ValidateLadder.
+type LadderOptions struct {
+	// MinStepRatio is minimum ratio of bandwidths of adjacent rungs, e.g. 1.3. Zero disables the check.
+	MinStepRatio float64
+	// MaxStepRatio is maximum ratio of bandwidths of adjacent rungs, e.g. 2.5. Zero disables the check.
+	MaxStepRatio float64
+}
+
+// ValidateLadder checks bitrate ladders of video AdaptationSets of m: resolution must not decrease
+// as bandwidth increases, adjacent rungs must differ in bandwidth by ratios allowed by o, and no rung
+// may exceed maximum bitrate or picture size of its codec level. It returns ValidationErrors or nil.
+func (m *MPD) ValidateLadder(o LadderOptions) error {
+	var res ValidationErrors
+	for i, p := range m.Periods {
+		for j, as := range p.AdaptationSets {
+			if InferContentType(as) != "video" {
+				continue
+			}
+			res = append(res, validateLadder(i, j, as, o)...)
+		}
+	}
+	if len(res) == 0 {
+		return nil
+	}
+	return res
+}
+
+// validateLadder checks ladder of AdaptationSet j of Period i.
+func validateLadder(i, j int, as *AdaptationSet, o LadderOptions) ValidationErrors {
+	var res ValidationErrors
+	order := make([]int, 0, len(as.Representations))
+	for k := range as.Representations {
+		if as.Representations[k].Bandwidth != nil {
+			order = append(order, k)
+		}
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return as.Representations[order[a]].GetBandwidth() < as.Representations[order[b]].GetBandwidth()
+	})
+
+	for n := 1; n < len(order); n++ {
+		lk, hk := order[n-1], order[n]
+		low, high := &as.Representations[lk], &as.Representations[hk]
+		if pixels(low) != 0 && pixels(high) != 0 && pixels(high) < pixels(low) {
+			res = append(res, newValidationError(representationPath(i, j, hk), "%dx%d at %d bps is below %dx%d of Representation %s at %d bps",
+				*high.Width, *high.Height, high.GetBandwidth(), *low.Width, *low.Height, representationName(low, lk), low.GetBandwidth()))
+		}
+		if low.GetBandwidth() == 0 {
+			continue
+		}
+		ratio := float64(high.GetBandwidth()) / float64(low.GetBandwidth())
+		if o.MinStepRatio > 0 && ratio < o.MinStepRatio {
+			res = append(res, newValidationError(representationPath(i, j, hk), "bandwidth step from Representation %s is x%.2f, below x%.2f",
+				representationName(low, lk), ratio, o.MinStepRatio))
+		}
+		if o.MaxStepRatio > 0 && ratio > o.MaxStepRatio {
+			res = append(res, newValidationError(representationPath(i, j, hk), "bandwidth step from Representation %s is x%.2f, above x%.2f",
+				representationName(low, lk), ratio, o.MaxStepRatio))
+		}
+	}
+
+	for k := range as.Representations {
+		r := &as.Representations[k]
+		s := r.Codecs
+		if s == nil {
+			s = as.Codecs
+		}
+		if s == nil {
+			continue
+		}
+		list, err := codecs.ParseList(*s)
+		if err != nil {
+			continue
+		}
+		for _, c := range list {
+			l, ok := c.Limits()
+			if !ok {
+				continue
+			}
+			if r.GetBandwidth() > l.MaxBitrate {
+				res = append(res, newValidationError(representationPath(i, j, k), "bandwidth %d exceeds %d of %s", r.GetBandwidth(), l.MaxBitrate, c.Raw))
+			}
+			if pixels(r) > l.MaxLumaPictureSize {
+				res = append(res, newValidationError(representationPath(i, j, k), "%dx%d exceeds picture size %d of %s", *r.Width, *r.Height, l.MaxLumaPictureSize, c.Raw))
+			}
+		}
+	}
+	return res
+}
@@ -0,0 +1,42 @@
+package mpd
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestValidateLadder(c *C) {
+	rung := func(id string, width, height, bandwidth uint64) Representation {
+		return Representation{ID: stringPtr(id), Width: uint64Ptr(width), Height: uint64Ptr(height), Bandwidth: uint64Ptr(bandwidth)}
+	}
+	m := &MPD{Periods: []*Period{{AdaptationSets: []*AdaptationSet{
+		{MimeType: "video/mp4", Codecs: stringPtr("avc1.64001f"), Representations: []Representation{
+			rung("360p", 640, 360, 800000),
+			rung("720p", 1280, 720, 3000000),
+			rung("540p", 960, 540, 4000000),
+			rung("720p-hi", 1280, 720, 4200000),
+			rung("1080p", 1920, 1080, 25000000),
+		}},
+		{MimeType: "audio/mp4", Representations: []Representation{
+			{Bandwidth: uint64Ptr(64000)},
+			{Bandwidth: uint64Ptr(65000)},
+		}},
+	}}}}
+
+	c.Check(m.ValidateLadder(LadderOptions{}), DeepEquals, ValidationErrors{
+		{Path: "Periods[0].AdaptationSets[0].Representations[2]", Message: `960x540 at 4000000 bps is below 1280x720 of Representation "720p" at 3000000 bps`},
+		{Path: "Periods[0].AdaptationSets[0].Representations[4]", Message: "bandwidth 25000000 exceeds 21000000 of avc1.64001f"},
+		{Path: "Periods[0].AdaptationSets[0].Representations[4]", Message: "1920x1080 exceeds picture size 921600 of avc1.64001f"},
+	})
+
+	err := m.ValidateLadder(LadderOptions{MinStepRatio: 1.2, MaxStepRatio: 4})
+	c.Assert(err, NotNil)
+	errs := err.(ValidationErrors)
+	c.Check(errs, HasLen, 5)
+	c.Check(errs[1], DeepEquals, &ValidationError{Path: "Periods[0].AdaptationSets[0].Representations[3]",
+		Message: `bandwidth step from Representation "540p" is x1.05, below x1.20`})
+	c.Check(errs[2], DeepEquals, &ValidationError{Path: "Periods[0].AdaptationSets[0].Representations[4]",
+		Message: `bandwidth step from Representation "720p-hi" is x5.95, above x4.00`})
+
+	m.Periods[0].AdaptationSets[0].Representations = []Representation{rung("360p", 640, 360, 800000), rung("720p", 1280, 720, 3000000)}
+	c.Check(m.ValidateLadder(LadderOptions{MinStepRatio: 1.2, MaxStepRatio: 4}), IsNil)
+}
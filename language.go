@@ -0,0 +1,245 @@
+package mpd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// grandfatheredLanguages maps lowercased BCP-47 grandfathered tags to their preferred values.
+// Tags without preferred value map to their canonical spelling.
+var grandfatheredLanguages = map[string]string{
+	"en-gb-oed":   "en-GB-oxendict",
+	"i-ami":       "ami",
+	"i-bnn":       "bnn",
+	"i-default":   "i-default",
+	"i-enochian":  "i-enochian",
+	"i-hak":       "hak",
+	"i-klingon":   "tlh",
+	"i-lux":       "lb",
+	"i-mingo":     "i-mingo",
+	"i-navajo":    "nv",
+	"i-pwn":       "pwn",
+	"i-tao":       "tao",
+	"i-tay":       "tay",
+	"i-tsu":       "tsu",
+	"sgn-be-fr":   "sfb",
+	"sgn-be-nl":   "vgt",
+	"sgn-ch-de":   "sgg",
+	"art-lojban":  "jbo",
+	"cel-gaulish": "cel-gaulish",
+	"no-bok":      "nb",
+	"no-nyn":      "nn",
+	"zh-guoyu":    "cmn",
+	"zh-hakka":    "hak",
+	"zh-min":      "zh-min",
+	"zh-min-nan":  "nan",
+	"zh-xiang":    "hsn",
+}
+
+// NormalizeLanguage validates BCP-47 language tag and returns it in canonical case,
+// e.g. "zh-hant-tw" becomes "zh-Hant-TW". Grandfathered tags are replaced with their preferred values.
+func NormalizeLanguage(tag string) (string, error) {
+	lower := strings.ToLower(strings.TrimSpace(tag))
+	if v, ok := grandfatheredLanguages[lower]; ok {
+		return v, nil
+	}
+	if lower == "" {
+		return "", fmt.Errorf("NormalizeLanguage: empty language tag")
+	}
+
+	subtags := strings.Split(lower, "-")
+	for _, s := range subtags {
+		if s == "" || len(s) > 8 || !isAlnum(s) {
+			return "", fmt.Errorf("NormalizeLanguage: invalid language tag %q", tag)
+		}
+	}
+
+	// private use only
+	if subtags[0] == "x" {
+		if len(subtags) < 2 {
+			return "", fmt.Errorf("NormalizeLanguage: invalid language tag %q", tag)
+		}
+		return lower, nil
+	}
+
+	// language
+	if !isAlpha(subtags[0]) || len(subtags[0]) < 2 {
+		return "", fmt.Errorf("NormalizeLanguage: invalid primary language subtag in %q", tag)
+	}
+	res := []string{subtags[0]}
+	i := 1
+
+	// extlang
+	if len(subtags[0]) <= 3 {
+		for n := 0; n < 3 && i < len(subtags) && len(subtags[i]) == 3 && isAlpha(subtags[i]); n++ {
+			res = append(res, subtags[i])
+			i++
+		}
+	}
+
+	// script
+	if i < len(subtags) && len(subtags[i]) == 4 && isAlpha(subtags[i]) {
+		res = append(res, strings.ToUpper(subtags[i][:1])+subtags[i][1:])
+		i++
+	}
+
+	// region
+	if i < len(subtags) && ((len(subtags[i]) == 2 && isAlpha(subtags[i])) || (len(subtags[i]) == 3 && isDigit(subtags[i]))) {
+		res = append(res, strings.ToUpper(subtags[i]))
+		i++
+	}
+
+	// variants
+	for i < len(subtags) && (len(subtags[i]) >= 5 || (len(subtags[i]) == 4 && isDigit(subtags[i][:1]))) {
+		res = append(res, subtags[i])
+		i++
+	}
+
+	// extensions and private use
+	for i < len(subtags) {
+		singleton := subtags[i]
+		if len(singleton) != 1 {
+			return "", fmt.Errorf("NormalizeLanguage: unexpected subtag %q in %q", singleton, tag)
+		}
+		res = append(res, singleton)
+		i++
+
+		minLen := 2
+		if singleton == "x" {
+			minLen = 1
+		}
+		start := i
+		for i < len(subtags) && len(subtags[i]) >= minLen && (singleton == "x" || len(subtags[i]) > 1) {
+			res = append(res, subtags[i])
+			i++
+		}
+		if i == start {
+			return "", fmt.Errorf("NormalizeLanguage: empty extension %q in %q", singleton, tag)
+		}
+		if singleton == "x" && i < len(subtags) {
+			return "", fmt.Errorf("NormalizeLanguage: invalid language tag %q", tag)
+		}
+	}
+
+	return strings.Join(res, "-"), nil
+}
+
+// MatchLanguage implements RFC 4647 basic filtering: it returns true if language range rng
+// matches tag, e.g. "en" matches "en-US", and "*" matches everything. Comparison is case-insensitive.
+func MatchLanguage(rng, tag string) bool {
+	rng, tag = strings.ToLower(strings.TrimSpace(rng)), strings.ToLower(strings.TrimSpace(tag))
+	if rng == "*" {
+		return true
+	}
+	return rng == tag || strings.HasPrefix(tag, rng+"-")
+}
+
+// LookupLanguage implements RFC 4647 lookup: for each range in order of preference it progressively
+// truncates the range until it equals one of tags, e.g. "en-US" finds "en". It returns matched tag or "".
+func LookupLanguage(ranges []string, tags []string) string {
+	for _, rng := range ranges {
+		rng = strings.ToLower(strings.TrimSpace(rng))
+		if rng == "*" {
+			continue
+		}
+		for rng != "" {
+			for _, t := range tags {
+				if strings.EqualFold(rng, strings.TrimSpace(t)) {
+					return t
+				}
+			}
+			i := strings.LastIndex(rng, "-")
+			if i < 0 {
+				break
+			}
+			rng = rng[:i]
+			// also remove trailing singleton
+			if j := strings.LastIndex(rng, "-"); j >= 0 && j == len(rng)-2 {
+				rng = rng[:j]
+			}
+		}
+	}
+	return ""
+}
+
+// validateLanguages checks that all @lang values are valid BCP-47 language tags.
+func validateLanguages(m *MPD) ValidationErrors {
+	var res ValidationErrors
+	check := func(path string, lang *string) {
+		if lang == nil {
+			return
+		}
+		if _, err := NormalizeLanguage(*lang); err != nil {
+			res = append(res, newValidationError(path, "invalid lang %q", *lang))
+		}
+	}
+	for i, p := range m.Periods {
+		for j, as := range p.AdaptationSets {
+			check(adaptationSetPath(i, j), as.Lang)
+		}
+		for n, ps := range p.Preselections {
+			check(fmt.Sprintf("%s.Preselections[%d]", periodPath(i), n), ps.Lang)
+		}
+	}
+	return res
+}
+
+// canonicalizeLanguages replaces all valid @lang values of m with normalized ones.
+// It returns a function which restores original values.
+func (m *MPD) canonicalizeLanguages() func() {
+	var fields []**string
+	var originals []*string
+	replace := func(lang **string) {
+		if *lang == nil {
+			return
+		}
+		norm, err := NormalizeLanguage(**lang)
+		if err != nil || norm == **lang {
+			return
+		}
+		fields = append(fields, lang)
+		originals = append(originals, *lang)
+		*lang = &norm
+	}
+	for _, p := range m.Periods {
+		for _, as := range p.AdaptationSets {
+			replace(&as.Lang)
+		}
+		for i := range p.Preselections {
+			replace(&p.Preselections[i].Lang)
+		}
+	}
+
+	return func() {
+		for i, f := range fields {
+			*f = originals[i]
+		}
+	}
+}
+
+func isAlpha(s string) bool {
+	for _, c := range s {
+		if c < 'a' || c > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigit(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlnum(s string) bool {
+	for _, c := range s {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,66 @@
+package mpd
+
+import (
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestNormalizeLanguage(c *C) {
+	for in, expected := range map[string]string{
+		"en":              "en",
+		"EN-us":           "en-US",
+		"zh-hant-tw":      "zh-Hant-TW",
+		"es-419":          "es-419",
+		"eng":             "eng",
+		"zh-yue-HK":       "zh-yue-HK",
+		"de-CH-1996":      "de-CH-1996",
+		"en-a-bbb-x-priv": "en-a-bbb-x-priv",
+		"x-Whatever":      "x-whatever",
+		"i-klingon":       "tlh",
+		"EN-gb-oed":       "en-GB-oxendict",
+		"i-default":       "i-default",
+	} {
+		res, err := NormalizeLanguage(in)
+		c.Check(err, IsNil, Commentf("%s", in))
+		c.Check(res, Equals, expected, Commentf("%s", in))
+	}
+
+	for _, in := range []string{"", "e", "en_US", "en--US", "english-us-toolongsubtag", "en-a", "1en", "en-x"} {
+		_, err := NormalizeLanguage(in)
+		c.Check(err, NotNil, Commentf("%s", in))
+	}
+}
+
+func (s *MPDSuite) TestMatchLanguage(c *C) {
+	c.Check(MatchLanguage("en", "en-US"), Equals, true)
+	c.Check(MatchLanguage("EN-us", "en-US"), Equals, true)
+	c.Check(MatchLanguage("*", "fr"), Equals, true)
+	c.Check(MatchLanguage("en-US", "en"), Equals, false)
+	c.Check(MatchLanguage("e", "en"), Equals, false)
+
+	c.Check(LookupLanguage([]string{"fr", "en-US-x-twain"}, []string{"de", "en"}), Equals, "en")
+	c.Check(LookupLanguage([]string{"zh-Hant-CN-x-private1"}, []string{"zh", "zh-Hant"}), Equals, "zh-Hant")
+	c.Check(LookupLanguage([]string{"ja"}, []string{"en"}), Equals, "")
+}
+
+func (s *MPDSuite) TestLanguageValidationAndEncoding(c *C) {
+	m := &MPD{Periods: []*Period{{AdaptationSets: []*AdaptationSet{
+		{MimeType: "audio/mp4", Lang: stringPtr("EN-us")},
+		{MimeType: "audio/mp4", Lang: stringPtr("en_US")},
+	}}}}
+
+	err := m.Validate()
+	c.Assert(err, NotNil)
+	c.Check(err.Error(), Equals, `Periods[0].AdaptationSets[1]: invalid lang "en_US"`)
+
+	b, err := m.EncodeWithOptions(EncodeOptions{CanonicalLanguages: true})
+	c.Assert(err, IsNil)
+	c.Check(strings.Contains(string(b), `lang="en-US"`), Equals, true)
+	c.Check(strings.Contains(string(b), `lang="en_US"`), Equals, true)
+	c.Check(*m.Periods[0].AdaptationSets[0].Lang, Equals, "EN-us")
+
+	b, err = m.Encode()
+	c.Assert(err, IsNil)
+	c.Check(strings.Contains(string(b), `lang="EN-us"`), Equals, true)
+}
@@ -0,0 +1,171 @@
+package mpd
+
+import (
+	"fmt"
+	"time"
+)
+
+// LatencyReport describes end-to-end latency achievable with a dynamic MPD.
+type LatencyReport struct {
+	// SegmentDuration is the longest segment duration.
+	SegmentDuration time.Duration
+	// AvailabilityTimeOffset is the smallest availabilityTimeOffset of SegmentTemplates, or zero.
+	AvailabilityTimeOffset time.Duration
+	// TargetLatency is ServiceDescription's Latency@target, or zero.
+	TargetLatency time.Duration
+	// SuggestedPresentationDelay is MPD@suggestedPresentationDelay, or zero.
+	SuggestedPresentationDelay time.Duration
+
+	// MinimumLatency is the lowest latency allowed by segment availability:
+	// segment duration reduced by availabilityTimeOffset.
+	MinimumLatency time.Duration
+	// EdgeLatency is the time between the end of the newest segment announced by SegmentTimeline and now,
+	// or zero if there is no SegmentTimeline.
+	EdgeLatency time.Duration
+	// LowLatency is true if MPD uses availabilityTimeOffset for chunked delivery.
+	LowLatency bool
+
+	// Problems lists inconsistent low-latency configuration.
+	Problems ValidationErrors
+}
+
+// AnalyzeLatency reports latency achievable with dynamic MPD m at wall-clock time now.
+func AnalyzeLatency(m *MPD, now time.Time) (*LatencyReport, error) {
+	if m.Type == nil || *m.Type != "dynamic" {
+		return nil, fmt.Errorf("AnalyzeLatency: MPD is not dynamic")
+	}
+
+	res := new(LatencyReport)
+	var err error
+	if m.SuggestedPresentationDelay != nil {
+		if res.SuggestedPresentationDelay, err = ParseDuration(*m.SuggestedPresentationDelay); err != nil {
+			return nil, fmt.Errorf("AnalyzeLatency: %w", err)
+		}
+	}
+	var latency *Latency
+	for _, sd := range m.ServiceDescriptions {
+		if sd.Latency != nil {
+			latency = sd.Latency
+			break
+		}
+	}
+	if latency != nil && latency.Target != nil {
+		res.TargetLatency = time.Duration(*latency.Target) * time.Millisecond
+	}
+
+	ast, err := m.AvailabilityStart()
+	if err != nil {
+		return nil, fmt.Errorf("AnalyzeLatency: %w", err)
+	}
+
+	ato := time.Duration(-1)
+	var edge time.Time
+	for i, p := range m.Periods {
+		var start time.Duration
+		if p.Start != nil {
+			if start, err = ParseDuration(*p.Start); err != nil {
+				return nil, fmt.Errorf("AnalyzeLatency: %w", err)
+			}
+		}
+
+		for j, as := range p.AdaptationSets {
+			templates := []*SegmentTemplate{as.SegmentTemplate}
+			for _, r := range as.Representations {
+				templates = append(templates, r.SegmentTemplate)
+			}
+			for _, t := range templates {
+				if t == nil {
+					continue
+				}
+				if d := maxSegmentDuration(t); d > res.SegmentDuration {
+					res.SegmentDuration = d
+				}
+
+				var offset time.Duration
+				if t.AvailabilityTimeOffset != nil {
+					offset = time.Duration(*t.AvailabilityTimeOffset * float64(time.Second))
+					res.LowLatency = true
+					if t.AvailabilityTimeComplete == nil || *t.AvailabilityTimeComplete {
+						res.Problems = append(res.Problems, newValidationError(adaptationSetPath(i, j),
+							"availabilityTimeOffset is set, but availabilityTimeComplete is not false"))
+					}
+				} else if t.AvailabilityTimeComplete != nil && !*t.AvailabilityTimeComplete {
+					res.Problems = append(res.Problems, newValidationError(adaptationSetPath(i, j),
+						"availabilityTimeComplete is false without availabilityTimeOffset"))
+				}
+				if ato < 0 || offset < ato {
+					ato = offset
+				}
+
+				if end, ok := timelineEnd(t); ok && !ast.IsZero() {
+					if e := ast.Add(start + end); e.After(edge) {
+						edge = e
+					}
+				}
+			}
+		}
+	}
+	if ato > 0 {
+		res.AvailabilityTimeOffset = ato
+	}
+
+	res.MinimumLatency = res.SegmentDuration - res.AvailabilityTimeOffset
+	if res.MinimumLatency < 0 {
+		res.MinimumLatency = 0
+	}
+	if !edge.IsZero() {
+		res.EdgeLatency = now.Sub(edge)
+	}
+
+	problem := func(format string, args ...interface{}) {
+		res.Problems = append(res.Problems, newValidationError("", format, args...))
+	}
+	if res.TargetLatency > 0 && res.TargetLatency < res.MinimumLatency {
+		problem("target latency %s is below achievable %s", FormatDuration(res.TargetLatency), FormatDuration(res.MinimumLatency))
+	}
+	if latency != nil && latency.Target != nil {
+		if latency.Min != nil && *latency.Min > *latency.Target {
+			problem("minimal latency %dms exceeds target %dms", *latency.Min, *latency.Target)
+		}
+		if latency.Max != nil && *latency.Max < *latency.Target {
+			problem("maximal latency %dms is below target %dms", *latency.Max, *latency.Target)
+		}
+	}
+	if res.SuggestedPresentationDelay > 0 && res.SuggestedPresentationDelay < res.MinimumLatency {
+		problem("suggestedPresentationDelay %s is below achievable %s", FormatDuration(res.SuggestedPresentationDelay), FormatDuration(res.MinimumLatency))
+	}
+	if res.LowLatency {
+		if res.TargetLatency == 0 {
+			problem("low-latency MPD without ServiceDescription latency target")
+		}
+		if len(m.UTCTiming) == 0 {
+			problem("low-latency MPD without UTCTiming")
+		}
+	}
+	return res, nil
+}
+
+// timelineEnd returns presentation end of the last segment in t's SegmentTimeline relative to Period start.
+// Segments repeated until the end of Period (@r is -1) are counted once.
+func timelineEnd(t *SegmentTemplate) (time.Duration, bool) {
+	if len(t.SegmentTimeline) == 0 {
+		return 0, false
+	}
+	var end uint64
+	for _, tl := range t.SegmentTimeline {
+		for _, s := range tl.Segments {
+			if s.T != nil {
+				end = *s.T
+			}
+			n := uint64(1)
+			if s.R != nil && *s.R > 0 {
+				n += uint64(*s.R)
+			}
+			end += s.D * n
+		}
+	}
+	if t.PresentationTimeOffset != nil && *t.PresentationTimeOffset <= end {
+		end -= *t.PresentationTimeOffset
+	}
+	return timescaled(end, t.Timescale), true
+}
@@ -0,0 +1,51 @@
+package mpd
+
+import (
+	"strings"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestAnalyzeLatency(c *C) {
+	m := &MPD{
+		Type:                       stringPtr("dynamic"),
+		AvailabilityStartTime:      stringPtr("2016-01-01T00:00:00Z"),
+		SuggestedPresentationDelay: stringPtr("PT1S"),
+		ServiceDescriptions:        []ServiceDescription{{Latency: &Latency{Target: uint64Ptr(500), Max: uint64Ptr(400)}}},
+		Periods: []*Period{{Start: stringPtr("PT10S"), AdaptationSets: []*AdaptationSet{{
+			SegmentTemplate: &SegmentTemplate{
+				Timescale:              uint64Ptr(1000),
+				AvailabilityTimeOffset: float64Ptr(1.5),
+				SegmentTimeline: []SegmentTimeline{{
+					Segments: []SegmentTimelineSegment{{T: uint64Ptr(0), D: 2000, R: int64Ptr(4)}},
+				}},
+			},
+		}}}},
+	}
+	now := time.Date(2016, 1, 1, 0, 0, 21, 0, time.UTC)
+
+	r, err := AnalyzeLatency(m, now)
+	c.Assert(err, IsNil)
+	c.Check(r.SegmentDuration, Equals, 2*time.Second)
+	c.Check(r.AvailabilityTimeOffset, Equals, 1500*time.Millisecond)
+	c.Check(r.MinimumLatency, Equals, 500*time.Millisecond)
+	c.Check(r.TargetLatency, Equals, 500*time.Millisecond)
+	c.Check(r.EdgeLatency, Equals, time.Second)
+	c.Check(r.LowLatency, Equals, true)
+	c.Check(r.Problems.Error(), Equals, strings.Join([]string{
+		"Periods[0].AdaptationSets[0]: availabilityTimeOffset is set, but availabilityTimeComplete is not false",
+		"maximal latency 400ms is below target 500ms",
+		"low-latency MPD without UTCTiming",
+	}, "\n"))
+
+	m.Periods[0].AdaptationSets[0].SegmentTemplate.AvailabilityTimeComplete = boolPtr(false)
+	m.ServiceDescriptions[0].Latency.Max = nil
+	m.UTCTiming = []Descriptor{NewDescriptor(UTCTimingHTTPISOScheme, "https://time.example.com/")}
+	r, err = AnalyzeLatency(m, now)
+	c.Assert(err, IsNil)
+	c.Check(r.Problems, HasLen, 0)
+
+	_, err = AnalyzeLatency(&MPD{}, now)
+	c.Check(err, ErrorMatches, "AnalyzeLatency: MPD is not dynamic")
+}
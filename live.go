@@ -0,0 +1,82 @@
+package mpd
+
+import (
+	"time"
+)
+
+// RecommendMinimumUpdatePeriod returns minimumUpdatePeriod recommended for live MPD with SegmentTimeline
+// and given segment durations: clients should refresh the manifest at least once per the shortest segment,
+// otherwise new segments are announced too late.
+func RecommendMinimumUpdatePeriod(segmentDurations []time.Duration) time.Duration {
+	var res time.Duration
+	for _, d := range segmentDurations {
+		if d > 0 && (res == 0 || d < res) {
+			res = d
+		}
+	}
+	return res
+}
+
+// RecommendTimeShiftBufferDepth returns timeShiftBufferDepth recommended for desired DVR window
+// and given segment durations: the window is extended by the longest segment,
+// so the whole window is always covered by complete segments.
+func RecommendTimeShiftBufferDepth(segmentDurations []time.Duration, dvrWindow time.Duration) time.Duration {
+	var max time.Duration
+	for _, d := range segmentDurations {
+		if d > max {
+			max = d
+		}
+	}
+	return dvrWindow + max
+}
+
+// validateLiveTiming flags dangerous combinations of dynamic MPD timing attributes.
+func validateLiveTiming(m *MPD) ValidationErrors {
+	if m.Type == nil || *m.Type != "dynamic" {
+		return nil
+	}
+
+	var res ValidationErrors
+	parse := func(name string, s *string) time.Duration {
+		if s == nil {
+			return 0
+		}
+		d, err := ParseDuration(*s)
+		if err != nil {
+			res = append(res, newValidationError("", "invalid %s %q", name, *s))
+			return 0
+		}
+		return d
+	}
+	mup := parse("minimumUpdatePeriod", m.MinimumUpdatePeriod)
+	tsbd := parse("timeShiftBufferDepth", m.TimeShiftBufferDepth)
+
+	var timelineMax, max time.Duration
+	check := func(t *SegmentTemplate) {
+		d := maxSegmentDuration(t)
+		if d > max {
+			max = d
+		}
+		if t != nil && len(t.SegmentTimeline) > 0 && d > timelineMax {
+			timelineMax = d
+		}
+	}
+	for _, p := range m.Periods {
+		for _, as := range p.AdaptationSets {
+			check(as.SegmentTemplate)
+			for _, r := range as.Representations {
+				check(r.SegmentTemplate)
+			}
+		}
+	}
+
+	if mup > 0 && timelineMax > 0 && mup > timelineMax {
+		res = append(res, newValidationError("", "minimumUpdatePeriod %s exceeds segment duration %s of SegmentTimeline",
+			FormatDuration(mup), FormatDuration(timelineMax)))
+	}
+	if tsbd > 0 && max > 0 && tsbd < max {
+		res = append(res, newValidationError("", "timeShiftBufferDepth %s is shorter than segment duration %s",
+			FormatDuration(tsbd), FormatDuration(max)))
+	}
+	return res
+}
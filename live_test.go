@@ -0,0 +1,42 @@
+package mpd
+
+import (
+	"strings"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestLiveAdvisors(c *C) {
+	durations := []time.Duration{2 * time.Second, 1900 * time.Millisecond, 2100 * time.Millisecond}
+	c.Check(RecommendMinimumUpdatePeriod(durations), Equals, 1900*time.Millisecond)
+	c.Check(RecommendMinimumUpdatePeriod(nil), Equals, time.Duration(0))
+	c.Check(RecommendTimeShiftBufferDepth(durations, time.Minute), Equals, 62100*time.Millisecond)
+}
+
+func (s *MPDSuite) TestValidateLiveTiming(c *C) {
+	m := &MPD{
+		Type:                 stringPtr("dynamic"),
+		MinimumUpdatePeriod:  stringPtr("PT10S"),
+		TimeShiftBufferDepth: stringPtr("PT1S"),
+		Periods: []*Period{{AdaptationSets: []*AdaptationSet{{
+			SegmentTemplate: &SegmentTemplate{Timescale: uint64Ptr(1000), SegmentTimeline: []SegmentTimeline{{
+				Segments: []SegmentTimelineSegment{{D: 2000}},
+			}}},
+		}}}},
+	}
+	err := m.Validate()
+	c.Assert(err, NotNil)
+	c.Check(err.Error(), Equals, strings.Join([]string{
+		"minimumUpdatePeriod PT10S exceeds segment duration PT2S of SegmentTimeline",
+		"timeShiftBufferDepth PT1S is shorter than segment duration PT2S",
+	}, "\n"))
+
+	m.MinimumUpdatePeriod = stringPtr("PT2S")
+	m.TimeShiftBufferDepth = stringPtr("PT30S")
+	c.Check(m.Validate(), IsNil)
+
+	m.Type = stringPtr("static")
+	m.MinimumUpdatePeriod = stringPtr("PT1M")
+	c.Check(m.Validate(), IsNil)
+}
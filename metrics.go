@@ -0,0 +1,132 @@
+package mpd
+
+import (
+	"expvar"
+	"sync"
+	"time"
+)
+
+// ManifestStats describes a single Decode or Encode call.
+type ManifestStats struct {
+	// Latency of the call.
+	Latency time.Duration
+	// Size of XML document in bytes.
+	Size int
+	// Err is a returned error; counts are zero if it is not nil.
+	Err error
+
+	Periods         int
+	AdaptationSets  int
+	Representations int
+	// Segments is a number of segments described by SegmentTimelines, S@r=-1 counts as one.
+	Segments int
+	// MinimumUpdatePeriod is a manifest update interval of dynamic MPD, or zero.
+	MinimumUpdatePeriod time.Duration
+}
+
+// Metrics receives measurements of Decode and Encode calls. Methods should be safe for concurrent use and fast.
+type Metrics interface {
+	ManifestDecoded(s *ManifestStats)
+	ManifestEncoded(s *ManifestStats)
+}
+
+var (
+	metricsM sync.RWMutex
+	metrics  Metrics
+)
+
+// SetMetrics sets Metrics receiving measurements of all Decode and Encode calls. Nil disables measurements.
+func SetMetrics(m Metrics) {
+	metricsM.Lock()
+	metrics = m
+	metricsM.Unlock()
+}
+
+// currentMetrics returns Metrics set by SetMetrics, or nil.
+func currentMetrics() Metrics {
+	metricsM.RLock()
+	defer metricsM.RUnlock()
+	return metrics
+}
+
+// newManifestStats returns stats of m measured since start.
+func newManifestStats(m *MPD, start time.Time, size int, err error) *ManifestStats {
+	s := &ManifestStats{Latency: now().Sub(start), Size: size, Err: err}
+	if err != nil {
+		return s
+	}
+
+	count := func(t *SegmentTemplate) {
+		if t == nil {
+			return
+		}
+		for _, tl := range t.SegmentTimeline {
+			for _, seg := range tl.Segments {
+				if r := seg.GetRepeat(); r > 0 {
+					s.Segments += int(r)
+				}
+				s.Segments++
+			}
+		}
+	}
+	s.Periods = len(m.Periods)
+	for _, p := range m.Periods {
+		count(p.SegmentTemplate)
+		s.AdaptationSets += len(p.AdaptationSets)
+		for _, as := range p.AdaptationSets {
+			count(as.SegmentTemplate)
+			s.Representations += len(as.Representations)
+			for k := range as.Representations {
+				count(as.Representations[k].SegmentTemplate)
+			}
+		}
+	}
+	if m.Type != nil && *m.Type == "dynamic" && m.MinimumUpdatePeriod != nil {
+		s.MinimumUpdatePeriod, _ = ParseDuration(*m.MinimumUpdatePeriod)
+	}
+	return s
+}
+
+// ExpvarMetrics is Metrics publishing counters as expvar.Map, e.g. for Prometheus expvar exporter:
+// decodes, decode_errors, decode_ns, decoded_bytes, encodes, encode_errors, encode_ns, encoded_bytes,
+// and last_periods, last_segments, last_minimum_update_period_ns of the last decoded manifest.
+type ExpvarMetrics struct {
+	*expvar.Map
+}
+
+// NewExpvarMetrics returns ExpvarMetrics published with given name.
+func NewExpvarMetrics(name string) *ExpvarMetrics {
+	return &ExpvarMetrics{Map: expvar.NewMap(name)}
+}
+
+// ManifestDecoded implements Metrics interface.
+func (e *ExpvarMetrics) ManifestDecoded(s *ManifestStats) {
+	e.Add("decodes", 1)
+	if s.Err != nil {
+		e.Add("decode_errors", 1)
+		return
+	}
+	e.Add("decode_ns", int64(s.Latency))
+	e.Add("decoded_bytes", int64(s.Size))
+	e.set("last_periods", int64(s.Periods))
+	e.set("last_segments", int64(s.Segments))
+	e.set("last_minimum_update_period_ns", int64(s.MinimumUpdatePeriod))
+}
+
+// ManifestEncoded implements Metrics interface.
+func (e *ExpvarMetrics) ManifestEncoded(s *ManifestStats) {
+	e.Add("encodes", 1)
+	if s.Err != nil {
+		e.Add("encode_errors", 1)
+		return
+	}
+	e.Add("encode_ns", int64(s.Latency))
+	e.Add("encoded_bytes", int64(s.Size))
+}
+
+// set sets gauge value.
+func (e *ExpvarMetrics) set(key string, v int64) {
+	i := new(expvar.Int)
+	i.Set(v)
+	e.Set(key, i)
+}
@@ -0,0 +1,65 @@
+package mpd
+
+import (
+	"errors"
+	"io/ioutil"
+
+	. "gopkg.in/check.v1"
+)
+
+type testMetrics struct {
+	decoded, encoded []*ManifestStats
+}
+
+func (t *testMetrics) ManifestDecoded(s *ManifestStats) { t.decoded = append(t.decoded, s) }
+func (t *testMetrics) ManifestEncoded(s *ManifestStats) { t.encoded = append(t.encoded, s) }
+
+func (s *MPDSuite) TestMetrics(c *C) {
+	b, err := ioutil.ReadFile("fixture_elemental_delta_live.mpd")
+	c.Assert(err, IsNil)
+
+	t := new(testMetrics)
+	SetMetrics(t)
+	defer SetMetrics(nil)
+
+	m := new(MPD)
+	c.Assert(m.Decode(b), IsNil)
+	_, err = m.Encode()
+	c.Assert(err, IsNil)
+	c.Check(new(MPD).Decode([]byte("<MPD")), NotNil)
+
+	c.Assert(t.decoded, HasLen, 2)
+	c.Assert(t.encoded, HasLen, 1)
+	d := t.decoded[0]
+	c.Check(d.Err, IsNil)
+	c.Check(d.Size, Equals, len(b))
+	c.Check(d.Periods, Equals, len(m.Periods))
+	c.Check(d.AdaptationSets > 0, Equals, true)
+	c.Check(d.Representations > 0, Equals, true)
+	c.Check(d.Segments > 0, Equals, true)
+	c.Check(d.MinimumUpdatePeriod > 0, Equals, true)
+	c.Check(t.encoded[0].Periods, Equals, d.Periods)
+	c.Check(t.decoded[1].Err, NotNil)
+	c.Check(t.decoded[1].Periods, Equals, 0)
+}
+
+func (s *MPDSuite) TestExpvarMetrics(c *C) {
+	e := NewExpvarMetrics("mpd_test")
+	e.ManifestDecoded(&ManifestStats{Size: 100, Latency: 5, Periods: 2, Segments: 10})
+	e.ManifestDecoded(&ManifestStats{Size: 50, Latency: 3, Periods: 1, Segments: 4})
+	e.ManifestDecoded(&ManifestStats{Err: errors.New("bad")})
+	e.ManifestEncoded(&ManifestStats{Size: 80, Latency: 2})
+
+	for key, expected := range map[string]string{
+		"decodes":       "3",
+		"decode_errors": "1",
+		"decode_ns":     "8",
+		"decoded_bytes": "150",
+		"last_periods":  "1",
+		"last_segments": "4",
+		"encodes":       "1",
+		"encoded_bytes": "80",
+	} {
+		c.Check(e.Get(key).String(), Equals, expected, Commentf("%s", key))
+	}
+}
@@ -0,0 +1,77 @@
+package mpd
+
+import (
+	"strings"
+
+	"github.com/jun-oku/mpd/codecs"
+)
+
+// Standard mimeType values.
+const (
+	MimeTypeVideoMP4       = "video/mp4"
+	MimeTypeAudioMP4       = "audio/mp4"
+	MimeTypeApplicationMP4 = "application/mp4"
+	MimeTypeTextVTT        = "text/vtt"
+	MimeTypeImageJPEG      = "image/jpeg"
+)
+
+// Standard contentType values.
+const (
+	ContentTypeVideo = "video"
+	ContentTypeAudio = "audio"
+	ContentTypeText  = "text"
+	ContentTypeImage = "image"
+)
+
+// InferContentType returns contentType of as: @contentType if present,
+// otherwise derived from @mimeType and Representation codecs. It returns empty string if type is unknown.
+func InferContentType(as *AdaptationSet) string {
+	if as.ContentType != nil && *as.ContentType != "" {
+		return *as.ContentType
+	}
+
+	switch {
+	case strings.HasPrefix(as.MimeType, "video/"):
+		return ContentTypeVideo
+	case strings.HasPrefix(as.MimeType, "audio/"):
+		return ContentTypeAudio
+	case strings.HasPrefix(as.MimeType, "text/"), as.MimeType == MimeTypeTTML:
+		return ContentTypeText
+	case strings.HasPrefix(as.MimeType, "image/"):
+		return ContentTypeImage
+	}
+
+	// application/mp4 and others: look at codecs
+	list := []*string{as.Codecs}
+	for _, r := range as.Representations {
+		list = append(list, r.Codecs)
+	}
+	for _, s := range list {
+		if s == nil {
+			continue
+		}
+		list, err := codecs.ParseList(*s)
+		if err != nil {
+			continue
+		}
+		for _, c := range list {
+			if c.Type != codecs.Unknown {
+				return c.Type.String()
+			}
+		}
+	}
+	return ""
+}
+
+// SetContentType writes inferred contentType to as if it's absent. It returns false if type is unknown.
+func (as *AdaptationSet) SetContentType() bool {
+	if as.ContentType != nil {
+		return true
+	}
+	t := InferContentType(as)
+	if t == "" {
+		return false
+	}
+	as.ContentType = &t
+	return true
+}
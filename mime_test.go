@@ -0,0 +1,27 @@
+package mpd
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestInferContentType(c *C) {
+	for _, t := range []struct {
+		as       *AdaptationSet
+		expected string
+	}{
+		{&AdaptationSet{MimeType: MimeTypeVideoMP4}, ContentTypeVideo},
+		{&AdaptationSet{MimeType: MimeTypeAudioMP4}, ContentTypeAudio},
+		{&AdaptationSet{MimeType: MimeTypeTextVTT}, ContentTypeText},
+		{&AdaptationSet{MimeType: MimeTypeImageJPEG}, ContentTypeImage},
+		{&AdaptationSet{MimeType: MimeTypeApplicationMP4, Representations: []Representation{{Codecs: stringPtr("stpp.ttml.im1t")}}}, ContentTypeText},
+		{&AdaptationSet{MimeType: MimeTypeVideoMP4, ContentType: stringPtr("audio")}, ContentTypeAudio},
+		{&AdaptationSet{MimeType: MimeTypeApplicationMP4}, ""},
+	} {
+		c.Check(InferContentType(t.as), Equals, t.expected, Commentf("%#v", t.as))
+	}
+
+	as := &AdaptationSet{MimeType: MimeTypeVideoMP4}
+	c.Check(as.SetContentType(), Equals, true)
+	c.Check(*as.ContentType, Equals, ContentTypeVideo)
+	c.Check((&AdaptationSet{}).SetContentType(), Equals, false)
+}
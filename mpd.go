@@ -3,6 +3,7 @@ package mpd
 
 import (
 	"bytes"
+	"context"
 	"encoding/xml"
 	"fmt"
 	"io"
@@ -58,39 +59,118 @@ func (c *ConditionalUint) UnmarshalXMLAttr(attr xml.Attr) error {
 var (
 	_ xml.MarshalerAttr   = ConditionalUint{}
 	_ xml.UnmarshalerAttr = &ConditionalUint{}
+	_ xml.Marshaler       = &MPD{}
 )
 
 // MPD represents root XML element.
 type MPD struct {
-	XMLNS                      *string   `xml:"xmlns,attr"`
-	Cenc                       *string   `xml:"cenc,attr"`
-	Mspr                       *string   `xml:"mspr,attr"`
-	Type                       *string   `xml:"type,attr"`
-	MinimumUpdatePeriod        *string   `xml:"minimumUpdatePeriod,attr"`
-	AvailabilityStartTime      *string   `xml:"availabilityStartTime,attr"`
-	MediaPresentationDuration  *string   `xml:"mediaPresentationDuration,attr"`
-	MinBufferTime              *string   `xml:"minBufferTime,attr"`
-	SuggestedPresentationDelay *string   `xml:"suggestedPresentationDelay,attr"`
-	TimeShiftBufferDepth       *string   `xml:"timeShiftBufferDepth,attr"`
-	PublishTime                *string   `xml:"publishTime,attr"`
-	Profiles                   string    `xml:"profiles,attr"`
-	BaseURL                    string    `xml:"BaseURL,omitempty"`
-	Periods                    []*Period `xml:"Period,omitempty"`
+	XSI                        *string              `xml:"xsi,attr"`
+	XMLNS                      *string              `xml:"xmlns,attr"`
+	SchemaLocation             *string              `xml:"schemaLocation,attr"`
+	Cenc                       *string              `xml:"cenc,attr"`
+	Mspr                       *string              `xml:"mspr,attr"`
+	Scte214                    *string              `xml:"scte214,attr"`
+	Omaf                       *string              `xml:"omaf,attr"`
+	Mas                        *string              `xml:"mas,attr"`
+	Dashif                     *string              `xml:"dashif,attr"`
+	Dvb                        *string              `xml:"dvb,attr"`
+	Type                       *string              `xml:"type,attr"`
+	MinimumUpdatePeriod        *string              `xml:"minimumUpdatePeriod,attr"`
+	AvailabilityStartTime      *string              `xml:"availabilityStartTime,attr"`
+	MediaPresentationDuration  *string              `xml:"mediaPresentationDuration,attr"`
+	MinBufferTime              *string              `xml:"minBufferTime,attr"`
+	SuggestedPresentationDelay *string              `xml:"suggestedPresentationDelay,attr"`
+	TimeShiftBufferDepth       *string              `xml:"timeShiftBufferDepth,attr"`
+	PublishTime                *string              `xml:"publishTime,attr"`
+	MaxSegmentDuration         *string              `xml:"maxSegmentDuration,attr"`
+	Profiles                   string               `xml:"profiles,attr"`
+	BaseURL                    string               `xml:"BaseURL,omitempty"`
+	ServiceDescriptions        []ServiceDescription `xml:"ServiceDescription,omitempty"`
+	Periods                    []*Period            `xml:"Period,omitempty"`
+	UTCTiming                  []Descriptor         `xml:"UTCTiming,omitempty"`
+	Extensions                 []Extension          `xml:",any"`
+
+	// NamespacePrefixes maps namespaces to prefixes used instead of default ones on encoding.
+	// Decode records prefixes declared on the root element.
+	NamespacePrefixes map[string]string `xml:"-"`
+
+	clock Clock
 }
 
+// Child element fields are declared in XSD sequence order, which encoding/xml follows on encoding.
+// Keep it that way when adding fields: see elementOrder.
+
 // Do not try to use encoding.TextMarshaler and encoding.TextUnmarshaler:
 // https://github.com/golang/go/issues/6859#issuecomment-118890463
 
 // Encode generates MPD XML.
 func (m *MPD) Encode() ([]byte, error) {
+	return m.EncodeWithOptions(EncodeOptions{})
+}
+
+// EncodeOptions control MPD encoding.
+type EncodeOptions struct {
+	// CanonicalLanguages normalizes @lang values with NormalizeLanguage. Invalid values are left intact.
+	CanonicalLanguages bool
+
+	// ValidateFirst runs Validate before encoding and refuses to encode invalid MPD,
+	// returning ValidationErrors instead.
+	ValidateFirst bool
+	// WarningsOnly makes ValidateFirst report problems to Warn (if set) and encode MPD anyway.
+	WarningsOnly bool
+	// Warn receives validation problems when WarningsOnly is set.
+	Warn func(err error)
+
+	// Dialect makes output follow conventions of a packager, e.g. DialectShaka.
+	Dialect *Dialect
+}
+
+// EncodeWithOptions generates MPD XML using given options.
+func (m *MPD) EncodeWithOptions(o EncodeOptions) ([]byte, error) {
+	if mt := currentMetrics(); mt != nil {
+		start := now()
+		b, err := m.encodeWithOptions(o)
+		mt.ManifestEncoded(newManifestStats(m, start, len(b), err))
+		return b, err
+	}
+	return m.encodeWithOptions(o)
+}
+
+func (m *MPD) encodeWithOptions(o EncodeOptions) ([]byte, error) {
+	if o.CanonicalLanguages {
+		restore := m.canonicalizeLanguages()
+		defer restore()
+	}
+
+	if o.ValidateFirst {
+		if err := m.Validate(); err != nil {
+			if !o.WarningsOnly {
+				return nil, err
+			}
+			if o.Warn != nil {
+				o.Warn(err)
+			}
+		}
+	}
+
 	x := new(bytes.Buffer)
 	e := xml.NewEncoder(x)
 	e.Indent("", "  ")
-	err := e.Encode(m)
+	err := e.EncodeElement((*plainMPD)(m), mpdStart)
 	if err != nil {
 		return nil, err
 	}
 
+	// declare namespaces of prefixed attributes if they are used but not declared
+	if mm := m.withUsedNamespaces(x.Bytes()); mm != nil {
+		x.Reset()
+		e = xml.NewEncoder(x)
+		e.Indent("", "  ")
+		if err = e.EncodeElement((*plainMPD)(mm), mpdStart); err != nil {
+			return nil, err
+		}
+	}
+
 	// hacks for self-closing tags
 	res := new(bytes.Buffer)
 	res.WriteString(`<?xml version="1.0" encoding="utf-8"?>`)
@@ -102,9 +182,24 @@ func (m *MPD) Encode() ([]byte, error) {
 			// namespaceへの対応が必要なためここで書き換える
 			// 参考 : https://github.com/golang/go/issues/11496
 			if strings.Contains(s, "<MPD") {
+				s = strings.Replace(s, ` xsi="`, ` xmlns:xsi="`, 1)
+				s = strings.Replace(s, ` schemaLocation="`, ` xsi:schemaLocation="`, 1)
 				s = strings.Replace(s, "cenc", "xmlns:cenc", 1)
 				s = strings.Replace(s, "mspr", "xmlns:mspr", 1)
+				s = strings.Replace(s, ` scte214="`, ` xmlns:scte214="`, 1)
+				s = strings.Replace(s, ` omaf="`, ` xmlns:omaf="`, 1)
+				s = strings.Replace(s, ` mas="`, ` xmlns:mas="`, 1)
+				s = strings.Replace(s, ` dashif="`, ` xmlns:dashif="`, 1)
+				s = strings.Replace(s, ` dvb="`, ` xmlns:dvb="`, 1)
+			}
+			if strings.Contains(s, ` supplementalCodecs="`) {
+				s = strings.Replace(s, ` supplementalCodecs="`, ` scte214:supplementalCodecs="`, 1)
 			}
+			if strings.Contains(s, ` projection_type="`) || strings.Contains(s, ` packing_type="`) {
+				s = strings.Replace(s, ` projection_type="`, ` omaf:projection_type="`, 1)
+				s = strings.Replace(s, ` packing_type="`, ` omaf:packing_type="`, 1)
+			}
+			s = renameGeneratedPrefixes(s)
 			if strings.Contains(s, "<pssh") {
 				s = strings.Replace(s, "cenc", "xmlns:cenc", 1)
 				s = strings.Replace(s, "pssh", "cenc:pssh", -1)
@@ -113,6 +208,14 @@ func (m *MPD) Encode() ([]byte, error) {
 				s = strings.Replace(s, "mspr", "xmlns:mspr", 1)
 				s = strings.Replace(s, "pro", "mspr:pro", -1)
 			}
+			if strings.Contains(s, "MarlinContentId") {
+				s = strings.Replace(s, "<MarlinContentId", "<mas:MarlinContentId", -1)
+				s = strings.Replace(s, "</MarlinContentId", "</mas:MarlinContentId", -1)
+			}
+			if strings.Contains(s, "Laurl") {
+				s = strings.Replace(s, "<Laurl", "<dashif:Laurl", -1)
+				s = strings.Replace(s, "</Laurl", "</dashif:Laurl", -1)
+			}
 			if strings.Contains(s, "default_KID") {
 				s = strings.Replace(s, "default_KID", "cenc:default_KID", -1)
 				s = strings.Replace(s, "cenc=", "xmlns:cenc=", -1)
@@ -130,12 +233,180 @@ func (m *MPD) Encode() ([]byte, error) {
 		}
 	}
 	res.WriteByte('\n')
-	return res.Bytes(), err
+	if o.Dialect == nil {
+		return applyPrefixes(res.Bytes(), m.NamespacePrefixes), err
+	}
+	return o.Dialect.apply(applyPrefixes(res.Bytes(), o.Dialect.prefixes(m.NamespacePrefixes))), err
+}
+
+// plainMPD is MPD without MarshalXML method, encoded by encoding/xml itself.
+type plainMPD MPD
+
+var mpdStart = xml.StartElement{Name: xml.Name{Local: "MPD"}}
+
+// MarshalXML implements xml.Marshaler interface, so MPD can be embedded in other XML documents.
+// It writes the same elements and namespace declarations as Encode, without XML declaration and
+// self-closing tags. start is ignored: MPD element is always named MPD.
+func (m *MPD) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	b, err := m.Encode()
+	if err != nil {
+		return fmt.Errorf("MarshalXML: %w", err)
+	}
+
+	// re-encode tokens with prefixes in local names, so encoding/xml doesn't redeclare namespaces
+	d := xml.NewDecoder(bytes.NewReader(b))
+	for {
+		t, err := d.RawToken()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("MarshalXML: %w", err)
+		}
+		switch tt := t.(type) {
+		case xml.ProcInst:
+			continue
+		case xml.CharData:
+			if len(bytes.TrimSpace(tt)) == 0 {
+				continue
+			}
+		case xml.StartElement:
+			se := xml.StartElement{Name: xml.Name{Local: prefixedName(tt.Name)}}
+			for _, a := range tt.Attr {
+				se.Attr = append(se.Attr, xml.Attr{Name: xml.Name{Local: prefixedName(a.Name)}, Value: a.Value})
+			}
+			t = se
+		case xml.EndElement:
+			t = xml.EndElement{Name: xml.Name{Local: prefixedName(tt.Name)}}
+		}
+		if err = e.EncodeToken(t); err != nil {
+			return err
+		}
+	}
+}
+
+// withUsedNamespaces returns shallow copy of m with declarations of namespaces used by prefixed
+// attributes and elements in encoded b, or nil if all of them are already declared.
+func (m *MPD) withUsedNamespaces(b []byte) *MPD {
+	mm := *m
+	var changed bool
+	declare := func(dst **string, ns string, patterns ...string) {
+		if *dst != nil {
+			return
+		}
+		for _, p := range patterns {
+			if bytes.Contains(b, []byte(p)) {
+				*dst = &ns
+				changed = true
+				return
+			}
+		}
+	}
+	declare(&mm.XSI, XSINamespace, ` schemaLocation="`)
+	declare(&mm.Scte214, SCTE214Namespace, ` supplementalCodecs="`)
+	declare(&mm.Omaf, OMAFNamespace, ` projection_type="`, ` packing_type="`)
+	declare(&mm.Mas, MarlinNamespace, "<MarlinContentIds>")
+	declare(&mm.Dashif, DASHIFCPSNamespace, "<Laurl>", "<Laurl ")
+	declare(&mm.Dvb, DVBNamespace, `="`+DVBNamespace+`"`)
+
+	if !changed {
+		return nil
+	}
+	return &mm
 }
 
 // Decode parses MPD XML.
 func (m *MPD) Decode(b []byte) error {
-	return xml.Unmarshal(b, m)
+	return m.DecodeWithOptions(b, DecodeOptions{})
+}
+
+// DecodeOptions control MPD decoding.
+type DecodeOptions struct {
+	// ValidateDRM checks ContentProtection schemeIdUri UUIDs and cenc:pssh and mspr:pro payloads
+	// after decoding, returning ValidationErrors for corrupt ones.
+	ValidateDRM bool
+
+	// Concurrency is a number of goroutines decoding Periods concurrently, which speeds up
+	// decoding of MPDs with many Periods. Zero or one decodes them sequentially.
+	Concurrency int
+
+	// InternStrings makes equal attribute values, like codecs or SegmentTemplate URLs repeated
+	// across thousands of Representations, share memory. It reduces heap usage of long MPDs.
+	InternStrings bool
+
+	// Strict rejects MPDs with duplicate attributes, duplicate Period, AdaptationSet or Representation ids
+	// within their parents, zero timescales and SegmentTimeline S elements with zero duration or repeat
+	// count below -1, returning ValidationErrors instead of decoding them as is.
+	Strict bool
+}
+
+// DecodeWithOptions parses MPD XML using given options.
+func (m *MPD) DecodeWithOptions(b []byte, o DecodeOptions) error {
+	return m.DecodeContext(context.Background(), b, o)
+}
+
+// DecodeContext is like DecodeWithOptions, but stops parsing when ctx is done and returns ctx.Err(),
+// so decoding of large MPDs respects request deadlines.
+func (m *MPD) DecodeContext(ctx context.Context, b []byte, o DecodeOptions) error {
+	if mt := currentMetrics(); mt != nil {
+		start := now()
+		err := m.decodeWithOptions(ctx, b, o)
+		mt.ManifestDecoded(newManifestStats(m, start, len(b), err))
+		return err
+	}
+	return m.decodeWithOptions(ctx, b, o)
+}
+
+func (m *MPD) decodeWithOptions(ctx context.Context, b []byte, o DecodeOptions) error {
+	if o.Strict {
+		if errs := checkDuplicateAttributes(b); len(errs) > 0 {
+			return errs
+		}
+	}
+	if o.Concurrency > 1 {
+		if err := m.decodeParallel(ctx, b, o.Concurrency); err != nil {
+			return err
+		}
+	} else if err := unmarshalContext(ctx, b, m); err != nil {
+		return err
+	}
+	m.NamespacePrefixes = scanPrefixes(b)
+	if o.InternStrings {
+		m.internStrings()
+	}
+
+	if o.Strict {
+		if errs := validateStrict(m); len(errs) > 0 {
+			return errs
+		}
+	}
+	if o.ValidateDRM {
+		if errs := validateDRM(m); len(errs) > 0 {
+			return errs
+		}
+	}
+	return nil
+}
+
+// ServiceDescription represents XSD's ServiceDescriptionType.
+type ServiceDescription struct {
+	ID           *uint64       `xml:"id,attr"`
+	Latency      *Latency      `xml:"Latency,omitempty"`
+	PlaybackRate *PlaybackRate `xml:"PlaybackRate,omitempty"`
+}
+
+// Latency represents XSD's LatencyType. Values are in milliseconds.
+type Latency struct {
+	ReferenceID *uint64 `xml:"referenceId,attr"`
+	Target      *uint64 `xml:"target,attr"`
+	Max         *uint64 `xml:"max,attr"`
+	Min         *uint64 `xml:"min,attr"`
+}
+
+// PlaybackRate represents XSD's PlaybackRateType.
+type PlaybackRate struct {
+	Max *float64 `xml:"max,attr"`
+	Min *float64 `xml:"min,attr"`
 }
 
 // Period represents XSD's PeriodType.
@@ -143,53 +414,150 @@ type Period struct {
 	Start                *string              `xml:"start,attr"`
 	ID                   *string              `xml:"id,attr"`
 	Duration             *string              `xml:"duration,attr"`
-	SupplementalProperty *Descriptor          `xml:"SupplementalProperty,omitempty"`
+	BitstreamSwitching   *bool                `xml:"bitstreamSwitching,attr"`
 	BaseURL              string               `xml:"BaseURL,omitempty"`
+	SegmentBase          *SegmentBase         `xml:"SegmentBase,omitempty"`
+	SegmentList          *SegmentList         `xml:"SegmentList,omitempty"`
+	SegmentTemplate      *SegmentTemplate     `xml:"SegmentTemplate,omitempty"`
 	EventStreams         []EventStream        `xml:"EventStream,omitempty"`
 	ProgramEventStreams  []ProgramEventStream `xml:"ProgramEventStream,omitempty"`
+	Extensions           []Extension          `xml:",any"`
 	AdaptationSets       []*AdaptationSet     `xml:"AdaptationSet,omitempty"`
+	SupplementalProperty *Descriptor          `xml:"SupplementalProperty,omitempty"`
+	// EmptyAdaptationSets signal AdaptationSets without Representations, e.g. ones continued in the next Period.
+	EmptyAdaptationSets []*AdaptationSet `xml:"EmptyAdaptationSet,omitempty"`
+	Preselections       []Preselection   `xml:"Preselection,omitempty"`
 }
 
+// Descriptor represents XSD's DescriptorType.
 type Descriptor struct {
 	SchemeIDURI *string `xml:"schemeIdUri,attr"`
 	Value       *string `xml:"value,attr"`
 	ID          *string `xml:"id,attr"`
+	// OMAF attributes
+	ProjectionType *string `xml:"projection_type,attr"`
+	PackingType    *string `xml:"packing_type,attr"`
+	// DVB font download attributes
+	FontURL      *string `xml:"urn:dvb:dash-extensions:2014-1 url,attr"`
+	FontFamily   *string `xml:"urn:dvb:dash-extensions:2014-1 fontFamily,attr"`
+	FontMimeType *string `xml:"urn:dvb:dash-extensions:2014-1 mimeType,attr"`
 }
 
 // AdaptationSet represents XSD's AdaptationSetType.
 type AdaptationSet struct {
-	MimeType                string              `xml:"mimeType,attr"`
-	SegmentAlignment        ConditionalUint     `xml:"segmentAlignment,attr"`
-	SubsegmentAlignment     ConditionalUint     `xml:"subsegmentAlignment,attr"`
-	StartWithSAP            *uint64             `xml:"startWithSAP,attr"`
-	SubsegmentStartsWithSAP *uint64             `xml:"subsegmentStartsWithSAP,attr"`
-	BitstreamSwitching      *bool               `xml:"bitstreamSwitching,attr"`
-	Lang                    *string             `xml:"lang,attr"`
-	ContentProtections      []ContentProtection `xml:"ContentProtection,omitempty"`
-	Representations         []Representation    `xml:"Representation,omitempty"`
-	FrameRate               *string             `xml:"frameRate,attr"`
-	SegmentTemplate         *SegmentTemplate    `xml:"SegmentTemplate,omitempty"`
+	ID                      *uint64         `xml:"id,attr"`
+	MimeType                string          `xml:"mimeType,attr"`
+	SegmentAlignment        ConditionalUint `xml:"segmentAlignment,attr"`
+	SubsegmentAlignment     ConditionalUint `xml:"subsegmentAlignment,attr"`
+	StartWithSAP            *uint64         `xml:"startWithSAP,attr"`
+	SubsegmentStartsWithSAP *uint64         `xml:"subsegmentStartsWithSAP,attr"`
+	BitstreamSwitching      *bool           `xml:"bitstreamSwitching,attr"`
+	Lang                    *string         `xml:"lang,attr"`
+	ContentType             *string         `xml:"contentType,attr"`
+	MaxPlayoutRate          *float64        `xml:"maxPlayoutRate,attr"`
+	CodingDependency        *bool           `xml:"codingDependency,attr"`
+	SelectionPriority       *uint64         `xml:"selectionPriority,attr"`
+	Tag                     *string         `xml:"tag,attr"`
+	RepresentationBase
+	Accessibility   []Descriptor     `xml:"Accessibility,omitempty"`
+	Roles           []Descriptor     `xml:"Role,omitempty"`
+	BaseURL         string           `xml:"BaseURL,omitempty"`
+	SegmentBase     *SegmentBase     `xml:"SegmentBase,omitempty"`
+	SegmentList     *SegmentList     `xml:"SegmentList,omitempty"`
+	SegmentTemplate *SegmentTemplate `xml:"SegmentTemplate,omitempty"`
+	Representations []Representation `xml:"Representation,omitempty"`
+	Extensions      []Extension      `xml:",any"`
+	FrameRate       *string          `xml:"frameRate,attr"`
+	Codecs          *string          `xml:"codecs,attr"`
 }
 
 // Representation represents XSD's RepresentationType.
 type Representation struct {
-	ID                        *string                    `xml:"id,attr"`
-	Width                     *uint64                    `xml:"width,attr"`
-	Height                    *uint64                    `xml:"height,attr"`
-	FrameRate                 *string                    `xml:"frameRate,attr"`
-	Bandwidth                 *uint64                    `xml:"bandwidth,attr"`
-	AudioSamplingRate         *string                    `xml:"audioSamplingRate,attr"`
-	Codecs                    *string                    `xml:"codecs,attr"`
-	ContentProtections        []ContentProtection        `xml:"ContentProtection,omitempty"`
-	SegmentTemplate           *SegmentTemplate           `xml:"SegmentTemplate,omitempty"`
-	ScanType                  *string                    `xml:"scanType,attr"`
-	AudioChannelConfiguration *AudioChannelConfiguration `xml:"AudioChannelConfiguration,omitempty"`
+	ID                 *string `xml:"id,attr"`
+	Width              *uint64 `xml:"width,attr"`
+	Height             *uint64 `xml:"height,attr"`
+	FrameRate          *string `xml:"frameRate,attr"`
+	Bandwidth          *uint64 `xml:"bandwidth,attr"`
+	QualityRanking     *uint64 `xml:"qualityRanking,attr"`
+	AudioSamplingRate  *string `xml:"audioSamplingRate,attr"`
+	Codecs             *string `xml:"codecs,attr"`
+	SupplementalCodecs *string `xml:"supplementalCodecs,attr"`
+	DependencyID       *string `xml:"dependencyId,attr"`
+	AssociationID      *string `xml:"associationId,attr"`
+	AssociationType    *string `xml:"associationType,attr"`
+	RepresentationBase
+	BaseURL         string           `xml:"BaseURL,omitempty"`
+	SegmentBase     *SegmentBase     `xml:"SegmentBase,omitempty"`
+	SegmentList     *SegmentList     `xml:"SegmentList,omitempty"`
+	SegmentTemplate *SegmentTemplate `xml:"SegmentTemplate,omitempty"`
+	ScanType        *ScanType        `xml:"scanType,attr"`
+	Extensions      []Extension      `xml:",any"`
+}
+
+// Preselection represents XSD's PreselectionType.
+type Preselection struct {
+	ID *string `xml:"id,attr"`
+	// PreselectionComponents is a whitespace-separated list of AdaptationSet ids.
+	PreselectionComponents string  `xml:"preselectionComponents,attr"`
+	Lang                   *string `xml:"lang,attr"`
+	Codecs                 *string `xml:"codecs,attr"`
+	SelectionPriority      *uint64 `xml:"selectionPriority,attr"`
+	Tag                    *string `xml:"tag,attr"`
+	RepresentationBase
+	Accessibility []Descriptor `xml:"Accessibility,omitempty"`
+	Roles         []Descriptor `xml:"Role,omitempty"`
+}
+
+// RepresentationBase contains children of XSD's RepresentationBaseType
+// shared by AdaptationSet, Representation and Preselection.
+type RepresentationBase struct {
+	FramePackings              []Descriptor                `xml:"FramePacking,omitempty"`
+	AudioChannelConfigurations []AudioChannelConfiguration `xml:"AudioChannelConfiguration,omitempty"`
+	ContentProtections         []ContentProtection         `xml:"ContentProtection,omitempty"`
+	OutputProtection           *Descriptor                 `xml:"OutputProtection,omitempty"`
+	EssentialProperties        []Descriptor                `xml:"EssentialProperty,omitempty"`
+	SupplementalProperties     []Descriptor                `xml:"SupplementalProperty,omitempty"`
+	InbandEventStreams         []InbandEventStream         `xml:"InbandEventStream,omitempty"`
+	Switchings                 []Switching                 `xml:"Switching,omitempty"`
+	RandomAccesses             []RandomAccess              `xml:"RandomAccess,omitempty"`
+	GroupLabels                []Label                     `xml:"GroupLabel,omitempty"`
+	Labels                     []Label                     `xml:"Label,omitempty"`
+}
+
+// InbandEventStream represents XSD's EventStreamType used for signaling of events carried in segments.
+type InbandEventStream struct {
+	SchemeIDURI *string `xml:"schemeIdUri,attr"`
+	Value       *string `xml:"value,attr"`
+}
+
+// Switching represents XSD's SwitchingType.
+type Switching struct {
+	Interval uint64 `xml:"interval,attr"`
+	// Type is "media" (default) or "bitstream".
+	Type *string `xml:"type,attr"`
+}
+
+// RandomAccess represents XSD's RandomAccessType.
+type RandomAccess struct {
+	Interval uint64 `xml:"interval,attr"`
+	// Type is "closed" (default), "open" or "gradual".
+	Type          *string `xml:"type,attr"`
+	MinBufferTime *string `xml:"minBufferTime,attr"`
+	Bandwidth     *uint64 `xml:"bandwidth,attr"`
+}
+
+// Label represents XSD's LabelType.
+type Label struct {
+	ID    *uint64 `xml:"id,attr"`
+	Lang  *string `xml:"lang,attr"`
+	Value string  `xml:",chardata"`
 }
 
 // AudioChannelConfiguration,EventStream,Event from github.com/zencoder/go-dash //
 type AudioChannelConfiguration struct {
 	SchemeIDURI *string `xml:"schemeIdUri,attr"`
-	// Value will be an int for non-Dolby Schemes, and a hexstring for Dolby Schemes, hence we make it a string
+	// Value will be an int for non-Dolby Schemes, and a hexstring for Dolby Schemes, hence we make it a string.
+	// Use NewDolbyChannelConfiguration and others to build correct values.
 	Value *string `xml:"value,attr"`
 }
 
@@ -204,11 +572,12 @@ type ProgramEventStream struct {
 
 // EventStream from github.com/zencoder/go-dash //
 type EventStream struct {
-	XMLName     xml.Name `xml:"EventStream"`
-	SchemeIDURI *string  `xml:"schemeIdUri,attr"`
-	Value       *string  `xml:"value,attr,omitempty"`
-	Timescale   *int64   `xml:"timescale,attr"`
-	Events      []Event  `xml:"Event,omitempty"`
+	XMLName                xml.Name `xml:"EventStream"`
+	SchemeIDURI            *string  `xml:"schemeIdUri,attr"`
+	Value                  *string  `xml:"value,attr,omitempty"`
+	Timescale              *int64   `xml:"timescale,attr"`
+	PresentationTimeOffset *uint64  `xml:"presentationTimeOffset,attr"`
+	Events                 []Event  `xml:"Event,omitempty"`
 }
 
 // Event from github.com/zencoder/go-dash //
@@ -217,6 +586,18 @@ type Event struct {
 	ID               *string  `xml:"id,attr,omitempty"`
 	PresentationTime *int64   `xml:"presentationTime,attr,omitempty"`
 	Duration         *int64   `xml:"duration,attr,omitempty"`
+	MessageData      *string  `xml:"messageData,attr"`
+	// ContentEncoding is "base64" for binary Content, see Payload.
+	ContentEncoding *string `xml:"contentEncoding,attr"`
+	// Signal is SCTE-35 payload of SCTE35BinScheme Events, see SpliceInfo.
+	Signal *SCTE35Signal `xml:"http://www.scte.org/schemas/35/2016 Signal,omitempty"`
+	// Content is a message payload, e.g. ID3 tag.
+	Content string `xml:",chardata"`
+}
+
+// SCTE35Signal represents SCTE-35 Signal element with base64-encoded splice_info_section.
+type SCTE35Signal struct {
+	Binary string `xml:"Binary"`
 }
 
 // ContentProtection represents XSD's ContentProtectionType.
@@ -227,6 +608,9 @@ type ContentProtection struct {
 	Cenc        *string `xml:"cenc,attr"`
 	Pssh        *Pssh   `xml:"pssh,omitempty"`
 	Pro         *Pro    `xml:"pro,omitempty"`
+
+	MarlinContentIDs *MarlinContentIDs `xml:"MarlinContentIds,omitempty"`
+	Laurl            *Laurl            `xml:"Laurl,omitempty"`
 }
 
 // Pssh represents XSD's PsshType.
@@ -243,13 +627,53 @@ type Pro struct {
 
 // SegmentTemplate represents XSD's SegmentTemplateType.
 type SegmentTemplate struct {
+	Timescale                *uint64           `xml:"timescale,attr"`
+	Media                    *string           `xml:"media,attr"`
+	Initialization           *string           `xml:"initialization,attr"`
+	StartNumber              *uint64           `xml:"startNumber,attr"`
+	PresentationTimeOffset   *uint64           `xml:"presentationTimeOffset,attr"`
+	Duration                 *uint32           `xml:"duration,attr,omitempty"`
+	AvailabilityTimeOffset   *float64          `xml:"availabilityTimeOffset,attr"`
+	AvailabilityTimeComplete *bool             `xml:"availabilityTimeComplete,attr"`
+	SegmentTimeline          []SegmentTimeline `xml:"SegmentTimeline,omitempty"`
+	BitstreamSwitching       *URLType          `xml:"BitstreamSwitching,omitempty"`
+	Extensions               []Extension       `xml:",any"`
+}
+
+// SegmentBase represents XSD's SegmentBaseType.
+type SegmentBase struct {
+	Timescale              *uint64  `xml:"timescale,attr"`
+	PresentationTimeOffset *uint64  `xml:"presentationTimeOffset,attr"`
+	IndexRange             *string  `xml:"indexRange,attr"`
+	IndexRangeExact        *bool    `xml:"indexRangeExact,attr"`
+	Initialization         *URLType `xml:"Initialization,omitempty"`
+	RepresentationIndex    *URLType `xml:"RepresentationIndex,omitempty"`
+}
+
+// URLType represents XSD's URLType.
+type URLType struct {
+	SourceURL *string `xml:"sourceURL,attr"`
+	Range     *string `xml:"range,attr"`
+}
+
+// SegmentList represents XSD's SegmentListType.
+type SegmentList struct {
 	Timescale              *uint64           `xml:"timescale,attr"`
-	Media                  *string           `xml:"media,attr"`
-	Initialization         *string           `xml:"initialization,attr"`
-	StartNumber            *uint64           `xml:"startNumber,attr"`
 	PresentationTimeOffset *uint64           `xml:"presentationTimeOffset,attr"`
-	Duration               *uint32           `xml:"duration,attr,omitempty"`
+	Duration               *uint64           `xml:"duration,attr"`
+	StartNumber            *uint64           `xml:"startNumber,attr"`
+	Initialization         *URLType          `xml:"Initialization,omitempty"`
 	SegmentTimeline        []SegmentTimeline `xml:"SegmentTimeline,omitempty"`
+	BitstreamSwitching     *URLType          `xml:"BitstreamSwitching,omitempty"`
+	SegmentURLs            []SegmentURL      `xml:"SegmentURL,omitempty"`
+}
+
+// SegmentURL represents XSD's SegmentURLType.
+type SegmentURL struct {
+	Media      *string `xml:"media,attr"`
+	MediaRange *string `xml:"mediaRange,attr"`
+	Index      *string `xml:"index,attr"`
+	IndexRange *string `xml:"indexRange,attr"`
 }
 
 // SegmentTimeline represents XSD's SegmentTimelineType.
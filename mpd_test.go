@@ -1,9 +1,11 @@
 package mpd
 
 import (
+	"encoding/xml"
 	"io/ioutil"
 	"strings"
 	"testing"
+	"time"
 
 	. "gopkg.in/check.v1"
 )
@@ -28,13 +30,8 @@ func testUnmarshalMarshal(c *C, name string) {
 	err = ioutil.WriteFile(obtainedName, obtained, 0666)
 	c.Assert(err, IsNil)
 
-	// strip stupid XML rubish
-	expectedS := string(expected)
-	expectedS = strings.Replace(expectedS, `xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" `, ``, 1)
-	expectedS = strings.Replace(expectedS, `xsi:schemaLocation="urn:mpeg:dash:schema:mpd:2011 http://standards.iso.org/ittf/PubliclyAvailableStandards/MPEG-DASH_schema_files/DASH-MPD.xsd" `, ``, 1)
-
 	obtainedSlice := strings.Split(strings.TrimSpace(string(obtained)), "\n")
-	expectedSlice := strings.Split(strings.TrimSpace(expectedS), "\n")
+	expectedSlice := strings.Split(strings.TrimSpace(string(expected)), "\n")
 	c.Check(obtainedSlice, HasLen, len(expectedSlice))
 	for i := range obtainedSlice {
 		c.Check(obtainedSlice[i], Equals, expectedSlice[i], Commentf("line %d", i+1))
@@ -52,3 +49,101 @@ func (s *MPDSuite) TestUnmarshalMarshalLive(c *C) {
 func (s *MPDSuite) TestUnmarshalMarshalLiveDelta161(c *C) {
 	testUnmarshalMarshal(c, "fixture_elemental_delta_1.6.1_live.mpd")
 }
+
+func (s *MPDSuite) TestSchemaLocation(c *C) {
+	m := NewStaticMPD(ProfileISOFFOnDemand, time.Minute)
+	m.SchemaLocation = stringPtr(SchemaLocation)
+
+	b, err := m.Encode()
+	c.Assert(err, IsNil)
+	c.Check(string(b), Matches, `(?s).*<MPD xmlns:xsi="`+XSINamespace+`" xmlns="`+MPDNamespace+`" xsi:schemaLocation="`+SchemaLocation+`" .*`)
+
+	decoded := new(MPD)
+	c.Assert(decoded.Decode(b), IsNil)
+	c.Check(*decoded.XSI, Equals, XSINamespace)
+	c.Check(*decoded.SchemaLocation, Equals, SchemaLocation)
+}
+
+func (s *MPDSuite) TestOutputProtection(c *C) {
+	b := []byte(`<?xml version="1.0" encoding="utf-8"?>
+<MPD xmlns="urn:mpeg:dash:schema:mpd:2011" profiles="">
+  <Period>
+    <AdaptationSet mimeType="video/mp4">
+      <ContentProtection schemeIdUri="urn:mpeg:dash:mp4protection:2011" value="cenc"/>
+      <OutputProtection schemeIdUri="urn:example:hdcp" value="2.2"/>
+      <Representation id="1">
+        <OutputProtection schemeIdUri="urn:example:hdcp" value="1.4"/>
+      </Representation>
+    </AdaptationSet>
+  </Period>
+</MPD>
+`)
+	m := new(MPD)
+	c.Assert(m.Decode(b), IsNil)
+	as := m.Periods[0].AdaptationSets[0]
+	c.Check(*as.OutputProtection.Value, Equals, "2.2")
+	c.Check(*as.Representations[0].OutputProtection.Value, Equals, "1.4")
+
+	obtained, err := m.Encode()
+	c.Assert(err, IsNil)
+	c.Check(string(obtained), Equals, string(b))
+}
+
+func (s *MPDSuite) TestRepresentationBase(c *C) {
+	b := []byte(`<?xml version="1.0" encoding="utf-8"?>
+<MPD xmlns="urn:mpeg:dash:schema:mpd:2011" profiles="">
+  <Period>
+    <AdaptationSet mimeType="video/mp4">
+      <InbandEventStream schemeIdUri="urn:mpeg:dash:event:2012" value="1"/>
+      <GroupLabel id="1" lang="en">Video</GroupLabel>
+      <Representation id="1">
+        <Switching interval="2000" type="bitstream"/>
+        <RandomAccess interval="4000" type="open" minBufferTime="PT2S" bandwidth="5000000"/>
+        <Label>HD</Label>
+      </Representation>
+    </AdaptationSet>
+  </Period>
+</MPD>
+`)
+	m := new(MPD)
+	c.Assert(m.Decode(b), IsNil)
+	as := m.Periods[0].AdaptationSets[0]
+	c.Check(as.InbandEventStreams, HasLen, 1)
+	c.Check(as.GroupLabels[0].Value, Equals, "Video")
+	r := as.Representations[0]
+	c.Check(r.Switchings[0].Interval, Equals, uint64(2000))
+	c.Check(*r.RandomAccesses[0].Type, Equals, "open")
+	c.Check(r.Labels[0].Value, Equals, "HD")
+
+	obtained, err := m.Encode()
+	c.Assert(err, IsNil)
+	c.Check(string(obtained), Equals, string(b))
+}
+
+func (s *MPDSuite) TestMarshalXML(c *C) {
+	b, err := ioutil.ReadFile("fixture_elemental_delta_vod.mpd")
+	c.Assert(err, IsNil)
+	m := new(MPD)
+	c.Assert(m.Decode(b), IsNil)
+	m.Periods[0].AdaptationSets[0].Representations[0].SupplementalCodecs = stringPtr("dvh1.08.01")
+	expected, err := m.Encode()
+	c.Assert(err, IsNil)
+
+	type envelope struct {
+		XMLName xml.Name `xml:"Envelope"`
+		Body    struct {
+			MPD *MPD
+		}
+	}
+	var env envelope
+	env.Body.MPD = m
+	wrapped, err := xml.MarshalIndent(env, "", "  ")
+	c.Assert(err, IsNil)
+	c.Check(string(wrapped), Matches, `(?s)<Envelope>\s*<Body>\s*<MPD [^>]*xmlns="urn:mpeg:dash:schema:mpd:2011" [^>]*xmlns:scte214=".*scte214:supplementalCodecs="dvh1.08.01".*</MPD>\s*</Body>\s*</Envelope>`)
+
+	var decoded envelope
+	c.Assert(xml.Unmarshal(wrapped, &decoded), IsNil)
+	obtained, err := decoded.Body.MPD.Encode()
+	c.Assert(err, IsNil)
+	c.Check(string(obtained), Equals, string(expected))
+}
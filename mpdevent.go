@@ -0,0 +1,90 @@
+package mpd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MPDEventScheme is a schemeIdUri of events signaling MPD changes.
+const MPDEventScheme = "urn:mpeg:dash:event:2012"
+
+// MPDEventScheme values.
+const (
+	// MPDValidityExpiration signals that MPD is not valid after Event's presentation time.
+	// Event@messageData contains publishTime of the updated MPD.
+	MPDValidityExpiration = "1"
+	// MPDPatch signals MPD patch in Event content.
+	MPDPatch = "2"
+	// MPDUpdate signals complete updated MPD in Event content.
+	MPDUpdate = "3"
+)
+
+// MPDEvent is an Event of MPDEventScheme found by MPDEvents.
+type MPDEvent struct {
+	// Type is one of MPDValidityExpiration, MPDPatch or MPDUpdate.
+	Type  string
+	Event *Event
+	// At is the wall-clock time of Event.
+	At time.Time
+	// PublishTime of the updated MPD, if signaled.
+	PublishTime time.Time
+}
+
+// AddValidityExpiration adds MPD validity expiration Event to Period p of dynamic MPD m:
+// MPD expires at wall-clock time at, and updated MPD has given publishTime.
+func (m *MPD) AddValidityExpiration(p *Period, at, publishTime time.Time) (*Event, error) {
+	es := p.eventStream(MPDEventScheme, MPDValidityExpiration, 1000)
+	e, err := ScheduleEvent(m, p, es, at, 0)
+	if err != nil {
+		return nil, fmt.Errorf("AddValidityExpiration: %w", err)
+	}
+	e.ID = stringPtr(es.nextEventID())
+	e.MessageData = stringPtr(FormatDateTime(publishTime))
+	es.Events = append(es.Events, *e)
+	return &es.Events[len(es.Events)-1], nil
+}
+
+// MPDEvents returns all Events of MPDEventScheme in dynamic MPD m.
+func (m *MPD) MPDEvents() ([]MPDEvent, error) {
+	var res []MPDEvent
+	for _, p := range m.Periods {
+		for i := range p.EventStreams {
+			es := &p.EventStreams[i]
+			if es.SchemeIDURI == nil || *es.SchemeIDURI != MPDEventScheme || es.Value == nil {
+				continue
+			}
+			for j := range es.Events {
+				e := &es.Events[j]
+				at, _, err := EventWallClock(m, p, es, e)
+				if err != nil {
+					return nil, fmt.Errorf("MPDEvents: %w", err)
+				}
+				me := MPDEvent{Type: *es.Value, Event: e, At: at}
+				if *es.Value == MPDValidityExpiration && e.MessageData != nil {
+					if me.PublishTime, err = ParseDateTime(strings.TrimSpace(*e.MessageData)); err != nil {
+						return nil, fmt.Errorf("MPDEvents: %w", err)
+					}
+				}
+				res = append(res, me)
+			}
+		}
+	}
+	return res, nil
+}
+
+// ExpiresAt returns the earliest wall-clock time signaled by MPD validity expiration Events of m,
+// after which a client should refresh the manifest, or zero time if there are none.
+func (m *MPD) ExpiresAt() (time.Time, error) {
+	events, err := m.MPDEvents()
+	if err != nil {
+		return time.Time{}, err
+	}
+	var res time.Time
+	for _, e := range events {
+		if e.Type == MPDValidityExpiration && (res.IsZero() || e.At.Before(res)) {
+			res = e.At
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,47 @@
+package mpd
+
+import (
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestMPDEvents(c *C) {
+	m := &MPD{Type: stringPtr("dynamic"), AvailabilityStartTime: stringPtr("2016-01-01T00:00:00Z"), Periods: []*Period{{Start: stringPtr("PT0S")}}}
+
+	expiresAt, err := m.ExpiresAt()
+	c.Assert(err, IsNil)
+	c.Check(expiresAt.IsZero(), Equals, true)
+
+	at := time.Date(2016, 1, 1, 0, 1, 0, 0, time.UTC)
+	e, err := m.AddValidityExpiration(m.Periods[0], at, at.Add(-time.Second))
+	c.Assert(err, IsNil)
+	c.Check(*e.PresentationTime, Equals, int64(60000))
+	c.Check(*e.ID, Equals, "1")
+	_, err = m.AddValidityExpiration(m.Periods[0], at.Add(time.Minute), at)
+	c.Assert(err, IsNil)
+
+	events, err := m.MPDEvents()
+	c.Assert(err, IsNil)
+	c.Assert(events, HasLen, 2)
+	c.Check(events[0].Type, Equals, MPDValidityExpiration)
+	c.Check(events[0].At.Equal(at), Equals, true)
+	c.Check(events[0].PublishTime.Equal(at.Add(-time.Second)), Equals, true)
+
+	expiresAt, err = m.ExpiresAt()
+	c.Assert(err, IsNil)
+	c.Check(expiresAt.Equal(at), Equals, true)
+
+	b, err := m.Encode()
+	c.Assert(err, IsNil)
+	c.Check(string(b), Equals, `<?xml version="1.0" encoding="utf-8"?>
+<MPD type="dynamic" availabilityStartTime="2016-01-01T00:00:00Z" profiles="">
+  <Period start="PT0S">
+    <EventStream schemeIdUri="urn:mpeg:dash:event:2012" value="1" timescale="1000">
+      <Event id="1" presentationTime="60000" messageData="2016-01-01T00:00:59Z"/>
+      <Event id="2" presentationTime="120000" messageData="2016-01-01T00:01:00Z"/>
+    </EventStream>
+  </Period>
+</MPD>
+`)
+}
@@ -0,0 +1,82 @@
+package mpdserve
+
+import (
+	"net/http"
+	"sync"
+)
+
+// defaultMaxEntries is used if Cache.MaxEntries is zero.
+const defaultMaxEntries = 1024
+
+// Cache stores encoded manifests keyed by request URL and publishTime of Source manifest, so Handler doesn't
+// apply Transforms and encode the same dynamic manifest again until Source publishes a new one.
+// Only the latest manifest is kept for every URL.
+//
+// Transforms of cached Handler should depend only on manifest and the key of request,
+// see Key. Window trims manifest only when its publishTime changes.
+type Cache struct {
+	// Key returns cache key of request: request URL by default, or Sessions.CacheKey for Sources
+	// wrapped by Sessions. Set it if Transforms depend on anything else, e.g. headers; it must then
+	// include Sessions.CacheKey for Sessions to keep sessions apart.
+	Key func(r *http.Request) string
+	// MaxEntries limits the number of stored manifests, 1024 by default.
+	MaxEntries int
+
+	m       sync.Mutex
+	entries map[string]*response
+}
+
+// cacheKeyer is implemented by Sources which manifests depend on more than request URL.
+type cacheKeyer interface {
+	CacheKey(r *http.Request) string
+}
+
+// key returns cache key of r for manifests of source.
+func (c *Cache) key(r *http.Request, source Source) string {
+	if c.Key != nil {
+		return c.Key(r)
+	}
+	if k, ok := source.(cacheKeyer); ok {
+		return k.CacheKey(r)
+	}
+	return r.URL.String()
+}
+
+// get returns response stored for key and publishTime, or nil.
+func (c *Cache) get(key, publishTime string) *response {
+	c.m.Lock()
+	defer c.m.Unlock()
+	if res := c.entries[key]; res != nil && res.publishTime == publishTime {
+		return res
+	}
+	return nil
+}
+
+// put stores res for key, replacing older one.
+func (c *Cache) put(key string, res *response) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	if c.entries == nil {
+		c.entries = make(map[string]*response)
+	}
+
+	max := c.MaxEntries
+	if max <= 0 {
+		max = defaultMaxEntries
+	}
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= max {
+		// evict an arbitrary entry
+		for k := range c.entries {
+			delete(c.entries, k)
+			break
+		}
+	}
+	c.entries[key] = res
+}
+
+// Len returns the number of stored manifests.
+func (c *Cache) Len() int {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return len(c.entries)
+}
@@ -0,0 +1,67 @@
+package mpdserve
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/jun-oku/mpd"
+)
+
+func (s *MPDServeSuite) TestCache(c *C) {
+	m := mpd.NewDynamicMPD(mpd.ProfileISOFFLive, time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC), 10*time.Second)
+	m.PublishTime = stringPtr("2016-01-01T00:00:10Z")
+
+	var transforms int
+	h := &Handler{
+		Source: SourceFunc(func(r *http.Request) (*mpd.MPD, error) { return m.Clone(), nil }),
+		Transforms: []Transform{func(r *http.Request, m *mpd.MPD) error {
+			transforms++
+			return nil
+		}},
+		Cache: &Cache{MaxEntries: 2},
+	}
+	get := func(url, etag string) *httptest.ResponseRecorder {
+		r := newRequest(c, url)
+		if etag != "" {
+			r.Header.Set("If-None-Match", etag)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	w := get("/live.mpd", "")
+	c.Assert(w.Code, Equals, http.StatusOK)
+	etag := w.Header().Get("ETag")
+	body := w.Body.String()
+
+	w = get("/live.mpd", "")
+	c.Check(w.Code, Equals, http.StatusOK)
+	c.Check(w.Body.String(), Equals, body)
+	c.Check(w.Header().Get("ETag"), Equals, etag)
+	c.Check(w.Header().Get("Cache-Control"), Equals, "public, max-age=5")
+	c.Check(get("/live.mpd", etag).Code, Equals, http.StatusNotModified)
+	c.Check(transforms, Equals, 1)
+
+	// new manifest
+	m.PublishTime = stringPtr("2016-01-01T00:00:20Z")
+	w = get("/live.mpd", etag)
+	c.Check(w.Code, Equals, http.StatusOK)
+	c.Check(w.Header().Get("ETag"), Not(Equals), etag)
+	c.Check(transforms, Equals, 2)
+	c.Check(h.Cache.Len(), Equals, 1)
+
+	get("/live.mpd?a=1", "")
+	get("/live.mpd?a=2", "")
+	c.Check(h.Cache.Len(), Equals, 2)
+	c.Check(transforms, Equals, 4)
+
+	// manifests without publishTime are not cached
+	m.PublishTime = nil
+	get("/live.mpd?a=1", "")
+	get("/live.mpd?a=1", "")
+	c.Check(transforms, Equals, 6)
+}
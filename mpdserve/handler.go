@@ -0,0 +1,176 @@
+// Package mpdserve serves MPEG-DASH manifests over HTTP.
+package mpdserve
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/jun-oku/mpd"
+)
+
+// ContentType is a MIME type of MPD documents.
+const ContentType = "application/dash+xml"
+
+// defaultStaticMaxAge is used for static manifests if Handler.StaticMaxAge is zero.
+const defaultStaticMaxAge = time.Hour
+
+// Source provides manifests served by Handler. Handler modifies returned MPD,
+// so it should be a new one or a copy made with MPD.Clone.
+type Source interface {
+	MPD(r *http.Request) (*mpd.MPD, error)
+}
+
+// SourceFunc is an adapter to allow the use of ordinary functions as Source.
+type SourceFunc func(r *http.Request) (*mpd.MPD, error)
+
+// MPD implements Source interface.
+func (f SourceFunc) MPD(r *http.Request) (*mpd.MPD, error) {
+	return f(r)
+}
+
+// Transform modifies manifest for a request.
+type Transform func(r *http.Request, m *mpd.MPD) error
+
+// Handler is an http.Handler serving manifests from Source with Transforms applied in order.
+// It sets Cache-Control and ETag headers and handles conditional requests.
+type Handler struct {
+	Source     Source
+	Transforms []Transform
+
+	// StaticMaxAge is a max-age of static manifests, one hour by default.
+	// Dynamic manifests are cached for half of minimumUpdatePeriod.
+	StaticMaxAge time.Duration
+
+	// Cache, if set, stores encoded manifests which have publishTime, see Cache.
+	Cache *Cache
+
+	// Preload, if set, adds Link preload headers for segments players fetch first.
+	Preload *Preload
+
+	// OnError, if set, receives errors of Source, Transforms and encoding.
+	// Clients get 500 Internal Server Error without details.
+	OnError func(r *http.Request, err error)
+}
+
+// Preload configures Link preload headers of Handler responses, listing URLs of mpd.PreloadURLs
+// except the manifest itself, relative to the request path.
+type Preload struct {
+	// Criteria select AdaptationSets a player starts with.
+	Criteria mpd.SelectionCriteria
+	// Segments is a number of media segments preloaded for each selected Representation.
+	Segments int
+	// EarlyHints sends Link headers in 103 Early Hints response as soon as they are known: after Transforms,
+	// which preloaded URLs depend on, but before the manifest is encoded, or right away for cached manifests.
+	EarlyHints bool
+}
+
+// ServeHTTP implements http.Handler interface.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	res, err := h.response(r, func(link string) {
+		w.Header().Set("Link", link)
+		if h.Preload.EarlyHints {
+			w.WriteHeader(http.StatusEarlyHints)
+		}
+	})
+	if err != nil {
+		if h.OnError != nil {
+			h.OnError(r, err)
+		}
+		w.Header().Del("Link")
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", ContentType)
+	w.Header().Set("Cache-Control", res.cacheControl)
+	w.Header().Set("ETag", res.etag)
+	http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(res.body))
+}
+
+// response is an encoded manifest with its headers.
+type response struct {
+	publishTime  string
+	body         []byte
+	etag         string
+	cacheControl string
+	link         string
+}
+
+// response returns encoded manifest for r, from Cache if possible. It passes non-empty Link header value
+// to hint before encoding the manifest.
+func (h *Handler) response(r *http.Request, hint func(link string)) (*response, error) {
+	m, err := h.Source.MPD(r)
+	if err != nil {
+		return nil, fmt.Errorf("mpdserve: source: %w", err)
+	}
+
+	var key string
+	if h.Cache != nil && m.PublishTime != nil {
+		key = h.Cache.key(r, h.Source)
+		if res := h.Cache.get(key, *m.PublishTime); res != nil {
+			if res.link != "" {
+				hint(res.link)
+			}
+			return res, nil
+		}
+	}
+
+	res := new(response)
+	if m.PublishTime != nil {
+		res.publishTime = *m.PublishTime
+	}
+	for _, t := range h.Transforms {
+		if err = t(r, m); err != nil {
+			return nil, fmt.Errorf("mpdserve: transform: %w", err)
+		}
+	}
+	if h.Preload != nil {
+		manifestURL := r.URL.Path
+		if manifestURL == "" {
+			manifestURL = "/"
+		}
+		urls, err := mpd.PreloadURLs(manifestURL, m, h.Preload.Criteria, h.Preload.Segments)
+		if err != nil {
+			return nil, fmt.Errorf("mpdserve: preload: %w", err)
+		}
+		if len(urls) > 1 {
+			res.link = mpd.PreloadLinkHeader(urls[1:])
+			hint(res.link)
+		}
+	}
+	if res.body, err = m.Encode(); err != nil {
+		return nil, fmt.Errorf("mpdserve: encode: %w", err)
+	}
+	sum := sha1.Sum(res.body)
+	res.etag = `"` + hex.EncodeToString(sum[:8]) + `"`
+	res.cacheControl = h.cacheControl(m)
+
+	if key != "" {
+		h.Cache.put(key, res)
+	}
+	return res, nil
+}
+
+// cacheControl returns Cache-Control header value for m.
+func (h *Handler) cacheControl(m *mpd.MPD) string {
+	if m.Type == nil || *m.Type != "dynamic" {
+		age := h.StaticMaxAge
+		if age == 0 {
+			age = defaultStaticMaxAge
+		}
+		return fmt.Sprintf("public, max-age=%d", int(age.Seconds()))
+	}
+
+	if m.MinimumUpdatePeriod == nil {
+		return "no-cache"
+	}
+	mup, err := mpd.ParseDuration(*m.MinimumUpdatePeriod)
+	if err != nil || mup < 2*time.Second {
+		return "no-cache"
+	}
+	return fmt.Sprintf("public, max-age=%d", int(mup.Seconds()/2))
+}
@@ -0,0 +1,135 @@
+package mpdserve
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httptrace"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/jun-oku/mpd"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type MPDServeSuite struct{}
+
+var _ = Suite(&MPDServeSuite{})
+
+func newMPD() *mpd.MPD {
+	m := mpd.NewStaticMPD(mpd.ProfileISOFFLive, time.Minute)
+	m.Periods[0].AdaptationSets = []*mpd.AdaptationSet{{
+		MimeType:        mpd.MimeTypeVideoMP4,
+		SegmentTemplate: &mpd.SegmentTemplate{Media: stringPtr("$RepresentationID$/$Number$.m4s")},
+		Representations: []mpd.Representation{
+			{ID: stringPtr("low"), Bandwidth: uint64Ptr(500000), Height: uint64Ptr(360)},
+			{ID: stringPtr("high"), Bandwidth: uint64Ptr(3000000), Height: uint64Ptr(1080)},
+		},
+	}}
+	return m
+}
+
+func newRequest(c *C, url string) *http.Request {
+	r, err := http.NewRequest("GET", url, nil)
+	c.Assert(err, IsNil)
+	return r
+}
+
+func stringPtr(s string) *string { return &s }
+func uint64Ptr(v uint64) *uint64 { return &v }
+func uint32Ptr(v uint32) *uint32 { return &v }
+
+func (s *MPDServeSuite) TestHandler(c *C) {
+	h := &Handler{
+		Source:     SourceFunc(func(r *http.Request) (*mpd.MPD, error) { return newMPD(), nil }),
+		Transforms: []Transform{FilterRepresentations, Tokenize("token")},
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, newRequest(c, "/manifest.mpd?max_height=720&token=secret"))
+	c.Assert(w.Code, Equals, http.StatusOK)
+	c.Check(w.Header().Get("Content-Type"), Equals, ContentType)
+	c.Check(w.Header().Get("Cache-Control"), Equals, "public, max-age=3600")
+	etag := w.Header().Get("ETag")
+	c.Check(etag, Matches, `"[0-9a-f]{16}"`)
+	body := w.Body.String()
+	c.Check(strings.Contains(body, `id="low"`), Equals, true)
+	c.Check(strings.Contains(body, `id="high"`), Equals, false)
+	c.Check(strings.Contains(body, `media="$RepresentationID$/$Number$.m4s?token=secret"`), Equals, true)
+
+	req := newRequest(c, "/manifest.mpd?max_height=720&token=secret")
+	req.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	c.Check(w.Code, Equals, http.StatusNotModified)
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, newRequest(c, "/manifest.mpd?max_height=x"))
+	c.Check(w.Code, Equals, http.StatusInternalServerError)
+
+	var logged error
+	h.Source = SourceFunc(func(r *http.Request) (*mpd.MPD, error) { return nil, errors.New("origin is down") })
+	h.OnError = func(r *http.Request, err error) { logged = err }
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, newRequest(c, "/manifest.mpd"))
+	c.Check(w.Code, Equals, http.StatusInternalServerError)
+	c.Check(strings.Contains(w.Body.String(), "origin"), Equals, false)
+	c.Check(logged, ErrorMatches, "mpdserve: source: origin is down")
+}
+
+func (s *MPDServeSuite) TestDynamic(c *C) {
+	m := mpd.NewDynamicMPD(mpd.ProfileISOFFLive, time.Now().Add(-time.Hour), 10*time.Second)
+	h := &Handler{
+		Source:     SourceFunc(func(r *http.Request) (*mpd.MPD, error) { return m.Clone(), nil }),
+		Transforms: []Transform{Window(time.Minute)},
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, newRequest(c, "/live.mpd"))
+	c.Assert(w.Code, Equals, http.StatusOK)
+	c.Check(w.Header().Get("Cache-Control"), Equals, "public, max-age=5")
+	c.Check(strings.Contains(w.Body.String(), `timeShiftBufferDepth="PT1M"`), Equals, true)
+	c.Check(m.TimeShiftBufferDepth, IsNil)
+}
+
+func (s *MPDServeSuite) TestHandlerPreload(c *C) {
+	h := &Handler{
+		Source: SourceFunc(func(r *http.Request) (*mpd.MPD, error) {
+			m := newMPD()
+			m.Periods[0].AdaptationSets[0].SegmentTemplate.Initialization = stringPtr("$RepresentationID$/init.mp4")
+			m.Periods[0].AdaptationSets[0].SegmentTemplate.Duration = uint32Ptr(2)
+			return m, nil
+		}),
+		Transforms: []Transform{Tokenize("token")},
+		Preload:    &Preload{Segments: 1},
+	}
+	link := "</vod/low/init.mp4?token=secret>; rel=preload; as=fetch; crossorigin, " +
+		"</vod/low/1.m4s?token=secret>; rel=preload; as=fetch; crossorigin"
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, newRequest(c, "/vod/manifest.mpd?token=secret"))
+	c.Assert(w.Code, Equals, http.StatusOK)
+	c.Check(w.Header().Get("Link"), Equals, link)
+
+	h.Preload.EarlyHints = true
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+	var hints []string
+	trace := &httptrace.ClientTrace{Got1xxResponse: func(code int, header textproto.MIMEHeader) error {
+		if code == http.StatusEarlyHints {
+			hints = append(hints, header.Get("Link"))
+		}
+		return nil
+	}}
+	req := newRequest(c, srv.URL+"/vod/manifest.mpd?token=secret")
+	resp, err := http.DefaultClient.Do(req.WithContext(httptrace.WithClientTrace(req.Context(), trace)))
+	c.Assert(err, IsNil)
+	resp.Body.Close()
+	c.Check(resp.StatusCode, Equals, http.StatusOK)
+	c.Check(hints, DeepEquals, []string{link})
+	c.Check(resp.Header.Get("Link"), Equals, link)
+}
@@ -0,0 +1,160 @@
+package mpdserve
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/jun-oku/mpd"
+)
+
+// Device classes detected by Sessions.
+const (
+	DeviceTV      = "tv"
+	DeviceMobile  = "mobile"
+	DeviceDesktop = "desktop"
+)
+
+// Default request headers used by Sessions.
+const (
+	DefaultSessionHeader     = "X-Session-Id"
+	DefaultDeviceClassHeader = "X-Device-Class"
+	DefaultCountryHeader     = "X-Country-Code"
+)
+
+// Session describes client of a request for SessionTransforms.
+// It is valid only during the call, so don't keep references to it.
+type Session struct {
+	Request *http.Request
+	// ID is taken from session query parameter or session header.
+	ID string
+	// DeviceClass is taken from device class header or guessed from User-Agent, empty if unknown.
+	DeviceClass string
+	// Country is taken from country header set by CDN or geo-IP proxy, e.g. "US".
+	Country string
+}
+
+// SessionTransform modifies a copy of manifest made for a single session.
+type SessionTransform func(s *Session, m *mpd.MPD) error
+
+// Sessions runs registered SessionTransforms on per-request copies of shared manifests.
+// Session values are pooled; manifests are copied with MPD.Clone, so shared ones are never modified.
+// Manifests of Sources returned by Wrap depend on sessions, so Handler with Cache keys its entries
+// with CacheKey unless Cache.Key is set.
+type Sessions struct {
+	// Header names, defaults are used if empty.
+	SessionHeader     string
+	DeviceClassHeader string
+	CountryHeader     string
+
+	m          sync.RWMutex
+	transforms []SessionTransform
+	pool       sync.Pool
+}
+
+// Register adds t to transforms run in registration order.
+func (ss *Sessions) Register(t SessionTransform) {
+	ss.m.Lock()
+	ss.transforms = append(ss.transforms, t)
+	ss.m.Unlock()
+}
+
+// Wrap returns Source returning copies of shared manifests of source with registered transforms applied.
+func (ss *Sessions) Wrap(source Source) Source {
+	return &sessionSource{sessions: ss, source: source}
+}
+
+// CacheKey returns Cache key of r which identifies its session besides request URL,
+// so responses transformed for one session are never served to another one.
+func (ss *Sessions) CacheKey(r *http.Request) string {
+	var s Session
+	ss.identify(r, &s)
+	return strings.Join([]string{r.URL.String(), s.ID, s.DeviceClass, s.Country}, "\n")
+}
+
+// sessionSource is a Source returned by Sessions.Wrap.
+type sessionSource struct {
+	sessions *Sessions
+	source   Source
+}
+
+// CacheKey implements cacheKeyer interface.
+func (src *sessionSource) CacheKey(r *http.Request) string {
+	return src.sessions.CacheKey(r)
+}
+
+// MPD implements Source interface.
+func (src *sessionSource) MPD(r *http.Request) (*mpd.MPD, error) {
+	ss := src.sessions
+	shared, err := src.source.MPD(r)
+	if err != nil {
+		return nil, err
+	}
+	ss.m.RLock()
+	transforms := ss.transforms
+	ss.m.RUnlock()
+	if len(transforms) == 0 {
+		return shared.Clone(), nil
+	}
+
+	s, _ := ss.pool.Get().(*Session)
+	if s == nil {
+		s = new(Session)
+	}
+	defer func() {
+		*s = Session{}
+		ss.pool.Put(s)
+	}()
+	ss.identify(r, s)
+
+	m := shared.Clone()
+	for _, t := range transforms {
+		if err = t(s, m); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// identify fills s from request headers.
+func (ss *Sessions) identify(r *http.Request, s *Session) {
+	header := func(name, def string) string {
+		if name == "" {
+			name = def
+		}
+		return strings.TrimSpace(r.Header.Get(name))
+	}
+
+	s.Request = r
+	if s.ID = r.URL.Query().Get("session"); s.ID == "" {
+		s.ID = header(ss.SessionHeader, DefaultSessionHeader)
+	}
+	if s.DeviceClass = strings.ToLower(header(ss.DeviceClassHeader, DefaultDeviceClassHeader)); s.DeviceClass == "" {
+		s.DeviceClass = guessDeviceClass(r.UserAgent())
+	}
+	s.Country = strings.ToUpper(header(ss.CountryHeader, DefaultCountryHeader))
+}
+
+// tvUserAgents and mobileUserAgents are User-Agent substrings of device classes.
+var (
+	tvUserAgents     = []string{"SmartTV", "SMART-TV", "Tizen", "Web0S", "WebOS", "AppleTV", "Roku", "CrKey", "AFT", "BRAVIA", "HbbTV"}
+	mobileUserAgents = []string{"Mobile", "Android", "iPhone", "iPad", "iPod"}
+)
+
+// guessDeviceClass returns device class for User-Agent ua, or empty string.
+func guessDeviceClass(ua string) string {
+	if ua == "" {
+		return ""
+	}
+	for _, s := range tvUserAgents {
+		if strings.Contains(ua, s) {
+			return DeviceTV
+		}
+	}
+	for _, s := range mobileUserAgents {
+		if strings.Contains(ua, s) {
+			return DeviceMobile
+		}
+	}
+	return DeviceDesktop
+}
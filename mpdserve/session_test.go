@@ -0,0 +1,91 @@
+package mpdserve
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/jun-oku/mpd"
+)
+
+func (s *MPDServeSuite) TestSessions(c *C) {
+	shared := newMPD()
+	ss := new(Sessions)
+	h := &Handler{Source: ss.Wrap(SourceFunc(func(r *http.Request) (*mpd.MPD, error) { return shared, nil }))}
+
+	var sessions []Session
+	ss.Register(func(s *Session, m *mpd.MPD) error {
+		sessions = append(sessions, *s)
+		if s.DeviceClass == DeviceMobile {
+			as := m.Periods[0].AdaptationSets[0]
+			as.Representations = as.Representations[:1]
+		}
+		return nil
+	})
+
+	r := newRequest(c, "/manifest.mpd?session=abc")
+	r.Header.Set("User-Agent", "Mozilla/5.0 (iPhone; CPU iPhone OS 12_0 like Mac OS X) Mobile/15E148")
+	r.Header.Set("X-Country-Code", "jp")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	c.Assert(w.Code, Equals, http.StatusOK)
+	c.Check(strings.Contains(w.Body.String(), `id="high"`), Equals, false)
+
+	r = newRequest(c, "/manifest.mpd")
+	r.Header.Set("X-Session-Id", "def")
+	r.Header.Set("X-Device-Class", "TV")
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	c.Check(strings.Contains(w.Body.String(), `id="high"`), Equals, true)
+
+	c.Assert(sessions, HasLen, 2)
+	c.Check(sessions[0].ID, Equals, "abc")
+	c.Check(sessions[0].DeviceClass, Equals, DeviceMobile)
+	c.Check(sessions[0].Country, Equals, "JP")
+	c.Check(sessions[1].ID, Equals, "def")
+	c.Check(sessions[1].DeviceClass, Equals, DeviceTV)
+	c.Check(sessions[1].Country, Equals, "")
+
+	// shared manifest is intact
+	c.Check(shared.Periods[0].AdaptationSets[0].Representations, HasLen, 2)
+}
+
+func (s *MPDServeSuite) TestGuessDeviceClass(c *C) {
+	for ua, expected := range map[string]string{
+		"": "",
+		"Mozilla/5.0 (SMART-TV; Linux; Tizen 5.0) AppleWebKit/537.36":           DeviceTV,
+		"Mozilla/5.0 (Linux; Android 10; Pixel 3) Mobile Safari/537.36":         DeviceMobile,
+		"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 Chrome/8": DeviceDesktop,
+	} {
+		c.Check(guessDeviceClass(ua), Equals, expected, Commentf("%s", ua))
+	}
+}
+
+func (s *MPDServeSuite) TestSessionsCache(c *C) {
+	shared := mpd.NewDynamicMPD(mpd.ProfileISOFFLive, time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC), 10*time.Second)
+	ss := new(Sessions)
+	ss.Register(func(s *Session, m *mpd.MPD) error {
+		m.BaseURL = "https://" + strings.ToLower(s.Country) + ".cdn.example.com/"
+		return nil
+	})
+	h := &Handler{
+		Source: ss.Wrap(SourceFunc(func(r *http.Request) (*mpd.MPD, error) { return shared, nil })),
+		Cache:  new(Cache),
+	}
+	get := func(country string) string {
+		r := newRequest(c, "/live.mpd")
+		r.Header.Set("X-Country-Code", country)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		c.Assert(w.Code, Equals, http.StatusOK)
+		return w.Body.String()
+	}
+
+	c.Check(strings.Contains(get("jp"), "https://jp.cdn.example.com/"), Equals, true)
+	c.Check(strings.Contains(get("us"), "https://us.cdn.example.com/"), Equals, true)
+	c.Check(strings.Contains(get("jp"), "https://jp.cdn.example.com/"), Equals, true)
+	c.Check(h.Cache.Len(), Equals, 2)
+}
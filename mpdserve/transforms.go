@@ -0,0 +1,95 @@
+package mpdserve
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/jun-oku/mpd"
+)
+
+// Window returns Transform trimming dynamic manifests to the last window of segments with MPD.TrimToWindow.
+// Static manifests are left intact.
+func Window(window time.Duration) Transform {
+	return func(r *http.Request, m *mpd.MPD) error {
+		if m.Type == nil || *m.Type != "dynamic" {
+			return nil
+		}
+		return m.TrimToWindow(window)
+	}
+}
+
+// FilterRepresentations is a Transform removing Representations not matching query parameters of request:
+//   - max_bandwidth and min_bandwidth: Representation@bandwidth limits;
+//   - max_height: Representation@height limit.
+//
+// AdaptationSets left without Representations are removed.
+func FilterRepresentations(r *http.Request, m *mpd.MPD) error {
+	limit := func(name string) (uint64, bool, error) {
+		s := r.URL.Query().Get(name)
+		if s == "" {
+			return 0, false, nil
+		}
+		v, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return 0, false, fmt.Errorf("FilterRepresentations: invalid %s %q", name, s)
+		}
+		return v, true, nil
+	}
+	maxBandwidth, hasMaxBandwidth, err := limit("max_bandwidth")
+	if err != nil {
+		return err
+	}
+	minBandwidth, hasMinBandwidth, err := limit("min_bandwidth")
+	if err != nil {
+		return err
+	}
+	maxHeight, hasMaxHeight, err := limit("max_height")
+	if err != nil {
+		return err
+	}
+	if !hasMaxBandwidth && !hasMinBandwidth && !hasMaxHeight {
+		return nil
+	}
+
+	for _, p := range m.Periods {
+		var sets []*mpd.AdaptationSet
+		for _, as := range p.AdaptationSets {
+			var reps []mpd.Representation
+			for _, rep := range as.Representations {
+				if rep.Bandwidth != nil {
+					if hasMaxBandwidth && *rep.Bandwidth > maxBandwidth || hasMinBandwidth && *rep.Bandwidth < minBandwidth {
+						continue
+					}
+				}
+				if hasMaxHeight && rep.Height != nil && *rep.Height > maxHeight {
+					continue
+				}
+				reps = append(reps, rep)
+			}
+			if len(reps) == 0 {
+				continue
+			}
+			as.Representations = reps
+			sets = append(sets, as)
+		}
+		p.AdaptationSets = sets
+	}
+	return nil
+}
+
+// Tokenize returns Transform copying given query parameters of request, e.g. CDN tokens,
+// to segment URLs with MPD.AddURLQuery.
+func Tokenize(params ...string) Transform {
+	return func(r *http.Request, m *mpd.MPD) error {
+		q := make(url.Values)
+		for _, name := range params {
+			if v, ok := r.URL.Query()[name]; ok {
+				q[name] = v
+			}
+		}
+		return m.AddURLQuery(q)
+	}
+}
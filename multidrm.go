@@ -0,0 +1,45 @@
+package mpd
+
+// ContentProtection schemes of DRM systems.
+const (
+	MP4ProtectionScheme = "urn:mpeg:dash:mp4protection:2011"
+	WidevineScheme      = "urn:uuid:edef8ba9-79d6-4ace-a3c8-27dcd51d21ed"
+	PlayReadyScheme     = "urn:uuid:9a04f079-9840-4286-ab92-e65be0885f95"
+	MarlinScheme        = "urn:uuid:5e629af5-38da-4063-8977-97ffbd9902d4"
+	FairPlayScheme      = "urn:uuid:94ce86fb-07ff-4f43-adb8-93d2fa968ca2"
+)
+
+// Namespaces of Marlin and DASH-IF ContentProtection extensions.
+const (
+	MarlinNamespace    = "urn:marlin:mas:1-0:services:schemas:mpd"
+	DASHIFCPSNamespace = "https://dashif.org/CPS"
+)
+
+// MarlinContentIDs represents mas:MarlinContentIds element.
+type MarlinContentIDs struct {
+	ContentIDs []string `xml:"MarlinContentId"`
+}
+
+// Laurl represents dashif:Laurl element with license acquisition URL, used by FairPlay signaling.
+type Laurl struct {
+	LicenseType *string `xml:"licenseType,attr"`
+	Value       string  `xml:",chardata"`
+}
+
+// NewMarlinContentProtection returns Marlin ContentProtection with given content IDs,
+// e.g. "urn:marlin:kid:10000000100010001000100000000000".
+func NewMarlinContentProtection(contentIDs ...string) ContentProtection {
+	return ContentProtection{
+		SchemeIDURI:      stringPtr(MarlinScheme),
+		MarlinContentIDs: &MarlinContentIDs{ContentIDs: contentIDs},
+	}
+}
+
+// NewFairPlayContentProtection returns FairPlay ContentProtection with given license acquisition URL.
+func NewFairPlayContentProtection(laurl string) ContentProtection {
+	return ContentProtection{
+		SchemeIDURI: stringPtr(FairPlayScheme),
+		Value:       stringPtr("FairPlay"),
+		Laurl:       &Laurl{Value: laurl},
+	}
+}
@@ -0,0 +1,44 @@
+package mpd
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestMultiDRM(c *C) {
+	m := &MPD{XMLNS: stringPtr(MPDNamespace), Periods: []*Period{{AdaptationSets: []*AdaptationSet{{
+		MimeType: "video/mp4",
+		RepresentationBase: RepresentationBase{ContentProtections: []ContentProtection{
+			NewMarlinContentProtection("urn:marlin:kid:10000000100010001000100000000000"),
+			NewFairPlayContentProtection("https://example.com/fairplay"),
+		}},
+	}}}}}
+	b, err := m.Encode()
+	c.Assert(err, IsNil)
+	expected := `<?xml version="1.0" encoding="utf-8"?>
+<MPD xmlns="urn:mpeg:dash:schema:mpd:2011" xmlns:mas="urn:marlin:mas:1-0:services:schemas:mpd" xmlns:dashif="https://dashif.org/CPS" profiles="">
+  <Period>
+    <AdaptationSet mimeType="video/mp4">
+      <ContentProtection schemeIdUri="urn:uuid:5e629af5-38da-4063-8977-97ffbd9902d4">
+        <mas:MarlinContentIds>
+          <mas:MarlinContentId>urn:marlin:kid:10000000100010001000100000000000</mas:MarlinContentId>
+        </mas:MarlinContentIds>
+      </ContentProtection>
+      <ContentProtection schemeIdUri="urn:uuid:94ce86fb-07ff-4f43-adb8-93d2fa968ca2" value="FairPlay">
+        <dashif:Laurl>https://example.com/fairplay</dashif:Laurl>
+      </ContentProtection>
+    </AdaptationSet>
+  </Period>
+</MPD>
+`
+	c.Check(string(b), Equals, expected)
+
+	decoded := new(MPD)
+	c.Assert(decoded.Decode(b), IsNil)
+	cps := decoded.Periods[0].AdaptationSets[0].ContentProtections
+	c.Check(cps[0].MarlinContentIDs.ContentIDs, DeepEquals, []string{"urn:marlin:kid:10000000100010001000100000000000"})
+	c.Check(cps[1].Laurl.Value, Equals, "https://example.com/fairplay")
+
+	b, err = decoded.Encode()
+	c.Assert(err, IsNil)
+	c.Check(string(b), Equals, expected)
+}
@@ -0,0 +1,169 @@
+package mpd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Namespaces of ContentProtection extensions.
+const (
+	CENCNamespace = "urn:mpeg:cenc:2013"
+	MSPRNamespace = "urn:microsoft:playready"
+)
+
+// canonicalPrefixes maps namespaces to prefixes used by Encode.
+var canonicalPrefixes = map[string]string{
+	MPDNamespace:       "",
+	XSINamespace:       "xsi",
+	CENCNamespace:      "cenc",
+	MSPRNamespace:      "mspr",
+	SCTE214Namespace:   "scte214",
+	OMAFNamespace:      "omaf",
+	MarlinNamespace:    "mas",
+	DASHIFCPSNamespace: "dashif",
+	DVBNamespace:       "dvb",
+}
+
+// scanPrefixes returns namespace prefix bindings declared on the root element of b
+// which differ from ones used by Encode, or nil.
+func scanPrefixes(b []byte) map[string]string {
+	d := xml.NewDecoder(bytes.NewReader(b))
+	for {
+		t, err := d.RawToken()
+		if err != nil {
+			return nil
+		}
+		start, ok := t.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		var res map[string]string
+		add := func(ns, prefix string) {
+			if p, ok := canonicalPrefixes[ns]; ok && p == prefix {
+				return
+			}
+			if res == nil {
+				res = make(map[string]string)
+			}
+			res[ns] = prefix
+		}
+		for _, a := range start.Attr {
+			switch {
+			case a.Name.Space == "xmlns":
+				add(a.Value, a.Name.Local)
+			case a.Name.Space == "" && a.Name.Local == "xmlns":
+				add(a.Value, "")
+			}
+		}
+		return res
+	}
+}
+
+// generatedPrefixRE matches declarations of prefixes which encoding/xml generates for attributes of fields
+// tagged with namespace, e.g. xmlns:_="urn:dvb:dash-extensions:2014-1" for dvb:url.
+var generatedPrefixRE = regexp.MustCompile(` xmlns:(_\d*)="([^"]*)"`)
+
+// renameGeneratedPrefixes replaces prefixes generated by encoding/xml in encoded line s with ones used
+// by Encode for their namespaces, which withUsedNamespaces declares on MPD element.
+func renameGeneratedPrefixes(s string) string {
+	for _, m := range generatedPrefixRE.FindAllStringSubmatch(s, -1) {
+		prefix, ok := canonicalPrefixes[m[2]]
+		if !ok || prefix == "" {
+			continue
+		}
+		s = strings.Replace(s, m[0], "", 1)
+		s = strings.ReplaceAll(s, " "+m[1]+":", " "+prefix+":")
+	}
+	return s
+}
+
+var (
+	tagRE          = regexp.MustCompile(`<(/?)([A-Za-z_][\w.:-]*)([^>]*)>`)
+	defaultXMLNSRE = regexp.MustCompile(` xmlns="([^"]*)"`)
+)
+
+// applyPrefixes rewrites encoded MPD b to use given namespace prefix bindings
+// and declares all of them on the root element.
+func applyPrefixes(b []byte, prefixes map[string]string) []byte {
+	if len(prefixes) == 0 {
+		return b
+	}
+
+	// rename prefixes of known namespaces
+	for ns, prefix := range prefixes {
+		c, ok := canonicalPrefixes[ns]
+		if !ok || c == "" || prefix == "" {
+			continue
+		}
+		re := regexp.MustCompile(`(<|</| |xmlns:)` + c + `([:=])`)
+		b = re.ReplaceAll(b, []byte("${1}"+prefix+"${2}"))
+	}
+
+	// prefix elements in default namespaces bound to prefixes
+	defaults := []string{MPDNamespace}
+	root := true
+	b = tagRE.ReplaceAllFunc(b, func(tag []byte) []byte {
+		m := tagRE.FindSubmatch(tag)
+		closing, name, rest := len(m[1]) > 0, string(m[2]), string(m[3])
+		selfClosing := strings.HasSuffix(rest, "/")
+
+		if closing {
+			ns := defaults[len(defaults)-1]
+			defaults = defaults[:len(defaults)-1]
+			if prefix := prefixes[ns]; prefix != "" && !strings.Contains(name, ":") {
+				return []byte("</" + prefix + ":" + name + ">")
+			}
+			return tag
+		}
+
+		ns := defaults[len(defaults)-1]
+		if dm := defaultXMLNSRE.FindStringSubmatch(rest); dm != nil {
+			ns = dm[1]
+		}
+		if !selfClosing {
+			defaults = append(defaults, ns)
+		}
+		if prefix := prefixes[ns]; prefix != "" && !strings.Contains(name, ":") {
+			name = prefix + ":" + name
+			rest = defaultXMLNSRE.ReplaceAllString(rest, "")
+		}
+		if root {
+			root = false
+			rest = declarePrefixes(rest, prefixes)
+		}
+		return []byte("<" + name + rest + ">")
+	})
+	return b
+}
+
+// declarePrefixes adds missing declarations of prefixes to attributes of start tag,
+// sorted by prefix, after the default namespace declaration if any.
+func declarePrefixes(attrs string, prefixes map[string]string) string {
+	var decl []string
+	for ns, prefix := range prefixes {
+		attr := ` xmlns="`
+		if prefix != "" {
+			attr = ` xmlns:` + prefix + `="`
+		}
+		if !strings.Contains(attrs, attr) {
+			decl = append(decl, attr+escapeAttr(ns)+`"`)
+		}
+	}
+	sort.Strings(decl)
+	var i int
+	if loc := defaultXMLNSRE.FindStringIndex(attrs); loc != nil {
+		i = loc[1]
+	}
+	return attrs[:i] + strings.Join(decl, "") + attrs[i:]
+}
+
+// escapeAttr escapes s for use as attribute value.
+func escapeAttr(s string) string {
+	b := new(bytes.Buffer)
+	xml.EscapeText(b, []byte(s))
+	return b.String()
+}
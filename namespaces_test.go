@@ -0,0 +1,44 @@
+package mpd
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestNamespacePrefixes(c *C) {
+	m := new(MPD)
+	c.Assert(m.Decode([]byte(`<?xml version="1.0" encoding="utf-8"?>
+<dash:MPD xmlns:dash="urn:mpeg:dash:schema:mpd:2011" xmlns:ns2="urn:mpeg:cenc:2013" xmlns:v="urn:example:vendor" profiles="">
+  <dash:Period>
+    <dash:AdaptationSet mimeType="video/mp4">
+      <dash:ContentProtection schemeIdUri="urn:mpeg:dash:mp4protection:2011" value="cenc" ns2:default_KID="10000000-1000-1000-1000-100000000001"/>
+      <v:Info a="1">
+        <v:Inner/>
+      </v:Info>
+    </dash:AdaptationSet>
+  </dash:Period>
+</dash:MPD>`)), IsNil)
+	c.Check(m.NamespacePrefixes, DeepEquals, map[string]string{
+		MPDNamespace:         "dash",
+		CENCNamespace:        "ns2",
+		"urn:example:vendor": "v",
+	})
+
+	b, err := m.Encode()
+	c.Assert(err, IsNil)
+	c.Check(string(b), Equals, `<?xml version="1.0" encoding="utf-8"?>
+<dash:MPD xmlns:dash="urn:mpeg:dash:schema:mpd:2011" xmlns:ns2="urn:mpeg:cenc:2013" xmlns:v="urn:example:vendor" profiles="">
+  <dash:Period>
+    <dash:AdaptationSet mimeType="video/mp4">
+      <dash:ContentProtection schemeIdUri="urn:mpeg:dash:mp4protection:2011" value="cenc" ns2:default_KID="10000000-1000-1000-1000-100000000001"/>
+      <v:Info a="1">
+        <v:Inner/>
+      </v:Info>
+    </dash:AdaptationSet>
+  </dash:Period>
+</dash:MPD>
+`)
+
+	m = new(MPD)
+	c.Assert(m.Decode([]byte(`<MPD xmlns="urn:mpeg:dash:schema:mpd:2011" xmlns:cenc="urn:mpeg:cenc:2013"/>`)), IsNil)
+	c.Check(m.NamespacePrefixes, IsNil)
+}
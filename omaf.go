@@ -0,0 +1,122 @@
+package mpd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// OMAF namespace and descriptor schemes.
+const (
+	OMAFNamespace               = "urn:mpeg:mpegI:omaf:2017"
+	OMAFProjectionScheme        = "urn:mpeg:mpegI:omaf:2017:pf"
+	OMAFRegionWisePackingScheme = "urn:mpeg:mpegI:omaf:2017:rwpk"
+)
+
+// ProjectionType is OMAF projection format.
+type ProjectionType int
+
+// OMAF projection formats.
+const (
+	ProjectionEquirectangular ProjectionType = 0
+	ProjectionCubemap         ProjectionType = 1
+)
+
+// PackingType is OMAF region-wise packing type.
+type PackingType int
+
+// OMAF region-wise packing types.
+const (
+	PackingRectangular PackingType = 0
+)
+
+// SetProjection replaces OMAF projection format descriptor of as.
+func (as *AdaptationSet) SetProjection(types ...ProjectionType) {
+	v := make([]int, len(types))
+	for i, t := range types {
+		v[i] = int(t)
+	}
+	d := Descriptor{ProjectionType: formatOMAFList(v)}
+	setOMAFDescriptor(&as.EssentialProperties, OMAFProjectionScheme, d)
+}
+
+// Projection returns OMAF projection formats of as, or nil if as has no projection format descriptor.
+func (as *AdaptationSet) Projection() ([]ProjectionType, error) {
+	d := findDescriptor(as.EssentialProperties, OMAFProjectionScheme)
+	if d == nil {
+		return nil, nil
+	}
+	v, err := parseOMAFList(d.ProjectionType)
+	if err != nil {
+		return nil, fmt.Errorf("Projection: %w", err)
+	}
+	res := make([]ProjectionType, len(v))
+	for i, t := range v {
+		res[i] = ProjectionType(t)
+	}
+	return res, nil
+}
+
+// SetRegionWisePacking replaces OMAF region-wise packing descriptor of as.
+func (as *AdaptationSet) SetRegionWisePacking(types ...PackingType) {
+	v := make([]int, len(types))
+	for i, t := range types {
+		v[i] = int(t)
+	}
+	d := Descriptor{PackingType: formatOMAFList(v)}
+	setOMAFDescriptor(&as.EssentialProperties, OMAFRegionWisePackingScheme, d)
+}
+
+// RegionWisePacking returns OMAF region-wise packing types of as, or nil if as has no packing descriptor.
+func (as *AdaptationSet) RegionWisePacking() ([]PackingType, error) {
+	d := findDescriptor(as.EssentialProperties, OMAFRegionWisePackingScheme)
+	if d == nil {
+		return nil, nil
+	}
+	v, err := parseOMAFList(d.PackingType)
+	if err != nil {
+		return nil, fmt.Errorf("RegionWisePacking: %w", err)
+	}
+	res := make([]PackingType, len(v))
+	for i, t := range v {
+		res[i] = PackingType(t)
+	}
+	return res, nil
+}
+
+func setOMAFDescriptor(list *[]Descriptor, scheme string, d Descriptor) {
+	removeDescriptors(list, scheme)
+	d.SchemeIDURI = &scheme
+	*list = append(*list, d)
+}
+
+// formatOMAFList formats values as space-separated list, absent list means default value 0.
+func formatOMAFList(v []int) *string {
+	if len(v) == 0 {
+		return nil
+	}
+	s := make([]string, len(v))
+	for i, n := range v {
+		s[i] = strconv.Itoa(n)
+	}
+	res := strings.Join(s, " ")
+	return &res
+}
+
+func parseOMAFList(s *string) ([]int, error) {
+	if s == nil {
+		return []int{0}, nil
+	}
+	var res []int
+	for _, f := range strings.Fields(*s) {
+		n, err := strconv.Atoi(f)
+		if err != nil || n < 0 || n > 255 {
+			return nil, fmt.Errorf("invalid value %q", f)
+		}
+		res = append(res, n)
+	}
+	if len(res) == 0 {
+		return nil, fmt.Errorf("empty list")
+	}
+	return res, nil
+}
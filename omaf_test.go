@@ -0,0 +1,36 @@
+package mpd
+
+import (
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestOMAF(c *C) {
+	as := &AdaptationSet{MimeType: MimeTypeVideoMP4}
+	as.SetProjection(ProjectionEquirectangular)
+	as.SetRegionWisePacking(PackingRectangular)
+
+	m := &MPD{Periods: []*Period{{AdaptationSets: []*AdaptationSet{as}}}}
+	b, err := m.Encode()
+	c.Assert(err, IsNil)
+	c.Check(strings.Contains(string(b), `<MPD xmlns:omaf="urn:mpeg:mpegI:omaf:2017"`), Equals, true, Commentf("%s", b))
+	c.Check(strings.Contains(string(b), `<EssentialProperty schemeIdUri="urn:mpeg:mpegI:omaf:2017:pf" omaf:projection_type="0"/>`), Equals, true, Commentf("%s", b))
+	c.Check(strings.Contains(string(b), `<EssentialProperty schemeIdUri="urn:mpeg:mpegI:omaf:2017:rwpk" omaf:packing_type="0"/>`), Equals, true, Commentf("%s", b))
+
+	decoded := new(MPD)
+	c.Assert(decoded.Decode(b), IsNil)
+	c.Check(*decoded.Omaf, Equals, OMAFNamespace)
+	pt, err := decoded.Periods[0].AdaptationSets[0].Projection()
+	c.Assert(err, IsNil)
+	c.Check(pt, DeepEquals, []ProjectionType{ProjectionEquirectangular})
+	pk, err := decoded.Periods[0].AdaptationSets[0].RegionWisePacking()
+	c.Assert(err, IsNil)
+	c.Check(pk, DeepEquals, []PackingType{PackingRectangular})
+
+	scheme := OMAFProjectionScheme
+	as = &AdaptationSet{RepresentationBase: RepresentationBase{EssentialProperties: []Descriptor{{SchemeIDURI: &scheme}}}}
+	pt, err = as.Projection()
+	c.Assert(err, IsNil)
+	c.Check(pt, DeepEquals, []ProjectionType{ProjectionEquirectangular})
+}
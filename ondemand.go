@@ -0,0 +1,76 @@
+package mpd
+
+import (
+	"fmt"
+	"time"
+)
+
+// OnDemandFile describes a single self-initializing ISO BMFF file of isoff-on-demand profile.
+type OnDemandFile struct {
+	Track
+	// URL of the file, written to Representation's BaseURL.
+	URL string
+	// Sidx is a parsed index of the file, see FindSidx.
+	Sidx *Sidx
+}
+
+// NewOnDemandMPD returns static MPD of isoff-on-demand profile with given duration and a Representation per file.
+// Each Representation gets its own BaseURL and SegmentBase with exact indexRange.
+// Track bitrate is computed as the peak bitrate of subsegments if not set.
+func NewOnDemandMPD(duration time.Duration, files []OnDemandFile) (*MPD, error) {
+	if len(files) == 0 {
+		return nil, fmt.Errorf("NewOnDemandMPD: no files")
+	}
+
+	m := NewStaticMPD(ProfileISOFFOnDemand, duration)
+	period := m.Periods[0]
+	period.Duration = stringPtr(FormatDuration(duration))
+
+	var maxSegment time.Duration
+	g := newTrackGrouper(period)
+	for _, f := range files {
+		if f.URL == "" || f.Sidx == nil {
+			return nil, fmt.Errorf("NewOnDemandMPD: file of track %q should have URL and Sidx", f.ID)
+		}
+		if f.Bitrate == 0 {
+			f.Bitrate = f.Sidx.peakBitrate()
+		}
+		as, r, err := g.add(f.Track)
+		if err != nil {
+			return nil, fmt.Errorf("NewOnDemandMPD: %w", err)
+		}
+
+		as.SubsegmentAlignment = ConditionalUint{b: boolPtr(true)}
+		as.SubsegmentStartsWithSAP = uint64Ptr(1)
+		r.BaseURL = f.URL
+		r.SegmentBase = f.Sidx.SegmentBase()
+		r.SegmentBase.IndexRangeExact = boolPtr(true)
+		as.Representations = append(as.Representations, *r)
+
+		for _, ref := range f.Sidx.References {
+			if d := timescaled(uint64(ref.SubsegmentDuration), uint64Ptr(uint64(f.Sidx.Timescale))); d > maxSegment {
+				maxSegment = d
+			}
+		}
+	}
+
+	if maxSegment > 0 {
+		m.MaxSegmentDuration = stringPtr(FormatDuration(maxSegment))
+	}
+	return m, nil
+}
+
+// peakBitrate returns the maximal bitrate of s subsegments in bits per second.
+func (s *Sidx) peakBitrate() uint64 {
+	var res uint64
+	for _, ref := range s.References {
+		if ref.SubsegmentDuration == 0 {
+			continue
+		}
+		d := uint64(ref.SubsegmentDuration)
+		if b := (uint64(ref.ReferencedSize)*8*uint64(s.Timescale) + d - 1) / d; b > res {
+			res = b
+		}
+	}
+	return res
+}
@@ -0,0 +1,42 @@
+package mpd
+
+import (
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestNewOnDemandMPD(c *C) {
+	sidx := &Sidx{Timescale: 1000, Offset: 800, Size: 68, References: []SidxReference{
+		{ReferencedSize: 1000000, SubsegmentDuration: 4000},
+		{ReferencedSize: 600000, SubsegmentDuration: 2000},
+	}}
+	m, err := NewOnDemandMPD(6*time.Second, []OnDemandFile{
+		{Track: Track{ID: "v1", Codec: "avc1.64001f", Width: 1280, Height: 720}, URL: "video_720.mp4", Sidx: sidx},
+		{Track: Track{ID: "a1", Codec: "mp4a.40.2", Bitrate: 128000, Language: "en"}, URL: "audio_en.mp4", Sidx: sidx},
+	})
+	c.Assert(err, IsNil)
+	b, err := m.Encode()
+	c.Assert(err, IsNil)
+	c.Check(string(b), Equals, `<?xml version="1.0" encoding="utf-8"?>
+<MPD xmlns="urn:mpeg:dash:schema:mpd:2011" type="static" mediaPresentationDuration="PT6S" minBufferTime="PT2S" maxSegmentDuration="PT4S" profiles="urn:mpeg:dash:profile:isoff-on-demand:2011">
+  <Period start="PT0S" id="0" duration="PT6S">
+    <AdaptationSet id="0" mimeType="video/mp4" subsegmentAlignment="true" subsegmentStartsWithSAP="1" contentType="video">
+      <Representation id="v1" width="1280" height="720" bandwidth="2400000" codecs="avc1.64001f">
+        <BaseURL>video_720.mp4</BaseURL>
+        <SegmentBase timescale="1000" indexRange="800-867" indexRangeExact="true"/>
+      </Representation>
+    </AdaptationSet>
+    <AdaptationSet id="1" mimeType="audio/mp4" subsegmentAlignment="true" subsegmentStartsWithSAP="1" lang="en" contentType="audio">
+      <Representation id="a1" bandwidth="128000" codecs="mp4a.40.2">
+        <BaseURL>audio_en.mp4</BaseURL>
+        <SegmentBase timescale="1000" indexRange="800-867" indexRangeExact="true"/>
+      </Representation>
+    </AdaptationSet>
+  </Period>
+</MPD>
+`)
+
+	_, err = NewOnDemandMPD(time.Second, []OnDemandFile{{Track: Track{ID: "v1"}, URL: "v.mp4"}})
+	c.Check(err, ErrorMatches, `NewOnDemandMPD: file of track "v1" should have URL and Sidx`)
+}
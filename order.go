@@ -0,0 +1,133 @@
+package mpd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// representationBaseOrder lists children of XSD's RepresentationBaseType in sequence order.
+var representationBaseOrder = []string{
+	"FramePacking", "AudioChannelConfiguration", "ContentProtection", "OutputProtection",
+	"EssentialProperty", "SupplementalProperty", "InbandEventStream", "Switching", "RandomAccess",
+	"GroupLabel", "Label", "ProducerReferenceTime", "ContentPopularityRate", "Resync",
+}
+
+// segmentBaseOrder lists children of XSD's MultipleSegmentBaseType in sequence order.
+var segmentBaseOrder = []string{
+	"Initialization", "RepresentationIndex", "FailoverContent", "SegmentTimeline", "BitstreamSwitching",
+}
+
+// elementOrder lists children of MPD elements in XSD sequence order.
+// ProgramEventStream is not a part of XSD and is kept next to EventStream.
+var elementOrder = map[string][]string{
+	"MPD": {
+		"ProgramInformation", "BaseURL", "Location", "PatchLocation", "ServiceDescription",
+		"InitializationSet", "InitializationGroup", "InitializationPresentation", "ContentProtection",
+		"Period", "Metrics", "EssentialProperty", "SupplementalProperty", "UTCTiming", "LeapSecondInformation",
+	},
+	"Period": {
+		"BaseURL", "SegmentBase", "SegmentList", "SegmentTemplate", "AssetIdentifier",
+		"EventStream", "ProgramEventStream", "ServiceDescription", "ContentProtection", "AdaptationSet",
+		"Subset", "SupplementalProperty", "EmptyAdaptationSet", "GroupLabel", "Preselection",
+	},
+	"AdaptationSet": concatOrder(representationBaseOrder, []string{
+		"Accessibility", "Role", "Rating", "Viewpoint", "ContentComponent",
+		"BaseURL", "SegmentBase", "SegmentList", "SegmentTemplate", "Representation",
+	}),
+	"EmptyAdaptationSet": concatOrder(representationBaseOrder, []string{
+		"Accessibility", "Role", "Rating", "Viewpoint", "ContentComponent",
+		"BaseURL", "SegmentBase", "SegmentList", "SegmentTemplate", "Representation",
+	}),
+	"Representation": concatOrder(representationBaseOrder, []string{
+		"BaseURL", "ExtendedBandwidth", "SubRepresentation", "SegmentBase", "SegmentList", "SegmentTemplate",
+	}),
+	"Preselection":    concatOrder(representationBaseOrder, []string{"Accessibility", "Role", "Rating", "Viewpoint"}),
+	"SegmentBase":     segmentBaseOrder[:3],
+	"SegmentTemplate": segmentBaseOrder,
+	"SegmentList":     concatOrder(segmentBaseOrder, []string{"SegmentURL"}),
+}
+
+// orderPathNames maps element names to field names used in ValidationError paths.
+var orderPathNames = map[string]string{
+	"Period":             "Periods",
+	"AdaptationSet":      "AdaptationSets",
+	"EmptyAdaptationSet": "EmptyAdaptationSets",
+	"Representation":     "Representations",
+	"Preselection":       "Preselections",
+}
+
+func concatOrder(a, b []string) []string {
+	res := make([]string, 0, len(a)+len(b))
+	return append(append(res, a...), b...)
+}
+
+// orderIndex returns position of child in parent's sequence, or -1 if unknown.
+func orderIndex(parent, child string) int {
+	for i, name := range elementOrder[parent] {
+		if name == child {
+			return i
+		}
+	}
+	return -1
+}
+
+// ValidateElementOrder checks that children of MPD elements in XML b are in XSD sequence order.
+// Decode accepts any order, but Encode always emits XSD order.
+// It returns ValidationErrors or nil; unknown elements are ignored.
+func ValidateElementOrder(b []byte) error {
+	type frame struct {
+		name   string
+		path   string
+		last   string
+		lastI  int
+		counts map[string]int
+	}
+
+	var res ValidationErrors
+	var stack []*frame
+	d := xml.NewDecoder(bytes.NewReader(b))
+	for {
+		t, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("ValidateElementOrder: %w", err)
+		}
+
+		switch t := t.(type) {
+		case xml.StartElement:
+			name := t.Name.Local
+			path := ""
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				if i := orderIndex(parent.name, name); i >= 0 {
+					if i < parent.lastI {
+						res = append(res, newValidationError(parent.path, "%s must precede %s", name, parent.last))
+					} else {
+						parent.last, parent.lastI = name, i
+					}
+				}
+
+				field := name
+				if f, ok := orderPathNames[name]; ok {
+					field = fmt.Sprintf("%s[%d]", f, parent.counts[name])
+				}
+				parent.counts[name]++
+				path = strings.TrimPrefix(parent.path+"."+field, ".")
+			}
+			stack = append(stack, &frame{name: name, path: path, counts: make(map[string]int)})
+
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	if len(res) == 0 {
+		return nil
+	}
+	return res
+}
@@ -0,0 +1,75 @@
+package mpd
+
+import (
+	"io/ioutil"
+	"reflect"
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestStructElementOrder(c *C) {
+	for name, v := range map[string]interface{}{
+		"MPD":             MPD{},
+		"Period":          Period{},
+		"AdaptationSet":   AdaptationSet{},
+		"Representation":  Representation{},
+		"Preselection":    Preselection{},
+		"SegmentTemplate": SegmentTemplate{},
+		"SegmentList":     SegmentList{},
+	} {
+		last := -1
+		for _, f := range structFields(reflect.TypeOf(v)) {
+			tag := strings.Split(f.Tag.Get("xml"), ",")
+			if len(tag) > 1 && (tag[1] == "attr" || tag[1] == "chardata") || tag[0] == "" || tag[0] == "-" {
+				continue
+			}
+			idx := orderIndex(name, tag[0])
+			c.Check(idx >= 0, Equals, true, Commentf("%s.%s is not in elementOrder", name, tag[0]))
+			c.Check(idx > last, Equals, true, Commentf("%s.%s is out of order", name, tag[0]))
+			last = idx
+		}
+	}
+}
+
+// structFields returns fields of t with fields of embedded structs in place.
+func structFields(t reflect.Type) []reflect.StructField {
+	var res []reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous {
+			res = append(res, structFields(f.Type)...)
+			continue
+		}
+		res = append(res, f)
+	}
+	return res
+}
+
+func (s *MPDSuite) TestValidateElementOrder(c *C) {
+	for _, name := range []string{"fixture_elemental_delta_vod.mpd", "fixture_elemental_delta_live.mpd"} {
+		b, err := ioutil.ReadFile(name)
+		c.Assert(err, IsNil)
+		c.Check(ValidateElementOrder(b), IsNil)
+	}
+
+	b := []byte(`<MPD><Period><SupplementalProperty schemeIdUri="x"/><AdaptationSet>
+<Representation id="1"><SegmentTemplate/><BaseURL>a/</BaseURL></Representation>
+<SegmentTemplate/><Unknown/>
+</AdaptationSet></Period></MPD>`)
+	err := ValidateElementOrder(b)
+	c.Assert(err, NotNil)
+	c.Check(err.Error(), Equals, strings.Join([]string{
+		"Periods[0]: AdaptationSet must precede SupplementalProperty",
+		"Periods[0].AdaptationSets[0].Representations[0]: BaseURL must precede SegmentTemplate",
+		"Periods[0].AdaptationSets[0]: SegmentTemplate must precede Representation",
+	}, "\n"))
+
+	m := new(MPD)
+	c.Assert(m.Decode(b), IsNil)
+	enc, err := m.Encode()
+	c.Assert(err, IsNil)
+	c.Check(ValidateElementOrder(enc), IsNil)
+
+	c.Check(ValidateElementOrder([]byte(`<MPD>`)), ErrorMatches, "ValidateElementOrder: .*")
+}
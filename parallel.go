@@ -0,0 +1,103 @@
+package mpd
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"sync"
+)
+
+// decodeParallel decodes b into m, unmarshaling Periods in up to workers goroutines.
+// The document is tokenized once to find Periods, which are then decoded in contiguous chunks
+// wrapped into an element with namespace declarations of MPD element.
+func (m *MPD) decodeParallel(ctx context.Context, b []byte, workers int) error {
+	d := newContextDecoder(ctx, b)
+	var root *xml.StartElement
+	var ranges [][2]int64
+loop:
+	for {
+		offset := d.InputOffset()
+		t, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := t.(type) {
+		case xml.StartElement:
+			if root == nil {
+				root = &t
+				continue
+			}
+			if err = d.Skip(); err != nil {
+				return err
+			}
+			if t.Name.Local == "Period" {
+				ranges = append(ranges, [2]int64{offset, d.InputOffset()})
+			}
+		case xml.EndElement:
+			break loop
+		}
+	}
+	if len(ranges) < 2 {
+		return unmarshalContext(ctx, b, m)
+	}
+
+	// MPD without Periods
+	rest := make([]byte, 0, len(b))
+	var last int64
+	for _, r := range ranges {
+		rest = append(rest, b[last:r[0]]...)
+		last = r[1]
+	}
+	rest = append(rest, b[last:]...)
+	if err := unmarshalContext(ctx, rest, m); err != nil {
+		return err
+	}
+
+	wrapper := "<MPD"
+	for _, a := range root.Attr {
+		switch {
+		case a.Name.Space == "xmlns":
+			wrapper += ` xmlns:` + a.Name.Local + `="` + escapeAttr(a.Value) + `"`
+		case a.Name.Space == "" && a.Name.Local == "xmlns":
+			wrapper += ` xmlns="` + escapeAttr(a.Value) + `"`
+		}
+	}
+	wrapper += ">"
+
+	if workers > len(ranges) {
+		workers = len(ranges)
+	}
+	periods := make([]*Period, len(ranges))
+	errs := make([]error, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		from, to := w*len(ranges)/workers, (w+1)*len(ranges)/workers
+		wg.Add(1)
+		go func(w, from, to int) {
+			defer wg.Done()
+			chunk := []byte(wrapper)
+			chunk = append(chunk, b[ranges[from][0]:ranges[to-1][1]]...)
+			chunk = append(chunk, "</MPD>"...)
+			var v struct {
+				Periods []*Period `xml:"Period"`
+			}
+			if err := unmarshalContext(ctx, chunk, &v); err != nil {
+				errs[w] = err
+				return
+			}
+			if len(v.Periods) != to-from {
+				errs[w] = fmt.Errorf("decoded %d Periods instead of %d", len(v.Periods), to-from)
+				return
+			}
+			copy(periods[from:to], v.Periods)
+		}(w, from, to)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	m.Periods = append(m.Periods, periods...)
+	return nil
+}
@@ -0,0 +1,53 @@
+package mpd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestDecodeConcurrency(c *C) {
+	var periods []string
+	for i := 0; i < 25; i++ {
+		periods = append(periods, fmt.Sprintf(`  <Period id="%d" start="PT%dS">
+    <AdaptationSet mimeType="video/mp4">
+      <ContentProtection schemeIdUri="urn:uuid:edef8ba9-79d6-4ace-a3c8-27dcd51d21ed" c:default_KID="10000000-1000-1000-1000-100000000000">
+        <c:pssh>AAAA</c:pssh>
+      </ContentProtection>
+      <Representation id="v%d" bandwidth="%d"/>
+    </AdaptationSet>
+  </Period>`, i, i*10, i, 1000+i))
+		if i == 10 {
+			periods = append(periods, `  <!-- <Period id="commented"/> -->`)
+		}
+	}
+	b := []byte(`<?xml version="1.0" encoding="utf-8"?>
+<MPD xmlns="urn:mpeg:dash:schema:mpd:2011" xmlns:c="urn:mpeg:cenc:2013" type="static" profiles="urn:mpeg:dash:profile:isoff-live:2011">
+  <BaseURL>http://example.com/</BaseURL>
+` + strings.Join(periods, "\n") + `
+  <UTCTiming schemeIdUri="urn:mpeg:dash:utc:direct:2014" value="2016-01-01T00:00:00Z"/>
+</MPD>
+`)
+
+	expected := new(MPD)
+	c.Assert(expected.Decode(b), IsNil)
+	c.Assert(expected.Periods, HasLen, 25)
+	for _, n := range []int{2, 4, 25, 100} {
+		m := new(MPD)
+		c.Assert(m.DecodeWithOptions(b, DecodeOptions{Concurrency: n}), IsNil)
+		c.Check(m, DeepEquals, expected, Commentf("%d", n))
+	}
+
+	b, err := ioutil.ReadFile("fixture_elemental_delta_vod.mpd")
+	c.Assert(err, IsNil)
+	expected = new(MPD)
+	c.Assert(expected.Decode(b), IsNil)
+	m := new(MPD)
+	c.Assert(m.DecodeWithOptions(b, DecodeOptions{Concurrency: 4}), IsNil)
+	c.Check(m, DeepEquals, expected)
+
+	err = new(MPD).DecodeWithOptions([]byte(`<MPD><Period></Period><Period><AdaptationSet></Period></MPD>`), DecodeOptions{Concurrency: 4})
+	c.Check(err, NotNil)
+}
@@ -0,0 +1,208 @@
+package mpd
+
+import (
+	"fmt"
+	"time"
+)
+
+// periodAlignmentTolerance is the largest mismatch between Period timing and segments which is not reported.
+// It allows audio and video segments to end up to a couple of frames apart.
+const periodAlignmentTolerance = 100 * time.Millisecond
+
+// validatePeriodAlignment checks that consecutive Periods line up: SegmentTimelines cover Period@duration,
+// Period@start equals the end of previous Period, and presentationTimeOffset continues in the next Period
+// for Representations with the same id.
+func validatePeriodAlignment(m *MPD) ValidationErrors {
+	var res ValidationErrors
+	parse := func(s *string) (time.Duration, bool) {
+		if s == nil {
+			return 0, false
+		}
+		d, err := ParseDuration(*s)
+		return d, err == nil
+	}
+	abs := func(d time.Duration) time.Duration {
+		if d < 0 {
+			return -d
+		}
+		return d
+	}
+
+	for i, p := range m.Periods {
+		start, hasStart := parse(p.Start)
+		duration, hasDuration := parse(p.Duration)
+
+		if hasDuration {
+			for j, as := range p.AdaptationSets {
+				check := func(path string, t *SegmentTemplate) {
+					if t == nil || hasOpenRepeat(t) {
+						return
+					}
+					if end, ok := timelineEnd(t); ok && abs(end-duration) > periodAlignmentTolerance {
+						res = append(res, newValidationError(path, "SegmentTimeline ends at %s, but Period duration is %s",
+							FormatDuration(end), FormatDuration(duration)))
+					}
+				}
+				check(adaptationSetPath(i, j), as.SegmentTemplate)
+				for k := range as.Representations {
+					check(representationPath(i, j, k), as.Representations[k].SegmentTemplate)
+				}
+			}
+		}
+
+		if i+1 >= len(m.Periods) || !hasStart || !hasDuration {
+			continue
+		}
+		next := m.Periods[i+1]
+		if nextStart, ok := parse(next.Start); ok && abs(nextStart-start-duration) > periodAlignmentTolerance {
+			res = append(res, newValidationError(periodPath(i+1), "start %s doesn't match end of previous Period %s",
+				FormatDuration(nextStart), FormatDuration(start+duration)))
+		}
+
+		// presentationTimeOffset continuity
+		prevTemplates := representationTemplates(p)
+		for j, as := range next.AdaptationSets {
+			for k := range as.Representations {
+				r := &as.Representations[k]
+				t := r.SegmentTemplate
+				if t == nil {
+					t = as.SegmentTemplate
+				}
+				if r.ID == nil || t == nil || t.PresentationTimeOffset == nil {
+					continue
+				}
+				prev := prevTemplates[*r.ID]
+				if prev == nil || prev.PresentationTimeOffset == nil || prev.GetTimescale() != t.GetTimescale() {
+					continue
+				}
+				expected := *prev.PresentationTimeOffset + uint64(duration.Seconds()*float64(t.GetTimescale())+0.5)
+				diff := time.Duration(int64(*t.PresentationTimeOffset)-int64(expected)) * time.Second / time.Duration(t.GetTimescale())
+				if abs(diff) > periodAlignmentTolerance {
+					res = append(res, newValidationError(representationPath(i+1, j, k),
+						"presentationTimeOffset %d doesn't continue previous Period, expected %d", *t.PresentationTimeOffset, expected))
+				}
+			}
+		}
+	}
+	return res
+}
+
+// validateEmptyAdaptationSets checks that EmptyAdaptationSets have no Representations
+// and their ids don't clash with AdaptationSets of the same Period.
+func validateEmptyAdaptationSets(m *MPD) ValidationErrors {
+	var res ValidationErrors
+	for i, p := range m.Periods {
+		for j, as := range p.EmptyAdaptationSets {
+			path := fmt.Sprintf("%s.EmptyAdaptationSets[%d]", periodPath(i), j)
+			if len(as.Representations) > 0 {
+				res = append(res, newValidationError(path, "EmptyAdaptationSet must not contain Representations"))
+			}
+			if as.ID != nil && p.findAdaptationSet(*as.ID) != nil {
+				res = append(res, newValidationError(path, "id %d is used by AdaptationSet", *as.ID))
+			}
+		}
+	}
+	return res
+}
+
+// representationTemplates returns effective SegmentTemplates of p's Representations by id.
+func representationTemplates(p *Period) map[string]*SegmentTemplate {
+	res := make(map[string]*SegmentTemplate)
+	for _, as := range p.AdaptationSets {
+		for k := range as.Representations {
+			r := &as.Representations[k]
+			if t := EffectiveSegmentTemplate(p, as, r); r.ID != nil && t != nil {
+				res[*r.ID] = t
+			}
+		}
+	}
+	return res
+}
+
+// hasOpenRepeat returns true if t's SegmentTimeline repeats a segment until the end of Period.
+func hasOpenRepeat(t *SegmentTemplate) bool {
+	for _, tl := range t.SegmentTimeline {
+		for _, s := range tl.Segments {
+			if s.GetRepeat() < 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// PeriodGap describes a gap or an overlap between adjacent Periods.
+type PeriodGap struct {
+	// Period is an index of the Period following the gap.
+	Period int
+	// At is the wall-clock time where previous Period ends.
+	At time.Time
+	// Duration is positive for gaps and negative for overlaps.
+	Duration time.Duration
+}
+
+// FindPeriodGaps detects wall-clock gaps and overlaps between Periods of dynamic MPD m, e.g. caused by encoder failover.
+// Period end is taken from Period@duration or, if absent, from the end of its SegmentTimelines.
+// Mismatches within periodAlignmentTolerance are ignored.
+func FindPeriodGaps(m *MPD) ([]PeriodGap, error) {
+	ast, err := m.AvailabilityStart()
+	if err != nil {
+		return nil, err
+	}
+	if ast.IsZero() {
+		return nil, newError("FindPeriodGaps", ErrNoAvailabilityStartTime, "no availabilityStartTime")
+	}
+
+	var res []PeriodGap
+	var prevEnd time.Duration
+	var hasPrevEnd bool
+	for i, p := range m.Periods {
+		if p.Start == nil {
+			hasPrevEnd = false
+			continue
+		}
+		start, err := ParseDuration(*p.Start)
+		if err != nil {
+			return nil, fmt.Errorf("FindPeriodGaps: %s@start: %w", periodPath(i), err)
+		}
+
+		if hasPrevEnd {
+			if d := start - prevEnd; d > periodAlignmentTolerance || d < -periodAlignmentTolerance {
+				res = append(res, PeriodGap{Period: i, At: ast.Add(prevEnd), Duration: d})
+			}
+		}
+
+		hasPrevEnd = false
+		if p.Duration != nil {
+			d, err := ParseDuration(*p.Duration)
+			if err != nil {
+				return nil, fmt.Errorf("FindPeriodGaps: %s@duration: %w", periodPath(i), err)
+			}
+			prevEnd, hasPrevEnd = start+d, true
+		} else if d, ok := p.segmentsEnd(); ok {
+			prevEnd, hasPrevEnd = start+d, true
+		}
+	}
+	return res, nil
+}
+
+// segmentsEnd returns the latest end of p's SegmentTimelines relative to Period start.
+func (p *Period) segmentsEnd() (time.Duration, bool) {
+	var res time.Duration
+	var found bool
+	check := func(t *SegmentTemplate) {
+		if t == nil || hasOpenRepeat(t) {
+			return
+		}
+		if end, ok := timelineEnd(t); ok && (!found || end > res) {
+			res, found = end, true
+		}
+	}
+	for _, as := range p.AdaptationSets {
+		check(as.SegmentTemplate)
+		for _, r := range as.Representations {
+			check(r.SegmentTemplate)
+		}
+	}
+	return res, found
+}
@@ -0,0 +1,96 @@
+package mpd
+
+import (
+	"strings"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestValidatePeriodAlignment(c *C) {
+	template := func(pto uint64, count int64) *SegmentTemplate {
+		return &SegmentTemplate{Timescale: uint64Ptr(1000), PresentationTimeOffset: uint64Ptr(pto), SegmentTimeline: []SegmentTimeline{{
+			Segments: []SegmentTimelineSegment{{T: uint64Ptr(pto), D: 2000, R: int64Ptr(count - 1)}},
+		}}}
+	}
+
+	m := &MPD{Periods: []*Period{
+		{Start: stringPtr("PT0S"), Duration: stringPtr("PT10S"), AdaptationSets: []*AdaptationSet{{
+			Representations: []Representation{{ID: stringPtr("v"), SegmentTemplate: template(0, 5)}},
+		}}},
+		{Start: stringPtr("PT10S"), Duration: stringPtr("PT10S"), AdaptationSets: []*AdaptationSet{{
+			Representations: []Representation{{ID: stringPtr("v"), SegmentTemplate: template(10000, 5)}},
+		}}},
+	}}
+	c.Check(m.Validate(), IsNil)
+
+	m.Periods[0].Duration = stringPtr("PT12S")
+	m.Periods[1].AdaptationSets[0].Representations[0].SegmentTemplate = template(11000, 5)
+	err := m.Validate()
+	c.Assert(err, NotNil)
+	c.Check(err.Error(), Equals, strings.Join([]string{
+		"Periods[0].AdaptationSets[0].Representations[0]: SegmentTimeline ends at PT10S, but Period duration is PT12S",
+		"Periods[1]: start PT10S doesn't match end of previous Period PT12S",
+		"Periods[1].AdaptationSets[0].Representations[0]: presentationTimeOffset 11000 doesn't continue previous Period, expected 12000",
+	}, "\n"))
+}
+
+func (s *MPDSuite) TestFindPeriodGaps(c *C) {
+	m := &MPD{
+		Type:                  stringPtr("dynamic"),
+		AvailabilityStartTime: stringPtr("2016-01-01T00:00:00Z"),
+		Periods: []*Period{
+			{Start: stringPtr("PT0S"), Duration: stringPtr("PT10S")},
+			{Start: stringPtr("PT12S"), AdaptationSets: []*AdaptationSet{{SegmentTemplate: &SegmentTemplate{
+				Timescale:       uint64Ptr(1000),
+				SegmentTimeline: []SegmentTimeline{{Segments: []SegmentTimelineSegment{{T: uint64Ptr(0), D: 5000}}}},
+			}}}},
+			{Start: stringPtr("PT16S")},
+			{Start: stringPtr("PT16.05S")},
+		},
+	}
+	gaps, err := FindPeriodGaps(m)
+	c.Assert(err, IsNil)
+	c.Check(gaps, DeepEquals, []PeriodGap{
+		{Period: 1, At: time.Date(2016, 1, 1, 0, 0, 10, 0, time.UTC), Duration: 2 * time.Second},
+		{Period: 2, At: time.Date(2016, 1, 1, 0, 0, 17, 0, time.UTC), Duration: -time.Second},
+	})
+
+	m.AvailabilityStartTime = nil
+	_, err = FindPeriodGaps(m)
+	c.Check(err, ErrorMatches, "FindPeriodGaps: no availabilityStartTime")
+}
+
+func (s *MPDSuite) TestEmptyAdaptationSets(c *C) {
+	b := []byte(`<?xml version="1.0" encoding="utf-8"?>
+<MPD xmlns="urn:mpeg:dash:schema:mpd:2011" profiles="">
+  <Period id="ad" bitstreamSwitching="true">
+    <AdaptationSet id="1" mimeType="video/mp4"></AdaptationSet>
+    <EmptyAdaptationSet id="2" mimeType="audio/mp4">
+      <SupplementalProperty schemeIdUri="urn:mpeg:dash:period-continuity:2015" value="1"/>
+    </EmptyAdaptationSet>
+  </Period>
+</MPD>
+`)
+	m := new(MPD)
+	c.Assert(m.Decode(b), IsNil)
+	p := m.Periods[0]
+	c.Check(*p.BitstreamSwitching, Equals, true)
+	c.Assert(p.EmptyAdaptationSets, HasLen, 1)
+	c.Check(p.EmptyAdaptationSets[0].MimeType, Equals, "audio/mp4")
+	c.Check(m.Validate(), IsNil)
+
+	obtained, err := m.Encode()
+	c.Assert(err, IsNil)
+	c.Check(string(obtained), Equals, strings.Replace(string(b), "></AdaptationSet>", "/>", 1))
+	c.Check(ValidateElementOrder(obtained), IsNil)
+
+	*p.EmptyAdaptationSets[0].ID = 1
+	p.EmptyAdaptationSets[0].Representations = []Representation{{}}
+	err = m.Validate()
+	c.Assert(err, NotNil)
+	c.Check(err.Error(), Equals, strings.Join([]string{
+		"Periods[0].EmptyAdaptationSets[0]: EmptyAdaptationSet must not contain Representations",
+		"Periods[0].EmptyAdaptationSets[0]: id 1 is used by AdaptationSet",
+	}, "\n"))
+}
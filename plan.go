@@ -0,0 +1,174 @@
+package mpd
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// PlanOptions control PlanDownload.
+type PlanOptions struct {
+	// Start and End limit media presentation time to download. Zero End means the end of presentation.
+	Start time.Duration
+	End   time.Duration
+	// MaxBandwidth limits the sum of bandwidths of Representations selected in a Period, in bits per second.
+	// Zero means no limit, so the best Representation of every switching set is selected.
+	MaxBandwidth uint64
+}
+
+// PlannedRepresentation is a Representation selected by PlanDownload with segments to fetch.
+type PlannedRepresentation struct {
+	ResolvedRepresentation
+	// Segments are initialization, bitstream switching or index segments followed by media segments
+	// overlapping the time range. Subsegments of SegmentBase Representations are not known without
+	// the index segment, so whole Representations are planned for them.
+	Segments []Segment
+}
+
+// DownloadPlan is a result of PlanDownload.
+type DownloadPlan struct {
+	Representations []PlannedRepresentation
+}
+
+// Segments returns segments of all Representations of the plan.
+func (dp *DownloadPlan) Segments() []Segment {
+	var res []Segment
+	for _, pr := range dp.Representations {
+		res = append(res, pr.Segments...)
+	}
+	return res
+}
+
+// PlanDownload selects a Representation from each switching set of Periods of m overlapping the time range
+// of o, and enumerates their segments overlapping the range, resolved against manifestURL as Segments does.
+// In every Period all switching sets start with their lowest bandwidth Representations, which then are
+// upgraded one step at a time, in turns, while the sum of bandwidths fits into o.MaxBandwidth.
+func PlanDownload(manifestURL string, m *MPD, o PlanOptions) (*DownloadPlan, error) {
+	sets, err := m.SwitchingSets()
+	if err != nil {
+		return nil, fmt.Errorf("PlanDownload: %w", err)
+	}
+
+	res := new(DownloadPlan)
+	for i, p := range m.Periods {
+		start, end, err := periodRange(m, i)
+		if err != nil {
+			return nil, fmt.Errorf("PlanDownload: %s: %w", periodPath(i), err)
+		}
+		if (end >= 0 && end <= o.Start) || (o.End > 0 && start >= o.End) {
+			continue
+		}
+
+		var ladders [][]ResolvedRepresentation
+		for _, s := range sets {
+			if s.Period != p {
+				continue
+			}
+			ladder := append([]ResolvedRepresentation(nil), s.Representations...)
+			sort.SliceStable(ladder, func(a, b int) bool {
+				return ladder[a].Representation.GetBandwidth() < ladder[b].Representation.GetBandwidth()
+			})
+			ladders = append(ladders, ladder)
+		}
+		selected, err := fitBandwidth(ladders, o.MaxBandwidth)
+		if err != nil {
+			return nil, fmt.Errorf("PlanDownload: %s: %w", periodPath(i), err)
+		}
+
+		for n, ladder := range ladders {
+			rr := ladder[selected[n]]
+			segments, err := Segments(manifestURL, m, p, rr.AdaptationSet, rr.Representation)
+			if err != nil {
+				return nil, fmt.Errorf("PlanDownload: %w", err)
+			}
+			res.Representations = append(res.Representations, PlannedRepresentation{
+				ResolvedRepresentation: rr,
+				Segments:               segmentsInRange(segments, EffectivePresentationTimeOffset(rr.Period, rr.AdaptationSet, rr.Representation), start, o),
+			})
+		}
+	}
+	return res, nil
+}
+
+// fitBandwidth returns indexes of Representations selected from ladders sorted by bandwidth.
+func fitBandwidth(ladders [][]ResolvedRepresentation, budget uint64) ([]int, error) {
+	selected := make([]int, len(ladders))
+	if budget == 0 {
+		for n, ladder := range ladders {
+			selected[n] = len(ladder) - 1
+		}
+		return selected, nil
+	}
+
+	var total uint64
+	for _, ladder := range ladders {
+		total += ladder[0].Representation.GetBandwidth()
+	}
+	if total > budget {
+		return nil, fmt.Errorf("lowest Representations need %d bps, above %d bps", total, budget)
+	}
+	for upgraded := true; upgraded; {
+		upgraded = false
+		for n, ladder := range ladders {
+			k := selected[n]
+			if k+1 == len(ladder) {
+				continue
+			}
+			more := ladder[k+1].Representation.GetBandwidth() - ladder[k].Representation.GetBandwidth()
+			if total+more <= budget {
+				selected[n], total, upgraded = k+1, total+more, true
+			}
+		}
+	}
+	return selected, nil
+}
+
+// periodRange returns start and end of Period i of m in media presentation time; end is -1 if unknown.
+func periodRange(m *MPD, i int) (start, end time.Duration, err error) {
+	p := m.Periods[i]
+	if p.Start != nil {
+		if start, err = ParseDuration(*p.Start); err != nil {
+			return 0, 0, err
+		}
+	}
+	d, err := periodDuration(m, p)
+	switch {
+	case err == nil:
+		return start, start + d, nil
+	case !errors.Is(err, ErrUnknownPeriodDuration):
+		return 0, 0, err
+	}
+	if i+1 < len(m.Periods) && m.Periods[i+1].Start != nil {
+		if end, err = ParseDuration(*m.Periods[i+1].Start); err != nil {
+			return 0, 0, err
+		}
+		return start, end, nil
+	}
+	return start, -1, nil
+}
+
+// segmentsInRange filters media segments with known timing to those overlapping time range of o, given
+// start of their Period. Other segments are kept if any media segment is.
+func segmentsInRange(segments []Segment, pto uint64, periodStart time.Duration, o PlanOptions) []Segment {
+	var header, media []Segment
+	for _, s := range segments {
+		if s.Kind != MediaSegment {
+			header = append(header, s)
+			continue
+		}
+		if s.Timescale != 0 && s.Duration != 0 {
+			ts := uint64Ptr(s.Timescale)
+			start := periodStart + timescaled(s.Time, ts) - timescaled(pto, ts)
+			end := start + timescaled(s.Duration, ts)
+			if end <= o.Start || (o.End > 0 && start >= o.End) {
+				continue
+			}
+		}
+		media = append(media, s)
+	}
+	if len(media) == 0 && len(segments) > len(header) {
+		return nil
+	}
+	return append(header, media...)
+}
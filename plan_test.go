@@ -0,0 +1,75 @@
+package mpd
+
+import (
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestPlanDownload(c *C) {
+	ladder := func(mimeType string, bandwidths map[string]uint64) *AdaptationSet {
+		as := &AdaptationSet{MimeType: mimeType, SegmentTemplate: &SegmentTemplate{
+			Timescale:      uint64Ptr(1000),
+			Duration:       uint32Ptr(2000),
+			Initialization: stringPtr("$RepresentationID$/init.mp4"),
+			Media:          stringPtr("$RepresentationID$/$Number$.m4s"),
+		}}
+		for _, id := range []string{"v1", "v2", "v3", "a1", "a2"} {
+			if b, ok := bandwidths[id]; ok {
+				as.Representations = append(as.Representations, Representation{ID: stringPtr(id), Bandwidth: uint64Ptr(b)})
+			}
+		}
+		return as
+	}
+	m := NewStaticMPD(ProfileISOFFLive, 20*time.Second)
+	m.Periods[0].Duration = stringPtr("PT10S")
+	m.Periods[0].AdaptationSets = []*AdaptationSet{
+		ladder(MimeTypeVideoMP4, map[string]uint64{"v3": 3000000, "v1": 500000, "v2": 1000000}),
+		ladder(MimeTypeAudioMP4, map[string]uint64{"a1": 64000, "a2": 128000}),
+	}
+	m.Periods = append(m.Periods, &Period{ID: stringPtr("p1"), Start: stringPtr("PT10S"), AdaptationSets: []*AdaptationSet{
+		{MimeType: MimeTypeVideoMP4, BaseURL: "full.mp4", SegmentBase: &SegmentBase{IndexRange: stringPtr("800-999")},
+			Representations: []Representation{{ID: stringPtr("full"), Bandwidth: uint64Ptr(2000000)}}},
+	}})
+
+	urls := func(plan *DownloadPlan) []string {
+		var res []string
+		for _, s := range plan.Segments() {
+			res = append(res, s.URL+" "+s.ByteRange)
+		}
+		return res
+	}
+
+	plan, err := PlanDownload("http://example.com/vod/manifest.mpd", m, PlanOptions{Start: 4 * time.Second, End: 8 * time.Second, MaxBandwidth: 1200000})
+	c.Assert(err, IsNil)
+	c.Assert(plan.Representations, HasLen, 2)
+	c.Check(*plan.Representations[0].Representation.ID, Equals, "v2")
+	c.Check(*plan.Representations[1].Representation.ID, Equals, "a2")
+	c.Check(urls(plan), DeepEquals, []string{
+		"http://example.com/vod/v2/init.mp4 ", "http://example.com/vod/v2/3.m4s ", "http://example.com/vod/v2/4.m4s ",
+		"http://example.com/vod/a2/init.mp4 ", "http://example.com/vod/a2/3.m4s ", "http://example.com/vod/a2/4.m4s ",
+	})
+
+	plan, err = PlanDownload("", m, PlanOptions{Start: 9 * time.Second})
+	c.Assert(err, IsNil)
+	c.Check(urls(plan), DeepEquals, []string{"v3/init.mp4 ", "v3/5.m4s ", "a2/init.mp4 ", "a2/5.m4s ", "full.mp4 800-999"})
+
+	_, err = PlanDownload("", m, PlanOptions{MaxBandwidth: 500000})
+	c.Check(err, ErrorMatches, `PlanDownload: Periods\[0\]: lowest Representations need 564000 bps, above 500000 bps`)
+}
+
+func (s *MPDSuite) TestPlanDownloadPresentationTimeOffset(c *C) {
+	m := NewStaticMPD(ProfileISOFFLive, 10*time.Second)
+	m.Periods[0].AdaptationSets = []*AdaptationSet{{MimeType: MimeTypeVideoMP4, SegmentTemplate: &SegmentTemplate{
+		Timescale:              uint64Ptr(1000),
+		Duration:               uint32Ptr(2000),
+		PresentationTimeOffset: uint64Ptr(10000),
+		Media:                  stringPtr("$Time$.m4s"),
+	}, Representations: []Representation{{ID: stringPtr("v1"), Bandwidth: uint64Ptr(500000)}}}}
+
+	plan, err := PlanDownload("", m, PlanOptions{Start: 0, End: 2 * time.Second})
+	c.Assert(err, IsNil)
+	segments := plan.Segments()
+	c.Assert(segments, HasLen, 1)
+	c.Check(segments[0].URL, Equals, "10000.m4s")
+}
@@ -0,0 +1,112 @@
+package mpd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// PreloadURLs returns URLs a player fetches first when starting playback of m from manifestURL, for use in
+// Link preload headers or 103 Early Hints: manifestURL itself (unless empty), then initialization segments and
+// count media segments of the lowest bandwidth Representations of video and audio AdaptationSets selected by
+// Period.Select with c (subtitles are usually off at start). Static MPDs start with the first segments of the
+// first Period, dynamic ones with the newest segments of the last Period; only initialization segments are
+// listed when the live edge is not known without a clock.
+// Byte ranges can't be preloaded, so segments with ByteRange are skipped. URLs are resolved as Segments does.
+func PreloadURLs(manifestURL string, m *MPD, c SelectionCriteria, count int) ([]string, error) {
+	if count < 0 {
+		count = 0
+	}
+	var res []string
+	seen := make(map[string]bool)
+	add := func(u string) {
+		if !seen[u] {
+			seen[u] = true
+			res = append(res, u)
+		}
+	}
+	if manifestURL != "" {
+		add(manifestURL)
+	}
+	if len(m.Periods) == 0 {
+		return res, nil
+	}
+
+	dynamic := m.Type != nil && *m.Type == "dynamic"
+	p := m.Periods[0]
+	if dynamic {
+		p = m.Periods[len(m.Periods)-1]
+	}
+	sel := p.Select(c)
+	for _, as := range []*AdaptationSet{sel.Video, sel.Audio} {
+		if as == nil || len(as.Representations) == 0 {
+			continue
+		}
+		r := &as.Representations[0]
+		for i := range as.Representations {
+			if as.Representations[i].GetBandwidth() < r.GetBandwidth() {
+				r = &as.Representations[i]
+			}
+		}
+
+		segments, err := Segments(manifestURL, m, p, as, r)
+		if dynamic && errors.Is(err, ErrUnknownPeriodDuration) {
+			segments, err = initializationSegment(manifestURL, m, p, as, r)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("PreloadURLs: %w", err)
+		}
+
+		var header, media []Segment
+		for _, s := range segments {
+			switch {
+			case s.ByteRange != "":
+			case s.Kind == MediaSegment:
+				media = append(media, s)
+			default:
+				header = append(header, s)
+			}
+		}
+		if count < len(media) {
+			if dynamic {
+				media = media[len(media)-count:]
+			} else {
+				media = media[:count]
+			}
+		}
+		for _, s := range append(header, media...) {
+			add(s.URL)
+		}
+	}
+	return res, nil
+}
+
+// PreloadLinkHeader returns Link header value preloading urls, as fetched by players with CORS requests.
+func PreloadLinkHeader(urls []string) string {
+	links := make([]string, len(urls))
+	for i, u := range urls {
+		links[i] = "<" + u + ">; rel=preload; as=fetch; crossorigin"
+	}
+	return strings.Join(links, ", ")
+}
+
+// initializationSegment returns initialization segment of SegmentTemplate addressing of r, if any,
+// which doesn't need media segments to be enumerable.
+func initializationSegment(manifestURL string, m *MPD, p *Period, as *AdaptationSet, r *Representation) ([]Segment, error) {
+	t := EffectiveSegmentTemplate(p, as, r)
+	if t == nil || t.Initialization == nil {
+		return nil, nil
+	}
+	if manifestURL == "" {
+		manifestURL = relativeBase
+	}
+	base, err := effectiveBaseURL(manifestURL, m, p, as, r)
+	if err != nil {
+		return nil, err
+	}
+	u, err := resolveBaseURL(base, expandTemplate(*t.Initialization, r, 0, 0))
+	if err != nil {
+		return nil, err
+	}
+	return []Segment{{Kind: InitializationSegment, URL: strings.TrimPrefix(u.String(), relativeBase)}}, nil
+}
@@ -0,0 +1,72 @@
+package mpd
+
+import (
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestPreloadURLs(c *C) {
+	template := func() *SegmentTemplate {
+		return &SegmentTemplate{
+			Timescale:      uint64Ptr(1000),
+			Duration:       uint32Ptr(2000),
+			Initialization: stringPtr("$RepresentationID$/init.mp4"),
+			Media:          stringPtr("$RepresentationID$/$Number$.m4s"),
+		}
+	}
+	m := NewStaticMPD(ProfileISOFFLive, 10*time.Second)
+	m.Periods[0].AdaptationSets = []*AdaptationSet{
+		{MimeType: MimeTypeVideoMP4, SegmentTemplate: template(), Representations: []Representation{
+			{ID: stringPtr("v2"), Bandwidth: uint64Ptr(3000000)},
+			{ID: stringPtr("v1"), Bandwidth: uint64Ptr(500000)},
+		}},
+		{MimeType: MimeTypeAudioMP4, Lang: stringPtr("en"), SegmentTemplate: template(), Representations: []Representation{
+			{ID: stringPtr("en"), Bandwidth: uint64Ptr(64000)},
+		}},
+		{MimeType: MimeTypeAudioMP4, Lang: stringPtr("fr"), SegmentTemplate: template(), Representations: []Representation{
+			{ID: stringPtr("fr"), Bandwidth: uint64Ptr(64000)},
+		}},
+		{MimeType: "text/vtt", Lang: stringPtr("en"), BaseURL: "subs.vtt", SegmentBase: &SegmentBase{IndexRange: stringPtr("0-99")},
+			Representations: []Representation{{ID: stringPtr("subs"), Bandwidth: uint64Ptr(1000)}}},
+	}
+
+	urls, err := PreloadURLs("https://cdn.example.com/vod/manifest.mpd", m, SelectionCriteria{AudioLanguages: []string{"fr"}}, 2)
+	c.Assert(err, IsNil)
+	c.Check(urls, DeepEquals, []string{
+		"https://cdn.example.com/vod/manifest.mpd",
+		"https://cdn.example.com/vod/v1/init.mp4",
+		"https://cdn.example.com/vod/v1/1.m4s",
+		"https://cdn.example.com/vod/v1/2.m4s",
+		"https://cdn.example.com/vod/fr/init.mp4",
+		"https://cdn.example.com/vod/fr/1.m4s",
+		"https://cdn.example.com/vod/fr/2.m4s",
+	})
+
+	urls, err = PreloadURLs("", m, SelectionCriteria{}, 0)
+	c.Assert(err, IsNil)
+	c.Check(urls, DeepEquals, []string{"v1/init.mp4", "en/init.mp4"})
+
+	c.Check(PreloadLinkHeader(urls), Equals,
+		"<v1/init.mp4>; rel=preload; as=fetch; crossorigin, <en/init.mp4>; rel=preload; as=fetch; crossorigin")
+
+	live := NewDynamicMPD(ProfileISOFFLive, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), 2*time.Second)
+	timeline := template()
+	timeline.Duration = nil
+	timeline.Media = stringPtr("$RepresentationID$/$Time$.m4s")
+	repeat := int64(2)
+	timeline.SegmentTimeline = []SegmentTimeline{{Segments: []SegmentTimelineSegment{{T: uint64Ptr(4000), D: 2000, R: &repeat}}}}
+	live.Periods[0].AdaptationSets = []*AdaptationSet{
+		{MimeType: MimeTypeVideoMP4, SegmentTemplate: timeline, Representations: []Representation{{ID: stringPtr("v"), Bandwidth: uint64Ptr(500000)}}},
+		{MimeType: MimeTypeAudioMP4, SegmentTemplate: template(), Representations: []Representation{{ID: stringPtr("a"), Bandwidth: uint64Ptr(64000)}}},
+	}
+	urls, err = PreloadURLs("/live/manifest.mpd", live, SelectionCriteria{}, 2)
+	c.Assert(err, IsNil)
+	c.Check(urls, DeepEquals, []string{
+		"/live/manifest.mpd",
+		"/live/v/init.mp4",
+		"/live/v/6000.m4s",
+		"/live/v/8000.m4s",
+		"/live/a/init.mp4",
+	})
+}
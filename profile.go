@@ -0,0 +1,192 @@
+package mpd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// profileAddressing lists segment addressing required by profiles, see ValidateProfiles.
+var profileAddressing = map[string]string{
+	ProfileISOFFLive:     "SegmentTemplate",
+	ProfileCMAF:          "SegmentTemplate",
+	ProfileDVBDASH:       "SegmentTemplate",
+	ProfileHbbTV:         "SegmentTemplate",
+	ProfileISOFFOnDemand: "SegmentBase",
+}
+
+// ProfileList returns profiles listed in MPD@profiles.
+func (m *MPD) ProfileList() []string {
+	var res []string
+	for _, p := range strings.Split(m.Profiles, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			res = append(res, p)
+		}
+	}
+	return res
+}
+
+// ValidateProfiles checks restrictions of profiles listed in MPD@profiles, which Validate doesn't check:
+// isoff-live and derived profiles require SegmentTemplate addressing and isoff-on-demand requires
+// static MPD with SegmentBase addressing. Representation should satisfy at least one of listed profiles;
+// full and isoff-main profiles have no restrictions. It returns ValidationErrors or nil.
+func (m *MPD) ValidateProfiles() error {
+	profiles := m.ProfileList()
+	if len(profiles) == 0 {
+		return ValidationErrors{newValidationError("", "no @profiles")}
+	}
+
+	var res ValidationErrors
+	allowed := make(map[string]bool)
+	for _, p := range profiles {
+		switch p {
+		case ProfileFull, ProfileISOFFMain:
+			return nil
+		case ProfileISOFFOnDemand:
+			if m.Type != nil && *m.Type == "dynamic" {
+				res = append(res, newValidationError("", "isoff-on-demand profile requires static MPD"))
+			}
+		}
+		if a, ok := profileAddressing[p]; ok {
+			allowed[a] = true
+		}
+	}
+	if len(allowed) == 0 {
+		return nil
+	}
+
+	for i, p := range m.Periods {
+		for j, as := range p.AdaptationSets {
+			for k := range as.Representations {
+				addressing := effectiveAddressing(p, as, &as.Representations[k])
+				if addressing != "" && !allowed[addressing] {
+					res = append(res, newValidationError(representationPath(i, j, k), "%s addressing is not allowed by @profiles", addressing))
+				}
+			}
+		}
+	}
+	if len(res) == 0 {
+		return nil
+	}
+	return res
+}
+
+// ConditionProfiles conditions m for a client which supports only given profiles: it removes Representations
+// with segment addressing not allowed by any of them, AdaptationSets left without Representations, segment
+// addressing elements of disallowed kinds and on-demand subsegment attributes if SegmentBase addressing is not
+// allowed, and sets MPD@profiles to supported profiles m now conforms to: profiles requiring segment addressing
+// no remaining Representation uses are left out. It returns paths of removed
+// Representations in m before conditioning, or error if no Representations or profiles are left, in which
+// case Representations may already have been removed from m.
+func (m *MPD) ConditionProfiles(supported ...string) ([]string, error) {
+	var profiles []string
+	allowed := make(map[string]bool)
+	unrestricted := false
+	for _, p := range supported {
+		switch p {
+		case ProfileFull, ProfileISOFFMain:
+			unrestricted = true
+		case ProfileISOFFOnDemand:
+			if m.Type != nil && *m.Type == "dynamic" {
+				continue
+			}
+		}
+		if a, ok := profileAddressing[p]; ok {
+			allowed[a] = true
+		}
+		profiles = append(profiles, p)
+	}
+	if len(profiles) == 0 {
+		return nil, fmt.Errorf("ConditionProfiles: MPD can't conform to any of %s", strings.Join(supported, ", "))
+	}
+	if unrestricted || len(allowed) == 0 {
+		m.Profiles = strings.Join(profiles, ",")
+		return nil, nil
+	}
+
+	var removed []string
+	var left int
+	used := make(map[string]bool)
+	for i, p := range m.Periods {
+		var sets []*AdaptationSet
+		for j, as := range p.AdaptationSets {
+			var reps []Representation
+			for k := range as.Representations {
+				r := &as.Representations[k]
+				a := effectiveAddressing(p, as, r)
+				if a != "" && !allowed[a] {
+					removed = append(removed, representationPath(i, j, k))
+					continue
+				}
+				used[a] = true
+				reps = append(reps, *r)
+			}
+			if len(reps) == 0 && len(as.Representations) > 0 {
+				continue
+			}
+			as.Representations = reps
+			left += len(reps)
+			sets = append(sets, as)
+		}
+		p.AdaptationSets = sets
+	}
+	if left == 0 {
+		return removed, fmt.Errorf("ConditionProfiles: no Representations conform to %s", strings.Join(profiles, ", "))
+	}
+	if len(used) > 1 || !used[""] {
+		var conforming []string
+		allowed = make(map[string]bool)
+		for _, p := range profiles {
+			if a, ok := profileAddressing[p]; ok {
+				if !used[a] {
+					continue
+				}
+				allowed[a] = true
+			}
+			conforming = append(conforming, p)
+		}
+		profiles = conforming
+	}
+
+	for _, p := range m.Periods {
+		removeAddressing(allowed, &p.SegmentTemplate, &p.SegmentList, &p.SegmentBase)
+		for _, as := range p.AdaptationSets {
+			removeAddressing(allowed, &as.SegmentTemplate, &as.SegmentList, &as.SegmentBase)
+			if !allowed["SegmentBase"] {
+				as.SubsegmentAlignment = ConditionalUint{}
+				as.SubsegmentStartsWithSAP = nil
+			}
+			for k := range as.Representations {
+				r := &as.Representations[k]
+				removeAddressing(allowed, &r.SegmentTemplate, &r.SegmentList, &r.SegmentBase)
+			}
+		}
+	}
+	m.Profiles = strings.Join(profiles, ",")
+	return removed, nil
+}
+
+// effectiveAddressing returns kind of segment addressing of r, or empty string if it has none.
+func effectiveAddressing(p *Period, as *AdaptationSet, r *Representation) string {
+	switch {
+	case EffectiveSegmentTemplate(p, as, r) != nil:
+		return "SegmentTemplate"
+	case EffectiveSegmentList(p, as, r) != nil:
+		return "SegmentList"
+	case EffectiveSegmentBase(p, as, r) != nil:
+		return "SegmentBase"
+	}
+	return ""
+}
+
+// removeAddressing removes segment addressing elements of kinds which are not allowed.
+func removeAddressing(allowed map[string]bool, st **SegmentTemplate, sl **SegmentList, sb **SegmentBase) {
+	if !allowed["SegmentTemplate"] {
+		*st = nil
+	}
+	if !allowed["SegmentList"] {
+		*sl = nil
+	}
+	if !allowed["SegmentBase"] {
+		*sb = nil
+	}
+}
@@ -0,0 +1,79 @@
+package mpd
+
+import (
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestValidateProfiles(c *C) {
+	m := NewStaticMPD(ProfileISOFFLive+", "+ProfileDVBDASH, time.Minute)
+	c.Check(m.ProfileList(), DeepEquals, []string{ProfileISOFFLive, ProfileDVBDASH})
+	m.Periods[0].AdaptationSets = []*AdaptationSet{{
+		SegmentTemplate: &SegmentTemplate{Media: stringPtr("$Number$.m4s")},
+		Representations: []Representation{{ID: stringPtr("1")}, {ID: stringPtr("2"), SegmentBase: &SegmentBase{}}},
+	}, {
+		Representations: []Representation{{ID: stringPtr("3"), SegmentBase: &SegmentBase{}}},
+	}}
+	c.Check(m.ValidateProfiles(), ErrorMatches, `Periods\[0\]\.AdaptationSets\[1\]\.Representations\[0\]: SegmentBase addressing is not allowed by @profiles`)
+
+	m.Profiles = ProfileISOFFLive + "," + ProfileISOFFOnDemand
+	c.Check(m.ValidateProfiles(), IsNil)
+	m.Type = stringPtr("dynamic")
+	c.Check(m.ValidateProfiles(), ErrorMatches, "isoff-on-demand profile requires static MPD")
+
+	m.Profiles = ProfileFull
+	c.Check(m.ValidateProfiles(), IsNil)
+	m.Profiles = " "
+	c.Check(m.ValidateProfiles(), ErrorMatches, "no @profiles")
+}
+
+func (s *MPDSuite) TestConditionProfiles(c *C) {
+	m := NewStaticMPD(ProfileFull, time.Minute)
+	as := &AdaptationSet{
+		SegmentBase:     &SegmentBase{},
+		Representations: []Representation{{ID: stringPtr("1"), SegmentTemplate: &SegmentTemplate{Media: stringPtr("$Number$.m4s")}}, {ID: stringPtr("2")}},
+	}
+	as.SubsegmentStartsWithSAP = uint64Ptr(1)
+	m.Periods[0].AdaptationSets = []*AdaptationSet{as, {
+		Representations: []Representation{{ID: stringPtr("3"), SegmentList: &SegmentList{}}},
+	}}
+
+	removed, err := m.ConditionProfiles(ProfileISOFFOnDemand, ProfileISOFFLive)
+	c.Assert(err, IsNil)
+	c.Check(removed, DeepEquals, []string{"Periods[0].AdaptationSets[1].Representations[0]"})
+	c.Check(m.Profiles, Equals, ProfileISOFFOnDemand+","+ProfileISOFFLive)
+	c.Check(m.Periods[0].AdaptationSets, HasLen, 1)
+	c.Check(m.ValidateProfiles(), IsNil)
+
+	m.Type = stringPtr("dynamic")
+	removed, err = m.ConditionProfiles(ProfileISOFFOnDemand, ProfileISOFFLive)
+	c.Assert(err, IsNil)
+	c.Check(removed, DeepEquals, []string{"Periods[0].AdaptationSets[0].Representations[1]"})
+	c.Check(m.Profiles, Equals, ProfileISOFFLive)
+	as = m.Periods[0].AdaptationSets[0]
+	c.Check(as.SegmentBase, IsNil)
+	c.Check(as.SubsegmentStartsWithSAP, IsNil)
+	c.Check(as.Representations, HasLen, 1)
+	c.Check(m.ValidateProfiles(), IsNil)
+
+	_, err = m.ConditionProfiles(ProfileISOFFOnDemand)
+	c.Check(err, ErrorMatches, "ConditionProfiles: MPD can't conform to any of .*")
+	m.Type = nil
+	_, err = m.ConditionProfiles(ProfileISOFFOnDemand)
+	c.Check(err, ErrorMatches, "ConditionProfiles: no Representations conform to .*")
+
+	// profiles of unused addressing are left out
+	m = NewStaticMPD(ProfileFull, time.Minute)
+	m.Periods[0].AdaptationSets = []*AdaptationSet{{
+		SegmentTemplate: &SegmentTemplate{Media: stringPtr("$Number$.m4s")},
+		Representations: []Representation{{ID: stringPtr("1")}},
+	}}
+	m.Periods[0].AdaptationSets[0].SubsegmentStartsWithSAP = uint64Ptr(1)
+	removed, err = m.ConditionProfiles(ProfileISOFFLive, ProfileISOFFOnDemand)
+	c.Assert(err, IsNil)
+	c.Check(removed, IsNil)
+	c.Check(m.Profiles, Equals, ProfileISOFFLive)
+	c.Check(m.Periods[0].AdaptationSets[0].SubsegmentStartsWithSAP, IsNil)
+	c.Check(m.ValidateProfiles(), IsNil)
+}
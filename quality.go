@@ -0,0 +1,91 @@
+package mpd
+
+import (
+	"fmt"
+	"sort"
+)
+
+// AssignQualityRankings sets @qualityRanking of Representations of as, lower values meaning higher quality:
+// Representations are ranked by resolution, then by bandwidth. Representations with equal resolution
+// and bandwidth share a ranking.
+func (as *AdaptationSet) AssignQualityRankings() {
+	order := make([]*Representation, len(as.Representations))
+	for k := range as.Representations {
+		order[k] = &as.Representations[k]
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return betterQuality(order[a], order[b])
+	})
+	var rank uint64
+	for n, r := range order {
+		if n == 0 || betterQuality(order[n-1], r) {
+			rank++
+		}
+		r.QualityRanking = uint64Ptr(rank)
+	}
+}
+
+// betterQuality returns true if a has higher resolution than b, or the same resolution and higher bandwidth.
+func betterQuality(a, b *Representation) bool {
+	if pa, pb := pixels(a), pixels(b); pa != pb {
+		return pa > pb
+	}
+	return a.GetBandwidth() > b.GetBandwidth()
+}
+
+// pixels returns number of pixels of r, or 0 if it has no dimensions.
+func pixels(r *Representation) uint64 {
+	if r.Width == nil || r.Height == nil {
+		return 0
+	}
+	return *r.Width * *r.Height
+}
+
+// validateQualityRankings checks that @qualityRanking is set on all Representations of an AdaptationSet
+// or none, and that Representations ranked higher don't have both lower resolution and lower bandwidth.
+func validateQualityRankings(m *MPD) ValidationErrors {
+	var res ValidationErrors
+	for i, p := range m.Periods {
+		for j, as := range p.AdaptationSets {
+			var ranked int
+			for k := range as.Representations {
+				if as.Representations[k].QualityRanking != nil {
+					ranked++
+				}
+			}
+			if ranked == 0 {
+				continue
+			}
+			if ranked < len(as.Representations) {
+				res = append(res, newValidationError(adaptationSetPath(i, j), "qualityRanking is set on %d of %d Representations", ranked, len(as.Representations)))
+			}
+
+			for k := range as.Representations {
+				r := &as.Representations[k]
+				if r.QualityRanking == nil {
+					continue
+				}
+				for n := range as.Representations {
+					other := &as.Representations[n]
+					if other.QualityRanking == nil || *r.QualityRanking >= *other.QualityRanking {
+						continue
+					}
+					if pixels(r) <= pixels(other) && r.GetBandwidth() < other.GetBandwidth() {
+						res = append(res, newValidationError(representationPath(i, j, k),
+							"qualityRanking %d ranks it above Representation %s (qualityRanking %d) with higher bandwidth and no lower resolution",
+							*r.QualityRanking, representationName(other, n), *other.QualityRanking))
+					}
+				}
+			}
+		}
+	}
+	return res
+}
+
+// representationName returns id of r, or its index if it has no id.
+func representationName(r *Representation, k int) string {
+	if r.ID != nil {
+		return fmt.Sprintf("%q", *r.ID)
+	}
+	return fmt.Sprintf("[%d]", k)
+}
@@ -0,0 +1,44 @@
+package mpd
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestAssignQualityRankings(c *C) {
+	as := &AdaptationSet{MimeType: "video/mp4", Representations: []Representation{
+		{ID: stringPtr("540p"), Width: uint64Ptr(960), Height: uint64Ptr(540), Bandwidth: uint64Ptr(2000000)},
+		{ID: stringPtr("1080p"), Width: uint64Ptr(1920), Height: uint64Ptr(1080), Bandwidth: uint64Ptr(5000000)},
+		{ID: stringPtr("540p-low"), Width: uint64Ptr(960), Height: uint64Ptr(540), Bandwidth: uint64Ptr(1000000)},
+		{ID: stringPtr("540p-copy"), Width: uint64Ptr(960), Height: uint64Ptr(540), Bandwidth: uint64Ptr(2000000)},
+	}}
+	as.AssignQualityRankings()
+	var rankings []uint64
+	for _, r := range as.Representations {
+		rankings = append(rankings, *r.QualityRanking)
+	}
+	c.Check(rankings, DeepEquals, []uint64{2, 1, 3, 2})
+
+	m := &MPD{Periods: []*Period{{AdaptationSets: []*AdaptationSet{as}}}}
+	c.Check(validateQualityRankings(m), HasLen, 0)
+
+	b, err := m.Encode()
+	c.Assert(err, IsNil)
+	c.Check(string(b), Matches, `(?s).*<Representation id="1080p" width="1920" height="1080" bandwidth="5000000" qualityRanking="1"/>.*`)
+}
+
+func (s *MPDSuite) TestValidateQualityRankings(c *C) {
+	m := &MPD{Periods: []*Period{{AdaptationSets: []*AdaptationSet{
+		{MimeType: "video/mp4", Representations: []Representation{
+			{ID: stringPtr("low"), Width: uint64Ptr(640), Height: uint64Ptr(360), Bandwidth: uint64Ptr(500000), QualityRanking: uint64Ptr(1)},
+			{ID: stringPtr("high"), Width: uint64Ptr(1280), Height: uint64Ptr(720), Bandwidth: uint64Ptr(3000000), QualityRanking: uint64Ptr(2)},
+		}},
+		{MimeType: "audio/mp4", Representations: []Representation{
+			{Bandwidth: uint64Ptr(128000), QualityRanking: uint64Ptr(1)},
+			{Bandwidth: uint64Ptr(64000)},
+		}},
+	}}}}
+	c.Check(validateQualityRankings(m), DeepEquals, ValidationErrors{
+		{Path: "Periods[0].AdaptationSets[0].Representations[0]", Message: `qualityRanking 1 ranks it above Representation "high" (qualityRanking 2) with higher bandwidth and no lower resolution`},
+		{Path: "Periods[0].AdaptationSets[1]", Message: "qualityRanking is set on 1 of 2 Representations"},
+	})
+}
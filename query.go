@@ -0,0 +1,117 @@
+package mpd
+
+import (
+	"net/url"
+)
+
+// AddURLQuery appends query parameters q to all segment URLs of m, e.g. for URL tokenization: SegmentTemplate
+// media and initialization, SegmentList SegmentURLs and Initialization, and BaseURLs of Representations
+// addressed by SegmentBase or without segment addressing.
+func (m *MPD) AddURLQuery(q url.Values) error {
+	if len(q) == 0 {
+		return nil
+	}
+	query := q.Encode()
+	add := func(s *string) error {
+		if s == nil {
+			return nil
+		}
+		return rewriteTemplate(s, func(u *url.URL) *url.URL {
+			if u.RawQuery == "" {
+				u.RawQuery = query
+			} else {
+				u.RawQuery += "&" + query
+			}
+			return u
+		})
+	}
+	addURLType := func(u *URLType) error {
+		if u == nil {
+			return nil
+		}
+		return add(u.SourceURL)
+	}
+	addTemplate := func(t *SegmentTemplate) error {
+		if t == nil {
+			return nil
+		}
+		if err := add(t.Media); err != nil {
+			return err
+		}
+		if err := add(t.Initialization); err != nil {
+			return err
+		}
+		return addURLType(t.BitstreamSwitching)
+	}
+	addList := func(l *SegmentList) error {
+		if l == nil {
+			return nil
+		}
+		if err := addURLType(l.Initialization); err != nil {
+			return err
+		}
+		if err := addURLType(l.BitstreamSwitching); err != nil {
+			return err
+		}
+		for i := range l.SegmentURLs {
+			if err := add(l.SegmentURLs[i].Media); err != nil {
+				return err
+			}
+			if err := add(l.SegmentURLs[i].Index); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	addBase := func(b *SegmentBase) error {
+		if b == nil {
+			return nil
+		}
+		if err := addURLType(b.Initialization); err != nil {
+			return err
+		}
+		return addURLType(b.RepresentationIndex)
+	}
+
+	for _, p := range m.Periods {
+		if err := addTemplate(p.SegmentTemplate); err != nil {
+			return err
+		}
+		if err := addList(p.SegmentList); err != nil {
+			return err
+		}
+		if err := addBase(p.SegmentBase); err != nil {
+			return err
+		}
+		for _, as := range p.AdaptationSets {
+			if err := addTemplate(as.SegmentTemplate); err != nil {
+				return err
+			}
+			if err := addList(as.SegmentList); err != nil {
+				return err
+			}
+			if err := addBase(as.SegmentBase); err != nil {
+				return err
+			}
+			for k := range as.Representations {
+				r := &as.Representations[k]
+				if err := addTemplate(r.SegmentTemplate); err != nil {
+					return err
+				}
+				if err := addList(r.SegmentList); err != nil {
+					return err
+				}
+				if err := addBase(r.SegmentBase); err != nil {
+					return err
+				}
+				// BaseURL is the media URL itself; empty one resolves to parent BaseURL with given query
+				if EffectiveSegmentTemplate(p, as, r) == nil && EffectiveSegmentList(p, as, r) == nil {
+					if err := add(&r.BaseURL); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
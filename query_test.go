@@ -0,0 +1,35 @@
+package mpd
+
+import (
+	"net/url"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestAddURLQuery(c *C) {
+	m := &MPD{Periods: []*Period{{AdaptationSets: []*AdaptationSet{
+		{
+			SegmentTemplate: &SegmentTemplate{Media: stringPtr("$RepresentationID$/$Number%05d$.m4s"), Initialization: stringPtr("$RepresentationID$/init.mp4?v=2")},
+			Representations: []Representation{{ID: stringPtr("v1")}},
+		},
+		{
+			SegmentList:     &SegmentList{Initialization: &URLType{SourceURL: stringPtr("init.mp4")}, SegmentURLs: []SegmentURL{{Media: stringPtr("1.m4s")}}},
+			Representations: []Representation{{ID: stringPtr("a1")}},
+		},
+		{Representations: []Representation{
+			{ID: stringPtr("t1"), BaseURL: "subtitles.vtt"},
+			{ID: stringPtr("t2")},
+		}},
+	}}}}
+
+	c.Assert(m.AddURLQuery(url.Values{"token": {"a b"}}), IsNil)
+	as := m.Periods[0].AdaptationSets
+	c.Check(*as[0].SegmentTemplate.Media, Equals, "$RepresentationID$/$Number%05d$.m4s?token=a+b")
+	c.Check(*as[0].SegmentTemplate.Initialization, Equals, "$RepresentationID$/init.mp4?v=2&token=a+b")
+	c.Check(as[0].Representations[0].BaseURL, Equals, "")
+	c.Check(*as[1].SegmentList.Initialization.SourceURL, Equals, "init.mp4?token=a+b")
+	c.Check(*as[1].SegmentList.SegmentURLs[0].Media, Equals, "1.m4s?token=a+b")
+	c.Check(as[2].Representations[0].BaseURL, Equals, "subtitles.vtt?token=a+b")
+	c.Check(as[2].Representations[1].BaseURL, Equals, "?token=a+b")
+
+}
@@ -0,0 +1,61 @@
+package mpd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// validateReferences checks that Representation@dependencyId, @associationId and
+// Preselection@preselectionComponents reference existing elements of the same Period.
+func validateReferences(m *MPD) ValidationErrors {
+	var res ValidationErrors
+	for i, p := range m.Periods {
+		reps := make(map[string]bool)
+		for _, as := range p.AdaptationSets {
+			for _, r := range as.Representations {
+				if r.ID != nil {
+					reps[*r.ID] = true
+				}
+			}
+		}
+
+		for j, as := range p.AdaptationSets {
+			for k, r := range as.Representations {
+				for _, ref := range []struct {
+					attr string
+					ids  *string
+				}{
+					{"dependencyId", r.DependencyID},
+					{"associationId", r.AssociationID},
+				} {
+					if ref.ids == nil {
+						continue
+					}
+					for _, id := range strings.Fields(*ref.ids) {
+						if !reps[id] {
+							res = append(res, newValidationError(representationPath(i, j, k), "%s references missing Representation %q", ref.attr, id))
+						} else if r.ID != nil && *r.ID == id {
+							res = append(res, newValidationError(representationPath(i, j, k), "%s references itself", ref.attr))
+						}
+					}
+				}
+			}
+		}
+
+		for n, ps := range p.Preselections {
+			path := fmt.Sprintf("%s.Preselections[%d]", periodPath(i), n)
+			components := strings.Fields(ps.PreselectionComponents)
+			if len(components) == 0 {
+				res = append(res, newValidationError(path, "empty preselectionComponents"))
+			}
+			for _, c := range components {
+				id, err := strconv.ParseUint(c, 10, 64)
+				if err != nil || p.findAdaptationSet(id) == nil {
+					res = append(res, newValidationError(path, "preselectionComponents references missing AdaptationSet %q", c))
+				}
+			}
+		}
+	}
+	return res
+}
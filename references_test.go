@@ -0,0 +1,27 @@
+package mpd
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestValidateReferences(c *C) {
+	m := &MPD{Periods: []*Period{{
+		AdaptationSets: []*AdaptationSet{
+			{ID: uint64Ptr(1), Representations: []Representation{{ID: stringPtr("v1")}, {ID: stringPtr("v2"), DependencyID: stringPtr("v1")}}},
+			{ID: uint64Ptr(2), Representations: []Representation{{ID: stringPtr("a1"), AssociationID: stringPtr("v1 v2"), AssociationType: stringPtr("cdsc cdsc")}}},
+		},
+		Preselections: []Preselection{{ID: stringPtr("1"), PreselectionComponents: "1 2"}},
+	}}}
+	c.Check(m.Validate(), IsNil)
+
+	as := m.Periods[0].AdaptationSets
+	as[0].Representations[1].DependencyID = stringPtr("v0")
+	as[1].Representations[0].AssociationID = stringPtr("a1")
+	m.Periods[0].Preselections[0].PreselectionComponents = "1 3"
+
+	err := m.Validate()
+	c.Assert(err, FitsTypeOf, ValidationErrors{})
+	c.Check(err.Error(), Equals, `Periods[0].AdaptationSets[0].Representations[1]: dependencyId references missing Representation "v0"
+Periods[0].AdaptationSets[1].Representations[0]: associationId references itself
+Periods[0].Preselections[0]: preselectionComponents references missing AdaptationSet "3"`)
+}
@@ -0,0 +1,101 @@
+package mpd
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ResolvedRepresentation is a read-only view of Representation with values inherited
+// from its Period and AdaptationSet. It shares values with MPD, so don't modify them.
+type ResolvedRepresentation struct {
+	Period         *Period
+	AdaptationSet  *AdaptationSet
+	Representation *Representation
+
+	// BaseURL is a combination of MPD, Period, AdaptationSet and Representation BaseURLs.
+	// It is relative if MPD BaseURL is relative, and empty if there are no BaseURLs.
+	BaseURL   string
+	MimeType  string
+	Codecs    *string
+	FrameRate *string
+	Lang      *string
+
+	// Only one of those is set for Representations with segment addressing.
+	SegmentTemplate *SegmentTemplate
+	SegmentList     *SegmentList
+	SegmentBase     *SegmentBase
+
+	ContentProtections     []ContentProtection
+	OutputProtection       *Descriptor
+	EssentialProperties    []Descriptor
+	SupplementalProperties []Descriptor
+	Accessibility          []Descriptor
+	Roles                  []Descriptor
+}
+
+// Resolve returns resolved views of all Representations of m in document order.
+func Resolve(m *MPD) ([]ResolvedRepresentation, error) {
+	var res []ResolvedRepresentation
+	for i, p := range m.Periods {
+		for j, as := range p.AdaptationSets {
+			for k := range as.Representations {
+				r := &as.Representations[k]
+				base, err := effectiveBaseURL(relativeBase, m, p, as, r)
+				if err != nil {
+					return nil, fmt.Errorf("Resolve: %s: %w", representationPath(i, j, k), err)
+				}
+
+				rr := ResolvedRepresentation{
+					Period:         p,
+					AdaptationSet:  as,
+					Representation: r,
+					BaseURL:        strings.TrimPrefix(base.String(), relativeBase),
+					MimeType:       as.MimeType,
+					Codecs:         r.Codecs,
+					FrameRate:      r.FrameRate,
+					Lang:           as.Lang,
+
+					ContentProtections:     append(append([]ContentProtection(nil), as.ContentProtections...), r.ContentProtections...),
+					EssentialProperties:    append(append([]Descriptor(nil), as.EssentialProperties...), r.EssentialProperties...),
+					SupplementalProperties: append(append([]Descriptor(nil), as.SupplementalProperties...), r.SupplementalProperties...),
+					Accessibility:          as.Accessibility,
+					Roles:                  as.Roles,
+				}
+				if rr.OutputProtection = r.OutputProtection; rr.OutputProtection == nil {
+					rr.OutputProtection = as.OutputProtection
+				}
+				if rr.Codecs == nil {
+					rr.Codecs = as.Codecs
+				}
+				if rr.FrameRate == nil {
+					rr.FrameRate = as.FrameRate
+				}
+				switch {
+				case r.SegmentTemplate != nil || as.SegmentTemplate != nil || p.SegmentTemplate != nil:
+					rr.SegmentTemplate = EffectiveSegmentTemplate(p, as, r)
+				case r.SegmentList != nil || as.SegmentList != nil || p.SegmentList != nil:
+					rr.SegmentList = EffectiveSegmentList(p, as, r)
+				default:
+					rr.SegmentBase = EffectiveSegmentBase(p, as, r)
+				}
+				res = append(res, rr)
+			}
+		}
+	}
+	return res, nil
+}
+
+// effectiveBaseURL returns BaseURL hierarchy of r resolved against manifestURL.
+func effectiveBaseURL(manifestURL string, m *MPD, p *Period, as *AdaptationSet, r *Representation) (*url.URL, error) {
+	base, err := url.Parse(manifestURL)
+	if err != nil {
+		return nil, fmt.Errorf("can't parse manifest URL %q: %w", manifestURL, err)
+	}
+	for _, ref := range []string{m.BaseURL, p.BaseURL, as.BaseURL, r.BaseURL} {
+		if base, err = resolveBaseURL(base, ref); err != nil {
+			return nil, err
+		}
+	}
+	return base, nil
+}
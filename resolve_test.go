@@ -0,0 +1,54 @@
+package mpd
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestResolve(c *C) {
+	cenc := ContentProtection{SchemeIDURI: stringPtr("urn:mpeg:dash:mp4protection:2011"), Value: stringPtr("cenc")}
+	widevine := ContentProtection{SchemeIDURI: stringPtr("urn:uuid:edef8ba9-79d6-4ace-a3c8-27dcd51d21ed")}
+	m := &MPD{
+		BaseURL: "http://cdn.example.com/content/",
+		Periods: []*Period{{
+			BaseURL:         "p1/",
+			SegmentTemplate: &SegmentTemplate{Timescale: uint64Ptr(1000)},
+			AdaptationSets: []*AdaptationSet{{
+				MimeType:           "video/mp4",
+				FrameRate:          stringPtr("25"),
+				RepresentationBase: RepresentationBase{ContentProtections: []ContentProtection{cenc}},
+				Roles:              []Descriptor{NewDescriptor(RoleScheme, "main")},
+				SegmentTemplate:    &SegmentTemplate{Media: stringPtr("$RepresentationID$/$Number$.m4s")},
+				Representations: []Representation{
+					{ID: stringPtr("1"), Codecs: stringPtr("avc1.64001f"), BaseURL: "hd/", RepresentationBase: RepresentationBase{ContentProtections: []ContentProtection{widevine}}},
+					{ID: stringPtr("2"), FrameRate: stringPtr("50")},
+				},
+			}},
+		}},
+	}
+
+	res, err := Resolve(m)
+	c.Assert(err, IsNil)
+	c.Assert(res, HasLen, 2)
+	c.Check(res[0].Representation, Equals, &m.Periods[0].AdaptationSets[0].Representations[0])
+	c.Check(res[0].BaseURL, Equals, "http://cdn.example.com/content/p1/hd/")
+	c.Check(res[0].MimeType, Equals, "video/mp4")
+	c.Check(*res[0].Codecs, Equals, "avc1.64001f")
+	c.Check(*res[0].FrameRate, Equals, "25")
+	c.Check(res[0].ContentProtections, DeepEquals, []ContentProtection{cenc, widevine})
+	c.Check(res[0].Roles, HasLen, 1)
+	c.Check(res[0].SegmentTemplate, DeepEquals, &SegmentTemplate{Timescale: uint64Ptr(1000), Media: stringPtr("$RepresentationID$/$Number$.m4s")})
+	c.Check(res[0].SegmentBase, IsNil)
+
+	c.Check(res[1].BaseURL, Equals, "http://cdn.example.com/content/p1/")
+	c.Check(*res[1].FrameRate, Equals, "50")
+	c.Check(res[1].ContentProtections, DeepEquals, []ContentProtection{cenc})
+
+	m.BaseURL = ""
+	res, err = Resolve(m)
+	c.Assert(err, IsNil)
+	c.Check(res[0].BaseURL, Equals, "p1/hd/")
+
+	m.Periods[0].BaseURL = "%zz"
+	_, err = Resolve(m)
+	c.Check(err, ErrorMatches, `Resolve: Periods\[0\].AdaptationSets\[0\].Representations\[0\]: can't parse BaseURL.*`)
+}
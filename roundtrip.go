@@ -0,0 +1,40 @@
+package mpd
+
+import (
+	"fmt"
+)
+
+// RoundTripReport is a result of RoundTrip.
+type RoundTripReport struct {
+	// Encoded is re-encoded MPD.
+	Encoded []byte
+	// Diffs lists semantic differences, empty if MPD survived round trip.
+	Diffs []Difference
+}
+
+// RoundTrip decodes MPD b, encodes it again and compares both documents semantically: namespace prefixes
+// and declarations, attribute order, order of sibling elements with different names, whitespace
+// and comments are ignored. It returns error if b can't be decoded or re-encoded.
+func RoundTrip(b []byte) (*RoundTripReport, error) {
+	m := new(MPD)
+	if err := m.Decode(b); err != nil {
+		return nil, fmt.Errorf("RoundTrip: %w", err)
+	}
+	encoded, err := m.Encode()
+	if err != nil {
+		return nil, fmt.Errorf("RoundTrip: %w", err)
+	}
+
+	original, err := parseXMLNode(b)
+	if err != nil {
+		return nil, fmt.Errorf("RoundTrip: %w", err)
+	}
+	reencoded, err := parseXMLNode(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("RoundTrip: re-encoded MPD: %w", err)
+	}
+	return &RoundTripReport{
+		Encoded: encoded,
+		Diffs:   diffXMLNodes(qualifiedName(original.name), original, reencoded, nil),
+	}, nil
+}
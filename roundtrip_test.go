@@ -0,0 +1,45 @@
+package mpd
+
+import (
+	"io/ioutil"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestRoundTripFixtures(c *C) {
+	for _, name := range []string{
+		"fixture_elemental_delta_vod.mpd",
+		"fixture_elemental_delta_live.mpd",
+		"fixture_elemental_delta_1.6.1_live.mpd",
+	} {
+		b, err := ioutil.ReadFile(name)
+		c.Assert(err, IsNil)
+		r, err := RoundTrip(b)
+		c.Assert(err, IsNil)
+		c.Check(r.Diffs, HasLen, 0, Commentf("%s: %v", name, r.Diffs))
+	}
+}
+
+func (s *MPDSuite) TestDifferences(c *C) {
+	b := []byte(`<?xml version="1.0"?>
+<!-- comment -->
+<MPD xmlns="urn:mpeg:dash:schema:mpd:2011" xmlns:cenc="urn:mpeg:cenc:2013" profiles="urn:mpeg:dash:profile:isoff-live:2011" type="static">
+  <Period>
+    <AdaptationSet mimeType="video/mp4" unknown="1">
+      <ContentProtection schemeIdUri="urn:mpeg:dash:mp4protection:2011" cenc:default_KID="10000000-1000-1000-1000-100000000000"/>
+      <Representation id="1" bandwidth="0100"/>
+      <Unknown/>
+    </AdaptationSet>
+  </Period>
+</MPD>`)
+	r, err := RoundTrip(b)
+	c.Assert(err, IsNil)
+	c.Check(r.Diffs, DeepEquals, []Difference{
+		{Kind: DiffRemoved, Path: "MPD/Period[0]/AdaptationSet[0]/@unknown", Old: "1"},
+		{Kind: DiffChanged, Path: "MPD/Period[0]/AdaptationSet[0]/Representation[0]/@bandwidth", Old: "0100", New: "100"},
+	})
+	c.Check(r.Diffs[1].String(), Equals, `MPD/Period[0]/AdaptationSet[0]/Representation[0]/@bandwidth: changed "0100" to "100"`)
+
+	_, err = RoundTrip([]byte("<MPD"))
+	c.Check(err, ErrorMatches, "RoundTrip: .*")
+}
@@ -0,0 +1,51 @@
+package mpd
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+// ScanType (VideoScanType in XSD) is a scanning type of video.
+type ScanType string
+
+// ScanType values.
+const (
+	ScanTypeProgressive ScanType = "progressive"
+	ScanTypeInterlaced  ScanType = "interlaced"
+	ScanTypeUnknown     ScanType = "unknown"
+)
+
+// UnmarshalXMLAttr decodes ScanType and rejects unknown values.
+func (s *ScanType) UnmarshalXMLAttr(attr xml.Attr) error {
+	switch v := ScanType(attr.Value); v {
+	case ScanTypeProgressive, ScanTypeInterlaced, ScanTypeUnknown:
+		*s = v
+		return nil
+	}
+	return fmt.Errorf("ScanType: can't UnmarshalXMLAttr %#v", attr)
+}
+
+// check interfaces
+var (
+	_ xml.UnmarshalerAttr = new(ScanType)
+)
+
+// IsInterlaced returns true if r is explicitly signaled as interlaced.
+// Absent @scanType means progressive.
+func (r *Representation) IsInterlaced() bool {
+	return r.ScanType != nil && *r.ScanType == ScanTypeInterlaced
+}
+
+// RemoveInterlaced removes interlaced Representations from as (e.g. for clients without deinterlacing support)
+// and returns number of removed ones.
+func (as *AdaptationSet) RemoveInterlaced() int {
+	res := as.Representations[:0]
+	for _, r := range as.Representations {
+		if !r.IsInterlaced() {
+			res = append(res, r)
+		}
+	}
+	n := len(as.Representations) - len(res)
+	as.Representations = res
+	return n
+}
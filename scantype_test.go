@@ -0,0 +1,32 @@
+package mpd
+
+import (
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestScanType(c *C) {
+	m := new(MPD)
+	c.Assert(m.Decode([]byte(`<MPD><Period><AdaptationSet>
+<Representation id="1" scanType="interlaced"/>
+<Representation id="2" scanType="progressive"/>
+<Representation id="3"/>
+</AdaptationSet></Period></MPD>`)), IsNil)
+	as := m.Periods[0].AdaptationSets[0]
+	c.Check(*as.Representations[0].ScanType, Equals, ScanTypeInterlaced)
+	c.Check(as.Representations[0].IsInterlaced(), Equals, true)
+	c.Check(as.Representations[1].IsInterlaced(), Equals, false)
+	c.Check(as.Representations[2].ScanType, IsNil)
+
+	b, err := m.Encode()
+	c.Assert(err, IsNil)
+	c.Check(strings.Contains(string(b), `<Representation id="1" scanType="interlaced"/>`), Equals, true, Commentf("%s", b))
+
+	c.Check(as.RemoveInterlaced(), Equals, 1)
+	c.Check(as.Representations, HasLen, 2)
+	c.Check(*as.Representations[0].ID, Equals, "2")
+
+	err = new(MPD).Decode([]byte(`<MPD><Period><AdaptationSet><Representation scanType="mixed"/></AdaptationSet></Period></MPD>`))
+	c.Check(err, ErrorMatches, `ScanType: can't UnmarshalXMLAttr .*mixed.*`)
+}
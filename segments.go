@@ -0,0 +1,330 @@
+package mpd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SegmentKind distinguishes segments returned by Segments.
+type SegmentKind int
+
+// Segment kinds.
+const (
+	MediaSegment SegmentKind = iota
+	InitializationSegment
+	IndexSegment
+	BitstreamSwitchingSegment
+)
+
+var segmentKindNames = [...]string{"media", "initialization", "index", "bitstreamSwitching"}
+
+// String implements fmt.Stringer interface.
+func (k SegmentKind) String() string {
+	if int(k) < len(segmentKindNames) {
+		return segmentKindNames[k]
+	}
+	return fmt.Sprintf("SegmentKind(%d)", int(k))
+}
+
+// Segment describes a single resource a client should fetch.
+type Segment struct {
+	Kind SegmentKind
+	URL  string
+	// ByteRange is a "first-last" byte range within URL to be used in Range request,
+	// or empty string if the whole resource should be fetched.
+	ByteRange string
+
+	// Number, Time and Duration of media segments. Time and Duration are in Timescale units.
+	Number    uint64
+	Time      uint64
+	Duration  uint64
+	Timescale uint64
+}
+
+// relativeBase is used to resolve BaseURLs when manifest URL is unknown.
+const relativeBase = "http://mpd.invalid/"
+
+// Segments enumerates segments of Representation r of AdaptationSet as in Period p of m:
+// initialization and bitstream switching segments (if any) followed by media segments for SegmentTemplate
+// and SegmentList addressing, or initialization segment (if any) and index segment for SegmentBase addressing
+// (use ParseSidx and Sidx.Segments to get subsegments).
+// URLs are resolved against manifestURL and BaseURL hierarchy; they are relative if manifestURL is empty.
+func Segments(manifestURL string, m *MPD, p *Period, as *AdaptationSet, r *Representation) ([]Segment, error) {
+	if manifestURL == "" {
+		manifestURL = relativeBase
+	}
+	base, err := effectiveBaseURL(manifestURL, m, p, as, r)
+	if err != nil {
+		return nil, fmt.Errorf("Segments: %w", err)
+	}
+	resolve := func(ref string) (string, error) {
+		u, err := resolveBaseURL(base, ref)
+		if err != nil {
+			return "", fmt.Errorf("Segments: %w", err)
+		}
+		return strings.TrimPrefix(u.String(), relativeBase), nil
+	}
+
+	var res []Segment
+	t, l, sb := EffectiveSegmentTemplate(p, as, r), EffectiveSegmentList(p, as, r), EffectiveSegmentBase(p, as, r)
+	pto := EffectivePresentationTimeOffset(p, as, r)
+	switch {
+	case t != nil:
+		timeline, err := segmentTimes(m, p, t.Timescale, uint64From32(t.Duration), t.StartNumber, pto, t.SegmentTimeline)
+		if err != nil {
+			return nil, err
+		}
+		if t.Initialization != nil {
+			u, err := resolve(expandTemplate(*t.Initialization, r, 0, 0))
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, Segment{Kind: InitializationSegment, URL: u})
+		}
+		if t.BitstreamSwitching != nil {
+			s, err := urlSegment(BitstreamSwitchingSegment, t.BitstreamSwitching, resolve)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, s)
+		}
+		if t.Media == nil {
+			return nil, newError("Segments", ErrUnknownAddressingMode, "SegmentTemplate without media")
+		}
+		for _, s := range timeline {
+			if s.URL, err = resolve(expandTemplate(*t.Media, r, s.Number, s.Time)); err != nil {
+				return nil, err
+			}
+			res = append(res, s)
+		}
+
+	case l != nil:
+		timeline, err := segmentTimes(m, p, l.Timescale, l.Duration, l.StartNumber, pto, l.SegmentTimeline)
+		if err != nil {
+			return nil, err
+		}
+		for _, u := range []struct {
+			kind SegmentKind
+			u    *URLType
+		}{{InitializationSegment, l.Initialization}, {BitstreamSwitchingSegment, l.BitstreamSwitching}} {
+			if u.u == nil {
+				continue
+			}
+			s, err := urlSegment(u.kind, u.u, resolve)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, s)
+		}
+		for i, su := range l.SegmentURLs {
+			s := Segment{Kind: MediaSegment}
+			if i < len(timeline) {
+				s = timeline[i]
+			}
+			var media string
+			if su.Media != nil {
+				media = *su.Media
+			}
+			if s.URL, err = resolve(media); err != nil {
+				return nil, err
+			}
+			if su.MediaRange != nil {
+				s.ByteRange = *su.MediaRange
+			}
+			res = append(res, s)
+		}
+
+	case sb != nil:
+		if sb.Initialization != nil {
+			s, err := urlSegment(InitializationSegment, sb.Initialization, resolve)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, s)
+		}
+		index := sb.RepresentationIndex
+		if index == nil {
+			if sb.IndexRange == nil {
+				return nil, newError("Segments", ErrUnknownAddressingMode, "SegmentBase without indexRange")
+			}
+			index = &URLType{Range: sb.IndexRange}
+		}
+		s, err := urlSegment(IndexSegment, index, resolve)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, s)
+
+	default:
+		// single segment Representation
+		u, err := resolve("")
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, Segment{Kind: MediaSegment, URL: u})
+	}
+	return res, nil
+}
+
+// urlSegment returns segment of given kind described by u. Missing sourceURL refers to BaseURL.
+func urlSegment(kind SegmentKind, u *URLType, resolve func(ref string) (string, error)) (Segment, error) {
+	var ref string
+	if u.SourceURL != nil {
+		ref = *u.SourceURL
+	}
+	res := Segment{Kind: kind}
+	var err error
+	if res.URL, err = resolve(ref); err != nil {
+		return Segment{}, err
+	}
+	if u.Range != nil {
+		res.ByteRange = *u.Range
+	}
+	return res, nil
+}
+
+// Segments returns subsegments of media resource u indexed by s, with byte ranges.
+func (s *Sidx) Segments(u string) []Segment {
+	ranges := s.ByteRanges()
+	res := make([]Segment, len(s.References))
+	t := s.EarliestPresentationTime
+	for i, ref := range s.References {
+		res[i] = Segment{
+			Kind:      MediaSegment,
+			URL:       u,
+			ByteRange: ranges[i],
+			Number:    uint64(i + 1),
+			Time:      t,
+			Duration:  uint64(ref.SubsegmentDuration),
+			Timescale: uint64(s.Timescale),
+		}
+		t += uint64(ref.SubsegmentDuration)
+	}
+	return res
+}
+
+// uint64From32 converts *uint32 to *uint64.
+func uint64From32(v *uint32) *uint64 {
+	if v == nil {
+		return nil
+	}
+	return uint64Ptr(uint64(*v))
+}
+
+// segmentTimes returns media segments described by either SegmentTimeline or fixed @duration.
+// Times are on the media timeline, so the Period start maps to pto.
+func segmentTimes(m *MPD, p *Period, timescale, duration, startNumber *uint64, pto uint64, timeline []SegmentTimeline) ([]Segment, error) {
+	ts := timescaleOrDefault(timescale)
+	number := uint64(1)
+	if startNumber != nil {
+		number = *startNumber
+	}
+
+	var res []Segment
+	if len(timeline) > 0 {
+		var t uint64
+		for _, tl := range timeline {
+			for i, s := range tl.Segments {
+				if s.T != nil {
+					t = *s.T
+				}
+				repeat := s.GetRepeat()
+				if repeat < 0 {
+					// repeat until the next S or the end of Period
+					var end uint64
+					if i+1 < len(tl.Segments) && tl.Segments[i+1].T != nil {
+						end = *tl.Segments[i+1].T
+					} else {
+						d, err := periodDuration(m, p)
+						if err != nil {
+							return nil, err
+						}
+						end = pto + uint64(d.Seconds()*float64(ts))
+					}
+					if s.D == 0 || end <= t {
+						return nil, fmt.Errorf("Segments: can't expand S@r=-1 at %d", t)
+					}
+					repeat = int64((end-t+s.D-1)/s.D) - 1
+				}
+				for n := int64(0); n <= repeat; n++ {
+					res = append(res, Segment{Kind: MediaSegment, Number: number, Time: t, Duration: s.D, Timescale: ts})
+					number++
+					t += s.D
+				}
+			}
+		}
+		return res, nil
+	}
+
+	if duration == nil || *duration == 0 {
+		return nil, nil
+	}
+	d, err := periodDuration(m, p)
+	if err != nil {
+		return nil, err
+	}
+	total := uint64(d.Seconds()*float64(ts) + 0.5)
+	for t := pto; t < pto+total; t += *duration {
+		res = append(res, Segment{Kind: MediaSegment, Number: number, Time: t, Duration: *duration, Timescale: ts})
+		number++
+	}
+	return res, nil
+}
+
+// periodDuration returns duration of p from Period@duration or mediaPresentationDuration of m.
+func periodDuration(m *MPD, p *Period) (time.Duration, error) {
+	if p.Duration != nil {
+		return ParseDuration(*p.Duration)
+	}
+	if m.MediaPresentationDuration == nil {
+		return 0, newError("Segments", ErrUnknownPeriodDuration, "unknown Period duration")
+	}
+	total, err := ParseDuration(*m.MediaPresentationDuration)
+	if err != nil {
+		return 0, err
+	}
+	var start time.Duration
+	if p.Start != nil {
+		if start, err = ParseDuration(*p.Start); err != nil {
+			return 0, err
+		}
+	}
+	return total - start, nil
+}
+
+// expandTemplate substitutes SegmentTemplate identifiers in s.
+func expandTemplate(s string, r *Representation, number, t uint64) string {
+	return templateIdentifierRE.ReplaceAllStringFunc(s, func(id string) string {
+		name := id[1 : len(id)-1]
+		format := "%d"
+		if i := strings.Index(name, "%"); i >= 0 {
+			name, format = name[:i], name[i:]
+		}
+		var v uint64
+		switch name {
+		case "":
+			return "$"
+		case "RepresentationID":
+			if r.ID == nil {
+				return ""
+			}
+			return *r.ID
+		case "Number":
+			v = number
+		case "Time":
+			v = t
+		case "Bandwidth":
+			if r.Bandwidth != nil {
+				v = *r.Bandwidth
+			}
+		default:
+			return id
+		}
+		if !strings.HasSuffix(format, "d") {
+			return strconv.FormatUint(v, 10)
+		}
+		return fmt.Sprintf(format, v)
+	})
+}
@@ -0,0 +1,124 @@
+package mpd
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestSegmentsTemplate(c *C) {
+	m := &MPD{MediaPresentationDuration: stringPtr("PT10S"), BaseURL: "http://cdn.example.com/vod/"}
+	p := &Period{}
+	as := &AdaptationSet{BaseURL: "video/", SegmentTemplate: &SegmentTemplate{
+		Timescale:      uint64Ptr(10),
+		Media:          stringPtr("$RepresentationID$/$Time$-$Number%03d$.m4s"),
+		Initialization: stringPtr("$RepresentationID$/init-$Bandwidth$.mp4"),
+		StartNumber:    uint64Ptr(5),
+		SegmentTimeline: []SegmentTimeline{{Segments: []SegmentTimelineSegment{
+			{T: uint64Ptr(0), D: 40, R: int64Ptr(1)},
+			{D: 20, R: int64Ptr(-1)},
+		}}},
+	}}
+	r := &Representation{ID: stringPtr("v1"), Bandwidth: uint64Ptr(500000)}
+
+	segments, err := Segments("", m, p, as, r)
+	c.Assert(err, IsNil)
+	c.Check(segments, DeepEquals, []Segment{
+		{Kind: InitializationSegment, URL: "http://cdn.example.com/vod/video/v1/init-500000.mp4"},
+		{URL: "http://cdn.example.com/vod/video/v1/0-005.m4s", Number: 5, Time: 0, Duration: 40, Timescale: 10},
+		{URL: "http://cdn.example.com/vod/video/v1/40-006.m4s", Number: 6, Time: 40, Duration: 40, Timescale: 10},
+		{URL: "http://cdn.example.com/vod/video/v1/80-007.m4s", Number: 7, Time: 80, Duration: 20, Timescale: 10},
+	})
+
+	m.BaseURL = ""
+	as.SegmentTemplate = &SegmentTemplate{Media: stringPtr("$Number$.m4s"), Timescale: uint64Ptr(1000), Duration: uint32Ptr(4000)}
+	segments, err = Segments("", m, p, as, r)
+	c.Assert(err, IsNil)
+	c.Assert(segments, HasLen, 3)
+	c.Check(segments[0].URL, Equals, "video/1.m4s")
+	c.Check(segments[2].URL, Equals, "video/3.m4s")
+	c.Check(segments[2].Time, Equals, uint64(8000))
+
+	m.MediaPresentationDuration = nil
+	_, err = Segments("", m, p, as, r)
+	c.Check(err, ErrorMatches, "Segments: unknown Period duration")
+}
+
+func (s *MPDSuite) TestSegmentsByteRanges(c *C) {
+	m := &MPD{}
+	p := &Period{}
+	as := &AdaptationSet{}
+	r := &Representation{BaseURL: "video.mp4", SegmentBase: &SegmentBase{IndexRange: stringPtr("800-1199")}}
+
+	segments, err := Segments("http://example.com/vod/manifest.mpd", m, p, as, r)
+	c.Assert(err, IsNil)
+	c.Check(segments, DeepEquals, []Segment{{Kind: IndexSegment, URL: "http://example.com/vod/video.mp4", ByteRange: "800-1199"}})
+
+	r.SegmentBase.Initialization = &URLType{Range: stringPtr("0-799")}
+	r.SegmentBase.RepresentationIndex = &URLType{SourceURL: stringPtr("video.sidx")}
+	segments, err = Segments("http://example.com/vod/manifest.mpd", m, p, as, r)
+	c.Assert(err, IsNil)
+	c.Check(segments, DeepEquals, []Segment{
+		{Kind: InitializationSegment, URL: "http://example.com/vod/video.mp4", ByteRange: "0-799"},
+		{Kind: IndexSegment, URL: "http://example.com/vod/video.sidx"},
+	})
+	r.SegmentBase.Initialization, r.SegmentBase.RepresentationIndex = nil, nil
+
+	sidx := &Sidx{Timescale: 1000, Offset: 800, Size: 400, References: []SidxReference{
+		{ReferencedSize: 1000, SubsegmentDuration: 2000},
+		{ReferencedSize: 500, SubsegmentDuration: 1000},
+	}}
+	c.Check(sidx.Segments(segments[0].URL), DeepEquals, []Segment{
+		{URL: "http://example.com/vod/video.mp4", ByteRange: "1200-2199", Number: 1, Time: 0, Duration: 2000, Timescale: 1000},
+		{URL: "http://example.com/vod/video.mp4", ByteRange: "2200-2699", Number: 2, Time: 2000, Duration: 1000, Timescale: 1000},
+	})
+
+	r = &Representation{BaseURL: "video.mp4", SegmentList: &SegmentList{
+		Timescale:          uint64Ptr(1000),
+		Duration:           uint64Ptr(2000),
+		Initialization:     &URLType{Range: stringPtr("0-799")},
+		BitstreamSwitching: &URLType{SourceURL: stringPtr("switch.mp4")},
+		SegmentURLs: []SegmentURL{
+			{MediaRange: stringPtr("1200-2199")},
+			{Media: stringPtr("other.mp4"), MediaRange: stringPtr("0-499")},
+		},
+	}}
+	m.MediaPresentationDuration = stringPtr("PT4S")
+	segments, err = Segments("", m, p, as, r)
+	c.Assert(err, IsNil)
+	c.Check(segments, DeepEquals, []Segment{
+		{Kind: InitializationSegment, URL: "video.mp4", ByteRange: "0-799"},
+		{Kind: BitstreamSwitchingSegment, URL: "switch.mp4"},
+		{URL: "video.mp4", ByteRange: "1200-2199", Number: 1, Time: 0, Duration: 2000, Timescale: 1000},
+		{URL: "other.mp4", ByteRange: "0-499", Number: 2, Time: 2000, Duration: 2000, Timescale: 1000},
+	})
+}
+
+func (s *MPDSuite) TestSegmentsPresentationTimeOffset(c *C) {
+	m := &MPD{MediaPresentationDuration: stringPtr("PT6S")}
+	p := &Period{}
+	as := &AdaptationSet{SegmentTemplate: &SegmentTemplate{
+		Media:                  stringPtr("$Time$.m4s"),
+		Timescale:              uint64Ptr(1000),
+		Duration:               uint32Ptr(2000),
+		PresentationTimeOffset: uint64Ptr(10000),
+	}}
+	r := &Representation{ID: stringPtr("v1")}
+
+	segments, err := Segments("", m, p, as, r)
+	c.Assert(err, IsNil)
+	c.Check(segments, DeepEquals, []Segment{
+		{URL: "10000.m4s", Number: 1, Time: 10000, Duration: 2000, Timescale: 1000},
+		{URL: "12000.m4s", Number: 2, Time: 12000, Duration: 2000, Timescale: 1000},
+		{URL: "14000.m4s", Number: 3, Time: 14000, Duration: 2000, Timescale: 1000},
+	})
+
+	as.SegmentTemplate.Duration = nil
+	as.SegmentTemplate.SegmentTimeline = []SegmentTimeline{{Segments: []SegmentTimelineSegment{
+		{T: uint64Ptr(10000), D: 2000, R: int64Ptr(-1)},
+	}}}
+	segments, err = Segments("", m, p, as, r)
+	c.Assert(err, IsNil)
+	c.Assert(segments, HasLen, 3)
+	c.Check(segments[0].Time, Equals, uint64(10000))
+	c.Check(segments[2].Time, Equals, uint64(14000))
+	c.Check(segments[2].URL, Equals, "14000.m4s")
+}
@@ -0,0 +1,126 @@
+package mpd
+
+// RoleScheme is a schemeIdUri of Role descriptors defined by MPEG-DASH.
+const RoleScheme = "urn:mpeg:dash:role:2011"
+
+// SelectionCriteria describes player preferences used by Select.
+type SelectionCriteria struct {
+	// AudioLanguages lists preferred audio languages, most preferred first.
+	AudioLanguages []string
+	// AudioRoles lists preferred audio Role values, most preferred first. Defaults to "main".
+	AudioRoles []string
+	// VideoRoles lists preferred video Role values, most preferred first. Defaults to "main".
+	VideoRoles []string
+	// TextLanguages limits selected subtitle sets to given languages. All subtitle sets are selected if empty.
+	TextLanguages []string
+}
+
+// Selection is a result of Select.
+type Selection struct {
+	Video *AdaptationSet
+	Audio *AdaptationSet
+	Text  []*AdaptationSet
+}
+
+// Select picks AdaptationSets of p the same way a typical player does:
+// one video set, one audio set matching language and role preferences, and subtitle sets.
+func (p *Period) Select(c SelectionCriteria) *Selection {
+	var video, audio, text []*AdaptationSet
+	for _, as := range p.AdaptationSets {
+		switch InferContentType(as) {
+		case ContentTypeVideo:
+			video = append(video, as)
+		case ContentTypeAudio:
+			audio = append(audio, as)
+		case ContentTypeText:
+			text = append(text, as)
+		}
+	}
+
+	res := new(Selection)
+	res.Video = selectByRole(video, c.VideoRoles)
+	res.Audio = selectByRole(selectByLanguage(audio, c.AudioLanguages), c.AudioRoles)
+	if len(c.TextLanguages) == 0 {
+		res.Text = text
+	} else {
+		for _, as := range text {
+			for _, l := range c.TextLanguages {
+				if as.Lang != nil && MatchLanguage(l, *as.Lang) {
+					res.Text = append(res.Text, as)
+					break
+				}
+			}
+		}
+	}
+	return res
+}
+
+// HasRole returns true if as has Role descriptor with given value.
+func (as *AdaptationSet) HasRole(value string) bool {
+	return hasDescriptorValue(as.Roles, RoleScheme, value)
+}
+
+// selectByLanguage returns sets matching the most preferred language using RFC 4647 basic filtering,
+// falling back to lookup (so "en-US" selects "en"), or all sets if none match.
+func selectByLanguage(sets []*AdaptationSet, languages []string) []*AdaptationSet {
+	for _, l := range languages {
+		var res []*AdaptationSet
+		for _, as := range sets {
+			if as.Lang != nil && MatchLanguage(l, *as.Lang) {
+				res = append(res, as)
+			}
+		}
+		if len(res) > 0 {
+			return res
+		}
+	}
+
+	var tags []string
+	for _, as := range sets {
+		if as.Lang != nil {
+			tags = append(tags, *as.Lang)
+		}
+	}
+	if tag := LookupLanguage(languages, tags); tag != "" {
+		var res []*AdaptationSet
+		for _, as := range sets {
+			if as.Lang != nil && *as.Lang == tag {
+				res = append(res, as)
+			}
+		}
+		return res
+	}
+	return sets
+}
+
+// selectByRole returns the set with the most preferred role and the highest @selectionPriority.
+// Sets without roles are treated as "main".
+func selectByRole(sets []*AdaptationSet, roles []string) *AdaptationSet {
+	if len(sets) == 0 {
+		return nil
+	}
+	if len(roles) == 0 {
+		roles = []string{"main"}
+	}
+	for _, role := range roles {
+		var res *AdaptationSet
+		for _, as := range sets {
+			if as.HasRole(role) || (role == "main" && len(as.Roles) == 0) {
+				if res == nil || as.GetSelectionPriority() > res.GetSelectionPriority() {
+					res = as
+				}
+			}
+		}
+		if res != nil {
+			return res
+		}
+	}
+
+	res := sets[0]
+	for _, as := range sets[1:] {
+		if as.GetSelectionPriority() > res.GetSelectionPriority() {
+			res = as
+		}
+	}
+	return res
+}
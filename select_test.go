@@ -0,0 +1,45 @@
+package mpd
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestSelect(c *C) {
+	role := func(v string) []Descriptor {
+		return []Descriptor{{SchemeIDURI: stringPtr(RoleScheme), Value: stringPtr(v)}}
+	}
+
+	p := &Period{
+		AdaptationSets: []*AdaptationSet{
+			{MimeType: "video/mp4"},
+			{MimeType: "audio/mp4", Lang: stringPtr("en"), Roles: role("commentary")},
+			{MimeType: "audio/mp4", Lang: stringPtr("en-US"), Roles: role("main")},
+			{MimeType: "audio/mp4", Lang: stringPtr("fr")},
+			{MimeType: "application/mp4", Lang: stringPtr("fr"), Representations: []Representation{{Codecs: stringPtr("stpp.ttml.im1t")}}},
+			{MimeType: "text/vtt", Lang: stringPtr("de")},
+		},
+	}
+
+	sel := p.Select(SelectionCriteria{AudioLanguages: []string{"de", "en"}})
+	c.Check(sel.Video, Equals, p.AdaptationSets[0])
+	c.Check(sel.Audio, Equals, p.AdaptationSets[2])
+	c.Check(sel.Text, DeepEquals, p.AdaptationSets[4:])
+
+	sel = p.Select(SelectionCriteria{AudioLanguages: []string{"en"}, AudioRoles: []string{"commentary"}, TextLanguages: []string{"DE"}})
+	c.Check(sel.Audio, Equals, p.AdaptationSets[1])
+	c.Check(sel.Text, DeepEquals, p.AdaptationSets[5:])
+
+	sel = p.Select(SelectionCriteria{AudioLanguages: []string{"fr-CA"}})
+	c.Check(sel.Audio, Equals, p.AdaptationSets[3])
+
+	sel = p.Select(SelectionCriteria{AudioLanguages: []string{"ja"}})
+	c.Check(sel.Audio, Equals, p.AdaptationSets[2])
+
+	// @selectionPriority breaks ties
+	p.AdaptationSets = append(p.AdaptationSets, &AdaptationSet{MimeType: "video/mp4", SelectionPriority: uint64Ptr(2), Tag: stringPtr("hdr")})
+	sel = p.Select(SelectionCriteria{})
+	c.Check(sel.Video, Equals, p.AdaptationSets[6])
+	p.AdaptationSets[6].SelectionPriority = uint64Ptr(0)
+	sel = p.Select(SelectionCriteria{})
+	c.Check(sel.Video, Equals, p.AdaptationSets[0])
+}
@@ -0,0 +1,194 @@
+package mpd
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Sidx represents ISO BMFF Segment Index Box.
+type Sidx struct {
+	Version                  byte
+	ReferenceID              uint32
+	Timescale                uint32
+	EarliestPresentationTime uint64
+	FirstOffset              uint64
+	References               []SidxReference
+
+	// Offset and Size locate the box itself in the file.
+	Offset uint64
+	Size   uint64
+}
+
+// SidxReference represents a single reference of Segment Index Box.
+type SidxReference struct {
+	// ReferenceType is true if reference points to another sidx box.
+	ReferenceType      bool
+	ReferencedSize     uint32
+	SubsegmentDuration uint32
+	StartsWithSAP      bool
+	SAPType            byte
+	SAPDeltaTime       uint32
+}
+
+// FindSidx scans top-level boxes of ISO BMFF file r of given size and parses the first sidx box.
+func FindSidx(r io.ReaderAt, size int64) (*Sidx, error) {
+	var offset int64
+	header := make([]byte, 16)
+	for offset+8 <= size {
+		n, err := r.ReadAt(header, offset)
+		if n < 8 {
+			return nil, fmt.Errorf("FindSidx: can't read box header at %d: %w", offset, err)
+		}
+
+		boxSize := int64(binary.BigEndian.Uint32(header))
+		switch boxSize {
+		case 0:
+			boxSize = size - offset
+		case 1:
+			if n < 16 {
+				return nil, fmt.Errorf("FindSidx: can't read box largesize at %d: %w", offset, err)
+			}
+			boxSize = int64(binary.BigEndian.Uint64(header[8:]))
+		}
+		if boxSize < 8 || offset+boxSize > size {
+			return nil, fmt.Errorf("FindSidx: invalid box size %d at %d", boxSize, offset)
+		}
+
+		if string(header[4:8]) == "sidx" {
+			b := make([]byte, boxSize)
+			if _, err = r.ReadAt(b, offset); err != nil && err != io.EOF {
+				return nil, fmt.Errorf("FindSidx: %w", err)
+			}
+			s, err := ParseSidx(b)
+			if err != nil {
+				return nil, err
+			}
+			s.Offset = uint64(offset)
+			return s, nil
+		}
+		offset += boxSize
+	}
+	return nil, fmt.Errorf("FindSidx: sidx box not found")
+}
+
+// ParseSidx parses sidx box b, starting with box header. Offset of returned Sidx is zero.
+func ParseSidx(b []byte) (*Sidx, error) {
+	short := fmt.Errorf("ParseSidx: box is too short")
+	if len(b) < 8 {
+		return nil, short
+	}
+	if string(b[4:8]) != "sidx" {
+		return nil, fmt.Errorf("ParseSidx: unexpected box type %q", b[4:8])
+	}
+	size := uint64(binary.BigEndian.Uint32(b))
+	pos := 8
+	if size == 1 {
+		if len(b) < 16 {
+			return nil, short
+		}
+		size = binary.BigEndian.Uint64(b[8:])
+		pos = 16
+	}
+	if size == 0 {
+		size = uint64(len(b))
+	}
+	if uint64(len(b)) < size {
+		return nil, short
+	}
+	b = b[:size]
+
+	s := &Sidx{Size: size}
+	if len(b) < pos+12 {
+		return nil, short
+	}
+	s.Version = b[pos]
+	s.ReferenceID = binary.BigEndian.Uint32(b[pos+4:])
+	s.Timescale = binary.BigEndian.Uint32(b[pos+8:])
+	pos += 12
+
+	if s.Version == 0 {
+		if len(b) < pos+8 {
+			return nil, short
+		}
+		s.EarliestPresentationTime = uint64(binary.BigEndian.Uint32(b[pos:]))
+		s.FirstOffset = uint64(binary.BigEndian.Uint32(b[pos+4:]))
+		pos += 8
+	} else {
+		if len(b) < pos+16 {
+			return nil, short
+		}
+		s.EarliestPresentationTime = binary.BigEndian.Uint64(b[pos:])
+		s.FirstOffset = binary.BigEndian.Uint64(b[pos+8:])
+		pos += 16
+	}
+
+	if len(b) < pos+4 {
+		return nil, short
+	}
+	count := int(binary.BigEndian.Uint16(b[pos+2:]))
+	pos += 4
+	if len(b) < pos+count*12 {
+		return nil, short
+	}
+
+	s.References = make([]SidxReference, count)
+	for i := range s.References {
+		ref := &s.References[i]
+		v := binary.BigEndian.Uint32(b[pos:])
+		ref.ReferenceType = v>>31 == 1
+		ref.ReferencedSize = v & 0x7fffffff
+		if ref.ReferencedSize == 0 {
+			return nil, fmt.Errorf("ParseSidx: reference %d has zero size", i)
+		}
+		ref.SubsegmentDuration = binary.BigEndian.Uint32(b[pos+4:])
+		v = binary.BigEndian.Uint32(b[pos+8:])
+		ref.StartsWithSAP = v>>31 == 1
+		ref.SAPType = byte(v >> 28 & 0x7)
+		ref.SAPDeltaTime = v & 0x0fffffff
+		pos += 12
+	}
+	return s, nil
+}
+
+// SegmentTimeline returns SegmentTimeline equivalent to s references.
+func (s *Sidx) SegmentTimeline() []SegmentTimeline {
+	durations := make([]uint64, len(s.References))
+	for i, ref := range s.References {
+		durations[i] = uint64(ref.SubsegmentDuration)
+	}
+	segments := compressTimeline(durations)
+	if len(segments) > 0 {
+		segments[0].T = uint64Ptr(s.EarliestPresentationTime)
+	}
+	return []SegmentTimeline{{Segments: segments}}
+}
+
+// SegmentBase returns SegmentBase with s timescale, earliest presentation time and byte range.
+func (s *Sidx) SegmentBase() *SegmentBase {
+	sb := &SegmentBase{
+		Timescale:  uint64Ptr(uint64(s.Timescale)),
+		IndexRange: stringPtr(formatByteRange(s.Offset, s.Offset+s.Size-1)),
+	}
+	if s.EarliestPresentationTime != 0 {
+		sb.PresentationTimeOffset = uint64Ptr(s.EarliestPresentationTime)
+	}
+	return sb
+}
+
+// ByteRanges returns byte ranges of referenced subsegments in "first-last" form.
+func (s *Sidx) ByteRanges() []string {
+	res := make([]string, len(s.References))
+	offset := s.Offset + s.Size + s.FirstOffset
+	for i, ref := range s.References {
+		res[i] = formatByteRange(offset, offset+uint64(ref.ReferencedSize)-1)
+		offset += uint64(ref.ReferencedSize)
+	}
+	return res
+}
+
+// formatByteRange formats byte range as used by @indexRange and @mediaRange.
+func formatByteRange(first, last uint64) string {
+	return strconv.FormatUint(first, 10) + "-" + strconv.FormatUint(last, 10)
+}
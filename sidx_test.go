@@ -0,0 +1,66 @@
+package mpd
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	. "gopkg.in/check.v1"
+)
+
+// makeSidx returns version 0 sidx box with given subsegment sizes and durations.
+func makeSidx(timescale, ept uint32, sizes, durations []uint32) []byte {
+	b := new(bytes.Buffer)
+	w := func(v interface{}) { binary.Write(b, binary.BigEndian, v) }
+	w(uint32(32 + 12*len(sizes)))
+	b.WriteString("sidx")
+	w(uint32(0)) // version and flags
+	w(uint32(1)) // reference_ID
+	w(timescale)
+	w(ept)
+	w(uint32(0)) // first_offset
+	w(uint16(0))
+	w(uint16(len(sizes)))
+	for i := range sizes {
+		w(sizes[i])
+		w(durations[i])
+		w(uint32(0x90000000)) // starts_with_SAP, SAP_type 1
+	}
+	return b.Bytes()
+}
+
+func (s *MPDSuite) TestSidx(c *C) {
+	ftyp := []byte{0, 0, 0, 16, 'f', 't', 'y', 'p', 'i', 's', 'o', '6', 0, 0, 0, 0}
+	moov := []byte{0, 0, 0, 8, 'm', 'o', 'o', 'v'}
+	sidx := makeSidx(1000, 500, []uint32{1000, 2000, 1500}, []uint32{4000, 4000, 2000})
+	file := append(append(append(ftyp, moov...), sidx...), make([]byte, 4500)...)
+
+	sx, err := FindSidx(bytes.NewReader(file), int64(len(file)))
+	c.Assert(err, IsNil)
+	c.Check(sx.Offset, Equals, uint64(24))
+	c.Check(sx.Size, Equals, uint64(68))
+	c.Check(sx.Timescale, Equals, uint32(1000))
+	c.Check(sx.References, HasLen, 3)
+	c.Check(sx.References[0], DeepEquals, SidxReference{ReferencedSize: 1000, SubsegmentDuration: 4000, StartsWithSAP: true, SAPType: 1})
+
+	c.Check(sx.ByteRanges(), DeepEquals, []string{"92-1091", "1092-3091", "3092-4591"})
+
+	sb := sx.SegmentBase()
+	c.Check(*sb.Timescale, Equals, uint64(1000))
+	c.Check(*sb.PresentationTimeOffset, Equals, uint64(500))
+	c.Check(*sb.IndexRange, Equals, "24-91")
+
+	tl := sx.SegmentTimeline()
+	c.Assert(tl, HasLen, 1)
+	c.Check(tl[0].Segments, HasLen, 2)
+	c.Check(*tl[0].Segments[0].T, Equals, uint64(500))
+	c.Check(tl[0].Segments[0].D, Equals, uint64(4000))
+	c.Check(*tl[0].Segments[0].R, Equals, int64(1))
+	c.Check(tl[0].Segments[1].D, Equals, uint64(2000))
+
+	_, err = FindSidx(bytes.NewReader(ftyp), int64(len(ftyp)))
+	c.Check(err, ErrorMatches, "FindSidx: sidx box not found")
+	_, err = ParseSidx(sidx[:40])
+	c.Check(err, ErrorMatches, "ParseSidx: box is too short")
+	_, err = ParseSidx(makeSidx(1000, 0, []uint32{1000, 0}, []uint32{4000, 4000}))
+	c.Check(err, ErrorMatches, "ParseSidx: reference 1 has zero size")
+}
@@ -0,0 +1,122 @@
+package mpd
+
+import (
+	"encoding/xml"
+	"strings"
+	"time"
+)
+
+// SizeReport breaks down encoded size of MPD.
+type SizeReport struct {
+	// Total is a size of the whole encoded MPD in bytes.
+	Total   int
+	Periods []PeriodSize
+	// GrowthPerMinute is a projected growth of dynamic MPD in bytes per minute,
+	// assuming every new segment adds an S element to each SegmentTimeline. It's zero for static MPD.
+	GrowthPerMinute int
+}
+
+// PeriodSize is a part of SizeReport.
+type PeriodSize struct {
+	Path           string
+	Size           int
+	AdaptationSets []AdaptationSetSize
+}
+
+// AdaptationSetSize is a part of SizeReport.
+type AdaptationSetSize struct {
+	Path string
+	Size int
+	// SegmentTimelines is a total size of SegmentTimeline elements of the set and its Representations.
+	SegmentTimelines int
+}
+
+// AnalyzeSize reports encoded size of m broken down per Period, AdaptationSet and SegmentTimeline.
+// Sizes of parts are measured with the same indentation Encode uses, so they add up approximately.
+func AnalyzeSize(m *MPD) (*SizeReport, error) {
+	b, err := m.Encode()
+	if err != nil {
+		return nil, err
+	}
+	res := &SizeReport{Total: len(b)}
+	dynamic := m.Type != nil && *m.Type == "dynamic"
+
+	for i, p := range m.Periods {
+		size, err := encodedSize(p, 1)
+		if err != nil {
+			return nil, err
+		}
+		ps := PeriodSize{Path: periodPath(i), Size: size}
+
+		for j, as := range p.AdaptationSets {
+			if size, err = encodedSize(as, 2); err != nil {
+				return nil, err
+			}
+			ass := AdaptationSetSize{Path: adaptationSetPath(i, j), Size: size}
+
+			templates := map[*SegmentTemplate]int{as.SegmentTemplate: 3}
+			for k := range as.Representations {
+				templates[as.Representations[k].SegmentTemplate] = 4
+			}
+			for t, depth := range templates {
+				if t == nil {
+					continue
+				}
+				for _, tl := range t.SegmentTimeline {
+					if size, err = encodedSize(tl, depth+1); err != nil {
+						return nil, err
+					}
+					ass.SegmentTimelines += size
+
+					if dynamic && len(tl.Segments) > 0 {
+						growth, err := timelineGrowth(t, tl, depth+2)
+						if err != nil {
+							return nil, err
+						}
+						res.GrowthPerMinute += growth
+					}
+				}
+			}
+			ps.AdaptationSets = append(ps.AdaptationSets, ass)
+		}
+		res.Periods = append(res.Periods, ps)
+	}
+	return res, nil
+}
+
+// timelineGrowth returns bytes per minute added to tl if each new segment gets its own S element.
+func timelineGrowth(t *SegmentTemplate, tl SegmentTimeline, depth int) (int, error) {
+	var count, total uint64
+	for _, s := range tl.Segments {
+		n := uint64(1)
+		if s.R != nil && *s.R > 0 {
+			n += uint64(*s.R)
+		}
+		count += n
+		total += s.D * n
+	}
+	avg := timescaled(total/count, t.Timescale)
+	if avg <= 0 {
+		return 0, nil
+	}
+
+	last := tl.Segments[len(tl.Segments)-1]
+	last.T, last.R = nil, nil
+	size, err := encodedSize(struct {
+		XMLName xml.Name `xml:"S"`
+		SegmentTimelineSegment
+	}{SegmentTimelineSegment: last}, depth)
+	if err != nil {
+		return 0, err
+	}
+	return int(float64(size)*float64(time.Minute)/float64(avg) + 0.5), nil
+}
+
+// encodedSize returns size of v encoded the same way Encode does at given indentation depth.
+func encodedSize(v interface{}, depth int) (int, error) {
+	b, err := xml.MarshalIndent(v, strings.Repeat("  ", depth), "  ")
+	if err != nil {
+		return 0, err
+	}
+	return len(emptyElementRE.ReplaceAll(b, []byte("/>"))) + 1, nil
+}
@@ -0,0 +1,56 @@
+package mpd
+
+import (
+	"io/ioutil"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestAnalyzeSize(c *C) {
+	b, err := ioutil.ReadFile("fixture_elemental_delta_live.mpd")
+	c.Assert(err, IsNil)
+	m := new(MPD)
+	c.Assert(m.Decode(b), IsNil)
+
+	r, err := AnalyzeSize(m)
+	c.Assert(err, IsNil)
+	enc, err := m.Encode()
+	c.Assert(err, IsNil)
+	c.Check(r.Total, Equals, len(enc))
+	c.Assert(r.Periods, HasLen, 1)
+	c.Check(r.Periods[0].Path, Equals, "Periods[0]")
+	c.Check(r.Periods[0].AdaptationSets, HasLen, len(m.Periods[0].AdaptationSets))
+
+	// everything except XML declaration and MPD element is in the Period
+	var sets int
+	for _, as := range r.Periods[0].AdaptationSets {
+		sets += as.Size
+		c.Check(as.SegmentTimelines > 0 && as.SegmentTimelines < as.Size, Equals, true)
+	}
+	c.Check(sets < r.Periods[0].Size, Equals, true)
+	c.Check(r.Periods[0].Size < r.Total, Equals, true)
+
+	c.Check(r.GrowthPerMinute > 0, Equals, true)
+}
+
+func (s *MPDSuite) TestAnalyzeSizeGrowth(c *C) {
+	template := func() *SegmentTemplate {
+		return &SegmentTemplate{Timescale: uint64Ptr(1000), SegmentTimeline: []SegmentTimeline{{
+			Segments: []SegmentTimelineSegment{{T: uint64Ptr(0), D: 4000}},
+		}}}
+	}
+	m := &MPD{Type: stringPtr("dynamic"), Periods: []*Period{{AdaptationSets: []*AdaptationSet{
+		{SegmentTemplate: template()},
+		{Representations: []Representation{{SegmentTemplate: template()}}},
+	}}}}
+
+	r, err := AnalyzeSize(m)
+	c.Assert(err, IsNil)
+	// 15 segments per minute in each timeline
+	c.Check(r.GrowthPerMinute, Equals, 15*len(`          <S d="4000"/>`+"\n")+15*len(`            <S d="4000"/>`+"\n"))
+
+	m.Type = nil
+	r, err = AnalyzeSize(m)
+	c.Assert(err, IsNil)
+	c.Check(r.GrowthPerMinute, Equals, 0)
+}
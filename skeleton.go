@@ -0,0 +1,57 @@
+package mpd
+
+import (
+	"time"
+)
+
+// MPDNamespace is the default namespace of MPD documents.
+const MPDNamespace = "urn:mpeg:dash:schema:mpd:2011"
+
+// XSINamespace is a namespace of @xsi:schemaLocation.
+const XSINamespace = "http://www.w3.org/2001/XMLSchema-instance"
+
+// SchemaLocation is a common @xsi:schemaLocation value pointing to the official MPD schema.
+const SchemaLocation = MPDNamespace + " http://standards.iso.org/ittf/PubliclyAvailableStandards/MPEG-DASH_schema_files/DASH-MPD.xsd"
+
+// Common @profiles values.
+const (
+	ProfileFull          = "urn:mpeg:dash:profile:full:2011"
+	ProfileISOFFOnDemand = "urn:mpeg:dash:profile:isoff-on-demand:2011"
+	ProfileISOFFLive     = "urn:mpeg:dash:profile:isoff-live:2011"
+	ProfileISOFFMain     = "urn:mpeg:dash:profile:isoff-main:2011"
+	ProfileCMAF          = "urn:mpeg:dash:profile:cmaf:2019"
+	ProfileDVBDASH       = "urn:dvb:dash:profile:dvb-dash:2014"
+	ProfileHbbTV         = "urn:hbbtv:dash:profile:isoff-live:2012"
+)
+
+// defaultMinBufferTime is used by NewStaticMPD and NewDynamicMPD.
+const defaultMinBufferTime = "PT2S"
+
+// NewStaticMPD returns minimal valid static MPD with given profiles (comma-separated) and duration,
+// containing a single empty Period.
+func NewStaticMPD(profiles string, duration time.Duration) *MPD {
+	return &MPD{
+		XMLNS:                     stringPtr(MPDNamespace),
+		Type:                      stringPtr("static"),
+		MediaPresentationDuration: stringPtr(FormatDuration(duration)),
+		MinBufferTime:             stringPtr(defaultMinBufferTime),
+		Profiles:                  profiles,
+		Periods:                   []*Period{{ID: stringPtr("0"), Start: stringPtr("PT0S")}},
+	}
+}
+
+// NewDynamicMPD returns minimal valid dynamic MPD with given profiles (comma-separated),
+// availabilityStartTime and minimumUpdatePeriod, containing a single empty Period.
+func NewDynamicMPD(profiles string, availabilityStart time.Time, minimumUpdatePeriod time.Duration) *MPD {
+	m := &MPD{
+		XMLNS:                 stringPtr(MPDNamespace),
+		Type:                  stringPtr("dynamic"),
+		MinimumUpdatePeriod:   stringPtr(FormatDuration(minimumUpdatePeriod)),
+		AvailabilityStartTime: stringPtr(FormatDateTime(availabilityStart)),
+		MinBufferTime:         stringPtr(defaultMinBufferTime),
+		Profiles:              profiles,
+		Periods:               []*Period{{ID: stringPtr("0"), Start: stringPtr("PT0S")}},
+	}
+	m.PublishTime = stringPtr(FormatDateTime(m.Clock().Now()))
+	return m
+}
@@ -0,0 +1,35 @@
+package mpd
+
+import (
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestNewStaticMPD(c *C) {
+	m := NewStaticMPD(ProfileISOFFOnDemand, 90*time.Second)
+	b, err := m.Encode()
+	c.Assert(err, IsNil)
+	c.Check(string(b), Equals, `<?xml version="1.0" encoding="utf-8"?>
+<MPD xmlns="urn:mpeg:dash:schema:mpd:2011" type="static" mediaPresentationDuration="PT1M30S" minBufferTime="PT2S" profiles="urn:mpeg:dash:profile:isoff-on-demand:2011">
+  <Period start="PT0S" id="0"/>
+</MPD>
+`)
+	c.Check(m.Validate(), IsNil)
+}
+
+func (s *MPDSuite) TestNewDynamicMPD(c *C) {
+	DefaultClock = fixedClock(time.Date(2016, 1, 2, 3, 4, 5, 0, time.UTC))
+	defer func() { DefaultClock = systemClock{} }()
+
+	ast := time.Date(2016, 1, 2, 12, 0, 0, 0, time.FixedZone("JST", 9*3600))
+	m := NewDynamicMPD(ProfileISOFFLive+","+ProfileDVBDASH, ast, 10*time.Second)
+	b, err := m.Encode()
+	c.Assert(err, IsNil)
+	c.Check(string(b), Equals, `<?xml version="1.0" encoding="utf-8"?>
+<MPD xmlns="urn:mpeg:dash:schema:mpd:2011" type="dynamic" minimumUpdatePeriod="PT10S" availabilityStartTime="2016-01-02T03:00:00Z" minBufferTime="PT2S" publishTime="2016-01-02T03:04:05Z" profiles="urn:mpeg:dash:profile:isoff-live:2011,urn:dvb:dash:profile:dvb-dash:2014">
+  <Period start="PT0S" id="0"/>
+</MPD>
+`)
+	c.Check(m.Validate(), IsNil)
+}
@@ -0,0 +1,40 @@
+package mpd
+
+// SplitByContentType splits m into MPDs with AdaptationSets of a single content type, keyed by type inferred
+// with InferContentType (empty for unknown). All Periods are kept in every MPD to preserve timing, even if they
+// have no AdaptationSets of that type. Preselections are dropped as they combine AdaptationSets of different types.
+func SplitByContentType(m *MPD) map[string]*MPD {
+	res := make(map[string]*MPD)
+	for _, p := range m.Periods {
+		for _, as := range p.AdaptationSets {
+			t := InferContentType(as)
+			if res[t] != nil {
+				continue
+			}
+			res[t] = filterContentType(m, t)
+		}
+	}
+	return res
+}
+
+// filterContentType returns deep copy of m with AdaptationSets of content type t only.
+func filterContentType(m *MPD, t string) *MPD {
+	mm := *m
+	mm.Periods = make([]*Period, len(m.Periods))
+	for i, p := range m.Periods {
+		pp := *p
+		pp.AdaptationSets, pp.EmptyAdaptationSets, pp.Preselections = nil, nil, nil
+		for _, as := range p.AdaptationSets {
+			if InferContentType(as) == t {
+				pp.AdaptationSets = append(pp.AdaptationSets, as)
+			}
+		}
+		for _, as := range p.EmptyAdaptationSets {
+			if InferContentType(as) == t {
+				pp.EmptyAdaptationSets = append(pp.EmptyAdaptationSets, as)
+			}
+		}
+		mm.Periods[i] = &pp
+	}
+	return mm.Clone()
+}
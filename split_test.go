@@ -0,0 +1,55 @@
+package mpd
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestSplitByContentType(c *C) {
+	m := &MPD{
+		Type:                      stringPtr("static"),
+		MediaPresentationDuration: stringPtr("PT1M"),
+		Periods: []*Period{
+			{ID: stringPtr("1"), Start: stringPtr("PT0S"), Duration: stringPtr("PT30S"), AdaptationSets: []*AdaptationSet{
+				{ID: uint64Ptr(1), MimeType: MimeTypeVideoMP4, Representations: []Representation{{ID: stringPtr("v")}}},
+				{ID: uint64Ptr(2), MimeType: MimeTypeAudioMP4, Lang: stringPtr("en"), Representations: []Representation{{ID: stringPtr("a-en")}}},
+				{ID: uint64Ptr(3), MimeType: MimeTypeAudioMP4, Lang: stringPtr("fr"), Representations: []Representation{{ID: stringPtr("a-fr")}}},
+			}, Preselections: []Preselection{{PreselectionComponents: "2 3"}}},
+			{ID: stringPtr("2"), Start: stringPtr("PT30S"), Duration: stringPtr("PT30S"), AdaptationSets: []*AdaptationSet{
+				{ID: uint64Ptr(1), MimeType: MimeTypeVideoMP4, Representations: []Representation{{ID: stringPtr("v")}}},
+				{ID: uint64Ptr(4), MimeType: MimeTypeTextVTT, Representations: []Representation{{ID: stringPtr("t")}}},
+			}},
+		},
+	}
+
+	res := SplitByContentType(m)
+	c.Assert(res, HasLen, 3)
+
+	ids := func(m *MPD) [][]string {
+		var res [][]string
+		for _, p := range m.Periods {
+			var list []string
+			for _, as := range p.AdaptationSets {
+				for _, r := range as.Representations {
+					list = append(list, *r.ID)
+				}
+			}
+			res = append(res, list)
+		}
+		return res
+	}
+	c.Check(ids(res[ContentTypeVideo]), DeepEquals, [][]string{{"v"}, {"v"}})
+	c.Check(ids(res[ContentTypeAudio]), DeepEquals, [][]string{{"a-en", "a-fr"}, nil})
+	c.Check(ids(res[ContentTypeText]), DeepEquals, [][]string{nil, {"t"}})
+
+	for t, mm := range res {
+		c.Check(*mm.MediaPresentationDuration, Equals, "PT1M", Commentf("%s", t))
+		c.Check(*mm.Periods[1].Start, Equals, "PT30S", Commentf("%s", t))
+		c.Check(mm.Periods[0].Preselections, IsNil, Commentf("%s", t))
+	}
+
+	// m is intact
+	c.Check(ids(m), DeepEquals, [][]string{{"v", "a-en", "a-fr"}, {"v", "t"}})
+	c.Check(m.Periods[0].Preselections, HasLen, 1)
+	res[ContentTypeVideo].Periods[0].AdaptationSets[0].Representations[0].ID = stringPtr("changed")
+	c.Check(*m.Periods[0].AdaptationSets[0].Representations[0].ID, Equals, "v")
+}
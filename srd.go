@@ -0,0 +1,114 @@
+package mpd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SRDScheme is a schemeIdUri of spatial relationship descriptor.
+const SRDScheme = "urn:mpeg:dash:srd:2014"
+
+// SRD represents value of spatial relationship descriptor.
+type SRD struct {
+	SourceID     int
+	ObjectX      int
+	ObjectY      int
+	ObjectWidth  int
+	ObjectHeight int
+	// TotalWidth and TotalHeight are zero if absent.
+	TotalWidth  int
+	TotalHeight int
+	// SpatialSetID is nil if absent.
+	SpatialSetID *int
+}
+
+// ParseSRD parses SRD descriptor value like "0,1920,0,1920,1080,3840,2160".
+func ParseSRD(s string) (*SRD, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 5 && len(parts) != 7 && len(parts) != 8 {
+		return nil, fmt.Errorf("SRD: expected 5, 7 or 8 values in %q", s)
+	}
+	v := make([]int, len(parts))
+	for i, p := range parts {
+		n, err := strconv.Atoi(strings.TrimSpace(p))
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("SRD: invalid value %q in %q", p, s)
+		}
+		v[i] = n
+	}
+
+	res := &SRD{SourceID: v[0], ObjectX: v[1], ObjectY: v[2], ObjectWidth: v[3], ObjectHeight: v[4]}
+	if len(v) > 5 {
+		res.TotalWidth, res.TotalHeight = v[5], v[6]
+	}
+	if len(v) > 7 {
+		res.SpatialSetID = &v[7]
+	}
+	return res, res.validate()
+}
+
+// String formats s as descriptor value.
+func (s SRD) String() string {
+	v := []int{s.SourceID, s.ObjectX, s.ObjectY, s.ObjectWidth, s.ObjectHeight}
+	if s.TotalWidth != 0 || s.TotalHeight != 0 || s.SpatialSetID != nil {
+		v = append(v, s.TotalWidth, s.TotalHeight)
+	}
+	if s.SpatialSetID != nil {
+		v = append(v, *s.SpatialSetID)
+	}
+	parts := make([]string, len(v))
+	for i, n := range v {
+		parts[i] = strconv.Itoa(n)
+	}
+	return strings.Join(parts, ",")
+}
+
+func (s *SRD) validate() error {
+	if s.TotalWidth == 0 && s.TotalHeight == 0 {
+		if s.SpatialSetID != nil {
+			return fmt.Errorf("SRD: spatial_set_id requires total_width and total_height")
+		}
+		return nil
+	}
+	if s.TotalWidth == 0 || s.TotalHeight == 0 {
+		return fmt.Errorf("SRD: total_width and total_height should be both present")
+	}
+	if s.ObjectX+s.ObjectWidth > s.TotalWidth || s.ObjectY+s.ObjectHeight > s.TotalHeight {
+		return fmt.Errorf("SRD: object %dx%d at %d,%d doesn't fit into %dx%d",
+			s.ObjectWidth, s.ObjectHeight, s.ObjectX, s.ObjectY, s.TotalWidth, s.TotalHeight)
+	}
+	return nil
+}
+
+// SetSRD replaces spatial relationship descriptor of as. AdaptationSets which must not be presented alone
+// (e.g. tiles) should use essential flag, so legacy clients ignore them.
+func (as *AdaptationSet) SetSRD(srd SRD, essential bool) error {
+	if err := srd.validate(); err != nil {
+		return err
+	}
+	removeDescriptors(&as.EssentialProperties, SRDScheme)
+	removeDescriptors(&as.SupplementalProperties, SRDScheme)
+	d := NewDescriptor(SRDScheme, srd.String())
+	if essential {
+		as.EssentialProperties = append(as.EssentialProperties, d)
+	} else {
+		as.SupplementalProperties = append(as.SupplementalProperties, d)
+	}
+	return nil
+}
+
+// SRD returns spatial relationship descriptor of as, or nil.
+func (as *AdaptationSet) SRD() (*SRD, error) {
+	d := findDescriptor(as.SupplementalProperties, SRDScheme)
+	if d == nil {
+		d = findDescriptor(as.EssentialProperties, SRDScheme)
+	}
+	if d == nil {
+		return nil, nil
+	}
+	if d.Value == nil {
+		return nil, fmt.Errorf("SRD: descriptor without value")
+	}
+	return ParseSRD(*d.Value)
+}
@@ -0,0 +1,35 @@
+package mpd
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestSRD(c *C) {
+	srd, err := ParseSRD("0,1920,0,1920,1080,3840,2160")
+	c.Assert(err, IsNil)
+	c.Check(*srd, DeepEquals, SRD{ObjectX: 1920, ObjectWidth: 1920, ObjectHeight: 1080, TotalWidth: 3840, TotalHeight: 2160})
+	c.Check(srd.String(), Equals, "0,1920,0,1920,1080,3840,2160")
+
+	srd, err = ParseSRD("1, 0, 0, 2, 2, 4, 4, 7")
+	c.Assert(err, IsNil)
+	c.Check(*srd.SpatialSetID, Equals, 7)
+	c.Check(srd.String(), Equals, "1,0,0,2,2,4,4,7")
+
+	srd, err = ParseSRD("1,0,0,2,2")
+	c.Assert(err, IsNil)
+	c.Check(srd.String(), Equals, "1,0,0,2,2")
+
+	for _, v := range []string{"1,2,3", "1,0,0,2,2,4", "1,3,0,2,2,4,4", "a,0,0,2,2"} {
+		_, err = ParseSRD(v)
+		c.Check(err, NotNil, Commentf("%q", v))
+	}
+
+	as := new(AdaptationSet)
+	c.Assert(as.SetSRD(SRD{SourceID: 1, ObjectWidth: 2, ObjectHeight: 2, TotalWidth: 4, TotalHeight: 4}, true), IsNil)
+	c.Check(as.EssentialProperties, DeepEquals, []Descriptor{NewDescriptor(SRDScheme, "1,0,0,2,2,4,4")})
+	srd, err = as.SRD()
+	c.Assert(err, IsNil)
+	c.Check(srd.TotalWidth, Equals, 4)
+
+	c.Check(as.SetSRD(SRD{ObjectX: 3, ObjectWidth: 2, ObjectHeight: 2, TotalWidth: 4, TotalHeight: 4}, false), NotNil)
+}
@@ -0,0 +1,132 @@
+package mpd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// checkDuplicateAttributes returns errors for elements of b with repeated attributes, which encoding/xml
+// silently resolves to the last value. Paths are like Difference ones, e.g. "MPD/Period[0]/AdaptationSet[1]".
+func checkDuplicateAttributes(b []byte) ValidationErrors {
+	var res ValidationErrors
+	type frame struct {
+		path   string
+		counts map[string]int
+	}
+	var stack []frame
+	d := xml.NewDecoder(bytes.NewReader(b))
+	for {
+		t, err := d.RawToken()
+		if err != nil {
+			return res
+		}
+		switch t := t.(type) {
+		case xml.StartElement:
+			name := prefixedName(t.Name)
+			path := name
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				path = fmt.Sprintf("%s/%s[%d]", parent.path, name, parent.counts[name])
+				parent.counts[name]++
+			}
+			seen := make(map[xml.Name]bool, len(t.Attr))
+			for _, a := range t.Attr {
+				if seen[a.Name] {
+					res = append(res, newValidationError(path, "duplicate attribute %s", prefixedName(a.Name)))
+				}
+				seen[a.Name] = true
+			}
+			stack = append(stack, frame{path: path, counts: make(map[string]int)})
+		case xml.EndElement:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+}
+
+// prefixedName returns name of raw token as written, with prefix.
+func prefixedName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return name.Space + ":" + name.Local
+}
+
+// validateStrict checks m for duplicate ids and values which can't be processed: zero timescales
+// and SegmentTimeline S elements with zero duration or repeat count below -1.
+func validateStrict(m *MPD) ValidationErrors {
+	var res ValidationErrors
+	duplicate := func(seen map[string]bool, path, kind, id string) {
+		if seen[id] {
+			res = append(res, newValidationError(path, "duplicate %s id %q", kind, id))
+		}
+		seen[id] = true
+	}
+
+	periodIDs := make(map[string]bool)
+	for i, p := range m.Periods {
+		if p.ID != nil {
+			duplicate(periodIDs, periodPath(i), "Period", *p.ID)
+		}
+		for n, es := range p.EventStreams {
+			if es.Timescale != nil && *es.Timescale <= 0 {
+				res = append(res, newValidationError(fmt.Sprintf("%s.EventStreams[%d]", periodPath(i), n), "timescale must be positive"))
+			}
+		}
+		res = append(res, checkAddressing(periodPath(i), p.SegmentTemplate, p.SegmentList, p.SegmentBase)...)
+
+		asIDs := make(map[string]bool)
+		repIDs := make(map[string]bool)
+		for j, as := range p.AdaptationSets {
+			if as.ID != nil {
+				duplicate(asIDs, adaptationSetPath(i, j), "AdaptationSet", fmt.Sprint(*as.ID))
+			}
+			res = append(res, checkAddressing(adaptationSetPath(i, j), as.SegmentTemplate, as.SegmentList, as.SegmentBase)...)
+			for k := range as.Representations {
+				r := &as.Representations[k]
+				if r.ID != nil {
+					duplicate(repIDs, representationPath(i, j, k), "Representation", *r.ID)
+				}
+				res = append(res, checkAddressing(representationPath(i, j, k), r.SegmentTemplate, r.SegmentList, r.SegmentBase)...)
+			}
+		}
+	}
+	return res
+}
+
+// checkAddressing checks timescales and SegmentTimelines of segment addressing elements of element at path.
+func checkAddressing(path string, st *SegmentTemplate, sl *SegmentList, sb *SegmentBase) ValidationErrors {
+	var res ValidationErrors
+	check := func(name string, timescale *uint64, timeline []SegmentTimeline) {
+		if timescale != nil && *timescale == 0 {
+			res = append(res, newValidationError(path+"."+name, "timescale must be positive"))
+		}
+		for _, t := range timeline {
+			for n, s := range t.Segments {
+				var problems []string
+				if s.D == 0 {
+					problems = append(problems, "d must be positive")
+				}
+				if s.R != nil && *s.R < -1 {
+					problems = append(problems, fmt.Sprintf("r=%d is below -1", *s.R))
+				}
+				if len(problems) > 0 {
+					res = append(res, newValidationError(fmt.Sprintf("%s.%s.SegmentTimeline.S[%d]", path, name, n), "%s", strings.Join(problems, ", ")))
+				}
+			}
+		}
+	}
+	if st != nil {
+		check("SegmentTemplate", st.Timescale, st.SegmentTimeline)
+	}
+	if sl != nil {
+		check("SegmentList", sl.Timescale, sl.SegmentTimeline)
+	}
+	if sb != nil {
+		check("SegmentBase", sb.Timescale, nil)
+	}
+	return res
+}
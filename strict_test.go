@@ -0,0 +1,76 @@
+package mpd
+
+import (
+	"io/ioutil"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestDecodeStrict(c *C) {
+	for _, name := range []string{
+		"fixture_elemental_delta_vod.mpd",
+		"fixture_elemental_delta_live.mpd",
+		"fixture_elemental_delta_1.6.1_live.mpd",
+	} {
+		b, err := ioutil.ReadFile(name)
+		c.Assert(err, IsNil)
+		c.Check(new(MPD).DecodeWithOptions(b, DecodeOptions{Strict: true}), IsNil, Commentf("%s", name))
+	}
+
+	b := []byte(`<MPD xmlns="urn:mpeg:dash:schema:mpd:2011" type="static">
+  <Period id="p">
+    <AdaptationSet id="1" mimeType="video/mp4">
+      <SegmentTemplate timescale="0" media="$Time$.m4s">
+        <SegmentTimeline>
+          <S t="0" d="2" r="-2"/>
+          <S d="0"/>
+        </SegmentTimeline>
+      </SegmentTemplate>
+      <Representation id="v" bandwidth="1000"/>
+      <Representation id="v" bandwidth="2000"/>
+    </AdaptationSet>
+    <AdaptationSet id="1" mimeType="audio/mp4">
+      <Representation id="a" bandwidth="100">
+        <SegmentBase timescale="0"/>
+      </Representation>
+    </AdaptationSet>
+  </Period>
+  <Period id="p">
+    <EventStream schemeIdUri="urn:example" timescale="0"/>
+  </Period>
+</MPD>`)
+	m := new(MPD)
+	c.Assert(m.Decode(b), IsNil)
+	err := new(MPD).DecodeWithOptions(b, DecodeOptions{Strict: true})
+	c.Assert(err, FitsTypeOf, ValidationErrors{})
+	c.Check(err.(ValidationErrors), DeepEquals, ValidationErrors{
+		{Path: "Periods[0].AdaptationSets[0].SegmentTemplate", Message: "timescale must be positive"},
+		{Path: "Periods[0].AdaptationSets[0].SegmentTemplate.SegmentTimeline.S[0]", Message: "r=-2 is below -1"},
+		{Path: "Periods[0].AdaptationSets[0].SegmentTemplate.SegmentTimeline.S[1]", Message: "d must be positive"},
+		{Path: "Periods[0].AdaptationSets[0].Representations[1]", Message: `duplicate Representation id "v"`},
+		{Path: "Periods[0].AdaptationSets[1]", Message: `duplicate AdaptationSet id "1"`},
+		{Path: "Periods[0].AdaptationSets[1].Representations[0].SegmentBase", Message: "timescale must be positive"},
+		{Path: "Periods[1]", Message: `duplicate Period id "p"`},
+		{Path: "Periods[1].EventStreams[0]", Message: "timescale must be positive"},
+	})
+}
+
+func (s *MPDSuite) TestDecodeStrictDuplicateAttributes(c *C) {
+	b := []byte(`<MPD xmlns="urn:mpeg:dash:schema:mpd:2011" xmlns:cenc="urn:mpeg:cenc:2013" type="static">
+  <Period>
+    <AdaptationSet mimeType="video/mp4"/>
+    <AdaptationSet mimeType="video/mp4" lang="en" lang="fr">
+      <ContentProtection schemeIdUri="urn:mpeg:dash:mp4protection:2011" cenc:default_KID="a" cenc:default_KID="b"/>
+    </AdaptationSet>
+  </Period>
+</MPD>`)
+	m := new(MPD)
+	c.Assert(m.Decode(b), IsNil)
+	c.Check(*m.Periods[0].AdaptationSets[1].Lang, Equals, "fr")
+
+	err := new(MPD).DecodeWithOptions(b, DecodeOptions{Strict: true})
+	c.Check(err, DeepEquals, ValidationErrors{
+		{Path: "MPD/Period[0]/AdaptationSet[1]", Message: "duplicate attribute lang"},
+		{Path: "MPD/Period[0]/AdaptationSet[1]/ContentProtection[0]", Message: "duplicate attribute cenc:default_KID"},
+	})
+}
@@ -0,0 +1,111 @@
+package mpd
+
+import (
+	"fmt"
+)
+
+// MimeTypeTTML is a mimeType of sidecar TTML files.
+const MimeTypeTTML = "application/ttml+xml"
+
+// SubtitleFormat is a format of text AdaptationSet.
+type SubtitleFormat int
+
+// Subtitle formats.
+const (
+	// IMSC1 Text Profile in fragmented MP4 segments.
+	IMSC1Text SubtitleFormat = iota
+	// IMSC1 Image Profile in fragmented MP4 segments.
+	IMSC1Image
+	// WebVTT in fragmented MP4 segments.
+	WebVTT
+	// Single WebVTT file.
+	WebVTTSidecar
+	// Single TTML file.
+	TTMLSidecar
+	// EBU-TT-D in fragmented MP4 segments, as required by DVB-DASH.
+	EBUTTD
+)
+
+// Segmented returns true for formats addressed by SegmentTemplate.
+func (f SubtitleFormat) Segmented() bool {
+	return f == IMSC1Text || f == IMSC1Image || f == WebVTT || f == EBUTTD
+}
+
+// defaultSubtitleBandwidth is written when SubtitleOptions.Bandwidth is not set, as @bandwidth is mandatory.
+const defaultSubtitleBandwidth = 256
+
+// SubtitleOptions describes text AdaptationSet for NewSubtitleAdaptationSet.
+type SubtitleOptions struct {
+	Format SubtitleFormat
+	// ID is Representation id.
+	ID   string
+	Lang string
+	// Role is a Role descriptor value, "subtitle" by default. Use "caption" for captions for the hard of hearing.
+	Role string
+	// Bandwidth is 256 by default.
+	Bandwidth uint64
+	// SegmentTemplate is required for segmented formats.
+	SegmentTemplate *SegmentTemplate
+	// URL is a location of single file, required for sidecar formats.
+	URL string
+}
+
+// NewSubtitleAdaptationSet returns text AdaptationSet with a single Representation,
+// with mimeType, codecs, Role and addressing matching subtitle format.
+func NewSubtitleAdaptationSet(o SubtitleOptions) (*AdaptationSet, error) {
+	if o.Lang == "" {
+		return nil, fmt.Errorf("NewSubtitleAdaptationSet: lang is required")
+	}
+	if o.Format.Segmented() && o.SegmentTemplate == nil {
+		return nil, fmt.Errorf("NewSubtitleAdaptationSet: SegmentTemplate is required for segmented format")
+	}
+	if !o.Format.Segmented() && o.URL == "" {
+		return nil, fmt.Errorf("NewSubtitleAdaptationSet: URL is required for sidecar format")
+	}
+
+	role := o.Role
+	if role == "" {
+		role = "subtitle"
+	}
+	bandwidth := o.Bandwidth
+	if bandwidth == 0 {
+		bandwidth = defaultSubtitleBandwidth
+	}
+	id, lang, contentType := o.ID, o.Lang, ContentTypeText
+
+	as := &AdaptationSet{
+		Lang:        &lang,
+		ContentType: &contentType,
+		Roles:       []Descriptor{NewDescriptor(RoleScheme, role)},
+	}
+	r := Representation{ID: &id, Bandwidth: &bandwidth}
+
+	var codecs string
+	switch o.Format {
+	case IMSC1Text:
+		as.MimeType, codecs = MimeTypeApplicationMP4, "stpp.ttml.im1t"
+	case IMSC1Image:
+		as.MimeType, codecs = MimeTypeApplicationMP4, "stpp.ttml.im1i"
+	case WebVTT:
+		as.MimeType, codecs = MimeTypeApplicationMP4, "wvtt"
+	case EBUTTD:
+		as.MimeType, codecs = MimeTypeApplicationMP4, "stpp.ttml.etd1"
+	case WebVTTSidecar:
+		as.MimeType = MimeTypeTextVTT
+	case TTMLSidecar:
+		as.MimeType = MimeTypeTTML
+	default:
+		return nil, fmt.Errorf("NewSubtitleAdaptationSet: unknown format %d", o.Format)
+	}
+
+	if codecs != "" {
+		r.Codecs = &codecs
+	}
+	if o.Format.Segmented() {
+		r.SegmentTemplate = o.SegmentTemplate
+	} else {
+		r.BaseURL = o.URL
+	}
+	as.Representations = []Representation{r}
+	return as, nil
+}
@@ -0,0 +1,33 @@
+package mpd
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestSubtitleAdaptationSet(c *C) {
+	media := "sub_$Number$.m4s"
+	as, err := NewSubtitleAdaptationSet(SubtitleOptions{
+		Format:          IMSC1Text,
+		ID:              "sub_en",
+		Lang:            "en",
+		SegmentTemplate: &SegmentTemplate{Media: &media},
+	})
+	c.Assert(err, IsNil)
+	c.Check(as.MimeType, Equals, MimeTypeApplicationMP4)
+	c.Check(*as.Representations[0].Codecs, Equals, "stpp.ttml.im1t")
+	c.Check(*as.Representations[0].Bandwidth, Equals, uint64(256))
+	c.Check(as.HasRole("subtitle"), Equals, true)
+	c.Check(InferContentType(as), Equals, ContentTypeText)
+
+	as, err = NewSubtitleAdaptationSet(SubtitleOptions{Format: WebVTTSidecar, ID: "cc", Lang: "fr", Role: "caption", URL: "subs/fr.vtt"})
+	c.Assert(err, IsNil)
+	c.Check(as.MimeType, Equals, MimeTypeTextVTT)
+	c.Check(as.Representations[0].Codecs, IsNil)
+	c.Check(as.Representations[0].BaseURL, Equals, "subs/fr.vtt")
+	c.Check(as.HasRole("caption"), Equals, true)
+
+	_, err = NewSubtitleAdaptationSet(SubtitleOptions{Format: WebVTT, Lang: "en"})
+	c.Check(err, ErrorMatches, "NewSubtitleAdaptationSet: SegmentTemplate is required for segmented format")
+	_, err = NewSubtitleAdaptationSet(SubtitleOptions{Format: TTMLSidecar, Lang: "en"})
+	c.Check(err, ErrorMatches, "NewSubtitleAdaptationSet: URL is required for sidecar format")
+}
@@ -0,0 +1,188 @@
+package mpd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jun-oku/mpd/codecs"
+)
+
+// AdaptationSetSwitchingScheme is a schemeIdUri of SupplementalProperty which allows seamless switching
+// between Representations of different AdaptationSets.
+const AdaptationSetSwitchingScheme = "urn:mpeg:dash:adaptation-set-switching:2016"
+
+// LinkAdaptationSets signals that clients may switch seamlessly between all given AdaptationSets,
+// each of which must have @id.
+func LinkAdaptationSets(sets ...*AdaptationSet) error {
+	for _, as := range sets {
+		if as.ID == nil {
+			return fmt.Errorf("LinkAdaptationSets: AdaptationSet without id")
+		}
+	}
+	for _, as := range sets {
+		var ids []string
+		for _, other := range sets {
+			if other != as {
+				ids = append(ids, strconv.FormatUint(*other.ID, 10))
+			}
+		}
+		removeDescriptors(&as.SupplementalProperties, AdaptationSetSwitchingScheme)
+		if len(ids) > 0 {
+			as.SupplementalProperties = append(as.SupplementalProperties, NewDescriptor(AdaptationSetSwitchingScheme, strings.Join(ids, ",")))
+		}
+	}
+	return nil
+}
+
+// AdaptationSetSwitching returns ids of AdaptationSets as can be switched to, or nil.
+func (as *AdaptationSet) AdaptationSetSwitching() ([]uint64, error) {
+	d := findDescriptor(as.SupplementalProperties, AdaptationSetSwitchingScheme)
+	if d == nil {
+		return nil, nil
+	}
+	if d.Value == nil {
+		return nil, fmt.Errorf("AdaptationSetSwitching: descriptor without value")
+	}
+	var res []uint64
+	for _, s := range strings.Split(*d.Value, ",") {
+		id, err := strconv.ParseUint(strings.TrimSpace(s), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("AdaptationSetSwitching: invalid AdaptationSet id %q", s)
+		}
+		res = append(res, id)
+	}
+	return res, nil
+}
+
+// validateAdaptationSetSwitching checks that adaptation-set-switching properties reference existing
+// AdaptationSets of the same Period with the same content type and codec families.
+func validateAdaptationSetSwitching(m *MPD) ValidationErrors {
+	var res ValidationErrors
+	for i, p := range m.Periods {
+		for j, as := range p.AdaptationSets {
+			ids, err := as.AdaptationSetSwitching()
+			if err != nil {
+				res = append(res, newValidationError(adaptationSetPath(i, j), "%s", err))
+				continue
+			}
+			for _, id := range ids {
+				other := p.findAdaptationSet(id)
+				switch {
+				case other == nil:
+					res = append(res, newValidationError(adaptationSetPath(i, j), "adaptation-set-switching references missing AdaptationSet %d", id))
+				case other == as:
+					res = append(res, newValidationError(adaptationSetPath(i, j), "adaptation-set-switching references itself"))
+				case InferContentType(as) != InferContentType(other):
+					res = append(res, newValidationError(adaptationSetPath(i, j), "adaptation-set-switching references AdaptationSet %d of different content type", id))
+				case codecFamilies(as) != codecFamilies(other):
+					res = append(res, newValidationError(adaptationSetPath(i, j), "adaptation-set-switching references AdaptationSet %d with incompatible codecs", id))
+				}
+			}
+		}
+	}
+	return res
+}
+
+// codecFamilies returns sorted comma-separated list of codec families used by Representations of as.
+func codecFamilies(as *AdaptationSet) string {
+	families := make(map[string]bool)
+	for _, r := range as.Representations {
+		if r.Codecs == nil {
+			continue
+		}
+		list, err := codecs.ParseList(*r.Codecs)
+		if err != nil {
+			families[*r.Codecs] = true
+			continue
+		}
+		for _, c := range list {
+			f := c.Family
+			if f == "" {
+				f = c.FourCC
+			}
+			families[f] = true
+		}
+	}
+	res := make([]string, 0, len(families))
+	for f := range families {
+		res = append(res, f)
+	}
+	sort.Strings(res)
+	return strings.Join(res, ",")
+}
+
+// SwitchingSet is a logical track: Representations of a Period between which clients may switch seamlessly,
+// from one AdaptationSet or from several ones linked by adaptation-set-switching properties.
+type SwitchingSet struct {
+	Period *Period
+	// ContentType is inferred content type of AdaptationSets, e.g. "video".
+	ContentType    string
+	AdaptationSets []*AdaptationSet
+	// Representations are in document order.
+	Representations []ResolvedRepresentation
+}
+
+// SwitchingSets groups Representations of m into switching sets, ordered by their first AdaptationSets.
+// AdaptationSets linked by adaptation-set-switching properties, directly or transitively, form a single set
+// if they have the same content type. Links to missing AdaptationSets are ignored, and so are AdaptationSets
+// without Representations.
+func (m *MPD) SwitchingSets() ([]SwitchingSet, error) {
+	resolved, err := Resolve(m)
+	if err != nil {
+		return nil, fmt.Errorf("SwitchingSets: %w", err)
+	}
+
+	var sets []SwitchingSet
+	index := make(map[*AdaptationSet]int)
+	for i, p := range m.Periods {
+		parent := make(map[*AdaptationSet]*AdaptationSet, len(p.AdaptationSets))
+		root := func(as *AdaptationSet) *AdaptationSet {
+			for parent[as] != nil {
+				as = parent[as]
+			}
+			return as
+		}
+		for j, as := range p.AdaptationSets {
+			ids, err := as.AdaptationSetSwitching()
+			if err != nil {
+				return nil, fmt.Errorf("SwitchingSets: %s: %w", adaptationSetPath(i, j), err)
+			}
+			for _, id := range ids {
+				other := p.findAdaptationSet(id)
+				if other == nil || InferContentType(other) != InferContentType(as) {
+					continue
+				}
+				if a, b := root(as), root(other); a != b {
+					parent[b] = a
+				}
+			}
+		}
+
+		first := make(map[*AdaptationSet]int)
+		for _, as := range p.AdaptationSets {
+			r := root(as)
+			n, ok := first[r]
+			if !ok {
+				n = len(sets)
+				first[r] = n
+				sets = append(sets, SwitchingSet{Period: p, ContentType: InferContentType(as)})
+			}
+			sets[n].AdaptationSets = append(sets[n].AdaptationSets, as)
+			index[as] = n
+		}
+	}
+	for _, rr := range resolved {
+		n := index[rr.AdaptationSet]
+		sets[n].Representations = append(sets[n].Representations, rr)
+	}
+
+	var res []SwitchingSet
+	for _, s := range sets {
+		if len(s.Representations) > 0 {
+			res = append(res, s)
+		}
+	}
+	return res, nil
+}
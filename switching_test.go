@@ -0,0 +1,74 @@
+package mpd
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestAdaptationSetSwitching(c *C) {
+	as1 := &AdaptationSet{ID: uint64Ptr(1), MimeType: MimeTypeVideoMP4, Representations: []Representation{{Codecs: stringPtr("avc1.64001f")}}}
+	as2 := &AdaptationSet{ID: uint64Ptr(2), MimeType: MimeTypeVideoMP4, Representations: []Representation{{Codecs: stringPtr("avc3.640028")}}}
+	as3 := &AdaptationSet{ID: uint64Ptr(3), MimeType: MimeTypeVideoMP4, Representations: []Representation{{Codecs: stringPtr("hvc1.1.6.L93.B0")}}}
+	m := &MPD{Periods: []*Period{{AdaptationSets: []*AdaptationSet{as1, as2, as3}}}}
+
+	c.Assert(LinkAdaptationSets(as1, as2), IsNil)
+	ids, err := as1.AdaptationSetSwitching()
+	c.Assert(err, IsNil)
+	c.Check(ids, DeepEquals, []uint64{2})
+	c.Check(m.Validate(), IsNil)
+
+	c.Assert(LinkAdaptationSets(as1, as2, as3), IsNil)
+	ids, err = as3.AdaptationSetSwitching()
+	c.Assert(err, IsNil)
+	c.Check(ids, DeepEquals, []uint64{1, 2})
+	c.Check(m.Validate(), ErrorMatches, `(?s)Periods\[0\].AdaptationSets\[0\]: adaptation-set-switching references AdaptationSet 3 with incompatible codecs.*`)
+
+	as3.SupplementalProperties = []Descriptor{NewDescriptor(AdaptationSetSwitchingScheme, "1,4")}
+	as1.SupplementalProperties, as2.SupplementalProperties = nil, nil
+	c.Check(m.Validate(), ErrorMatches, `(?s).*Periods\[0\].AdaptationSets\[2\]: adaptation-set-switching references missing AdaptationSet 4`)
+
+	c.Check(LinkAdaptationSets(as1, &AdaptationSet{}), ErrorMatches, "LinkAdaptationSets: AdaptationSet without id")
+}
+
+func (s *MPDSuite) TestSwitchingSets(c *C) {
+	video := func(n uint64, reps ...string) *AdaptationSet {
+		as := &AdaptationSet{ID: uint64Ptr(n), MimeType: MimeTypeVideoMP4}
+		for _, r := range reps {
+			as.Representations = append(as.Representations, Representation{ID: stringPtr(r), Codecs: stringPtr("avc1.64001f")})
+		}
+		return as
+	}
+	as1, as2, as3 := video(1, "v1", "v2"), video(2, "v3"), video(3, "v4")
+	audio := &AdaptationSet{ID: uint64Ptr(4), MimeType: MimeTypeAudioMP4, Representations: []Representation{{ID: stringPtr("a1")}}}
+	empty := video(5)
+	c.Assert(LinkAdaptationSets(as1, as3), IsNil)
+	// links to missing AdaptationSets and other content types are ignored
+	as2.SupplementalProperties = []Descriptor{NewDescriptor(AdaptationSetSwitchingScheme, "4,9")}
+	m := &MPD{Periods: []*Period{{AdaptationSets: []*AdaptationSet{as1, audio, as2, as3, empty}}, {AdaptationSets: []*AdaptationSet{video(1, "v5")}}}}
+
+	sets, err := m.SwitchingSets()
+	c.Assert(err, IsNil)
+	type track struct {
+		contentType string
+		sets        int
+		reps        []string
+	}
+	var tracks []track
+	for _, s := range sets {
+		t := track{contentType: s.ContentType, sets: len(s.AdaptationSets)}
+		for _, rr := range s.Representations {
+			t.reps = append(t.reps, *rr.Representation.ID)
+		}
+		tracks = append(tracks, t)
+	}
+	c.Check(tracks, DeepEquals, []track{
+		{"video", 2, []string{"v1", "v2", "v4"}},
+		{"audio", 1, []string{"a1"}},
+		{"video", 1, []string{"v3"}},
+		{"video", 1, []string{"v5"}},
+	})
+	c.Check(sets[3].Period, Equals, m.Periods[1])
+
+	as2.SupplementalProperties = []Descriptor{NewDescriptor(AdaptationSetSwitchingScheme, "x")}
+	_, err = m.SwitchingSets()
+	c.Check(err, ErrorMatches, `SwitchingSets: Periods\[0\].AdaptationSets\[2\]: AdaptationSetSwitching: invalid AdaptationSet id "x"`)
+}
@@ -0,0 +1,331 @@
+// Package testgen generates random valid MPEG-DASH manifests for fuzzing and benchmarks.
+package testgen
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/jun-oku/mpd"
+)
+
+// Addressing is a segment addressing mode.
+type Addressing int
+
+// Addressing modes.
+const (
+	// TemplateNumber is SegmentTemplate with @duration and $Number$ URLs.
+	TemplateNumber Addressing = iota
+	// TemplateTimeline is SegmentTemplate with SegmentTimeline and $Time$ URLs.
+	TemplateTimeline
+	// SegmentList is SegmentList with @duration and explicit SegmentURLs.
+	SegmentList
+	// SegmentBase is a single file per Representation indexed by sidx.
+	SegmentBase
+)
+
+// String implements fmt.Stringer interface.
+func (a Addressing) String() string {
+	switch a {
+	case TemplateNumber:
+		return "TemplateNumber"
+	case TemplateTimeline:
+		return "TemplateTimeline"
+	case SegmentList:
+		return "SegmentList"
+	case SegmentBase:
+		return "SegmentBase"
+	default:
+		return fmt.Sprintf("Addressing(%d)", int(a))
+	}
+}
+
+// Options configure Generate.
+type Options struct {
+	// Seed makes output reproducible: the same Options always produce the same MPD.
+	Seed int64
+	// Periods is a number of Periods, defaults to 1.
+	Periods int
+	// Addressing lists modes chosen randomly for each AdaptationSet. Defaults to all modes allowed
+	// for MPD type: dynamic MPDs use SegmentTemplate only.
+	Addressing []Addressing
+	// DRM adds cenc, Widevine and PlayReady ContentProtections with a random key ID.
+	DRM bool
+	// Dynamic makes live MPD instead of VOD one.
+	Dynamic bool
+	// MaxRepresentations limits Representations of video AdaptationSets, defaults to 4.
+	MaxRepresentations int
+}
+
+// availabilityStart is availabilityStartTime of dynamic MPDs.
+var availabilityStart = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// videoLadder lists possible video Representations from the lowest one.
+var videoLadder = []struct {
+	width, height, bandwidth uint64
+	codecs                   string
+}{
+	{416, 234, 145000, "avc1.42c00d"},
+	{640, 360, 365000, "avc1.4d401e"},
+	{960, 540, 2000000, "avc1.4d401f"},
+	{1280, 720, 4500000, "avc1.64001f"},
+	{1920, 1080, 7800000, "avc1.640028"},
+	{3840, 2160, 16000000, "avc1.640033"},
+}
+
+var (
+	languages        = []string{"en", "fr", "de", "es", "ja", "pt-BR"}
+	segmentDurations = []time.Duration{2 * time.Second, 4 * time.Second, 6 * time.Second}
+)
+
+// Generate returns a random MPD which passes MPD.Validate.
+func Generate(o Options) (*mpd.MPD, error) {
+	if o.Periods == 0 {
+		o.Periods = 1
+	}
+	if o.MaxRepresentations == 0 {
+		o.MaxRepresentations = 4
+	}
+	if o.Periods < 0 || o.MaxRepresentations < 0 {
+		return nil, fmt.Errorf("Generate: negative number of Periods or Representations")
+	}
+	if len(o.Addressing) == 0 {
+		o.Addressing = []Addressing{TemplateNumber, TemplateTimeline}
+		if !o.Dynamic {
+			o.Addressing = append(o.Addressing, SegmentList, SegmentBase)
+		}
+	}
+	profiles := mpd.ProfileISOFFLive
+	for _, a := range o.Addressing {
+		switch a {
+		case TemplateNumber, TemplateTimeline:
+		case SegmentList, SegmentBase:
+			if o.Dynamic {
+				return nil, fmt.Errorf("Generate: %s addressing in dynamic MPD", a)
+			}
+			profiles = mpd.ProfileFull
+		default:
+			return nil, fmt.Errorf("Generate: unknown addressing %s", a)
+		}
+	}
+
+	g := &generator{Options: o, rnd: rand.New(rand.NewSource(o.Seed))}
+	if o.DRM {
+		g.kid = make([]byte, 16)
+		g.rnd.Read(g.kid)
+	}
+
+	var m *mpd.MPD
+	if o.Dynamic {
+		m = mpd.NewDynamicMPD(profiles, availabilityStart, segmentDurations[0])
+		m.TimeShiftBufferDepth = stringPtr("PT30S")
+	} else {
+		m = mpd.NewStaticMPD(profiles, 0)
+	}
+	m.Periods = nil
+	if o.DRM {
+		m.Cenc = stringPtr(mpd.CENCNamespace)
+	}
+
+	var start time.Duration
+	for i := 0; i < o.Periods; i++ {
+		p := g.period(i, start)
+		d, err := mpd.ParseDuration(*p.Duration)
+		if err != nil {
+			return nil, fmt.Errorf("Generate: %w", err)
+		}
+		start += d
+		m.Periods = append(m.Periods, p)
+	}
+
+	if o.Dynamic {
+		m.PublishTime = stringPtr(mpd.FormatDateTime(availabilityStart.Add(start)))
+	} else {
+		m.MediaPresentationDuration = stringPtr(mpd.FormatDuration(start))
+	}
+	return m, nil
+}
+
+// generator keeps state of Generate.
+type generator struct {
+	Options
+	rnd *rand.Rand
+	kid []byte
+}
+
+// period returns Period i starting at start.
+func (g *generator) period(i int, start time.Duration) *mpd.Period {
+	segmentDuration := segmentDurations[g.rnd.Intn(len(segmentDurations))]
+	segments := 3 + g.rnd.Intn(18)
+	p := &mpd.Period{
+		ID:       stringPtr(fmt.Sprintf("p%d", i)),
+		Start:    stringPtr(mpd.FormatDuration(start)),
+		Duration: stringPtr(mpd.FormatDuration(segmentDuration * time.Duration(segments))),
+	}
+	s := &segmentation{duration: segmentDuration, count: segments}
+
+	video := &mpd.AdaptationSet{
+		ID:               uint64Ptr(0),
+		MimeType:         mpd.MimeTypeVideoMP4,
+		ContentType:      stringPtr("video"),
+		SegmentAlignment: conditionalTrue(),
+		StartWithSAP:     uint64Ptr(1),
+	}
+	count := 1 + g.rnd.Intn(g.MaxRepresentations)
+	if count > len(videoLadder) {
+		count = len(videoLadder)
+	}
+	first := g.rnd.Intn(len(videoLadder) - count + 1)
+	for k, v := range videoLadder[first : first+count] {
+		video.Representations = append(video.Representations, mpd.Representation{
+			ID:        stringPtr(fmt.Sprintf("v%d", k)),
+			Width:     uint64Ptr(v.width),
+			Height:    uint64Ptr(v.height),
+			FrameRate: stringPtr("25"),
+			Bandwidth: uint64Ptr(v.bandwidth),
+			Codecs:    stringPtr(v.codecs),
+		})
+	}
+	g.address(video, s, 90000)
+	p.AdaptationSets = append(p.AdaptationSets, video)
+
+	for j, k := range g.rnd.Perm(len(languages))[:1+g.rnd.Intn(2)] {
+		audio := &mpd.AdaptationSet{
+			ID:               uint64Ptr(uint64(j + 1)),
+			MimeType:         mpd.MimeTypeAudioMP4,
+			ContentType:      stringPtr("audio"),
+			Lang:             stringPtr(languages[k]),
+			SegmentAlignment: conditionalTrue(),
+			StartWithSAP:     uint64Ptr(1),
+			Representations: []mpd.Representation{{
+				ID:                stringPtr(fmt.Sprintf("a%d", j)),
+				Bandwidth:         uint64Ptr(uint64(64000 << uint(g.rnd.Intn(3)))),
+				Codecs:            stringPtr("mp4a.40.2"),
+				AudioSamplingRate: stringPtr("48000"),
+			}},
+		}
+		audio.Representations[0].AudioChannelConfigurations = []mpd.AudioChannelConfiguration{mpd.NewChannelCountConfiguration(2)}
+		g.address(audio, s, 48000)
+		p.AdaptationSets = append(p.AdaptationSets, audio)
+	}
+	return p
+}
+
+// segmentation describes segments of a Period.
+type segmentation struct {
+	duration time.Duration
+	count    int
+}
+
+// address adds segment addressing of random mode to as and its Representations.
+func (g *generator) address(as *mpd.AdaptationSet, s *segmentation, timescale uint64) {
+	d := uint64(s.duration/time.Millisecond) * timescale / 1000
+	switch g.Addressing[g.rnd.Intn(len(g.Addressing))] {
+	case TemplateNumber:
+		as.SegmentTemplate = &mpd.SegmentTemplate{
+			Timescale:      uint64Ptr(timescale),
+			Duration:       uint32Ptr(uint32(d)),
+			StartNumber:    uint64Ptr(1),
+			Initialization: stringPtr("$RepresentationID$/init.mp4"),
+			Media:          stringPtr("$RepresentationID$/$Number$.m4s"),
+		}
+	case TemplateTimeline:
+		as.SegmentTemplate = &mpd.SegmentTemplate{
+			Timescale:      uint64Ptr(timescale),
+			Initialization: stringPtr("$RepresentationID$/init.mp4"),
+			Media:          stringPtr("$RepresentationID$/$Time$.m4s"),
+			SegmentTimeline: []mpd.SegmentTimeline{{Segments: []mpd.SegmentTimelineSegment{
+				{T: uint64Ptr(0), D: d, R: int64Ptr(int64(s.count - 1))},
+			}}},
+		}
+	case SegmentList:
+		for k := range as.Representations {
+			r := &as.Representations[k]
+			l := &mpd.SegmentList{
+				Timescale:      uint64Ptr(timescale),
+				Duration:       uint64Ptr(d),
+				Initialization: &mpd.URLType{SourceURL: stringPtr(*r.ID + "/init.mp4")},
+			}
+			for n := 1; n <= s.count; n++ {
+				l.SegmentURLs = append(l.SegmentURLs, mpd.SegmentURL{Media: stringPtr(fmt.Sprintf("%s/%d.m4s", *r.ID, n))})
+			}
+			r.SegmentList = l
+		}
+	case SegmentBase:
+		as.SegmentAlignment = mpd.ConditionalUint{}
+		as.StartWithSAP = nil
+		as.SubsegmentAlignment = conditionalTrue()
+		as.SubsegmentStartsWithSAP = uint64Ptr(1)
+		for k := range as.Representations {
+			r := &as.Representations[k]
+			init := 500 + g.rnd.Intn(1000)
+			index := init + 1 + 12*s.count + 32
+			r.BaseURL = *r.ID + ".mp4"
+			r.SegmentBase = &mpd.SegmentBase{
+				Timescale:      uint64Ptr(timescale),
+				IndexRange:     stringPtr(fmt.Sprintf("%d-%d", init+1, index)),
+				Initialization: &mpd.URLType{Range: stringPtr(fmt.Sprintf("0-%d", init))},
+			}
+		}
+	}
+
+	if g.kid != nil {
+		as.ContentProtections = []mpd.ContentProtection{
+			{SchemeIDURI: stringPtr(mpd.MP4ProtectionScheme), Value: stringPtr("cenc"), DefaultKID: stringPtr(formatKID(g.kid))},
+			{SchemeIDURI: stringPtr(mpd.WidevineScheme), Pssh: &mpd.Pssh{Value: stringPtr(pssh(mpd.WidevineScheme, g.kid))}},
+			{SchemeIDURI: stringPtr(mpd.PlayReadyScheme), Pssh: &mpd.Pssh{Value: stringPtr(pssh(mpd.PlayReadyScheme, g.kid))}},
+		}
+	}
+}
+
+// pssh returns base64-encoded version 1 PSSH box of DRM system with given urn:uuid scheme listing kid.
+func pssh(scheme string, kid []byte) string {
+	systemID, _ := hex.DecodeString(strings.Replace(strings.TrimPrefix(scheme, "urn:uuid:"), "-", "", -1))
+	b := make([]byte, 0, 52)
+	b = binary.BigEndian.AppendUint32(b, 52)
+	b = append(b, "pssh"...)
+	b = append(b, 1, 0, 0, 0)
+	b = append(b, systemID...)
+	b = binary.BigEndian.AppendUint32(b, 1)
+	b = append(b, kid...)
+	b = binary.BigEndian.AppendUint32(b, 0) // DataSize
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+// formatKID returns 16-byte key ID in canonical UUID form.
+func formatKID(b []byte) string {
+	kid, _ := mpd.NormalizeKID(hex.EncodeToString(b))
+	return kid
+}
+
+// conditionalTrue returns ConditionalUint with true value.
+func conditionalTrue() mpd.ConditionalUint {
+	var c mpd.ConditionalUint
+	c.UnmarshalXMLAttr(xml.Attr{Value: "true"})
+	return c
+}
+
+// stringPtr returns pointer to s.
+func stringPtr(s string) *string {
+	return &s
+}
+
+// uint64Ptr returns pointer to v.
+func uint64Ptr(v uint64) *uint64 {
+	return &v
+}
+
+// uint32Ptr returns pointer to v.
+func uint32Ptr(v uint32) *uint32 {
+	return &v
+}
+
+// int64Ptr returns pointer to v.
+func int64Ptr(v int64) *int64 {
+	return &v
+}
@@ -0,0 +1,78 @@
+package testgen
+
+import (
+	"testing"
+
+	. "gopkg.in/check.v1"
+
+	"github.com/jun-oku/mpd"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type TestGenSuite struct{}
+
+var _ = Suite(&TestGenSuite{})
+
+func (s *TestGenSuite) TestGenerate(c *C) {
+	for _, o := range []Options{
+		{},
+		{Periods: 3, DRM: true},
+		{Periods: 2, Dynamic: true},
+		{Addressing: []Addressing{SegmentBase}, MaxRepresentations: 6},
+		{Addressing: []Addressing{SegmentList, TemplateTimeline}, Periods: 4, DRM: true},
+	} {
+		for seed := int64(0); seed < 20; seed++ {
+			o.Seed = seed
+			m, err := Generate(o)
+			c.Assert(err, IsNil)
+			c.Check(m.Validate(), IsNil, Commentf("%+v", o))
+
+			b, err := m.Encode()
+			c.Assert(err, IsNil)
+			decoded := new(mpd.MPD)
+			c.Assert(decoded.Decode(b), IsNil)
+			c.Check(decoded.Validate(), IsNil, Commentf("%s", b))
+			b2, err := decoded.Encode()
+			c.Assert(err, IsNil)
+			c.Check(string(b2), Equals, string(b))
+
+			for _, p := range decoded.Periods {
+				for _, as := range p.AdaptationSets {
+					for k := range as.Representations {
+						segments, err := mpd.Segments("", decoded, p, as, &as.Representations[k])
+						c.Check(err, IsNil)
+						c.Check(segments, Not(HasLen), 0)
+					}
+				}
+			}
+
+			again, err := Generate(o)
+			c.Assert(err, IsNil)
+			b2, err = again.Encode()
+			c.Assert(err, IsNil)
+			c.Check(string(b2), Equals, string(b))
+		}
+	}
+}
+
+func (s *TestGenSuite) TestGenerateOptions(c *C) {
+	m, err := Generate(Options{Seed: 1, Periods: 3, DRM: true})
+	c.Assert(err, IsNil)
+	c.Check(m.Periods, HasLen, 3)
+	c.Check(*m.Type, Equals, "static")
+	c.Check(*m.Cenc, Equals, mpd.CENCNamespace)
+	kids, err := m.Periods[0].AdaptationSets[0].ContentProtections[1].PsshKIDs()
+	c.Assert(err, IsNil)
+	c.Check(kids, DeepEquals, []string{*m.Periods[0].AdaptationSets[0].ContentProtections[0].DefaultKID})
+
+	m, err = Generate(Options{Seed: 1, Dynamic: true})
+	c.Assert(err, IsNil)
+	c.Check(*m.Type, Equals, "dynamic")
+	c.Check(m.Profiles, Equals, mpd.ProfileISOFFLive)
+
+	_, err = Generate(Options{Dynamic: true, Addressing: []Addressing{SegmentBase}})
+	c.Check(err, ErrorMatches, "Generate: SegmentBase addressing in dynamic MPD")
+	_, err = Generate(Options{Periods: -1})
+	c.Check(err, ErrorMatches, "Generate: negative number of Periods or Representations")
+}
@@ -0,0 +1,142 @@
+package mpd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ThumbnailTileScheme is a schemeIdUri of DASH-IF thumbnail tile EssentialProperty.
+const ThumbnailTileScheme = "http://dashif.org/thumbnail_tile"
+
+// thumbnailTileSchemes lists all known spellings of thumbnail tile scheme.
+var thumbnailTileSchemes = []string{
+	ThumbnailTileScheme,
+	"http://dashif.org/guidelines/thumbnail_tile",
+	"urn:mpeg:dash:thumbnail_tile",
+}
+
+// ThumbnailTile describes a grid of thumbnails in a single tile image.
+type ThumbnailTile struct {
+	Columns int
+	Rows    int
+}
+
+// ParseThumbnailTile parses thumbnail tile descriptor value like "10x20" (columns x rows).
+func ParseThumbnailTile(s string) (*ThumbnailTile, error) {
+	parts := strings.Split(strings.ToLower(strings.TrimSpace(s)), "x")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("ThumbnailTile: can't parse %q", s)
+	}
+	c, err1 := strconv.Atoi(parts[0])
+	r, err2 := strconv.Atoi(parts[1])
+	if err1 != nil || err2 != nil || c < 1 || r < 1 {
+		return nil, fmt.Errorf("ThumbnailTile: can't parse %q", s)
+	}
+	return &ThumbnailTile{Columns: c, Rows: r}, nil
+}
+
+// String formats t as descriptor value.
+func (t ThumbnailTile) String() string {
+	return fmt.Sprintf("%dx%d", t.Columns, t.Rows)
+}
+
+// Count returns number of thumbnails in a tile image.
+func (t ThumbnailTile) Count() int {
+	return t.Columns * t.Rows
+}
+
+// Rect returns position and size of thumbnail i (counting row by row from 0) in a tile image of given size.
+// It returns zeros if t has no columns or rows.
+func (t ThumbnailTile) Rect(i int, width, height uint64) (x, y, w, h uint64) {
+	if t.Columns <= 0 || t.Rows <= 0 {
+		return 0, 0, 0, 0
+	}
+	w = width / uint64(t.Columns)
+	h = height / uint64(t.Rows)
+	x = uint64(i%t.Columns) * w
+	y = uint64(i/t.Columns) * h
+	return
+}
+
+// ThumbnailOptions describes thumbnail track for NewThumbnailAdaptationSet.
+type ThumbnailOptions struct {
+	ID        string
+	Bandwidth uint64
+	// Width and Height are dimensions of a whole tile image.
+	Width  uint64
+	Height uint64
+	Tile   ThumbnailTile
+	// Media is a SegmentTemplate@media of tile images, e.g. "thumbs/$Number$.jpg".
+	Media       string
+	StartNumber uint64
+	// Duration of a tile image in Timescale units.
+	Timescale uint64
+	Duration  uint32
+}
+
+// NewThumbnailAdaptationSet returns image AdaptationSet with a single thumbnail Representation.
+func NewThumbnailAdaptationSet(o ThumbnailOptions) (*AdaptationSet, error) {
+	if o.Tile.Columns < 1 || o.Tile.Rows < 1 {
+		return nil, fmt.Errorf("NewThumbnailAdaptationSet: invalid tile %s", o.Tile)
+	}
+	if o.Media == "" || o.Duration == 0 {
+		return nil, fmt.Errorf("NewThumbnailAdaptationSet: media and duration are required")
+	}
+
+	contentType := ContentTypeImage
+	codecs := "jpeg"
+	startNumber := o.StartNumber
+	if startNumber == 0 {
+		startNumber = 1
+	}
+	timescale := o.Timescale
+	if timescale == 0 {
+		timescale = 1
+	}
+	id, bandwidth, width, height, media, duration := o.ID, o.Bandwidth, o.Width, o.Height, o.Media, o.Duration
+
+	r := Representation{
+		ID:        &id,
+		Bandwidth: &bandwidth,
+		Width:     &width,
+		Height:    &height,
+		Codecs:    &codecs,
+		RepresentationBase: RepresentationBase{
+			EssentialProperties: []Descriptor{NewDescriptor(ThumbnailTileScheme, o.Tile.String())},
+		},
+		SegmentTemplate: &SegmentTemplate{
+			Timescale:   &timescale,
+			Media:       &media,
+			StartNumber: &startNumber,
+			Duration:    &duration,
+		},
+	}
+	return &AdaptationSet{
+		MimeType:        MimeTypeImageJPEG,
+		ContentType:     &contentType,
+		Representations: []Representation{r},
+	}, nil
+}
+
+// ThumbnailTile returns thumbnail grid of r, or nil if r is not a thumbnail Representation.
+func (r *Representation) ThumbnailTile() (*ThumbnailTile, error) {
+	return thumbnailTile(r.EssentialProperties)
+}
+
+// ThumbnailTile returns thumbnail grid signaled on as, or nil.
+func (as *AdaptationSet) ThumbnailTile() (*ThumbnailTile, error) {
+	return thumbnailTile(as.EssentialProperties)
+}
+
+func thumbnailTile(list []Descriptor) (*ThumbnailTile, error) {
+	for _, scheme := range thumbnailTileSchemes {
+		if d := findDescriptor(list, scheme); d != nil {
+			if d.Value == nil {
+				return nil, fmt.Errorf("ThumbnailTile: descriptor without value")
+			}
+			return ParseThumbnailTile(*d.Value)
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,42 @@
+package mpd
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestThumbnails(c *C) {
+	as, err := NewThumbnailAdaptationSet(ThumbnailOptions{
+		ID:        "thumbs",
+		Bandwidth: 10000,
+		Width:     1024,
+		Height:    1152,
+		Tile:      ThumbnailTile{Columns: 4, Rows: 8},
+		Media:     "thumbs/$Number$.jpg",
+		Duration:  320,
+	})
+	c.Assert(err, IsNil)
+	c.Check(InferContentType(as), Equals, ContentTypeImage)
+
+	tile, err := as.Representations[0].ThumbnailTile()
+	c.Assert(err, IsNil)
+	c.Check(*tile, Equals, ThumbnailTile{Columns: 4, Rows: 8})
+	c.Check(tile.Count(), Equals, 32)
+
+	x, y, w, h := tile.Rect(5, 1024, 1152)
+	c.Check([]uint64{x, y, w, h}, DeepEquals, []uint64{256, 144, 256, 144})
+	x, y, w, h = ThumbnailTile{Columns: 4}.Rect(5, 1024, 1152)
+	c.Check([]uint64{x, y, w, h}, DeepEquals, []uint64{0, 0, 0, 0})
+	x, y, w, h = ThumbnailTile{Rows: 8}.Rect(5, 1024, 1152)
+	c.Check([]uint64{x, y, w, h}, DeepEquals, []uint64{0, 0, 0, 0})
+
+	tile, err = ParseThumbnailTile("10X20")
+	c.Assert(err, IsNil)
+	c.Check(tile.String(), Equals, "10x20")
+
+	r := &Representation{RepresentationBase: RepresentationBase{EssentialProperties: []Descriptor{NewDescriptor("urn:mpeg:dash:thumbnail_tile", "0x1")}}}
+	_, err = r.ThumbnailTile()
+	c.Check(err, ErrorMatches, `ThumbnailTile: can't parse "0x1"`)
+
+	_, err = NewThumbnailAdaptationSet(ThumbnailOptions{Tile: ThumbnailTile{Columns: 1, Rows: 1}})
+	c.Check(err, NotNil)
+}
@@ -0,0 +1,250 @@
+package mpd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Timeline is a presentation timeline of MPD for visual inspection: Periods with segments of their
+// Representations and their Events. Times are in seconds of media presentation time.
+type Timeline struct {
+	Periods []TimelinePeriod `json:"periods"`
+}
+
+// TimelinePeriod is a Period of Timeline.
+type TimelinePeriod struct {
+	ID    string  `json:"id"`
+	Start float64 `json:"start"`
+	// Duration is nil if unknown, e.g. for the last Period of live MPD.
+	Duration        *float64                 `json:"duration,omitempty"`
+	Representations []TimelineRepresentation `json:"representations"`
+	Events          []TimelineEvent          `json:"events,omitempty"`
+}
+
+// TimelineRepresentation is a Representation of TimelinePeriod with its media segments.
+type TimelineRepresentation struct {
+	ID          string            `json:"id"`
+	ContentType string            `json:"contentType"`
+	Bandwidth   uint64            `json:"bandwidth"`
+	Segments    []TimelineSegment `json:"segments"`
+	// Error tells why segments can't be enumerated.
+	Error string `json:"error,omitempty"`
+}
+
+// TimelineSegment is a media segment of TimelineRepresentation.
+type TimelineSegment struct {
+	Number   uint64  `json:"number"`
+	Start    float64 `json:"start"`
+	Duration float64 `json:"duration"`
+}
+
+// TimelineEvent is an Event of TimelinePeriod.
+type TimelineEvent struct {
+	Scheme   string  `json:"scheme"`
+	Value    string  `json:"value,omitempty"`
+	ID       string  `json:"id,omitempty"`
+	Start    float64 `json:"start"`
+	Duration float64 `json:"duration,omitempty"`
+}
+
+// Timeline returns presentation timeline of m. Representations which segments can't be enumerated
+// are included with Error, so a broken manifest can still be inspected.
+func (m *MPD) Timeline() (*Timeline, error) {
+	res := &Timeline{Periods: []TimelinePeriod{}}
+	for i, p := range m.Periods {
+		start, end, err := periodRange(m, i)
+		if err != nil {
+			return nil, fmt.Errorf("Timeline: %s: %w", periodPath(i), err)
+		}
+		tp := TimelinePeriod{ID: periodPath(i), Start: start.Seconds(), Representations: []TimelineRepresentation{}}
+		if p.ID != nil {
+			tp.ID = *p.ID
+		}
+		if end >= 0 {
+			d := (end - start).Seconds()
+			tp.Duration = &d
+		}
+
+		for j, as := range p.AdaptationSets {
+			for k := range as.Representations {
+				r := &as.Representations[k]
+				tr := TimelineRepresentation{ID: representationPath(i, j, k), ContentType: InferContentType(as), Bandwidth: r.GetBandwidth(), Segments: []TimelineSegment{}}
+				if r.ID != nil {
+					tr.ID = *r.ID
+				}
+				segments, err := Segments("", m, p, as, r)
+				if err != nil {
+					tr.Error = err.Error()
+				}
+				pto := EffectivePresentationTimeOffset(p, as, r)
+				for _, s := range segments {
+					if s.Kind != MediaSegment || s.Timescale == 0 || s.Duration == 0 {
+						continue
+					}
+					ts := uint64Ptr(s.Timescale)
+					tr.Segments = append(tr.Segments, TimelineSegment{
+						Number:   s.Number,
+						Start:    (start + timescaled(s.Time, ts) - timescaled(pto, ts)).Seconds(),
+						Duration: timescaled(s.Duration, ts).Seconds(),
+					})
+				}
+				tp.Representations = append(tp.Representations, tr)
+			}
+		}
+
+		for n := range p.EventStreams {
+			es := &p.EventStreams[n]
+			ts := eventTimescale(es)
+			for _, e := range es.Events {
+				te := TimelineEvent{}
+				if es.SchemeIDURI != nil {
+					te.Scheme = *es.SchemeIDURI
+				}
+				if es.Value != nil {
+					te.Value = *es.Value
+				}
+				if e.ID != nil {
+					te.ID = *e.ID
+				}
+				var pt int64
+				if e.PresentationTime != nil {
+					pt = *e.PresentationTime
+				}
+				if es.PresentationTimeOffset != nil {
+					pt -= int64(*es.PresentationTimeOffset)
+				}
+				te.Start = (start + scaleEventTime(pt, ts)).Seconds()
+				if e.Duration != nil {
+					te.Duration = scaleEventTime(*e.Duration, ts).Seconds()
+				}
+				tp.Events = append(tp.Events, te)
+			}
+		}
+		res.Periods = append(res.Periods, tp)
+	}
+	return res, nil
+}
+
+// WriteJSON writes t to w as indented JSON.
+func (t *Timeline) WriteJSON(w io.Writer) error {
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	return e.Encode(t)
+}
+
+// WriteMermaid writes t to w as Mermaid gantt chart with a section per Period, contiguous segments of
+// each Representation merged into a single bar and Events as bars or milestones.
+func (t *Timeline) WriteMermaid(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("gantt\n    title MPD timeline\n    dateFormat x\n    axisFormat %H:%M:%S\n")
+	ms := func(seconds float64) string {
+		return strconv.FormatInt(int64(seconds*1000+0.5), 10)
+	}
+	for _, p := range t.Periods {
+		fmt.Fprintf(&b, "    section Period %s\n", mermaidText(p.ID))
+		end := p.Start
+		if p.Duration != nil {
+			end += *p.Duration
+		}
+		fmt.Fprintf(&b, "    Period %s : %s, %s\n", mermaidText(p.ID), ms(p.Start), ms(end))
+		for _, r := range p.Representations {
+			if r.Error != "" {
+				fmt.Fprintf(&b, "    %s %s error %s : crit, %s, %s\n", r.ContentType, mermaidText(r.ID), mermaidText(r.Error), ms(p.Start), ms(end))
+			}
+			for _, run := range segmentRuns(r.Segments) {
+				fmt.Fprintf(&b, "    %s %s segments %d-%d : %s, %s\n", r.ContentType, mermaidText(r.ID), run.first, run.last, ms(run.start), ms(run.end))
+			}
+		}
+		for _, e := range p.Events {
+			name := mermaidText(e.Scheme + " " + e.ID)
+			if e.Duration == 0 {
+				fmt.Fprintf(&b, "    event %s : milestone, %s, %s\n", name, ms(e.Start), ms(e.Start))
+			} else {
+				fmt.Fprintf(&b, "    event %s : %s, %s\n", name, ms(e.Start), ms(e.Start+e.Duration))
+			}
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteDOT writes t to w as Graphviz digraph of Periods, their Representations with segment runs and Events.
+func (t *Timeline) WriteDOT(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("digraph timeline {\n  rankdir=LR;\n  node [shape=box];\n  mpd [label=\"MPD\"];\n")
+	for i, p := range t.Periods {
+		label := fmt.Sprintf("Period %s\\n%s", p.ID, formatSeconds(p.Start))
+		if p.Duration != nil {
+			label += " - " + formatSeconds(p.Start+*p.Duration)
+		}
+		fmt.Fprintf(&b, "  p%d [label=%s];\n  mpd -> p%d;\n", i, dotQuote(label), i)
+		if i > 0 {
+			fmt.Fprintf(&b, "  p%d -> p%d [style=dashed];\n", i-1, i)
+		}
+		for j, r := range p.Representations {
+			label := fmt.Sprintf("%s %s\\n%d bps", r.ContentType, r.ID, r.Bandwidth)
+			runs := segmentRuns(r.Segments)
+			for _, run := range runs {
+				label += fmt.Sprintf("\\n#%d-%d: %s - %s", run.first, run.last, formatSeconds(run.start), formatSeconds(run.end))
+			}
+			attrs := ""
+			switch {
+			case r.Error != "":
+				label += "\\n" + r.Error
+				attrs = ", color=red"
+			case len(runs) > 1:
+				attrs = ", color=orange"
+			}
+			fmt.Fprintf(&b, "  p%dr%d [label=%s%s];\n  p%d -> p%dr%d;\n", i, j, dotQuote(label), attrs, i, i, j)
+		}
+		for j, e := range p.Events {
+			label := fmt.Sprintf("%s %s\\n%s", e.Scheme, e.ID, formatSeconds(e.Start))
+			if e.Duration > 0 {
+				label += " - " + formatSeconds(e.Start+e.Duration)
+			}
+			fmt.Fprintf(&b, "  p%de%d [label=%s, shape=ellipse];\n  p%d -> p%de%d;\n", i, j, dotQuote(label), i, i, j)
+		}
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// segmentRun is a sequence of contiguous segments.
+type segmentRun struct {
+	first, last uint64
+	start, end  float64
+}
+
+// segmentRuns merges contiguous segments into runs; gaps and overlaps start new runs.
+func segmentRuns(segments []TimelineSegment) []segmentRun {
+	var res []segmentRun
+	for _, s := range segments {
+		if n := len(res); n > 0 && math.Abs(res[n-1].end-s.Start) < 1e-6 {
+			res[n-1].last, res[n-1].end = s.Number, s.Start+s.Duration
+			continue
+		}
+		res = append(res, segmentRun{first: s.Number, last: s.Number, start: s.Start, end: s.Start + s.Duration})
+	}
+	return res
+}
+
+// mermaidText replaces characters which have special meaning in Mermaid gantt task names.
+func mermaidText(s string) string {
+	return strings.NewReplacer(":", "_", "#", "_", ";", "_", "\n", " ").Replace(s)
+}
+
+// dotQuote returns Graphviz quoted string of s, keeping \n escapes of line breaks.
+func dotQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+// formatSeconds formats seconds like FormatDuration.
+func formatSeconds(seconds float64) string {
+	return FormatDuration(time.Duration(seconds * float64(time.Second)))
+}
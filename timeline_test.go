@@ -0,0 +1,82 @@
+package mpd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestTimeline(c *C) {
+	m := NewStaticMPD(ProfileISOFFLive, 12*time.Second)
+	m.Periods[0].ID = stringPtr("p0")
+	m.Periods[0].Duration = stringPtr("PT8S")
+	as := &AdaptationSet{MimeType: MimeTypeVideoMP4, SegmentTemplate: &SegmentTemplate{
+		Timescale: uint64Ptr(1000),
+		Media:     stringPtr("$Number$.m4s"),
+		// gap between 4s and 6s
+		SegmentTimeline: []SegmentTimeline{{Segments: []SegmentTimelineSegment{{T: uint64Ptr(0), D: 2000, R: new(int64)}, {D: 2000}, {T: uint64Ptr(6000), D: 2000}}}},
+	}, Representations: []Representation{{ID: stringPtr("v1"), Bandwidth: uint64Ptr(1000000)}}}
+	m.Periods[0].AdaptationSets = []*AdaptationSet{as}
+	_, err := InsertAdBreak(m, 4*time.Second, 2*time.Second, []byte{0xFC})
+	c.Assert(err, IsNil)
+	m.Periods = append(m.Periods, &Period{ID: stringPtr("p1"), Start: stringPtr("PT8S"), AdaptationSets: []*AdaptationSet{{
+		MimeType:        MimeTypeAudioMP4,
+		SegmentTemplate: &SegmentTemplate{Duration: uint32Ptr(2)},
+		Representations: []Representation{{ID: stringPtr("a1"), Bandwidth: uint64Ptr(64000)}},
+	}}})
+
+	t, err := m.Timeline()
+	c.Assert(err, IsNil)
+	c.Assert(t.Periods, HasLen, 2)
+	c.Check(*t.Periods[1].Duration, Equals, 4.0)
+	c.Check(t.Periods[0].Representations[0].Segments, DeepEquals, []TimelineSegment{{1, 0, 2}, {2, 2, 2}, {3, 6, 2}})
+	c.Check(t.Periods[0].Events, DeepEquals, []TimelineEvent{{Scheme: SCTE35BinScheme, ID: "1", Start: 4, Duration: 2}})
+	c.Check(t.Periods[1].Representations[0].Error, Equals, "Segments: SegmentTemplate without media")
+
+	var b bytes.Buffer
+	c.Assert(t.WriteJSON(&b), IsNil)
+	var decoded map[string]interface{}
+	c.Assert(json.Unmarshal(b.Bytes(), &decoded), IsNil)
+	c.Check(decoded["periods"], HasLen, 2)
+
+	b.Reset()
+	c.Assert(t.WriteMermaid(&b), IsNil)
+	c.Check(b.String(), Equals, `gantt
+    title MPD timeline
+    dateFormat x
+    axisFormat %H:%M:%S
+    section Period p0
+    Period p0 : 0, 8000
+    video v1 segments 1-2 : 0, 4000
+    video v1 segments 3-3 : 6000, 8000
+    event urn_scte_scte35_2014_xml+bin 1 : 4000, 6000
+    section Period p1
+    Period p1 : 8000, 12000
+    audio a1 error Segments_ SegmentTemplate without media : crit, 8000, 12000
+`)
+
+	b.Reset()
+	c.Assert(t.WriteDOT(&b), IsNil)
+	dot := b.String()
+	c.Check(strings.HasPrefix(dot, "digraph timeline {\n"), Equals, true)
+	c.Check(dot, Matches, `(?s).*p0r0 \[label="video v1\\n1000000 bps\\n#1-2: PT0S - PT4S\\n#3-3: PT6S - PT8S", color=orange\];.*`)
+	c.Check(dot, Matches, `(?s).*p1r0 \[label="audio a1\\n64000 bps\\nSegments: SegmentTemplate without media", color=red\];.*`)
+	c.Check(dot, Matches, `(?s).*p0e0 \[label="urn:scte:scte35:2014:xml\+bin 1\\nPT4S - PT6S", shape=ellipse\];.*`)
+}
+
+func (s *MPDSuite) TestTimelinePresentationTimeOffset(c *C) {
+	m := NewStaticMPD(ProfileISOFFLive, 8*time.Second)
+	m.Periods[0].AdaptationSets = []*AdaptationSet{{MimeType: MimeTypeVideoMP4, SegmentTemplate: &SegmentTemplate{
+		Timescale:              uint64Ptr(1000),
+		Duration:               uint32Ptr(2000),
+		PresentationTimeOffset: uint64Ptr(10000),
+		Media:                  stringPtr("$Time$.m4s"),
+	}, Representations: []Representation{{ID: stringPtr("v1")}}}}
+
+	t, err := m.Timeline()
+	c.Assert(err, IsNil)
+	c.Check(t.Periods[0].Representations[0].Segments, DeepEquals, []TimelineSegment{{1, 0, 2}, {2, 2, 2}, {3, 4, 2}, {4, 6, 2}})
+}
@@ -0,0 +1,91 @@
+package mpd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// TrickModeScheme is a schemeIdUri of DASH-IF trick mode EssentialProperty,
+// which links I-frame-only AdaptationSet to its main AdaptationSet by @id.
+const TrickModeScheme = "http://dashif.org/guidelines/trickmode"
+
+// trickModeSchemes lists all known spellings of trick mode scheme.
+var trickModeSchemes = []string{
+	TrickModeScheme,
+	"urn:mpeg:dash:trickmode",
+}
+
+// SetTrickMode marks trick as I-frame-only AdaptationSet for main, which must have @id.
+// maxPlayoutRate is written if it's not zero.
+func SetTrickMode(trick, main *AdaptationSet, maxPlayoutRate float64) error {
+	if main.ID == nil {
+		return fmt.Errorf("SetTrickMode: main AdaptationSet without id")
+	}
+	if trick == main {
+		return fmt.Errorf("SetTrickMode: AdaptationSet can't be trick mode of itself")
+	}
+
+	for _, scheme := range trickModeSchemes {
+		removeDescriptors(&trick.EssentialProperties, scheme)
+	}
+	trick.EssentialProperties = append(trick.EssentialProperties, NewDescriptor(TrickModeScheme, strconv.FormatUint(*main.ID, 10)))
+
+	codingDependency := false
+	trick.CodingDependency = &codingDependency
+	if maxPlayoutRate != 0 {
+		trick.MaxPlayoutRate = &maxPlayoutRate
+	}
+	return nil
+}
+
+// TrickModeFor returns ids of main AdaptationSets referenced by trick mode descriptor of as,
+// or nil if as is not a trick mode AdaptationSet.
+func (as *AdaptationSet) TrickModeFor() ([]uint64, error) {
+	for _, scheme := range trickModeSchemes {
+		d := findDescriptor(as.EssentialProperties, scheme)
+		if d == nil {
+			continue
+		}
+		if d.Value == nil {
+			return nil, fmt.Errorf("TrickModeFor: descriptor without value")
+		}
+		var res []uint64
+		for _, s := range strings.Fields(*d.Value) {
+			id, err := strconv.ParseUint(s, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("TrickModeFor: invalid AdaptationSet id %q", s)
+			}
+			res = append(res, id)
+		}
+		if len(res) == 0 {
+			return nil, fmt.Errorf("TrickModeFor: descriptor without AdaptationSet ids")
+		}
+		return res, nil
+	}
+	return nil, nil
+}
+
+// validateTrickMode checks that trick mode AdaptationSets reference existing AdaptationSets of the same Period.
+func validateTrickMode(m *MPD) ValidationErrors {
+	var res ValidationErrors
+	for i, p := range m.Periods {
+		for j, as := range p.AdaptationSets {
+			ids, err := as.TrickModeFor()
+			if err != nil {
+				res = append(res, newValidationError(adaptationSetPath(i, j), "%s", err))
+				continue
+			}
+			for _, id := range ids {
+				main := p.findAdaptationSet(id)
+				switch {
+				case main == nil:
+					res = append(res, newValidationError(adaptationSetPath(i, j), "trick mode references missing AdaptationSet %d", id))
+				case main == as:
+					res = append(res, newValidationError(adaptationSetPath(i, j), "trick mode references itself"))
+				}
+			}
+		}
+	}
+	return res
+}
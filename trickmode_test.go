@@ -0,0 +1,31 @@
+package mpd
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestTrickMode(c *C) {
+	id := uint64(1)
+	main := &AdaptationSet{ID: &id, MimeType: MimeTypeVideoMP4}
+	trick := &AdaptationSet{MimeType: MimeTypeVideoMP4}
+	m := &MPD{Periods: []*Period{{AdaptationSets: []*AdaptationSet{main, trick}}}}
+
+	ids, err := trick.TrickModeFor()
+	c.Check(err, IsNil)
+	c.Check(ids, IsNil)
+	c.Check(SetTrickMode(main, trick, 0), ErrorMatches, "SetTrickMode: main AdaptationSet without id")
+
+	c.Assert(SetTrickMode(trick, main, 8), IsNil)
+	c.Check(*trick.CodingDependency, Equals, false)
+	c.Check(*trick.MaxPlayoutRate, Equals, 8.0)
+	ids, err = trick.TrickModeFor()
+	c.Assert(err, IsNil)
+	c.Check(ids, DeepEquals, []uint64{1})
+	c.Check(m.Validate(), IsNil)
+
+	*main.ID = 2
+	err = m.Validate()
+	c.Assert(err, FitsTypeOf, ValidationErrors{})
+	c.Check(err.(ValidationErrors)[0].Path, Equals, "Periods[0].AdaptationSets[1]")
+	c.Check(err, ErrorMatches, `Periods\[0\].AdaptationSets\[1\]: trick mode references missing AdaptationSet 1`)
+}
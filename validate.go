@@ -0,0 +1,100 @@
+package mpd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidationError describes a single problem found by Validate.
+type ValidationError struct {
+	// Path locates the problematic element, e.g. "Periods[0].AdaptationSets[1]".
+	Path    string
+	Message string
+}
+
+// Error implements error interface.
+func (e *ValidationError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// ValidationErrors is a list of problems returned by Validate.
+type ValidationErrors []*ValidationError
+
+// Error implements error interface.
+func (e ValidationErrors) Error() string {
+	s := make([]string, len(e))
+	for i, err := range e {
+		s[i] = err.Error()
+	}
+	return strings.Join(s, "\n")
+}
+
+// Unwrap returns all problems, so errors.As can find *ValidationError in ValidationErrors.
+func (e ValidationErrors) Unwrap() []error {
+	res := make([]error, len(e))
+	for i, err := range e {
+		res[i] = err
+	}
+	return res
+}
+
+// validators are run by Validate in order.
+var validators = []func(m *MPD) ValidationErrors{
+	validateTrickMode,
+	validateAdaptationSetSwitching,
+	validateReferences,
+	validateLanguages,
+	validateLiveTiming,
+	validatePeriodAlignment,
+	validateOrphanSegments,
+	validateEmptyAdaptationSets,
+	validateHomogeneity,
+	validateQualityRankings,
+	validateDefaultKIDs,
+	validateDRM,
+}
+
+// Validate checks m for semantic problems. It returns ValidationErrors or nil.
+func (m *MPD) Validate() error {
+	var res ValidationErrors
+	for _, v := range validators {
+		res = append(res, v(m)...)
+	}
+	if len(res) == 0 {
+		return nil
+	}
+	return res
+}
+
+// newValidationError returns ValidationError with formatted message.
+func newValidationError(path string, format string, args ...interface{}) *ValidationError {
+	return &ValidationError{Path: path, Message: fmt.Sprintf(format, args...)}
+}
+
+// periodPath returns validation path of Period i.
+func periodPath(i int) string {
+	return fmt.Sprintf("Periods[%d]", i)
+}
+
+// adaptationSetPath returns validation path of AdaptationSet j in Period i.
+func adaptationSetPath(i, j int) string {
+	return fmt.Sprintf("Periods[%d].AdaptationSets[%d]", i, j)
+}
+
+// representationPath returns validation path of Representation k in AdaptationSet j in Period i.
+func representationPath(i, j, k int) string {
+	return fmt.Sprintf("Periods[%d].AdaptationSets[%d].Representations[%d]", i, j, k)
+}
+
+// findAdaptationSet returns AdaptationSet of p with given id, or nil.
+func (p *Period) findAdaptationSet(id uint64) *AdaptationSet {
+	for _, as := range p.AdaptationSets {
+		if as.ID != nil && *as.ID == id {
+			return as
+		}
+	}
+	return nil
+}
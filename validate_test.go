@@ -0,0 +1,30 @@
+package mpd
+
+import (
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestEncodeValidateFirst(c *C) {
+	m := &MPD{Periods: []*Period{{AdaptationSets: []*AdaptationSet{
+		{MimeType: "audio/mp4", Lang: stringPtr("en_US")},
+	}}}}
+
+	b, err := m.EncodeWithOptions(EncodeOptions{ValidateFirst: true})
+	c.Check(b, IsNil)
+	c.Assert(err, FitsTypeOf, ValidationErrors{})
+	c.Check(err.Error(), Equals, `Periods[0].AdaptationSets[0]: invalid lang "en_US"`)
+
+	var warnings []error
+	b, err = m.EncodeWithOptions(EncodeOptions{ValidateFirst: true, WarningsOnly: true, Warn: func(err error) {
+		warnings = append(warnings, err)
+	}})
+	c.Check(err, IsNil)
+	c.Check(b, NotNil)
+	c.Assert(warnings, HasLen, 1)
+	c.Check(warnings[0].Error(), Equals, `Periods[0].AdaptationSets[0]: invalid lang "en_US"`)
+
+	m.Periods[0].AdaptationSets[0].Lang = stringPtr("en-US")
+	b, err = m.EncodeWithOptions(EncodeOptions{ValidateFirst: true})
+	c.Check(err, IsNil)
+	c.Check(b, NotNil)
+}
@@ -0,0 +1,118 @@
+package mpd
+
+import (
+	"fmt"
+	"time"
+)
+
+// TrimToWindow drops SegmentTimeline segments of dynamic m which end more than window before now according to
+// m's Clock, increasing startNumber accordingly. Periods ending before that are removed, except for the last one.
+// timeShiftBufferDepth is set to window.
+func (m *MPD) TrimToWindow(window time.Duration) error {
+	since, err := m.SinceAvailabilityStart()
+	if err != nil {
+		return fmt.Errorf("TrimToWindow: %w", err)
+	}
+	m.TimeShiftBufferDepth = stringPtr(FormatDuration(window))
+	cutoff := since - window
+	if cutoff <= 0 {
+		return nil
+	}
+
+	var periods []*Period
+	for i, p := range m.Periods {
+		var start time.Duration
+		if p.Start != nil {
+			if start, err = ParseDuration(*p.Start); err != nil {
+				return fmt.Errorf("TrimToWindow: %s@start: %w", periodPath(i), err)
+			}
+		}
+		if p.Duration != nil && i+1 < len(m.Periods) {
+			d, err := ParseDuration(*p.Duration)
+			if err != nil {
+				return fmt.Errorf("TrimToWindow: %s@duration: %w", periodPath(i), err)
+			}
+			if start+d <= cutoff {
+				continue
+			}
+		}
+		periods = append(periods, p)
+		if cutoff <= start {
+			continue
+		}
+
+		// effective templates provide startNumber and timescale, which may be inherited;
+		// they are collected before any template is changed
+		var templates, effective []*SegmentTemplate
+		add := func(t, e *SegmentTemplate) {
+			if t != nil && len(t.SegmentTimeline) > 0 {
+				templates, effective = append(templates, t), append(effective, e)
+			}
+		}
+		add(p.SegmentTemplate, p.SegmentTemplate)
+		for _, as := range p.AdaptationSets {
+			add(as.SegmentTemplate, EffectiveSegmentTemplate(p, as, new(Representation)))
+			for k := range as.Representations {
+				r := &as.Representations[k]
+				add(r.SegmentTemplate, EffectiveSegmentTemplate(p, as, r))
+			}
+		}
+		for n, t := range templates {
+			e := effective[n]
+			end := uint64(unscaleEventTime(cutoff-start, int64(e.GetTimescale()))) + e.GetPresentationTimeOffset()
+			if dropped := trimTimelineStart(t.SegmentTimeline, end); dropped > 0 {
+				t.StartNumber = uint64Ptr(e.GetStartNumber() + dropped)
+			}
+		}
+	}
+	m.Periods = periods
+	return nil
+}
+
+// trimTimelineStart removes segments ending at or before end from timeline and returns their count.
+// The first remaining S gets explicit @t.
+func trimTimelineStart(timeline []SegmentTimeline, end uint64) uint64 {
+	var dropped, cur uint64
+	keep := false
+	for i := range timeline {
+		tl := &timeline[i]
+		var res []SegmentTimelineSegment
+		for _, s := range tl.Segments {
+			if keep {
+				res = append(res, s)
+				continue
+			}
+			if s.T != nil {
+				cur = *s.T
+			}
+
+			n := uint64(0)
+			if s.D > 0 && cur+s.D <= end {
+				n = (end - cur) / s.D
+			}
+			if r := s.GetRepeat(); r >= 0 && n > uint64(r) {
+				// whole S is dropped
+				dropped += uint64(r) + 1
+				cur += (uint64(r) + 1) * s.D
+				continue
+			}
+
+			keep = true
+			if n > 0 || dropped > 0 {
+				dropped += n
+				t := cur + n*s.D
+				s.T = &t
+				if r := s.GetRepeat(); r >= 0 {
+					s.R = nil
+					if r > int64(n) {
+						rr := r - int64(n)
+						s.R = &rr
+					}
+				}
+			}
+			res = append(res, s)
+		}
+		tl.Segments = res
+	}
+	return dropped
+}
@@ -0,0 +1,52 @@
+package mpd
+
+import (
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *MPDSuite) TestTrimToWindow(c *C) {
+	m := &MPD{
+		Type:                  stringPtr("dynamic"),
+		AvailabilityStartTime: stringPtr("2016-01-01T00:00:00Z"),
+		Periods: []*Period{
+			{ID: stringPtr("0"), Start: stringPtr("PT0S"), Duration: stringPtr("PT30S")},
+			{ID: stringPtr("1"), Start: stringPtr("PT30S"), AdaptationSets: []*AdaptationSet{
+				{
+					SegmentTemplate: &SegmentTemplate{Timescale: uint64Ptr(1000), SegmentTimeline: []SegmentTimeline{{Segments: []SegmentTimelineSegment{
+						{T: uint64Ptr(0), D: 2000, R: int64Ptr(2)},
+						{D: 3000, R: int64Ptr(3)},
+					}}}},
+					Representations: []Representation{{ID: stringPtr("v")}},
+				},
+				{
+					SegmentTemplate: &SegmentTemplate{Timescale: uint64Ptr(1000), StartNumber: uint64Ptr(5)},
+					Representations: []Representation{{ID: stringPtr("a"), SegmentTemplate: &SegmentTemplate{
+						SegmentTimeline: []SegmentTimeline{{Segments: []SegmentTimelineSegment{{T: uint64Ptr(0), D: 4000, R: int64Ptr(-1)}}}},
+					}}},
+				},
+			}},
+		},
+	}
+	m.SetClock(fixedClock(time.Date(2016, 1, 1, 0, 1, 0, 0, time.UTC)))
+
+	c.Assert(m.TrimToWindow(20*time.Second), IsNil)
+	c.Check(*m.TimeShiftBufferDepth, Equals, "PT20S")
+	c.Assert(m.Periods, HasLen, 1)
+	c.Check(*m.Periods[0].ID, Equals, "1")
+
+	as := m.Periods[0].AdaptationSets
+	c.Check(*as[0].SegmentTemplate.StartNumber, Equals, uint64(5))
+	c.Check(as[0].SegmentTemplate.SegmentTimeline[0].Segments, DeepEquals, []SegmentTimelineSegment{{T: uint64Ptr(9000), D: 3000, R: int64Ptr(2)}})
+	c.Check(*as[1].SegmentTemplate.StartNumber, Equals, uint64(5))
+	c.Check(*as[1].Representations[0].SegmentTemplate.StartNumber, Equals, uint64(7))
+	c.Check(as[1].Representations[0].SegmentTemplate.SegmentTimeline[0].Segments, DeepEquals, []SegmentTimelineSegment{{T: uint64Ptr(8000), D: 4000, R: int64Ptr(-1)}})
+
+	// nothing to trim yet
+	m.SetClock(fixedClock(time.Date(2016, 1, 1, 0, 0, 10, 0, time.UTC)))
+	c.Assert(m.TrimToWindow(20*time.Second), IsNil)
+	c.Check(as[0].SegmentTemplate.SegmentTimeline[0].Segments, HasLen, 1)
+
+	c.Check(new(MPD).TrimToWindow(time.Minute), ErrorMatches, "TrimToWindow: SinceAvailabilityStart: no availabilityStartTime")
+}